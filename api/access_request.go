@@ -0,0 +1,373 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
+)
+
+// reviewAccessRequests is the requirement for deciding access requests and
+// for seeing everyone's.
+var reviewAccessRequests = middlewares.AllOf("scope:manage")
+
+type accessRequestHandler struct {
+	accessRequestService services.IAccessRequestService
+	jwtMiddleware        middlewares.IJWTMiddleware
+}
+
+func NewAccessRequestHandler(accessRequestService services.IAccessRequestService, jwtMiddleware middlewares.IJWTMiddleware) *accessRequestHandler {
+	return &accessRequestHandler{accessRequestService, jwtMiddleware}
+}
+
+func (h *accessRequestHandler) Routes() []Route {
+	return []Route{
+		{http.MethodPost, "/access-requests/create", middlewares.Authenticated(), h.Create},
+		{http.MethodGet, "/access-requests/list", reviewAccessRequests, h.ListAll},
+		{http.MethodGet, "/access-requests/:id", middlewares.Authenticated(), h.FindOne},
+		{http.MethodPut, "/access-requests/approve", reviewAccessRequests, h.Approve},
+		{http.MethodPut, "/access-requests/reject", reviewAccessRequests, h.Reject},
+		{http.MethodPut, "/access-requests/cancel", middlewares.Authenticated(), h.Cancel},
+		{http.MethodPost, "/access-requests/comment", middlewares.Authenticated(), h.Comment},
+		{http.MethodGet, "/me/access-requests", middlewares.Authenticated(), h.ListOwn},
+	}
+}
+
+func (h *accessRequestHandler) SetupRoutes(r *gin.Engine) {
+	registerRoutes(r, h.jwtMiddleware, h.Routes())
+}
+
+// Create godoc
+// @Summary Request a scope
+// @Description Ask reviewers to grant the authenticated user a scope, optionally only until grant_expires_at. Pending requests expire if nobody decides them in time.
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateAccessRequestRequest true "Scope, justification and optional grant expiry"
+// @Success 201 {object} dto.APIResponse{data=dto.AccessRequestResponse} "Access request submitted successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Scope not found"
+// @Failure 409 {object} dto.APIResponse "A pending request for this scope already exists"
+// @Failure 422 {object} dto.APIResponse "Grant expiry is not in the future"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /access-requests/create [post]
+func (h *accessRequestHandler) Create(c *gin.Context) {
+	var req dto.CreateAccessRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	request, err := h.accessRequestService.Create(c.Request.Context(), c.GetString("userId"), req.Scope, req.Justification, req.GrantExpiresAt)
+	if err != nil {
+		abortWithError(c, err, "Failed to submit access request")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Code:    "ACCESS_REQUEST_CREATED",
+		Message: "Access request submitted successfully",
+		Data:    dto.NewAccessRequestResponse(request),
+	})
+}
+
+// ListAll godoc
+// @Summary List access requests
+// @Description Retrieve a cursor-paginated page of access requests from every user (requires scope:manage)
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param cursor query string false "Cursor returned as paging.next_cursor by the previous page"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param status query string false "Only requests in this state" Enums(pending, approved, rejected, cancelled, expired)
+// @Param scope query string false "Only requests for this scope"
+// @Param requester_id query string false "Only requests from this user"
+// @Param sort_by query string false "Sort field" Enums(id)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} dto.APIResponse{data=[]dto.AccessRequestResponse} "Access requests retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /access-requests/list [get]
+func (h *accessRequestHandler) ListAll(c *gin.Context) {
+	var req dto.ListAccessRequestsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	h.list(c, req)
+}
+
+// ListOwn godoc
+// @Summary List own access requests
+// @Description Retrieve a cursor-paginated page of the authenticated user's access requests
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param cursor query string false "Cursor returned as paging.next_cursor by the previous page"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param status query string false "Only requests in this state" Enums(pending, approved, rejected, cancelled, expired)
+// @Param scope query string false "Only requests for this scope"
+// @Param sort_by query string false "Sort field" Enums(id)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} dto.APIResponse{data=[]dto.AccessRequestResponse} "Access requests retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /me/access-requests [get]
+func (h *accessRequestHandler) ListOwn(c *gin.Context) {
+	var req dto.ListAccessRequestsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	req.RequesterID = c.GetString("userId")
+	h.list(c, req)
+}
+
+func (h *accessRequestHandler) list(c *gin.Context, req dto.ListAccessRequestsRequest) {
+	requests, paging, err := h.accessRequestService.FindAll(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve access requests")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "ACCESS_REQUESTS_RETRIEVED",
+		Message: "Access requests retrieved successfully",
+		Data:    dto.NewAccessRequestResponses(requests),
+		Paging:  paging,
+	})
+}
+
+// FindOne godoc
+// @Summary Get an access request
+// @Description Retrieve an access request and its comments. Requesters see their own requests; holders of scope:manage see all.
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param id path int true "Access request ID"
+// @Success 200 {object} dto.APIResponse{data=dto.AccessRequestResponse} "Access request retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Not the requester or a reviewer"
+// @Failure 404 {object} dto.APIResponse "Access request not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /access-requests/{id} [get]
+func (h *accessRequestHandler) FindOne(c *gin.Context) {
+	requestId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	request, err := h.accessRequestService.FindOne(c.Request.Context(), uint(requestId), c.GetString("userId"), isReviewer(c))
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve access request")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "ACCESS_REQUEST_RETRIEVED",
+		Message: "Access request retrieved successfully",
+		Data:    dto.NewAccessRequestResponse(request),
+	})
+}
+
+// Approve godoc
+// @Summary Approve an access request
+// @Description Grant the requested scope to the requester, until the requested grant expiry if any, and close the request (requires scope:manage)
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param body body dto.ReviewAccessRequestRequest true "Access request ID and optional comment"
+// @Success 200 {object} dto.APIResponse{data=dto.AccessRequestResponse} "Access request approved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Requesters cannot review their own request, and reviewers cannot approve scopes they do not hold"
+// @Failure 404 {object} dto.APIResponse "Access request, scope or requester not found"
+// @Failure 409 {object} dto.APIResponse "Access request is no longer pending"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /access-requests/approve [put]
+func (h *accessRequestHandler) Approve(c *gin.Context) {
+	var req dto.ReviewAccessRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	request, err := h.accessRequestService.Approve(c.Request.Context(), req.RequestID, c.GetString("userId"), c.GetStringSlice("scopes"), req.Comment)
+	if err != nil {
+		abortWithError(c, err, "Failed to approve access request")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "ACCESS_REQUEST_APPROVED",
+		Message: "Access request approved successfully",
+		Data:    dto.NewAccessRequestResponse(request),
+	})
+}
+
+// Reject godoc
+// @Summary Reject an access request
+// @Description Close an access request without granting the scope (requires scope:manage)
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param body body dto.ReviewAccessRequestRequest true "Access request ID and optional comment"
+// @Success 200 {object} dto.APIResponse{data=dto.AccessRequestResponse} "Access request rejected successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Requesters cannot review their own request"
+// @Failure 404 {object} dto.APIResponse "Access request not found"
+// @Failure 409 {object} dto.APIResponse "Access request is no longer pending"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /access-requests/reject [put]
+func (h *accessRequestHandler) Reject(c *gin.Context) {
+	var req dto.ReviewAccessRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	request, err := h.accessRequestService.Reject(c.Request.Context(), req.RequestID, c.GetString("userId"), req.Comment)
+	if err != nil {
+		abortWithError(c, err, "Failed to reject access request")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "ACCESS_REQUEST_REJECTED",
+		Message: "Access request rejected successfully",
+		Data:    dto.NewAccessRequestResponse(request),
+	})
+}
+
+// Cancel godoc
+// @Summary Cancel an access request
+// @Description Withdraw one of the authenticated user's pending access requests
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param body body dto.CancelAccessRequestRequest true "Access request ID"
+// @Success 200 {object} dto.APIResponse{data=dto.AccessRequestResponse} "Access request cancelled successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Not the requester"
+// @Failure 404 {object} dto.APIResponse "Access request not found"
+// @Failure 409 {object} dto.APIResponse "Access request is no longer pending"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /access-requests/cancel [put]
+func (h *accessRequestHandler) Cancel(c *gin.Context) {
+	var req dto.CancelAccessRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	request, err := h.accessRequestService.Cancel(c.Request.Context(), req.RequestID, c.GetString("userId"))
+	if err != nil {
+		abortWithError(c, err, "Failed to cancel access request")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "ACCESS_REQUEST_CANCELLED",
+		Message: "Access request cancelled successfully",
+		Data:    dto.NewAccessRequestResponse(request),
+	})
+}
+
+// Comment godoc
+// @Summary Comment on an access request
+// @Description Add a comment to an access request. Requesters comment on their own requests; holders of scope:manage on any.
+// @Tags access-requests
+// @Accept json
+// @Produce json
+// @Param body body dto.CommentAccessRequestRequest true "Access request ID and comment"
+// @Success 201 {object} dto.APIResponse{data=dto.AccessRequestCommentResponse} "Comment added successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Not the requester or a reviewer"
+// @Failure 404 {object} dto.APIResponse "Access request not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /access-requests/comment [post]
+func (h *accessRequestHandler) Comment(c *gin.Context) {
+	var req dto.CommentAccessRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	comment, err := h.accessRequestService.Comment(c.Request.Context(), req.RequestID, c.GetString("userId"), isReviewer(c), req.Body)
+	if err != nil {
+		abortWithError(c, err, "Failed to comment on access request")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Code:    "ACCESS_REQUEST_COMMENTED",
+		Message: "Comment added successfully",
+		Data:    dto.NewAccessRequestCommentResponse(comment),
+	})
+}
+
+// isReviewer reports whether the caller's token satisfies
+// reviewAccessRequests, for routes open to requesters and reviewers alike.
+func isReviewer(c *gin.Context) bool {
+	return reviewAccessRequests.SatisfiedBy(c.GetStringSlice("scopes"))
+}
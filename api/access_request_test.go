@@ -0,0 +1,285 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+)
+
+type AccessRequestHandlerSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	accessRequestHandler *accessRequestHandler
+	mockAccessRequestSvc *services.MockIAccessRequestService
+	mockJWT              *middlewares.MockIJWTMiddleware
+	mockLogger           *logger.MockILogger
+	router               *gin.Engine
+	scopes               []string
+}
+
+func (s *AccessRequestHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.ctrl = gomock.NewController(s.T())
+	s.mockAccessRequestSvc = services.NewMockIAccessRequestService(s.ctrl)
+	s.mockJWT = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+	s.scopes = nil
+
+	s.accessRequestHandler = NewAccessRequestHandler(s.mockAccessRequestSvc, s.mockJWT)
+	s.router = gin.New()
+	s.router.Use(pkgmiddlewares.ErrorHandler(s.mockLogger))
+
+	s.mockJWT.EXPECT().Require(gomock.Any()).Return(func(c *gin.Context) {
+		c.Set("userId", "alice")
+		c.Set("scopes", s.scopes)
+		c.Next()
+	}).AnyTimes()
+
+	s.accessRequestHandler.SetupRoutes(s.router)
+}
+
+func (s *AccessRequestHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestAccessRequestHandlerSuite(t *testing.T) {
+	suite.Run(t, new(AccessRequestHandlerSuite))
+}
+
+func (s *AccessRequestHandlerSuite) serve(method, path string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest(method, path, &buf)
+	httpReq.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(w, httpReq)
+	return w
+}
+
+func (s *AccessRequestHandlerSuite) request(status string) *entities.AccessRequest {
+	return &entities.AccessRequest{
+		ID:            1,
+		RequesterID:   "alice",
+		Scope:         "user:view",
+		Justification: "on-call",
+		Status:        status,
+		ExpiresAt:     time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:     time.Date(2029, 12, 25, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (s *AccessRequestHandlerSuite) TestCreate() {
+	grantExpiresAt := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	request := s.request(entities.AccessRequestPending)
+	request.GrantExpiresAt = &grantExpiresAt
+
+	s.mockAccessRequestSvc.EXPECT().Create(gomock.Any(), "alice", "user:view", "on-call", gomock.Any()).
+		DoAndReturn(func(_ interface{}, _, _, _ string, expiresAt *time.Time) (*entities.AccessRequest, error) {
+			assert.True(s.T(), grantExpiresAt.Equal(*expiresAt))
+			return request, nil
+		})
+
+	w := s.serve("POST", "/access-requests/create", dto.CreateAccessRequestRequest{Scope: "user:view", Justification: "on-call", GrantExpiresAt: &grantExpiresAt})
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+
+	var data struct {
+		Code string                    `json:"code"`
+		Data dto.AccessRequestResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "ACCESS_REQUEST_CREATED", data.Code)
+	assert.Equal(s.T(), dto.NewAccessRequestResponse(request), data.Data)
+}
+
+func (s *AccessRequestHandlerSuite) TestCreateInvalidInput() {
+	w := s.serve("POST", "/access-requests/create", dto.CreateAccessRequestRequest{Scope: "user:view"})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *AccessRequestHandlerSuite) TestCreateDuplicate() {
+	s.mockAccessRequestSvc.EXPECT().Create(gomock.Any(), "alice", "user:view", "on-call", nil).
+		Return(nil, apperrors.Conflict(dto.CodeAccessRequestExists, "duplicated key not allowed", nil))
+
+	w := s.serve("POST", "/access-requests/create", dto.CreateAccessRequestRequest{Scope: "user:view", Justification: "on-call"})
+	assert.Equal(s.T(), http.StatusConflict, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeAccessRequestExists, response.Code)
+	assert.Equal(s.T(), "Failed to submit access request", response.Message)
+}
+
+func (s *AccessRequestHandlerSuite) TestListAll() {
+	requests := []*entities.AccessRequest{s.request(entities.AccessRequestPending)}
+	query := dto.ListAccessRequestsRequest{Status: "pending", Scope: "user:view", RequesterID: "bob"}
+
+	s.mockAccessRequestSvc.EXPECT().FindAll(gomock.Any(), query).Return(requests, &dto.Paging{}, nil)
+
+	w := s.serve("GET", "/access-requests/list?status=pending&scope=user:view&requester_id=bob", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Code string                      `json:"code"`
+		Data []dto.AccessRequestResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "ACCESS_REQUESTS_RETRIEVED", data.Code)
+	assert.Equal(s.T(), dto.NewAccessRequestResponses(requests), data.Data)
+}
+
+func (s *AccessRequestHandlerSuite) TestListAllInvalidStatus() {
+	w := s.serve("GET", "/access-requests/list?status=granted", nil)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *AccessRequestHandlerSuite) TestListOwn() {
+	// requester_id in the query cannot widen the listing past the caller.
+	query := dto.ListAccessRequestsRequest{Status: "pending", RequesterID: "alice"}
+	s.mockAccessRequestSvc.EXPECT().FindAll(gomock.Any(), query).Return([]*entities.AccessRequest{}, &dto.Paging{}, nil)
+
+	w := s.serve("GET", "/me/access-requests?status=pending&requester_id=bob", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+func (s *AccessRequestHandlerSuite) TestFindOne() {
+	request := s.request(entities.AccessRequestPending)
+	s.mockAccessRequestSvc.EXPECT().FindOne(gomock.Any(), uint(1), "alice", false).Return(request, nil)
+
+	w := s.serve("GET", "/access-requests/1", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Code string                    `json:"code"`
+		Data dto.AccessRequestResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "ACCESS_REQUEST_RETRIEVED", data.Code)
+	assert.Equal(s.T(), dto.NewAccessRequestResponse(request), data.Data)
+}
+
+func (s *AccessRequestHandlerSuite) TestFindOneAsReviewer() {
+	s.scopes = []string{"scope:*"}
+	s.mockAccessRequestSvc.EXPECT().FindOne(gomock.Any(), uint(1), "alice", true).Return(s.request(entities.AccessRequestPending), nil)
+
+	w := s.serve("GET", "/access-requests/1", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+func (s *AccessRequestHandlerSuite) TestFindOneInvalidID() {
+	w := s.serve("GET", "/access-requests/abc", nil)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *AccessRequestHandlerSuite) TestFindOneForbidden() {
+	s.mockAccessRequestSvc.EXPECT().FindOne(gomock.Any(), uint(1), "alice", false).
+		Return(nil, apperrors.Forbidden(dto.CodeForbidden, "only the requester and reviewers may view an access request", nil))
+
+	w := s.serve("GET", "/access-requests/1", nil)
+	assert.Equal(s.T(), http.StatusForbidden, w.Code)
+}
+
+func (s *AccessRequestHandlerSuite) TestApprove() {
+	approved := s.request(entities.AccessRequestApproved)
+	s.scopes = []string{"scope:manage", "user:*"}
+	s.mockAccessRequestSvc.EXPECT().Approve(gomock.Any(), uint(1), "alice", []string{"scope:manage", "user:*"}, "ok").Return(approved, nil)
+
+	w := s.serve("PUT", "/access-requests/approve", dto.ReviewAccessRequestRequest{RequestID: 1, Comment: "ok"})
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Code string                    `json:"code"`
+		Data dto.AccessRequestResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "ACCESS_REQUEST_APPROVED", data.Code)
+	assert.Equal(s.T(), entities.AccessRequestApproved, data.Data.Status)
+}
+
+func (s *AccessRequestHandlerSuite) TestApproveNotPending() {
+	s.mockAccessRequestSvc.EXPECT().Approve(gomock.Any(), uint(1), "alice", gomock.Any(), "").
+		Return(nil, apperrors.Conflict(dto.CodeAccessRequestNotPending, "access request is no longer pending", nil))
+
+	w := s.serve("PUT", "/access-requests/approve", dto.ReviewAccessRequestRequest{RequestID: 1})
+	assert.Equal(s.T(), http.StatusConflict, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeAccessRequestNotPending, response.Code)
+	assert.Equal(s.T(), "Failed to approve access request", response.Message)
+}
+
+func (s *AccessRequestHandlerSuite) TestApproveInvalidInput() {
+	w := s.serve("PUT", "/access-requests/approve", dto.ReviewAccessRequestRequest{Comment: "ok"})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *AccessRequestHandlerSuite) TestReject() {
+	s.mockAccessRequestSvc.EXPECT().Reject(gomock.Any(), uint(1), "alice", "not needed").Return(s.request(entities.AccessRequestRejected), nil)
+
+	w := s.serve("PUT", "/access-requests/reject", dto.ReviewAccessRequestRequest{RequestID: 1, Comment: "not needed"})
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "ACCESS_REQUEST_REJECTED", response.Code)
+}
+
+func (s *AccessRequestHandlerSuite) TestCancel() {
+	s.mockAccessRequestSvc.EXPECT().Cancel(gomock.Any(), uint(1), "alice").Return(s.request(entities.AccessRequestCancelled), nil)
+
+	w := s.serve("PUT", "/access-requests/cancel", dto.CancelAccessRequestRequest{RequestID: 1})
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "ACCESS_REQUEST_CANCELLED", response.Code)
+}
+
+func (s *AccessRequestHandlerSuite) TestComment() {
+	s.scopes = []string{"scope:manage"}
+	comment := &entities.AccessRequestComment{ID: 3, AccessRequestID: 1, AuthorID: "alice", Body: "why?", CreatedAt: time.Date(2029, 12, 26, 0, 0, 0, 0, time.UTC)}
+	s.mockAccessRequestSvc.EXPECT().Comment(gomock.Any(), uint(1), "alice", true, "why?").Return(comment, nil)
+
+	w := s.serve("POST", "/access-requests/comment", dto.CommentAccessRequestRequest{RequestID: 1, Body: "why?"})
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+
+	var data struct {
+		Code string                           `json:"code"`
+		Data dto.AccessRequestCommentResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "ACCESS_REQUEST_COMMENTED", data.Code)
+	assert.Equal(s.T(), dto.NewAccessRequestCommentResponse(comment), data.Data)
+}
+
+func (s *AccessRequestHandlerSuite) TestCommentInvalidInput() {
+	w := s.serve("POST", "/access-requests/comment", dto.CommentAccessRequestRequest{RequestID: 1})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
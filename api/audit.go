@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
+)
+
+type auditHandler struct {
+	auditService  services.IAuditService
+	jwtMiddleware middlewares.IJWTMiddleware
+}
+
+func NewAuditHandler(auditService services.IAuditService, jwtMiddleware middlewares.IJWTMiddleware) *auditHandler {
+	return &auditHandler{auditService, jwtMiddleware}
+}
+
+func (h *auditHandler) Routes() []Route {
+	return []Route{
+		{http.MethodGet, "/audit", middlewares.AllOf("audit:view"), h.ListAll},
+	}
+}
+
+func (h *auditHandler) SetupRoutes(r *gin.Engine) {
+	registerRoutes(r, h.jwtMiddleware, h.Routes())
+}
+
+// ListAll godoc
+// @Summary List audit entries
+// @Description Retrieve a cursor-paginated page of the audit log of user and scope changes (requires audit:view)
+// @Tags audit
+// @Accept json
+// @Produce json
+// @Param cursor query string false "Cursor returned as paging.next_cursor by the previous page"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param actor_id query string false "Only changes made by this user"
+// @Param action query string false "Only this action, e.g. user.scope.update"
+// @Param target_type query string false "Only changes to this kind of target" Enums(user, scope)
+// @Param target_id query string false "Only changes to this target"
+// @Param request_id query string false "Only changes made by this request"
+// @Param since query string false "Only changes at or after this RFC 3339 time"
+// @Param until query string false "Only changes before this RFC 3339 time"
+// @Param sort_by query string false "Sort field" Enums(id)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} dto.APIResponse{data=[]dto.AuditEntryResponse} "Audit entries retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /audit [get]
+func (h *auditHandler) ListAll(c *gin.Context) {
+	var req dto.ListAuditEntriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	entries, paging, err := h.auditService.FindAll(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve audit entries")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "AUDIT_ENTRIES_RETRIEVED",
+		Message: "Audit entries retrieved successfully",
+		Data:    dto.NewAuditEntryResponses(entries),
+		Paging:  paging,
+	})
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+)
+
+type AuditHandlerSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	auditHandler *auditHandler
+	mockAuditSvc *services.MockIAuditService
+	mockJWT      *middlewares.MockIJWTMiddleware
+	mockLogger   *logger.MockILogger
+	router       *gin.Engine
+}
+
+func (s *AuditHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.ctrl = gomock.NewController(s.T())
+	s.mockAuditSvc = services.NewMockIAuditService(s.ctrl)
+	s.mockJWT = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.auditHandler = NewAuditHandler(s.mockAuditSvc, s.mockJWT)
+	s.router = gin.New()
+	s.router.Use(pkgmiddlewares.ErrorHandler(s.mockLogger))
+
+	s.mockJWT.EXPECT().Require(gomock.Any()).Return(func(c *gin.Context) {
+		c.Next()
+	}).AnyTimes()
+
+	s.auditHandler.SetupRoutes(s.router)
+}
+
+func (s *AuditHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestAuditHandlerSuite(t *testing.T) {
+	suite.Run(t, new(AuditHandlerSuite))
+}
+
+func (s *AuditHandlerSuite) serve(path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", path, nil)
+	s.router.ServeHTTP(w, httpReq)
+	return w
+}
+
+func (s *AuditHandlerSuite) TestListAll() {
+	before := `{"id":"user1","scopes":[]}`
+	after := `{"id":"user1","scopes":["container:delete"]}`
+	entries := []*entities.AuditEntry{{
+		ID:         1,
+		ActorID:    "admin",
+		Action:     entities.AuditUserScopeUpdate,
+		TargetType: entities.AuditTargetUser,
+		TargetID:   "user1",
+		Before:     &before,
+		After:      &after,
+		RequestID:  "req-1",
+		SourceIP:   "10.0.0.1",
+		CreatedAt:  time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+	}}
+	since := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := dto.ListAuditEntriesRequest{ActorID: "admin", Action: entities.AuditUserScopeUpdate, Since: &since}
+
+	s.mockAuditSvc.EXPECT().FindAll(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, req dto.ListAuditEntriesRequest) ([]*entities.AuditEntry, *dto.Paging, error) {
+		assert.Equal(s.T(), query.ActorID, req.ActorID)
+		assert.Equal(s.T(), query.Action, req.Action)
+		assert.True(s.T(), since.Equal(*req.Since))
+		assert.Nil(s.T(), req.Until)
+		return entries, &dto.Paging{Limit: 20}, nil
+	})
+
+	w := s.serve("/audit?actor_id=admin&action=user.scope.update&since=2030-01-01T00:00:00Z")
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Code string `json:"code"`
+		Data []struct {
+			Action string          `json:"action"`
+			Before json.RawMessage `json:"before"`
+			After  json.RawMessage `json:"after"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "AUDIT_ENTRIES_RETRIEVED", data.Code)
+	assert.Len(s.T(), data.Data, 1)
+	assert.Equal(s.T(), entities.AuditUserScopeUpdate, data.Data[0].Action)
+	assert.JSONEq(s.T(), before, string(data.Data[0].Before))
+	assert.JSONEq(s.T(), after, string(data.Data[0].After))
+}
+
+func (s *AuditHandlerSuite) TestListAllInvalidTime() {
+	w := s.serve("/audit?since=yesterday")
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *AuditHandlerSuite) TestListAllInvalidCursor() {
+	s.mockAuditSvc.EXPECT().FindAll(gomock.Any(), gomock.Any()).
+		Return(nil, nil, apperrors.BadRequest(dto.CodeInvalidPagination, "invalid pagination parameters", nil))
+
+	w := s.serve("/audit?cursor=invalid")
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeInvalidPagination, response.Code)
+	assert.Equal(s.T(), "Failed to retrieve audit entries", response.Message)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
+)
+
+type authzHandler struct {
+	authzService  services.IAuthzService
+	jwtMiddleware middlewares.IJWTMiddleware
+}
+
+func NewAuthzHandler(authzService services.IAuthzService, jwtMiddleware middlewares.IJWTMiddleware) *authzHandler {
+	return &authzHandler{authzService, jwtMiddleware}
+}
+
+func (h *authzHandler) Routes() []Route {
+	check := middlewares.AllOf("authz:check")
+	return []Route{
+		{http.MethodPost, "/authz/check", check, h.Check},
+		{http.MethodPost, "/authz/check/batch", check, h.CheckBatch},
+	}
+}
+
+func (h *authzHandler) SetupRoutes(r *gin.Engine) {
+	registerRoutes(r, h.jwtMiddleware, h.Routes())
+}
+
+// Check godoc
+// @Summary Check a subject's scopes
+// @Description Decide whether a user currently holds every required scope, from its live direct, role and group grants rather than the scopes in its token (requires authz:check). A denial is a successful response with allowed set to false and a reason.
+// @Tags authz
+// @Accept json
+// @Produce json
+// @Param body body dto.AuthzCheckRequest true "Subject and required scopes"
+// @Success 200 {object} dto.APIResponse{data=dto.AuthzDecision} "Authorization checked successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 422 {object} dto.APIResponse "Required scope is malformed or a pattern"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /authz/check [post]
+func (h *authzHandler) Check(c *gin.Context) {
+	var req dto.AuthzCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	decision, err := h.authzService.Check(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err, "Failed to check authorization")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "AUTHZ_CHECKED",
+		Message: "Authorization checked successfully",
+		Data:    decision,
+	})
+}
+
+// CheckBatch godoc
+// @Summary Check several subjects' scopes
+// @Description Answer up to 100 authorization checks at once, in order (requires authz:check)
+// @Tags authz
+// @Accept json
+// @Produce json
+// @Param body body dto.AuthzBatchCheckRequest true "Checks"
+// @Success 200 {object} dto.APIResponse{data=[]dto.AuthzDecision} "Authorizations checked successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 422 {object} dto.APIResponse "Required scope is malformed or a pattern"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /authz/check/batch [post]
+func (h *authzHandler) CheckBatch(c *gin.Context) {
+	var req dto.AuthzBatchCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	decisions, err := h.authzService.CheckBatch(c.Request.Context(), req.Checks)
+	if err != nil {
+		abortWithError(c, err, "Failed to check authorizations")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "AUTHZ_CHECKED",
+		Message: "Authorizations checked successfully",
+		Data:    decisions,
+	})
+}
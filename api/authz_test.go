@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+)
+
+type AuthzHandlerSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	authzHandler *authzHandler
+	mockAuthzSvc *services.MockIAuthzService
+	mockJWT      *middlewares.MockIJWTMiddleware
+	mockLogger   *logger.MockILogger
+	router       *gin.Engine
+}
+
+func (s *AuthzHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.ctrl = gomock.NewController(s.T())
+	s.mockAuthzSvc = services.NewMockIAuthzService(s.ctrl)
+	s.mockJWT = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.authzHandler = NewAuthzHandler(s.mockAuthzSvc, s.mockJWT)
+	s.router = gin.New()
+	s.router.Use(pkgmiddlewares.ErrorHandler(s.mockLogger))
+
+	s.mockJWT.EXPECT().Require(gomock.Any()).Return(func(c *gin.Context) {
+		c.Next()
+	}).AnyTimes()
+
+	s.authzHandler.SetupRoutes(s.router)
+}
+
+func (s *AuthzHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestAuthzHandlerSuite(t *testing.T) {
+	suite.Run(t, new(AuthzHandlerSuite))
+}
+
+func (s *AuthzHandlerSuite) serve(path string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(body)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", path, &buf)
+	httpReq.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(w, httpReq)
+	return w
+}
+
+func (s *AuthzHandlerSuite) TestCheck() {
+	check := dto.AuthzCheckRequest{Subject: "user1", Scopes: []string{"container:view", "user:manage"}}
+	decision := &dto.AuthzDecision{Subject: "user1", Scopes: check.Scopes, Reason: dto.AuthzReasonMissingScope, Missing: []string{"user:manage"}}
+	s.mockAuthzSvc.EXPECT().Check(gomock.Any(), check).Return(decision, nil)
+
+	w := s.serve("/authz/check", check)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Code string            `json:"code"`
+		Data dto.AuthzDecision `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "AUTHZ_CHECKED", data.Code)
+	assert.Equal(s.T(), *decision, data.Data)
+}
+
+func (s *AuthzHandlerSuite) TestCheckInvalidInput() {
+	w := s.serve("/authz/check", dto.AuthzCheckRequest{Subject: "user1"})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *AuthzHandlerSuite) TestCheckInvalidScope() {
+	check := dto.AuthzCheckRequest{Subject: "user1", Scopes: []string{"container:*"}}
+	s.mockAuthzSvc.EXPECT().Check(gomock.Any(), check).Return(nil, apperrors.Validation(dto.CodeInvalidScope, "invalid scope name", nil))
+
+	w := s.serve("/authz/check", check)
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, w.Code)
+}
+
+func (s *AuthzHandlerSuite) TestCheckBatch() {
+	checks := []dto.AuthzCheckRequest{
+		{Subject: "user1", Scopes: []string{"container:view"}},
+		{Subject: "ghost", Scopes: []string{"container:view"}},
+	}
+	decisions := []*dto.AuthzDecision{
+		{Subject: "user1", Scopes: checks[0].Scopes, Allowed: true, Reason: dto.AuthzReasonGranted},
+		{Subject: "ghost", Scopes: checks[1].Scopes, Reason: dto.AuthzReasonUnknownSubject},
+	}
+	s.mockAuthzSvc.EXPECT().CheckBatch(gomock.Any(), checks).Return(decisions, nil)
+
+	w := s.serve("/authz/check/batch", dto.AuthzBatchCheckRequest{Checks: checks})
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Data []dto.AuthzDecision `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), data.Data, 2)
+	assert.True(s.T(), data.Data[0].Allowed)
+	assert.Equal(s.T(), dto.AuthzReasonUnknownSubject, data.Data[1].Reason)
+}
+
+func (s *AuthzHandlerSuite) TestCheckBatchInvalidInput() {
+	w := s.serve("/authz/check/batch", dto.AuthzBatchCheckRequest{Checks: []dto.AuthzCheckRequest{{Subject: "user1"}}})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
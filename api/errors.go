@@ -0,0 +1,11 @@
+package api
+
+import "github.com/gin-gonic/gin"
+
+// abortWithError hands err to middlewares.ErrorHandler, which picks the HTTP
+// status and response code from the domain error kind. message describes the
+// operation that failed and becomes the response message.
+func abortWithError(c *gin.Context, err error, message string) {
+	_ = c.Error(err).SetMeta(message)
+	c.Abort()
+}
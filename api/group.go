@@ -0,0 +1,321 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
+)
+
+type groupHandler struct {
+	scopeService  services.IScopeService
+	groupService  services.IGroupService
+	userService   services.IUserService
+	jwtMiddleware middlewares.IJWTMiddleware
+}
+
+func NewGroupHandler(scopeService services.IScopeService, groupService services.IGroupService, userService services.IUserService, jwtMiddleware middlewares.IJWTMiddleware) *groupHandler {
+	return &groupHandler{scopeService, groupService, userService, jwtMiddleware}
+}
+
+func (h *groupHandler) Routes() []Route {
+	manage := middlewares.AllOf("group:manage")
+	view := middlewares.AnyOf("group:manage", "group:view")
+	return []Route{
+		{http.MethodPost, "/groups/create", manage, h.Create},
+		{http.MethodGet, "/groups/list", view, h.ListAll},
+		{http.MethodGet, "/groups/:name", view, h.FindOne},
+		{http.MethodPut, "/groups/update/scope", manage, h.UpdateScope},
+		{http.MethodPut, "/groups/update/parent", manage, h.UpdateParent},
+		{http.MethodPut, "/groups/update/member", manage, h.UpdateMember},
+		{http.MethodDelete, "/groups/delete", manage, h.Delete},
+	}
+}
+
+func (h *groupHandler) SetupRoutes(r *gin.Engine) {
+	registerRoutes(r, h.jwtMiddleware, h.Routes())
+}
+
+// Create godoc
+// @Summary Create a new group
+// @Description Create a group, optionally nested under a parent group (admin only)
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateGroupRequest true "Group creation request"
+// @Success 201 {object} dto.APIResponse{data=dto.GroupResponse} "New group created successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Parent group or scope not found"
+// @Failure 409 {object} dto.APIResponse "Group already exists"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /groups/create [post]
+func (h *groupHandler) Create(c *gin.Context) {
+	var req dto.CreateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	var scopes []*entities.UserScope
+	if len(req.Scopes) > 0 {
+		var err error
+		scopes, err = h.scopeService.FindMany(c.Request.Context(), req.Scopes)
+		if err != nil {
+			abortWithError(c, err, "Failed to find scopes")
+			return
+		}
+	}
+
+	group, err := h.groupService.Create(c.Request.Context(), req.GroupName, req.Parent, scopes)
+	if err != nil {
+		abortWithError(c, err, "Failed to create group")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Code:    "GROUP_CREATED",
+		Message: "New group created successfully",
+		Data:    dto.NewGroupResponse(group),
+	})
+}
+
+// ListAll godoc
+// @Summary List groups
+// @Description Retrieve a cursor-paginated page of groups (requires group:manage or group:view)
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param cursor query string false "Cursor returned as paging.next_cursor by the previous page"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param name_prefix query string false "Only groups whose name starts with this prefix"
+// @Param sort_by query string false "Sort field" Enums(id, name)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} dto.APIResponse{data=[]dto.GroupResponse} "Groups retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /groups/list [get]
+func (h *groupHandler) ListAll(c *gin.Context) {
+	var req dto.ListGroupsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	groups, paging, err := h.groupService.FindAll(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve groups")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "GROUPS_RETRIEVED",
+		Message: "All groups retrieved successfully",
+		Data:    dto.NewGroupResponses(groups),
+		Paging:  paging,
+	})
+}
+
+// FindOne godoc
+// @Summary Get a group
+// @Description Retrieve a single group, its parent and its scopes by name (requires group:manage or group:view)
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param name path string true "Group name"
+// @Success 200 {object} dto.APIResponse{data=dto.GroupResponse} "Group retrieved successfully"
+// @Failure 404 {object} dto.APIResponse "Group not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /groups/{name} [get]
+func (h *groupHandler) FindOne(c *gin.Context) {
+	group, err := h.groupService.FindOne(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve group")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "GROUP_RETRIEVED",
+		Message: "Group retrieved successfully",
+		Data:    dto.NewGroupResponse(group),
+	})
+}
+
+// UpdateScope godoc
+// @Summary Update a group's scope
+// @Description Add or remove a scope from a group and revoke the refresh tokens of everyone who inherits from it (admin only)
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param body body dto.UpdateGroupScopeRequest true "Group name, scope, and whether to add or remove"
+// @Success 200 {object} dto.APIResponse "Group scope updated successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Group or scope not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /groups/update/scope [put]
+func (h *groupHandler) UpdateScope(c *gin.Context) {
+	var req dto.UpdateGroupScopeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	scope, err := h.scopeService.FindOne(c.Request.Context(), req.Scope)
+	if err != nil {
+		abortWithError(c, err, "Failed to find scope")
+		return
+	}
+
+	if err := h.groupService.UpdateScope(c.Request.Context(), req.GroupName, scope, req.IsAdded); err != nil {
+		abortWithError(c, err, "Failed to update group scope")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "GROUP_SCOPE_UPDATED",
+		Message: "Group scope updated successfully",
+	})
+}
+
+// UpdateParent godoc
+// @Summary Move a group
+// @Description Nest a group under a parent, or move it to the top level with an empty parent (admin only)
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param body body dto.UpdateGroupParentRequest true "Group name and new parent"
+// @Success 200 {object} dto.APIResponse "Group parent updated successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Group or parent not found"
+// @Failure 422 {object} dto.APIResponse "Nesting would create a cycle"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /groups/update/parent [put]
+func (h *groupHandler) UpdateParent(c *gin.Context) {
+	var req dto.UpdateGroupParentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := h.groupService.UpdateParent(c.Request.Context(), req.GroupName, req.Parent); err != nil {
+		abortWithError(c, err, "Failed to update group parent")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "GROUP_PARENT_UPDATED",
+		Message: "Group parent updated successfully",
+	})
+}
+
+// UpdateMember godoc
+// @Summary Update a group's membership
+// @Description Add a user to or remove a user from a group (admin only)
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param body body dto.UpdateGroupMemberRequest true "Group name, user ID, and whether to add or remove"
+// @Success 200 {object} dto.APIResponse "Group member updated successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Group or user not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /groups/update/member [put]
+func (h *groupHandler) UpdateMember(c *gin.Context) {
+	var req dto.UpdateGroupMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	user, err := h.userService.FindById(c.Request.Context(), req.UserId)
+	if err != nil {
+		abortWithError(c, err, "Failed to find user")
+		return
+	}
+
+	if err := h.groupService.UpdateMember(c.Request.Context(), req.GroupName, user, req.IsAdded); err != nil {
+		abortWithError(c, err, "Failed to update group member")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "GROUP_MEMBER_UPDATED",
+		Message: "Group member updated successfully",
+	})
+}
+
+// Delete godoc
+// @Summary Delete a group
+// @Description Delete a group; its child groups move to the top level (admin only)
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param body body dto.DeleteGroupRequest true "Group deletion request"
+// @Success 200 {object} dto.APIResponse "Group deleted successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Group not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /groups/delete [delete]
+func (h *groupHandler) Delete(c *gin.Context) {
+	var req dto.DeleteGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := h.groupService.Delete(c.Request.Context(), req.GroupName); err != nil {
+		abortWithError(c, err, "Failed to delete group")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "GROUP_DELETED",
+		Message: "Group deleted successfully",
+	})
+}
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+)
+
+type GroupHandlerSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	groupHandler *groupHandler
+	mockScopeSvc *services.MockIScopeService
+	mockGroupSvc *services.MockIGroupService
+	mockUserSvc  *services.MockIUserService
+	mockJWT      *middlewares.MockIJWTMiddleware
+	mockLogger   *logger.MockILogger
+	router       *gin.Engine
+}
+
+func (s *GroupHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.ctrl = gomock.NewController(s.T())
+	s.mockScopeSvc = services.NewMockIScopeService(s.ctrl)
+	s.mockGroupSvc = services.NewMockIGroupService(s.ctrl)
+	s.mockUserSvc = services.NewMockIUserService(s.ctrl)
+	s.mockJWT = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.groupHandler = NewGroupHandler(s.mockScopeSvc, s.mockGroupSvc, s.mockUserSvc, s.mockJWT)
+	s.router = gin.New()
+	s.router.Use(pkgmiddlewares.ErrorHandler(s.mockLogger))
+
+	s.mockJWT.EXPECT().Require(gomock.Any()).Return(func(c *gin.Context) {
+		c.Next()
+	}).AnyTimes()
+
+	s.groupHandler.SetupRoutes(s.router)
+}
+
+func (s *GroupHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestGroupHandlerSuite(t *testing.T) {
+	suite.Run(t, new(GroupHandlerSuite))
+}
+
+func (s *GroupHandlerSuite) serve(method, path string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest(method, path, &buf)
+	httpReq.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(w, httpReq)
+	return w
+}
+
+func (s *GroupHandlerSuite) TestCreate() {
+	req := dto.CreateGroupRequest{GroupName: "backend", Parent: "engineering", Scopes: []string{"container:view"}}
+	scopes := []*entities.UserScope{{ID: 1, Name: "container:view"}}
+	group := &entities.Group{ID: 2, Name: "backend", Parent: &entities.Group{ID: 1, Name: "engineering"}, Scopes: scopes}
+
+	s.mockScopeSvc.EXPECT().FindMany(gomock.Any(), req.Scopes).Return(scopes, nil)
+	s.mockGroupSvc.EXPECT().Create(gomock.Any(), "backend", "engineering", scopes).Return(group, nil)
+
+	w := s.serve("POST", "/groups/create", req)
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+
+	var data struct {
+		Code string            `json:"code"`
+		Data dto.GroupResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "GROUP_CREATED", data.Code)
+	assert.Equal(s.T(), dto.NewGroupResponse(group), data.Data)
+}
+
+func (s *GroupHandlerSuite) TestCreateInvalidInput() {
+	w := s.serve("POST", "/groups/create", dto.CreateGroupRequest{})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *GroupHandlerSuite) TestCreateParentNotFound() {
+	s.mockGroupSvc.EXPECT().Create(gomock.Any(), "backend", "missing", nil).Return(nil, apperrors.NotFound(dto.CodeGroupNotFound, "record not found", nil))
+
+	w := s.serve("POST", "/groups/create", dto.CreateGroupRequest{GroupName: "backend", Parent: "missing"})
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+func (s *GroupHandlerSuite) TestCreateConflict() {
+	s.mockGroupSvc.EXPECT().Create(gomock.Any(), "backend", "", nil).Return(nil, apperrors.Conflict(dto.CodeGroupAlreadyExists, "record already exists", nil))
+
+	w := s.serve("POST", "/groups/create", dto.CreateGroupRequest{GroupName: "backend"})
+	assert.Equal(s.T(), http.StatusConflict, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeGroupAlreadyExists, response.Code)
+	assert.Equal(s.T(), "Failed to create group", response.Message)
+}
+
+func (s *GroupHandlerSuite) TestListAll() {
+	groups := []*entities.Group{{ID: 1, Name: "backend"}, {ID: 2, Name: "engineering"}}
+
+	s.mockGroupSvc.EXPECT().FindAll(gomock.Any(), dto.ListGroupsRequest{SortBy: "name"}).Return(groups, &dto.Paging{Limit: 20}, nil)
+
+	w := s.serve("GET", "/groups/list?sort_by=name", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Code string              `json:"code"`
+		Data []dto.GroupResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "GROUPS_RETRIEVED", data.Code)
+	assert.Len(s.T(), data.Data, 2)
+}
+
+func (s *GroupHandlerSuite) TestListAllServiceError() {
+	s.mockGroupSvc.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("database error"))
+	s.mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	w := s.serve("GET", "/groups/list", nil)
+	assert.Equal(s.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (s *GroupHandlerSuite) TestFindOne() {
+	s.mockGroupSvc.EXPECT().FindOne(gomock.Any(), "backend").Return(&entities.Group{ID: 2, Name: "backend"}, nil)
+
+	w := s.serve("GET", "/groups/backend", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"code":"GROUP_RETRIEVED"`)
+}
+
+func (s *GroupHandlerSuite) TestUpdateScope() {
+	req := dto.UpdateGroupScopeRequest{GroupName: "backend", IsAdded: true, Scope: "container:view"}
+	scope := &entities.UserScope{ID: 1, Name: "container:view"}
+
+	s.mockScopeSvc.EXPECT().FindOne(gomock.Any(), req.Scope).Return(scope, nil)
+	s.mockGroupSvc.EXPECT().UpdateScope(gomock.Any(), req.GroupName, scope, true).Return(nil)
+
+	w := s.serve("PUT", "/groups/update/scope", req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"code":"GROUP_SCOPE_UPDATED"`)
+}
+
+func (s *GroupHandlerSuite) TestUpdateScopeScopeNotFound() {
+	req := dto.UpdateGroupScopeRequest{GroupName: "backend", IsAdded: true, Scope: "missing"}
+
+	s.mockScopeSvc.EXPECT().FindOne(gomock.Any(), req.Scope).Return(nil, apperrors.NotFound(dto.CodeScopeNotFound, "record not found", nil))
+
+	w := s.serve("PUT", "/groups/update/scope", req)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+func (s *GroupHandlerSuite) TestUpdateParent() {
+	req := dto.UpdateGroupParentRequest{GroupName: "backend", Parent: "engineering"}
+
+	s.mockGroupSvc.EXPECT().UpdateParent(gomock.Any(), "backend", "engineering").Return(nil)
+
+	w := s.serve("PUT", "/groups/update/parent", req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"code":"GROUP_PARENT_UPDATED"`)
+}
+
+func (s *GroupHandlerSuite) TestUpdateParentCycle() {
+	req := dto.UpdateGroupParentRequest{GroupName: "engineering", Parent: "backend"}
+
+	s.mockGroupSvc.EXPECT().UpdateParent(gomock.Any(), "engineering", "backend").Return(apperrors.Validation(dto.CodeGroupCycle, "group nesting would create a cycle", nil))
+
+	w := s.serve("PUT", "/groups/update/parent", req)
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeGroupCycle, response.Code)
+}
+
+func (s *GroupHandlerSuite) TestUpdateMember() {
+	req := dto.UpdateGroupMemberRequest{GroupName: "backend", UserId: "user-123", IsAdded: true}
+	user := &entities.User{ID: "user-123"}
+
+	s.mockUserSvc.EXPECT().FindById(gomock.Any(), "user-123").Return(user, nil)
+	s.mockGroupSvc.EXPECT().UpdateMember(gomock.Any(), "backend", user, true).Return(nil)
+
+	w := s.serve("PUT", "/groups/update/member", req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"code":"GROUP_MEMBER_UPDATED"`)
+}
+
+func (s *GroupHandlerSuite) TestUpdateMemberInvalidInput() {
+	w := s.serve("PUT", "/groups/update/member", dto.UpdateGroupMemberRequest{GroupName: "backend"})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *GroupHandlerSuite) TestUpdateMemberUserNotFound() {
+	req := dto.UpdateGroupMemberRequest{GroupName: "backend", UserId: "missing", IsAdded: true}
+
+	s.mockUserSvc.EXPECT().FindById(gomock.Any(), "missing").Return(nil, apperrors.NotFound(dto.CodeUserNotFound, "record not found", nil))
+
+	w := s.serve("PUT", "/groups/update/member", req)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "Failed to find user", response.Message)
+}
+
+func (s *GroupHandlerSuite) TestDelete() {
+	s.mockGroupSvc.EXPECT().Delete(gomock.Any(), "backend").Return(nil)
+
+	w := s.serve("DELETE", "/groups/delete", dto.DeleteGroupRequest{GroupName: "backend"})
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"code":"GROUP_DELETED"`)
+}
+
+func (s *GroupHandlerSuite) TestDeleteNotFound() {
+	s.mockGroupSvc.EXPECT().Delete(gomock.Any(), "missing").Return(apperrors.NotFound(dto.CodeGroupNotFound, "record not found", nil))
+
+	w := s.serve("DELETE", "/groups/delete", dto.DeleteGroupRequest{GroupName: "missing"})
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
+)
+
+type meHandler struct {
+	userService   services.IUserService
+	jwtMiddleware middlewares.IJWTMiddleware
+}
+
+func NewMeHandler(userService services.IUserService, jwtMiddleware middlewares.IJWTMiddleware) *meHandler {
+	return &meHandler{userService, jwtMiddleware}
+}
+
+func (h *meHandler) Routes() []Route {
+	return []Route{
+		{http.MethodGet, "/me", middlewares.Authenticated(), h.Profile},
+		{http.MethodPut, "/me/password", middlewares.Authenticated(), h.ChangePassword},
+		{http.MethodPost, "/me/logout", middlewares.Authenticated(), h.Logout},
+	}
+}
+
+func (h *meHandler) SetupRoutes(r *gin.Engine) {
+	registerRoutes(r, h.jwtMiddleware, h.Routes())
+}
+
+// Profile godoc
+// @Summary Get own profile
+// @Description Retrieve the profile and scopes of the authenticated user, including when temporary scopes expire
+// @Tags me
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=dto.UserResponse} "Profile retrieved successfully"
+// @Failure 404 {object} dto.APIResponse "User not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /me [get]
+func (h *meHandler) Profile(c *gin.Context) {
+	user, err := h.userService.FindById(c.Request.Context(), c.GetString("userId"))
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "PROFILE_RETRIEVED",
+		Message: "Profile retrieved successfully",
+		Data:    dto.NewUserResponse(user),
+	})
+}
+
+// ChangePassword godoc
+// @Summary Change own password
+// @Description Change the password of the authenticated user after re-verifying the current one. Revokes every refresh session of the user and every access token issued so far, including the one making the request.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param body body dto.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} dto.APIResponse "Password changed successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Current password is incorrect"
+// @Failure 404 {object} dto.APIResponse "User not found"
+// @Failure 422 {object} dto.APIResponse "New password rejected by the password policy"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /me/password [put]
+func (h *meHandler) ChangePassword(c *gin.Context) {
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := h.userService.ChangePassword(c.Request.Context(), c.GetString("userId"), req.CurrentPassword, req.NewPassword); err != nil {
+		abortWithError(c, err, "Failed to change password")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "PASSWORD_CHANGED",
+		Message: "Password changed successfully",
+	})
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description End the session of the access token making the request. A token issued for a refresh session revokes that session and its access tokens; otherwise the token itself is revoked, if it carries a jti, along with the user's refresh token
+// @Tags me
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse "Logged out successfully"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /me/logout [post]
+func (h *meHandler) Logout(c *gin.Context) {
+	if err := h.userService.Logout(c.Request.Context(), c.GetString("userId"), c.GetString("tokenId"), c.GetString("sessionId"), c.GetTime("tokenExpiresAt")); err != nil {
+		abortWithError(c, err, "Failed to log out")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "LOGGED_OUT",
+		Message: "Logged out successfully",
+	})
+}
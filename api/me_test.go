@@ -0,0 +1,194 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+)
+
+type MeHandlerSuite struct {
+	suite.Suite
+	ctrl        *gomock.Controller
+	meHandler   *meHandler
+	mockUserSvc *services.MockIUserService
+	mockJWT     *middlewares.MockIJWTMiddleware
+	mockLogger  *logger.MockILogger
+	router      *gin.Engine
+}
+
+func (s *MeHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.ctrl = gomock.NewController(s.T())
+	s.mockUserSvc = services.NewMockIUserService(s.ctrl)
+	s.mockJWT = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.meHandler = NewMeHandler(s.mockUserSvc, s.mockJWT)
+	s.router = gin.New()
+	s.router.Use(pkgmiddlewares.ErrorHandler(s.mockLogger))
+
+	// Mock the middleware to authenticate every request as user-123
+	s.mockJWT.EXPECT().Require(pkgmiddlewares.Authenticated()).Return(func(c *gin.Context) {
+		c.Set("userId", "user-123")
+		c.Set("tokenId", "token-1")
+		c.Set("sessionId", "session-1")
+		c.Set("tokenExpiresAt", time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+		c.Next()
+	}).AnyTimes()
+
+	s.meHandler.SetupRoutes(s.router)
+}
+
+func (s *MeHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestMeHandlerSuite(t *testing.T) {
+	suite.Run(t, new(MeHandlerSuite))
+}
+
+func (s *MeHandlerSuite) TestProfile() {
+	user := &entities.User{
+		ID:       "user-123",
+		Username: "testuser",
+		Hash:     "$2a$10$secret",
+		Email:    "test@example.com",
+		Scopes:   []*entities.UserScope{{ID: 1, Name: "read"}},
+	}
+	s.mockUserSvc.EXPECT().FindById(gomock.Any(), "user-123").Return(user, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.NotContains(s.T(), w.Body.String(), "secret")
+
+	var response struct {
+		dto.APIResponse
+		Data dto.UserResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), response.Success)
+	assert.Equal(s.T(), "PROFILE_RETRIEVED", response.Code)
+	assert.Equal(s.T(), "testuser", response.Data.Username)
+	assert.Len(s.T(), response.Data.Scopes, 1)
+}
+
+func (s *MeHandlerSuite) TestProfileNotFound() {
+	s.mockUserSvc.EXPECT().FindById(gomock.Any(), "user-123").
+		Return(nil, apperrors.NotFound(dto.CodeUserNotFound, "user not found", nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), response.Success)
+	assert.Equal(s.T(), dto.CodeUserNotFound, response.Code)
+}
+
+func (s *MeHandlerSuite) TestChangePassword() {
+	body, _ := json.Marshal(dto.ChangePasswordRequest{
+		CurrentPassword: "old-password",
+		NewPassword:     "new-password",
+	})
+	s.mockUserSvc.EXPECT().ChangePassword(gomock.Any(), "user-123", "old-password", "new-password").Return(nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/me/password", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), response.Success)
+	assert.Equal(s.T(), "PASSWORD_CHANGED", response.Code)
+}
+
+func (s *MeHandlerSuite) TestChangePasswordInvalidRequest() {
+	req := httptest.NewRequest(http.MethodPut, "/me/password", bytes.NewBufferString(`{"new_password":"new-password"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *MeHandlerSuite) TestChangePasswordWrongCurrent() {
+	body, _ := json.Marshal(dto.ChangePasswordRequest{
+		CurrentPassword: "wrong-password",
+		NewPassword:     "new-password",
+	})
+	s.mockUserSvc.EXPECT().ChangePassword(gomock.Any(), "user-123", "wrong-password", "new-password").
+		Return(apperrors.Forbidden(dto.CodeInvalidCredentials, "current password is incorrect", nil))
+
+	req := httptest.NewRequest(http.MethodPut, "/me/password", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusForbidden, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeInvalidCredentials, response.Code)
+	assert.Equal(s.T(), "Failed to change password", response.Message)
+}
+
+func (s *MeHandlerSuite) TestChangePasswordServiceError() {
+	body, _ := json.Marshal(dto.ChangePasswordRequest{
+		CurrentPassword: "old-password",
+		NewPassword:     "new-password",
+	})
+	s.mockUserSvc.EXPECT().ChangePassword(gomock.Any(), "user-123", "old-password", "new-password").
+		Return(errors.New("redis error"))
+
+	req := httptest.NewRequest(http.MethodPut, "/me/password", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (s *MeHandlerSuite) TestLogout() {
+	s.mockUserSvc.EXPECT().Logout(gomock.Any(), "user-123", "token-1", "session-1", time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/me/logout", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "LOGGED_OUT", response.Code)
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
+)
+
+const grantTypeClientCredentials = "client_credentials"
+
+type oauthHandler struct {
+	introspectionService  services.IIntrospectionService
+	serviceAccountService services.IServiceAccountService
+	jwtMiddleware         middlewares.IJWTMiddleware
+}
+
+func NewOAuthHandler(introspectionService services.IIntrospectionService, serviceAccountService services.IServiceAccountService, jwtMiddleware middlewares.IJWTMiddleware) *oauthHandler {
+	return &oauthHandler{introspectionService, serviceAccountService, jwtMiddleware}
+}
+
+func (h *oauthHandler) Routes() []Route {
+	return []Route{
+		{http.MethodPost, "/oauth/introspect", middlewares.AllOf("token:introspect"), h.Introspect},
+	}
+}
+
+// SetupRoutes also registers the token endpoint, which is left out of Routes
+// because clients authenticate there with their credentials rather than a
+// bearer token.
+func (h *oauthHandler) SetupRoutes(r *gin.Engine) {
+	registerRoutes(r, h.jwtMiddleware, h.Routes())
+	r.POST("/oauth/token", h.Token)
+}
+
+// Token godoc
+// @Summary Issue a client_credentials access token
+// @Description RFC 6749 client_credentials grant for service accounts. The client authenticates with HTTP Basic or with client_id and client_secret in the body, not both. The token carries the requested scopes, or every scope of the account when scope is omitted, and is accepted like any other bearer token.
+// @Tags oauth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "Must be client_credentials"
+// @Param client_id formData string false "Service account id, unless sent with HTTP Basic"
+// @Param client_secret formData string false "Service account secret, unless sent with HTTP Basic"
+// @Param scope formData string false "Space-delimited scopes to request"
+// @Success 200 {object} dto.TokenResponse "Access token issued"
+// @Failure 400 {object} dto.OAuthError "Invalid request, unsupported grant type or invalid scope"
+// @Failure 401 {object} dto.OAuthError "Client authentication failed"
+// @Failure 500 {object} dto.OAuthError "Internal server error"
+// @Router /oauth/token [post]
+func (h *oauthHandler) Token(c *gin.Context) {
+	var req dto.TokenRequest
+	if err := c.ShouldBind(&req); err != nil {
+		oauthError(c, http.StatusBadRequest, "invalid_request", "grant_type is required")
+		return
+	}
+	if req.GrantType != grantTypeClientCredentials {
+		oauthError(c, http.StatusBadRequest, "unsupported_grant_type", "only client_credentials is supported")
+		return
+	}
+
+	clientId, clientSecret, basic := c.Request.BasicAuth()
+	if basic {
+		if req.ClientID != "" || req.ClientSecret != "" {
+			oauthError(c, http.StatusBadRequest, "invalid_request", "client credentials must be sent one way only")
+			return
+		}
+		// RFC 6749 form-encodes the credentials before Basic encoding them.
+		var idErr, secretErr error
+		clientId, idErr = url.QueryUnescape(clientId)
+		clientSecret, secretErr = url.QueryUnescape(clientSecret)
+		if idErr != nil || secretErr != nil {
+			oauthError(c, http.StatusBadRequest, "invalid_request", "client credentials are malformed")
+			return
+		}
+	} else {
+		clientId, clientSecret = req.ClientID, req.ClientSecret
+	}
+	if clientId == "" || clientSecret == "" {
+		invalidClient(c, basic)
+		return
+	}
+
+	response, err := h.serviceAccountService.IssueToken(c.Request.Context(), clientId, clientSecret, strings.Fields(req.Scope))
+	if err != nil {
+		appErr, ok := apperrors.As(err)
+		switch {
+		case ok && appErr.Code == dto.CodeInvalidClient:
+			invalidClient(c, basic)
+		case ok && appErr.Code == dto.CodeInvalidScope:
+			oauthError(c, http.StatusBadRequest, "invalid_scope", appErr.Message)
+		default:
+			oauthError(c, http.StatusInternalServerError, "server_error", "")
+		}
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.JSON(http.StatusOK, response)
+}
+
+// Introspect godoc
+// @Summary Introspect an access token
+// @Description RFC 7662 token introspection. The calling client authenticates with its own bearer token (requires token:introspect). A token is active when its signature and claims are valid, it has not been revoked and its subject still exists; otherwise only active=false is returned.
+// @Tags oauth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param token formData string true "Token to introspect"
+// @Param token_type_hint formData string false "Type of the token, ignored"
+// @Success 200 {object} dto.IntrospectionResponse "Token introspected"
+// @Failure 400 {object} dto.OAuthError "Invalid request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /oauth/introspect [post]
+func (h *oauthHandler) Introspect(c *gin.Context) {
+	var req dto.IntrospectRequest
+	if err := c.ShouldBind(&req); err != nil {
+		oauthError(c, http.StatusBadRequest, "invalid_request", "token is required")
+		return
+	}
+
+	response, err := h.introspectionService.Introspect(c.Request.Context(), req.Token)
+	if err != nil {
+		abortWithError(c, err, "Failed to introspect token")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response)
+}
+
+func oauthError(c *gin.Context, status int, code, description string) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, dto.OAuthError{
+		Error:            code,
+		ErrorDescription: description,
+	})
+}
+
+// invalidClient challenges for Basic credentials when the client sent them,
+// as RFC 6749 requires.
+func invalidClient(c *gin.Context, basic bool) {
+	if basic {
+		c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	oauthError(c, http.StatusUnauthorized, "invalid_client", "client authentication failed")
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+)
+
+type OAuthHandlerSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	oauthHandler         *oauthHandler
+	mockIntrospectionSvc *services.MockIIntrospectionService
+	mockAccountSvc       *services.MockIServiceAccountService
+	mockJWT              *middlewares.MockIJWTMiddleware
+	mockLogger           *logger.MockILogger
+	router               *gin.Engine
+}
+
+func (s *OAuthHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.ctrl = gomock.NewController(s.T())
+	s.mockIntrospectionSvc = services.NewMockIIntrospectionService(s.ctrl)
+	s.mockAccountSvc = services.NewMockIServiceAccountService(s.ctrl)
+	s.mockJWT = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.oauthHandler = NewOAuthHandler(s.mockIntrospectionSvc, s.mockAccountSvc, s.mockJWT)
+	s.router = gin.New()
+	s.router.Use(pkgmiddlewares.ErrorHandler(s.mockLogger))
+
+	s.mockJWT.EXPECT().Require(gomock.Any()).Return(func(c *gin.Context) {
+		c.Next()
+	}).AnyTimes()
+
+	s.oauthHandler.SetupRoutes(s.router)
+}
+
+func (s *OAuthHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestOAuthHandlerSuite(t *testing.T) {
+	suite.Run(t, new(OAuthHandlerSuite))
+}
+
+func (s *OAuthHandlerSuite) serve(path string, form url.Values) *httptest.ResponseRecorder {
+	return s.serveBasic(path, form, "", "")
+}
+
+func (s *OAuthHandlerSuite) serveBasic(path string, form url.Values, username, password string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", path, strings.NewReader(form.Encode()))
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if username != "" {
+		httpReq.SetBasicAuth(username, password)
+	}
+	s.router.ServeHTTP(w, httpReq)
+	return w
+}
+
+func (s *OAuthHandlerSuite) oauthError(w *httptest.ResponseRecorder) string {
+	var response dto.OAuthError
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	return response.Error
+}
+
+func (s *OAuthHandlerSuite) TestIntrospect() {
+	response := &dto.IntrospectionResponse{Active: true, Scope: "container:view", Sub: "user1", Exp: 1900000000, ClientID: "dashboard"}
+	s.mockIntrospectionSvc.EXPECT().Introspect(gomock.Any(), "token").Return(response, nil)
+
+	w := s.serve("/oauth/introspect", url.Values{"token": {"token"}, "token_type_hint": {"access_token"}})
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Equal(s.T(), "no-store", w.Header().Get("Cache-Control"))
+	assert.JSONEq(s.T(), `{"active":true,"scope":"container:view","sub":"user1","exp":1900000000,"client_id":"dashboard"}`, w.Body.String())
+}
+
+func (s *OAuthHandlerSuite) TestIntrospectInactive() {
+	s.mockIntrospectionSvc.EXPECT().Introspect(gomock.Any(), "token").Return(&dto.IntrospectionResponse{Active: false}, nil)
+
+	w := s.serve("/oauth/introspect", url.Values{"token": {"token"}})
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.JSONEq(s.T(), `{"active":false}`, w.Body.String())
+}
+
+func (s *OAuthHandlerSuite) TestIntrospectMissingToken() {
+	w := s.serve("/oauth/introspect", url.Values{})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+
+	var response dto.OAuthError
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "invalid_request", response.Error)
+}
+
+func (s *OAuthHandlerSuite) TestIntrospectServiceError() {
+	s.mockIntrospectionSvc.EXPECT().Introspect(gomock.Any(), "token").Return(nil, errors.New("db error"))
+	s.mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	w := s.serve("/oauth/introspect", url.Values{"token": {"token"}})
+	assert.Equal(s.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (s *OAuthHandlerSuite) TestToken() {
+	response := &dto.TokenResponse{AccessToken: "jwt", TokenType: "Bearer", ExpiresIn: 900, Scope: "container:view"}
+	s.mockAccountSvc.EXPECT().IssueToken(gomock.Any(), "sa1", "secret", []string{"container:view"}).Return(response, nil)
+
+	w := s.serve("/oauth/token", url.Values{"grant_type": {"client_credentials"}, "client_id": {"sa1"}, "client_secret": {"secret"}, "scope": {"container:view"}})
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Equal(s.T(), "no-store", w.Header().Get("Cache-Control"))
+	assert.JSONEq(s.T(), `{"access_token":"jwt","token_type":"Bearer","expires_in":900,"scope":"container:view"}`, w.Body.String())
+}
+
+func (s *OAuthHandlerSuite) TestTokenBasicAuth() {
+	response := &dto.TokenResponse{AccessToken: "jwt", TokenType: "Bearer", ExpiresIn: 900}
+	s.mockAccountSvc.EXPECT().IssueToken(gomock.Any(), "sa1", "se cret", []string{}).Return(response, nil)
+
+	w := s.serveBasic("/oauth/token", url.Values{"grant_type": {"client_credentials"}}, "sa1", "se+cret")
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+func (s *OAuthHandlerSuite) TestTokenUnsupportedGrantType() {
+	w := s.serve("/oauth/token", url.Values{"grant_type": {"password"}})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(s.T(), "unsupported_grant_type", s.oauthError(w))
+}
+
+func (s *OAuthHandlerSuite) TestTokenCredentialsSentTwice() {
+	w := s.serveBasic("/oauth/token", url.Values{"grant_type": {"client_credentials"}, "client_id": {"sa1"}}, "sa1", "secret")
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(s.T(), "invalid_request", s.oauthError(w))
+}
+
+func (s *OAuthHandlerSuite) TestTokenMissingCredentials() {
+	w := s.serve("/oauth/token", url.Values{"grant_type": {"client_credentials"}, "client_id": {"sa1"}})
+	assert.Equal(s.T(), http.StatusUnauthorized, w.Code)
+	assert.Equal(s.T(), "invalid_client", s.oauthError(w))
+}
+
+func (s *OAuthHandlerSuite) TestTokenInvalidClient() {
+	s.mockAccountSvc.EXPECT().IssueToken(gomock.Any(), "sa1", "wrong", []string{}).Return(nil, apperrors.Unauthorized(dto.CodeInvalidClient, "client authentication failed", nil))
+
+	w := s.serveBasic("/oauth/token", url.Values{"grant_type": {"client_credentials"}}, "sa1", "wrong")
+	assert.Equal(s.T(), http.StatusUnauthorized, w.Code)
+	assert.Equal(s.T(), `Basic realm="oauth"`, w.Header().Get("WWW-Authenticate"))
+	assert.Equal(s.T(), "invalid_client", s.oauthError(w))
+}
+
+func (s *OAuthHandlerSuite) TestTokenInvalidScope() {
+	s.mockAccountSvc.EXPECT().IssueToken(gomock.Any(), "sa1", "secret", []string{"user:manage"}).Return(nil, apperrors.Validation(dto.CodeInvalidScope, "requested scope is not granted to the client", nil))
+
+	w := s.serve("/oauth/token", url.Values{"grant_type": {"client_credentials"}, "client_id": {"sa1"}, "client_secret": {"secret"}, "scope": {"user:manage"}})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(s.T(), "invalid_scope", s.oauthError(w))
+}
+
+func (s *OAuthHandlerSuite) TestTokenServiceError() {
+	s.mockAccountSvc.EXPECT().IssueToken(gomock.Any(), "sa1", "secret", []string{}).Return(nil, errors.New("db error"))
+
+	w := s.serve("/oauth/token", url.Values{"grant_type": {"client_credentials"}, "client_id": {"sa1"}, "client_secret": {"secret"}})
+	assert.Equal(s.T(), http.StatusInternalServerError, w.Code)
+	assert.Equal(s.T(), "server_error", s.oauthError(w))
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
+)
+
+type organizationHandler struct {
+	organizationService services.IOrganizationService
+	jwtMiddleware       middlewares.IJWTMiddleware
+}
+
+func NewOrganizationHandler(organizationService services.IOrganizationService, jwtMiddleware middlewares.IJWTMiddleware) *organizationHandler {
+	return &organizationHandler{organizationService, jwtMiddleware}
+}
+
+func (h *organizationHandler) Routes() []Route {
+	manage := middlewares.AllOf("organization:manage")
+	return []Route{
+		{http.MethodPost, "/organizations/create", manage, h.Create},
+		{http.MethodGet, "/organizations/list", manage, h.ListAll},
+		{http.MethodGet, "/organizations/:id", manage, h.FindOne},
+	}
+}
+
+func (h *organizationHandler) SetupRoutes(r *gin.Engine) {
+	registerRoutes(r, h.jwtMiddleware, h.Routes())
+}
+
+// Create godoc
+// @Summary Create a new organization
+// @Description Create a tenant organization (super-admin only). Users, scopes, roles and groups are then created in it by sending its id in the X-Organization-ID header.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateOrganizationRequest true "Organization creation request"
+// @Success 201 {object} dto.APIResponse{data=dto.OrganizationResponse} "New organization created successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Caller is not a super-admin"
+// @Failure 409 {object} dto.APIResponse "Organization already exists"
+// @Failure 422 {object} dto.APIResponse "Invalid organization id"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/create [post]
+func (h *organizationHandler) Create(c *gin.Context) {
+	var req dto.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	organization, err := h.organizationService.Create(c.Request.Context(), req.OrganizationID, req.Name)
+	if err != nil {
+		abortWithError(c, err, "Failed to create organization")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Code:    "ORGANIZATION_CREATED",
+		Message: "New organization created successfully",
+		Data:    dto.NewOrganizationResponse(organization),
+	})
+}
+
+// ListAll godoc
+// @Summary List organizations
+// @Description Retrieve a cursor-paginated page of organizations (super-admin only)
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param cursor query string false "Cursor returned as paging.next_cursor by the previous page"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param name_prefix query string false "Only organizations whose name starts with this prefix"
+// @Param sort_by query string false "Sort field" Enums(id, name)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} dto.APIResponse{data=[]dto.OrganizationResponse} "Organizations retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Caller is not a super-admin"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/list [get]
+func (h *organizationHandler) ListAll(c *gin.Context) {
+	var req dto.ListOrganizationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	organizations, paging, err := h.organizationService.FindAll(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve organizations")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "ORGANIZATIONS_RETRIEVED",
+		Message: "All organizations retrieved successfully",
+		Data:    dto.NewOrganizationResponses(organizations),
+		Paging:  paging,
+	})
+}
+
+// FindOne godoc
+// @Summary Get an organization
+// @Description Retrieve a single organization by id (super-admin only)
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} dto.APIResponse{data=dto.OrganizationResponse} "Organization retrieved successfully"
+// @Failure 403 {object} dto.APIResponse "Caller is not a super-admin"
+// @Failure 404 {object} dto.APIResponse "Organization not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id} [get]
+func (h *organizationHandler) FindOne(c *gin.Context) {
+	organization, err := h.organizationService.FindOne(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve organization")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "ORGANIZATION_RETRIEVED",
+		Message: "Organization retrieved successfully",
+		Data:    dto.NewOrganizationResponse(organization),
+	})
+}
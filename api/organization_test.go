@@ -0,0 +1,147 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+)
+
+type OrganizationHandlerSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	organizationHandler *organizationHandler
+	mockOrganizationSvc *services.MockIOrganizationService
+	mockJWT             *middlewares.MockIJWTMiddleware
+	mockLogger          *logger.MockILogger
+	router              *gin.Engine
+}
+
+func (s *OrganizationHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.ctrl = gomock.NewController(s.T())
+	s.mockOrganizationSvc = services.NewMockIOrganizationService(s.ctrl)
+	s.mockJWT = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.organizationHandler = NewOrganizationHandler(s.mockOrganizationSvc, s.mockJWT)
+	s.router = gin.New()
+	s.router.Use(pkgmiddlewares.ErrorHandler(s.mockLogger))
+
+	s.mockJWT.EXPECT().Require(gomock.Any()).Return(func(c *gin.Context) {
+		c.Next()
+	}).AnyTimes()
+
+	s.organizationHandler.SetupRoutes(s.router)
+}
+
+func (s *OrganizationHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestOrganizationHandlerSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationHandlerSuite))
+}
+
+func (s *OrganizationHandlerSuite) serve(method, path string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest(method, path, &buf)
+	httpReq.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(w, httpReq)
+	return w
+}
+
+func (s *OrganizationHandlerSuite) TestCreate() {
+	req := dto.CreateOrganizationRequest{OrganizationID: "acme", Name: "Acme Corp"}
+	organization := &entities.Organization{ID: "acme", Name: "Acme Corp"}
+
+	s.mockOrganizationSvc.EXPECT().Create(gomock.Any(), "acme", "Acme Corp").Return(organization, nil)
+
+	w := s.serve("POST", "/organizations/create", req)
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+
+	var data struct {
+		Code string                   `json:"code"`
+		Data dto.OrganizationResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "ORGANIZATION_CREATED", data.Code)
+	assert.Equal(s.T(), dto.NewOrganizationResponse(organization), data.Data)
+}
+
+func (s *OrganizationHandlerSuite) TestCreateInvalidInput() {
+	w := s.serve("POST", "/organizations/create", dto.CreateOrganizationRequest{Name: "Acme Corp"})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *OrganizationHandlerSuite) TestCreateForbidden() {
+	s.mockOrganizationSvc.EXPECT().Create(gomock.Any(), "acme", "Acme Corp").Return(nil, apperrors.Forbidden(dto.CodeTenantForbidden, "only super-admins manage organizations", nil))
+
+	w := s.serve("POST", "/organizations/create", dto.CreateOrganizationRequest{OrganizationID: "acme", Name: "Acme Corp"})
+	assert.Equal(s.T(), http.StatusForbidden, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeTenantForbidden, response.Code)
+	assert.Equal(s.T(), "Failed to create organization", response.Message)
+}
+
+func (s *OrganizationHandlerSuite) TestListAll() {
+	organizations := []*entities.Organization{{ID: "acme", Name: "Acme Corp"}, {ID: "globex", Name: "Globex"}}
+
+	s.mockOrganizationSvc.EXPECT().FindAll(gomock.Any(), dto.ListOrganizationsRequest{SortBy: "name"}).Return(organizations, &dto.Paging{Limit: 20}, nil)
+
+	w := s.serve("GET", "/organizations/list?sort_by=name", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Code string                     `json:"code"`
+		Data []dto.OrganizationResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "ORGANIZATIONS_RETRIEVED", data.Code)
+	assert.Len(s.T(), data.Data, 2)
+}
+
+func (s *OrganizationHandlerSuite) TestListAllInvalidQuery() {
+	w := s.serve("GET", "/organizations/list?sort_by=users", nil)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *OrganizationHandlerSuite) TestFindOne() {
+	organization := &entities.Organization{ID: "acme", Name: "Acme Corp"}
+
+	s.mockOrganizationSvc.EXPECT().FindOne(gomock.Any(), "acme").Return(organization, nil)
+
+	w := s.serve("GET", "/organizations/acme", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"code":"ORGANIZATION_RETRIEVED"`)
+}
+
+func (s *OrganizationHandlerSuite) TestFindOneNotFound() {
+	s.mockOrganizationSvc.EXPECT().FindOne(gomock.Any(), "missing").Return(nil, apperrors.NotFound(dto.CodeOrganizationNotFound, "record not found", nil))
+
+	w := s.serve("GET", "/organizations/missing", nil)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
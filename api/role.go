@@ -0,0 +1,237 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
+)
+
+type roleHandler struct {
+	scopeService  services.IScopeService
+	roleService   services.IRoleService
+	jwtMiddleware middlewares.IJWTMiddleware
+}
+
+func NewRoleHandler(scopeService services.IScopeService, roleService services.IRoleService, jwtMiddleware middlewares.IJWTMiddleware) *roleHandler {
+	return &roleHandler{scopeService, roleService, jwtMiddleware}
+}
+
+func (h *roleHandler) Routes() []Route {
+	manage := middlewares.AllOf("role:manage")
+	view := middlewares.AnyOf("role:manage", "role:view")
+	return []Route{
+		{http.MethodPost, "/roles/create", manage, h.Create},
+		{http.MethodGet, "/roles/list", view, h.ListAll},
+		{http.MethodGet, "/roles/:name", view, h.FindOne},
+		{http.MethodPut, "/roles/update/scope", manage, h.UpdateScope},
+		{http.MethodDelete, "/roles/delete", manage, h.Delete},
+	}
+}
+
+func (h *roleHandler) SetupRoutes(r *gin.Engine) {
+	registerRoutes(r, h.jwtMiddleware, h.Routes())
+}
+
+// Create godoc
+// @Summary Create a new role
+// @Description Create a named bundle of scopes (admin only)
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateRoleRequest true "Role creation request"
+// @Success 201 {object} dto.APIResponse{data=dto.RoleResponse} "New role created successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Scope not found"
+// @Failure 409 {object} dto.APIResponse "Role already exists"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /roles/create [post]
+func (h *roleHandler) Create(c *gin.Context) {
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	var scopes []*entities.UserScope
+	if len(req.Scopes) > 0 {
+		var err error
+		scopes, err = h.scopeService.FindMany(c.Request.Context(), req.Scopes)
+		if err != nil {
+			abortWithError(c, err, "Failed to find scopes")
+			return
+		}
+	}
+
+	role, err := h.roleService.Create(c.Request.Context(), req.RoleName, scopes)
+	if err != nil {
+		abortWithError(c, err, "Failed to create role")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Code:    "ROLE_CREATED",
+		Message: "New role created successfully",
+		Data:    dto.NewRoleResponse(role),
+	})
+}
+
+// ListAll godoc
+// @Summary List roles
+// @Description Retrieve a cursor-paginated page of roles (requires role:manage or role:view)
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param cursor query string false "Cursor returned as paging.next_cursor by the previous page"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param name_prefix query string false "Only roles whose name starts with this prefix"
+// @Param sort_by query string false "Sort field" Enums(id, name)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} dto.APIResponse{data=[]dto.RoleResponse} "Roles retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /roles/list [get]
+func (h *roleHandler) ListAll(c *gin.Context) {
+	var req dto.ListRolesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	roles, paging, err := h.roleService.FindAll(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve roles")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "ROLES_RETRIEVED",
+		Message: "All roles retrieved successfully",
+		Data:    dto.NewRoleResponses(roles),
+		Paging:  paging,
+	})
+}
+
+// FindOne godoc
+// @Summary Get a role
+// @Description Retrieve a single role and its scopes by name (requires role:manage or role:view)
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param name path string true "Role name"
+// @Success 200 {object} dto.APIResponse{data=dto.RoleResponse} "Role retrieved successfully"
+// @Failure 404 {object} dto.APIResponse "Role not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /roles/{name} [get]
+func (h *roleHandler) FindOne(c *gin.Context) {
+	role, err := h.roleService.FindOne(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve role")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "ROLE_RETRIEVED",
+		Message: "Role retrieved successfully",
+		Data:    dto.NewRoleResponse(role),
+	})
+}
+
+// UpdateScope godoc
+// @Summary Update a role's scope
+// @Description Add or remove a scope from a role and revoke the refresh tokens of its holders (admin only)
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param body body dto.UpdateRoleScopeRequest true "Role name, scope, and whether to add or remove"
+// @Success 200 {object} dto.APIResponse "Role scope updated successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Role or scope not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /roles/update/scope [put]
+func (h *roleHandler) UpdateScope(c *gin.Context) {
+	var req dto.UpdateRoleScopeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	scope, err := h.scopeService.FindOne(c.Request.Context(), req.Scope)
+	if err != nil {
+		abortWithError(c, err, "Failed to find scope")
+		return
+	}
+
+	if err := h.roleService.UpdateScope(c.Request.Context(), req.RoleName, scope, req.IsAdded); err != nil {
+		abortWithError(c, err, "Failed to update role scope")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "ROLE_SCOPE_UPDATED",
+		Message: "Role scope updated successfully",
+	})
+}
+
+// Delete godoc
+// @Summary Delete a role
+// @Description Delete a role and revoke the refresh tokens of its holders (admin only)
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param body body dto.DeleteRoleRequest true "Role deletion request"
+// @Success 200 {object} dto.APIResponse "Role deleted successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Role not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /roles/delete [delete]
+func (h *roleHandler) Delete(c *gin.Context) {
+	var req dto.DeleteRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := h.roleService.Delete(c.Request.Context(), req.RoleName); err != nil {
+		abortWithError(c, err, "Failed to delete role")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "ROLE_DELETED",
+		Message: "Role deleted successfully",
+	})
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+)
+
+type RoleHandlerSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	roleHandler  *roleHandler
+	mockScopeSvc *services.MockIScopeService
+	mockRoleSvc  *services.MockIRoleService
+	mockJWT      *middlewares.MockIJWTMiddleware
+	mockLogger   *logger.MockILogger
+	router       *gin.Engine
+}
+
+func (s *RoleHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.ctrl = gomock.NewController(s.T())
+	s.mockScopeSvc = services.NewMockIScopeService(s.ctrl)
+	s.mockRoleSvc = services.NewMockIRoleService(s.ctrl)
+	s.mockJWT = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.roleHandler = NewRoleHandler(s.mockScopeSvc, s.mockRoleSvc, s.mockJWT)
+	s.router = gin.New()
+	s.router.Use(pkgmiddlewares.ErrorHandler(s.mockLogger))
+
+	s.mockJWT.EXPECT().Require(gomock.Any()).Return(func(c *gin.Context) {
+		c.Next()
+	}).AnyTimes()
+
+	s.roleHandler.SetupRoutes(s.router)
+}
+
+func (s *RoleHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestRoleHandlerSuite(t *testing.T) {
+	suite.Run(t, new(RoleHandlerSuite))
+}
+
+func (s *RoleHandlerSuite) serve(method, path string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest(method, path, &buf)
+	httpReq.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(w, httpReq)
+	return w
+}
+
+func (s *RoleHandlerSuite) TestCreate() {
+	req := dto.CreateRoleRequest{RoleName: "operator", Scopes: []string{"container:view", "container:update"}}
+	scopes := []*entities.UserScope{{ID: 1, Name: "container:view"}, {ID: 2, Name: "container:update"}}
+	role := &entities.Role{ID: 1, Name: "operator", Scopes: scopes}
+
+	s.mockScopeSvc.EXPECT().FindMany(gomock.Any(), req.Scopes).Return(scopes, nil)
+	s.mockRoleSvc.EXPECT().Create(gomock.Any(), "operator", scopes).Return(role, nil)
+
+	w := s.serve("POST", "/roles/create", req)
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+
+	var data struct {
+		Code string           `json:"code"`
+		Data dto.RoleResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "ROLE_CREATED", data.Code)
+	assert.Equal(s.T(), dto.NewRoleResponse(role), data.Data)
+}
+
+func (s *RoleHandlerSuite) TestCreateWithoutScopes() {
+	role := &entities.Role{ID: 1, Name: "empty"}
+
+	s.mockRoleSvc.EXPECT().Create(gomock.Any(), "empty", nil).Return(role, nil)
+
+	w := s.serve("POST", "/roles/create", dto.CreateRoleRequest{RoleName: "empty"})
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+}
+
+func (s *RoleHandlerSuite) TestCreateInvalidInput() {
+	w := s.serve("POST", "/roles/create", dto.CreateRoleRequest{})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *RoleHandlerSuite) TestCreateScopeNotFound() {
+	req := dto.CreateRoleRequest{RoleName: "operator", Scopes: []string{"missing"}}
+
+	s.mockScopeSvc.EXPECT().FindMany(gomock.Any(), req.Scopes).Return(nil, apperrors.NotFound(dto.CodeScopeNotFound, "record not found", nil))
+
+	w := s.serve("POST", "/roles/create", req)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+func (s *RoleHandlerSuite) TestCreateConflict() {
+	s.mockRoleSvc.EXPECT().Create(gomock.Any(), "operator", nil).Return(nil, apperrors.Conflict(dto.CodeRoleAlreadyExists, "record already exists", nil))
+
+	w := s.serve("POST", "/roles/create", dto.CreateRoleRequest{RoleName: "operator"})
+	assert.Equal(s.T(), http.StatusConflict, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeRoleAlreadyExists, response.Code)
+	assert.Equal(s.T(), "Failed to create role", response.Message)
+}
+
+func (s *RoleHandlerSuite) TestListAll() {
+	roles := []*entities.Role{{ID: 1, Name: "auditor"}, {ID: 2, Name: "operator"}}
+
+	s.mockRoleSvc.EXPECT().FindAll(gomock.Any(), dto.ListRolesRequest{SortBy: "name"}).Return(roles, &dto.Paging{Limit: 20}, nil)
+
+	w := s.serve("GET", "/roles/list?sort_by=name", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Code string             `json:"code"`
+		Data []dto.RoleResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "ROLES_RETRIEVED", data.Code)
+	assert.Len(s.T(), data.Data, 2)
+}
+
+func (s *RoleHandlerSuite) TestListAllInvalidQuery() {
+	w := s.serve("GET", "/roles/list?sort_by=scopes", nil)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *RoleHandlerSuite) TestListAllServiceError() {
+	s.mockRoleSvc.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("database error"))
+	s.mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	w := s.serve("GET", "/roles/list", nil)
+	assert.Equal(s.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (s *RoleHandlerSuite) TestFindOne() {
+	role := &entities.Role{ID: 1, Name: "operator"}
+
+	s.mockRoleSvc.EXPECT().FindOne(gomock.Any(), "operator").Return(role, nil)
+
+	w := s.serve("GET", "/roles/operator", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"code":"ROLE_RETRIEVED"`)
+}
+
+func (s *RoleHandlerSuite) TestFindOneNotFound() {
+	s.mockRoleSvc.EXPECT().FindOne(gomock.Any(), "missing").Return(nil, apperrors.NotFound(dto.CodeRoleNotFound, "record not found", nil))
+
+	w := s.serve("GET", "/roles/missing", nil)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+func (s *RoleHandlerSuite) TestUpdateScope() {
+	req := dto.UpdateRoleScopeRequest{RoleName: "operator", IsAdded: true, Scope: "container:view"}
+	scope := &entities.UserScope{ID: 1, Name: "container:view"}
+
+	s.mockScopeSvc.EXPECT().FindOne(gomock.Any(), req.Scope).Return(scope, nil)
+	s.mockRoleSvc.EXPECT().UpdateScope(gomock.Any(), req.RoleName, scope, true).Return(nil)
+
+	w := s.serve("PUT", "/roles/update/scope", req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"code":"ROLE_SCOPE_UPDATED"`)
+}
+
+func (s *RoleHandlerSuite) TestUpdateScopeInvalidInput() {
+	w := s.serve("PUT", "/roles/update/scope", dto.UpdateRoleScopeRequest{RoleName: "operator"})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *RoleHandlerSuite) TestUpdateScopeScopeNotFound() {
+	req := dto.UpdateRoleScopeRequest{RoleName: "operator", IsAdded: true, Scope: "missing"}
+
+	s.mockScopeSvc.EXPECT().FindOne(gomock.Any(), req.Scope).Return(nil, apperrors.NotFound(dto.CodeScopeNotFound, "record not found", nil))
+
+	w := s.serve("PUT", "/roles/update/scope", req)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+func (s *RoleHandlerSuite) TestUpdateScopeRoleNotFound() {
+	req := dto.UpdateRoleScopeRequest{RoleName: "missing", IsAdded: true, Scope: "container:view"}
+	scope := &entities.UserScope{ID: 1, Name: "container:view"}
+
+	s.mockScopeSvc.EXPECT().FindOne(gomock.Any(), req.Scope).Return(scope, nil)
+	s.mockRoleSvc.EXPECT().UpdateScope(gomock.Any(), req.RoleName, scope, true).Return(apperrors.NotFound(dto.CodeRoleNotFound, "record not found", nil))
+
+	w := s.serve("PUT", "/roles/update/scope", req)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeRoleNotFound, response.Code)
+	assert.Equal(s.T(), "Failed to update role scope", response.Message)
+}
+
+func (s *RoleHandlerSuite) TestDelete() {
+	s.mockRoleSvc.EXPECT().Delete(gomock.Any(), "operator").Return(nil)
+
+	w := s.serve("DELETE", "/roles/delete", dto.DeleteRoleRequest{RoleName: "operator"})
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"code":"ROLE_DELETED"`)
+}
+
+func (s *RoleHandlerSuite) TestDeleteInvalidInput() {
+	w := s.serve("DELETE", "/roles/delete", dto.DeleteRoleRequest{})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *RoleHandlerSuite) TestDeleteNotFound() {
+	s.mockRoleSvc.EXPECT().Delete(gomock.Any(), "missing").Return(apperrors.NotFound(dto.CodeRoleNotFound, "record not found", nil))
+
+	w := s.serve("DELETE", "/roles/delete", dto.DeleteRoleRequest{RoleName: "missing"})
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+)
+
+// Route declares one endpoint together with the scopes it requires, so the
+// authorization table can be listed without starting the server.
+type Route struct {
+	Method      string
+	Path        string
+	Requirement middlewares.ScopeRequirement
+	Handler     gin.HandlerFunc
+}
+
+type RouteProvider interface {
+	Routes() []Route
+}
+
+// RouteTable collects the routes of every provider in registration order.
+func RouteTable(providers ...RouteProvider) []Route {
+	var routes []Route
+	for _, provider := range providers {
+		routes = append(routes, provider.Routes()...)
+	}
+	return routes
+}
+
+func registerRoutes(r gin.IRouter, jwtMiddleware middlewares.IJWTMiddleware, routes []Route) {
+	for _, route := range routes {
+		r.Handle(route.Method, route.Path, jwtMiddleware.Require(route.Requirement), route.Handler)
+	}
+}
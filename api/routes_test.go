@@ -0,0 +1,162 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+)
+
+func newRouteProviders(ctrl *gomock.Controller, jwt *middlewares.MockIJWTMiddleware) []RouteProvider {
+	userService := services.NewMockIUserService(ctrl)
+	scopeService := services.NewMockIScopeService(ctrl)
+	roleService := services.NewMockIRoleService(ctrl)
+	groupService := services.NewMockIGroupService(ctrl)
+	organizationService := services.NewMockIOrganizationService(ctrl)
+	accessRequestService := services.NewMockIAccessRequestService(ctrl)
+	auditService := services.NewMockIAuditService(ctrl)
+	webhookService := services.NewMockIWebhookService(ctrl)
+	sessionService := services.NewMockISessionService(ctrl)
+	authzService := services.NewMockIAuthzService(ctrl)
+	introspectionService := services.NewMockIIntrospectionService(ctrl)
+	serviceAccountService := services.NewMockIServiceAccountService(ctrl)
+	return []RouteProvider{
+		NewOrganizationHandler(organizationService, jwt),
+		NewScopeHandler(scopeService, jwt),
+		NewRoleHandler(scopeService, roleService, jwt),
+		NewGroupHandler(scopeService, groupService, userService, jwt),
+		NewUserHandler(scopeService, roleService, userService, jwt),
+		NewMeHandler(userService, jwt),
+		NewAccessRequestHandler(accessRequestService, jwt),
+		NewAuditHandler(auditService, jwt),
+		NewWebhookHandler(webhookService, jwt),
+		NewSessionHandler(sessionService, jwt),
+		NewAuthzHandler(authzService, jwt),
+		NewServiceAccountHandler(scopeService, serviceAccountService, jwt),
+		NewOAuthHandler(introspectionService, serviceAccountService, jwt),
+	}
+}
+
+func TestRouteTable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	routes := RouteTable(newRouteProviders(ctrl, middlewares.NewMockIJWTMiddleware(ctrl))...)
+
+	table := make(map[string]string, len(routes))
+	for _, route := range routes {
+		table[route.Method+" "+route.Path] = route.Requirement.String()
+	}
+
+	assert.Equal(t, map[string]string{
+		"POST /organizations/create":              "all(organization:manage)",
+		"GET /organizations/list":                 "all(organization:manage)",
+		"GET /organizations/:id":                  "all(organization:manage)",
+		"POST /scopes/create":                     "all(scope:manage)",
+		"GET /scopes/list":                        "any(scope:manage, scope:view)",
+		"GET /scopes/expand":                      "any(scope:manage, scope:view)",
+		"GET /scopes/:name":                       "any(scope:manage, scope:view)",
+		"DELETE /scopes/delete":                   "all(scope:manage)",
+		"POST /roles/create":                      "all(role:manage)",
+		"GET /roles/list":                         "any(role:manage, role:view)",
+		"GET /roles/:name":                        "any(role:manage, role:view)",
+		"PUT /roles/update/scope":                 "all(role:manage)",
+		"DELETE /roles/delete":                    "all(role:manage)",
+		"POST /groups/create":                     "all(group:manage)",
+		"GET /groups/list":                        "any(group:manage, group:view)",
+		"GET /groups/:name":                       "any(group:manage, group:view)",
+		"PUT /groups/update/scope":                "all(group:manage)",
+		"PUT /groups/update/parent":               "all(group:manage)",
+		"PUT /groups/update/member":               "all(group:manage)",
+		"DELETE /groups/delete":                   "all(group:manage)",
+		"POST /users/create":                      "all(user:manage)",
+		"GET /users/list":                         "any(user:manage, user:view)",
+		"GET /users/:id":                          "any(user:manage, user:view)",
+		"GET /users/:id/scopes/explain":           "any(user:manage, user:view)",
+		"PUT /users/update/scope":                 "all(user:manage)",
+		"PUT /users/update/role":                  "all(user:manage)",
+		"DELETE /users/delete":                    "all(user:manage)",
+		"GET /me":                                 "authenticated",
+		"PUT /me/password":                        "authenticated",
+		"POST /me/logout":                         "authenticated",
+		"POST /access-requests/create":            "authenticated",
+		"GET /access-requests/list":               "all(scope:manage)",
+		"GET /access-requests/:id":                "authenticated",
+		"PUT /access-requests/approve":            "all(scope:manage)",
+		"PUT /access-requests/reject":             "all(scope:manage)",
+		"PUT /access-requests/cancel":             "authenticated",
+		"POST /access-requests/comment":           "authenticated",
+		"GET /me/access-requests":                 "authenticated",
+		"GET /audit":                              "all(audit:view)",
+		"POST /webhooks/create":                   "all(webhook:manage)",
+		"GET /webhooks/list":                      "all(webhook:manage)",
+		"GET /webhooks/:id":                       "all(webhook:manage)",
+		"PUT /webhooks/update":                    "all(webhook:manage)",
+		"DELETE /webhooks/delete":                 "all(webhook:manage)",
+		"GET /webhooks/:id/deliveries":            "all(webhook:manage)",
+		"GET /me/sessions":                        "authenticated",
+		"DELETE /me/sessions/revoke":              "authenticated",
+		"DELETE /me/sessions/revoke-all":          "authenticated",
+		"GET /users/:id/sessions":                 "any(user:manage, user:view)",
+		"DELETE /users/sessions/revoke":           "all(user:manage)",
+		"DELETE /users/sessions/revoke-all":       "all(user:manage)",
+		"POST /sessions/create":                   "all(session:create)",
+		"POST /sessions/revoke-everything":        "all(organization:manage)",
+		"POST /authz/check":                       "all(authz:check)",
+		"POST /authz/check/batch":                 "all(authz:check)",
+		"POST /service-accounts/create":           "all(service_account:manage)",
+		"GET /service-accounts/list":              "any(service_account:manage, service_account:view)",
+		"GET /service-accounts/:id":               "any(service_account:manage, service_account:view)",
+		"PUT /service-accounts/update":            "all(service_account:manage)",
+		"PUT /service-accounts/update/scope":      "all(service_account:manage)",
+		"POST /service-accounts/secrets/create":   "all(service_account:manage)",
+		"DELETE /service-accounts/secrets/delete": "all(service_account:manage)",
+		"DELETE /service-accounts/delete":         "all(service_account:manage)",
+		"POST /oauth/introspect":                  "all(token:introspect)",
+	}, table)
+}
+
+func TestRegisteredRoutesMatchTable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	jwt := middlewares.NewMockIJWTMiddleware(ctrl)
+
+	// Every route must be wrapped in exactly the requirement it declares.
+	var requested []pkgmiddlewares.ScopeRequirement
+	jwt.EXPECT().Require(gomock.Any()).DoAndReturn(func(req pkgmiddlewares.ScopeRequirement) gin.HandlerFunc {
+		requested = append(requested, req)
+		return func(c *gin.Context) {
+			c.AbortWithStatus(http.StatusForbidden)
+		}
+	}).AnyTimes()
+
+	router := gin.New()
+	providers := newRouteProviders(ctrl, jwt)
+	for _, provider := range providers {
+		provider.(interface{ SetupRoutes(*gin.Engine) }).SetupRoutes(router)
+	}
+
+	// The token endpoint is the only one clients reach without a bearer token.
+	var registered []string
+	for _, route := range router.Routes() {
+		if key := route.Method + " " + route.Path; key != "POST /oauth/token" {
+			registered = append(registered, key)
+		}
+	}
+
+	routes := RouteTable(providers...)
+	assert.Len(t, registered, len(routes))
+	for i, route := range routes {
+		assert.Equal(t, route.Requirement, requested[i], route.Path)
+
+		req := httptest.NewRequest(route.Method, route.Path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code, route.Path)
+	}
+}
@@ -18,15 +18,22 @@ func NewScopeHandler(scopeService services.IScopeService, jwtMiddleware middlewa
 	return &scopeHandler{scopeService, jwtMiddleware}
 }
 
-func (h *scopeHandler) SetupRoutes(r *gin.Engine) {
-	scopeRoutes := r.Group("/scopes", h.jwtMiddleware.RequireScope("scope:manage"))
-	{
-		scopeRoutes.POST("/create", h.Create)
-		scopeRoutes.GET("/list", h.ListAll)
-		scopeRoutes.DELETE("/delete", h.Delete)
+func (h *scopeHandler) Routes() []Route {
+	manage := middlewares.AllOf("scope:manage")
+	view := middlewares.AnyOf("scope:manage", "scope:view")
+	return []Route{
+		{http.MethodPost, "/scopes/create", manage, h.Create},
+		{http.MethodGet, "/scopes/list", view, h.ListAll},
+		{http.MethodGet, "/scopes/expand", view, h.Expand},
+		{http.MethodGet, "/scopes/:name", view, h.FindOne},
+		{http.MethodDelete, "/scopes/delete", manage, h.Delete},
 	}
 }
 
+func (h *scopeHandler) SetupRoutes(r *gin.Engine) {
+	registerRoutes(r, h.jwtMiddleware, h.Routes())
+}
+
 // Create godoc
 // @Summary Create a new scope
 // @Description Create a scope (admin only)
@@ -34,8 +41,10 @@ func (h *scopeHandler) SetupRoutes(r *gin.Engine) {
 // @Accept json
 // @Produce json
 // @Param body body dto.CreateScopeRequest true "Scope creation request"
-// @Success 201 {object} dto.APIResponse "New scope created successfully"
+// @Success 201 {object} dto.APIResponse{data=dto.ScopeResponse} "New scope created successfully"
 // @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 409 {object} dto.APIResponse "Scope already exists"
+// @Failure 422 {object} dto.APIResponse "Invalid scope name or pattern"
 // @Failure 500 {object} dto.APIResponse "Internal server error"
 // @Security BearerAuth
 // @Router /scopes/create [post]
@@ -51,14 +60,9 @@ func (h *scopeHandler) Create(c *gin.Context) {
 		return
 	}
 
-	_, err := h.scopeService.Create(c.Request.Context(), req.ScopeName)
+	scope, err := h.scopeService.Create(c.Request.Context(), req.ScopeName)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.APIResponse{
-			Success: false,
-			Code:    "INTERNAL_SERVER_ERROR",
-			Message: "Failed to find scopes",
-			Error:   err.Error(),
-		})
+		abortWithError(c, err, "Failed to create scope")
 		return
 	}
 
@@ -66,41 +70,116 @@ func (h *scopeHandler) Create(c *gin.Context) {
 		Success: true,
 		Code:    "SCOPE_CREATED",
 		Message: "New scope created successfully",
+		Data:    dto.NewScopeResponse(scope),
 	})
 }
 
 // ListAll godoc
-// @Summary List all scopes
-// @Description Retrieve all scopes (admin only)
+// @Summary List scopes
+// @Description Retrieve a cursor-paginated page of scopes (requires scope:manage or scope:view)
 // @Tags scopes
 // @Accept json
 // @Produce json
-// @Success 200 {object} dto.APIResponse "Scopes retrieved successfully"
+// @Param cursor query string false "Cursor returned as paging.next_cursor by the previous page"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param name_prefix query string false "Only scopes whose name starts with this prefix"
+// @Param sort_by query string false "Sort field" Enums(id, name)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} dto.APIResponse{data=[]dto.ScopeResponse} "Scopes retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
 // @Failure 500 {object} dto.APIResponse "Internal server error"
 // @Security BearerAuth
-// @Router /scopes/ [get]
+// @Router /scopes/list [get]
 func (h *scopeHandler) ListAll(c *gin.Context) {
-	scopes, err := h.scopeService.FindAll(c.Request.Context())
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+	var req dto.ListScopesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
 			Success: false,
-			Code:    "INTERNAL_SERVER_ERROR",
-			Message: "Failed to retrieve scopes",
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
 			Error:   err.Error(),
 		})
 		return
 	}
 
-	names := make([]string, 0)
-	for _, scope := range scopes {
-		names = append(names, scope.Name)
+	scopes, paging, err := h.scopeService.FindAll(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve scopes")
+		return
 	}
 
 	c.JSON(http.StatusOK, dto.APIResponse{
 		Success: true,
 		Code:    "SCOPES_RETRIEVED",
 		Message: "All scopes retrieved successfully",
-		Data:    names,
+		Data:    dto.NewScopeResponses(scopes),
+		Paging:  paging,
+	})
+}
+
+// Expand godoc
+// @Summary Expand a scope pattern
+// @Description List the concrete scopes a wildcard pattern such as container:* or *:view grants (requires scope:manage or scope:view)
+// @Tags scopes
+// @Accept json
+// @Produce json
+// @Param pattern query string true "Scope pattern"
+// @Success 200 {object} dto.APIResponse{data=[]dto.ScopeResponse} "Scope pattern expanded successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 422 {object} dto.APIResponse "Invalid scope pattern"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /scopes/expand [get]
+func (h *scopeHandler) Expand(c *gin.Context) {
+	var req dto.ExpandScopeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	scopes, err := h.scopeService.Expand(c.Request.Context(), req.Pattern)
+	if err != nil {
+		abortWithError(c, err, "Failed to expand scope pattern")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SCOPE_EXPANDED",
+		Message: "Scope pattern expanded successfully",
+		Data:    dto.NewScopeResponses(scopes),
+	})
+}
+
+// FindOne godoc
+// @Summary Get a scope
+// @Description Retrieve a single scope by name (requires scope:manage or scope:view)
+// @Tags scopes
+// @Accept json
+// @Produce json
+// @Param name path string true "Scope name"
+// @Success 200 {object} dto.APIResponse{data=dto.ScopeResponse} "Scope retrieved successfully"
+// @Failure 404 {object} dto.APIResponse "Scope not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /scopes/{name} [get]
+func (h *scopeHandler) FindOne(c *gin.Context) {
+	scope, err := h.scopeService.FindOne(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve scope")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SCOPE_RETRIEVED",
+		Message: "Scope retrieved successfully",
+		Data:    dto.NewScopeResponse(scope),
 	})
 }
 
@@ -130,12 +209,7 @@ func (h *scopeHandler) Delete(c *gin.Context) {
 
 	err := h.scopeService.Delete(c.Request.Context(), req.ScopeName)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.APIResponse{
-			Success: false,
-			Code:    "INTERNAL_SERVER_ERROR",
-			Message: "Failed to delete scope",
-			Error:   err.Error(),
-		})
+		abortWithError(c, err, "Failed to delete scope")
 		return
 	}
 
@@ -15,8 +15,11 @@ import (
 
 	"github.com/vnFuhung2903/vcs-user-management-service/dto"
 	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
 	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
 	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
 )
 
 type ScopeHandlerSuite struct {
@@ -25,6 +28,7 @@ type ScopeHandlerSuite struct {
 	scopeHandler *scopeHandler
 	mockScopeSvc *services.MockIScopeService
 	mockJWT      *middlewares.MockIJWTMiddleware
+	mockLogger   *logger.MockILogger
 	router       *gin.Engine
 }
 
@@ -33,11 +37,13 @@ func (s *ScopeHandlerSuite) SetupTest() {
 	s.ctrl = gomock.NewController(s.T())
 	s.mockScopeSvc = services.NewMockIScopeService(s.ctrl)
 	s.mockJWT = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
 
 	s.scopeHandler = NewScopeHandler(s.mockScopeSvc, s.mockJWT)
 	s.router = gin.New()
+	s.router.Use(pkgmiddlewares.ErrorHandler(s.mockLogger))
 
-	s.mockJWT.EXPECT().RequireScope("scope:manage").Return(func(c *gin.Context) {
+	s.mockJWT.EXPECT().Require(gomock.Any()).Return(func(c *gin.Context) {
 		c.Next()
 	}).AnyTimes()
 
@@ -124,7 +130,7 @@ func (s *ScopeHandlerSuite) TestCreateServiceError() {
 	assert.NoError(s.T(), err)
 	assert.False(s.T(), response.Success)
 	assert.Equal(s.T(), "INTERNAL_SERVER_ERROR", response.Code)
-	assert.Equal(s.T(), "Failed to find scopes", response.Message)
+	assert.Equal(s.T(), "Failed to create scope", response.Message)
 }
 
 func (s *ScopeHandlerSuite) TestListAll() {
@@ -133,7 +139,7 @@ func (s *ScopeHandlerSuite) TestListAll() {
 		{ID: 2, Name: "test:write"},
 	}
 
-	s.mockScopeSvc.EXPECT().FindAll(gomock.Any()).Return(expectedScopes, nil)
+	s.mockScopeSvc.EXPECT().FindAll(gomock.Any(), dto.ListScopesRequest{}).Return(expectedScopes, &dto.Paging{Limit: 20}, nil)
 
 	w := httptest.NewRecorder()
 	httpReq, _ := http.NewRequest("GET", "/scopes/list", nil)
@@ -148,11 +154,17 @@ func (s *ScopeHandlerSuite) TestListAll() {
 	assert.True(s.T(), response.Success)
 	assert.Equal(s.T(), "SCOPES_RETRIEVED", response.Code)
 	assert.Equal(s.T(), "All scopes retrieved successfully", response.Message)
-	assert.NotNil(s.T(), response.Data)
+
+	var data struct {
+		Data []dto.ScopeResponse `json:"data"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []dto.ScopeResponse{{ID: 1, Name: "test:read"}, {ID: 2, Name: "test:write"}}, data.Data)
 }
 
 func (s *ScopeHandlerSuite) TestListAllServiceError() {
-	s.mockScopeSvc.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("database error"))
+	s.mockScopeSvc.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("database error"))
 
 	w := httptest.NewRecorder()
 	httpReq, _ := http.NewRequest("GET", "/scopes/list", nil)
@@ -255,3 +267,155 @@ func (s *ScopeHandlerSuite) TestDeleteServiceError() {
 	assert.Equal(s.T(), "INTERNAL_SERVER_ERROR", response.Code)
 	assert.Equal(s.T(), "Failed to delete scope", response.Message)
 }
+
+func (s *ScopeHandlerSuite) TestFindOne() {
+	expectedScope := &entities.UserScope{
+		ID:   1,
+		Name: "container:view",
+	}
+
+	s.mockScopeSvc.EXPECT().FindOne(gomock.Any(), "container:view").Return(expectedScope, nil)
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/scopes/container:view", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		dto.APIResponse
+		Data dto.ScopeResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), response.Success)
+	assert.Equal(s.T(), "SCOPE_RETRIEVED", response.Code)
+	assert.Equal(s.T(), dto.ScopeResponse{ID: 1, Name: "container:view"}, response.Data)
+}
+
+func (s *ScopeHandlerSuite) TestFindOneNotFound() {
+	s.mockScopeSvc.EXPECT().FindOne(gomock.Any(), "missing").Return(nil, apperrors.NotFound(dto.CodeScopeNotFound, "record not found", nil))
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/scopes/missing", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), response.Success)
+	assert.Equal(s.T(), "SCOPE_NOT_FOUND", response.Code)
+}
+
+func (s *ScopeHandlerSuite) TestFindOneServiceError() {
+	s.mockScopeSvc.EXPECT().FindOne(gomock.Any(), "container:view").Return(nil, errors.New("database error"))
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/scopes/container:view", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusInternalServerError, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), response.Success)
+	assert.Equal(s.T(), "INTERNAL_SERVER_ERROR", response.Code)
+	assert.Equal(s.T(), "Failed to retrieve scope", response.Message)
+}
+
+func (s *ScopeHandlerSuite) TestListAllInvalidCursor() {
+	s.mockScopeSvc.EXPECT().FindAll(gomock.Any(), dto.ListScopesRequest{Cursor: "bad", NamePrefix: "container:"}).Return(nil, nil, apperrors.BadRequest(dto.CodeInvalidPagination, "invalid pagination parameters", nil))
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/scopes/list?cursor=bad&name_prefix=container:", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "INVALID_PAGINATION", response.Code)
+}
+
+func (s *ScopeHandlerSuite) TestCreateConflict() {
+	req := dto.CreateScopeRequest{
+		ScopeName: "test:read",
+	}
+
+	s.mockScopeSvc.EXPECT().Create(gomock.Any(), req.ScopeName).Return(nil, apperrors.Conflict(dto.CodeScopeAlreadyExists, "record already exists", nil))
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/scopes/create", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusConflict, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), response.Success)
+	assert.Equal(s.T(), "SCOPE_ALREADY_EXISTS", response.Code)
+	assert.Equal(s.T(), "Failed to create scope", response.Message)
+}
+
+func (s *ScopeHandlerSuite) TestExpand() {
+	expectedScopes := []*entities.UserScope{
+		{ID: 2, Name: "container:create"},
+		{ID: 3, Name: "container:view"},
+	}
+
+	s.mockScopeSvc.EXPECT().Expand(gomock.Any(), "container:*").Return(expectedScopes, nil)
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/scopes/expand?pattern=container:*", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Code string              `json:"code"`
+		Data []dto.ScopeResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "SCOPE_EXPANDED", data.Code)
+	assert.Equal(s.T(), []dto.ScopeResponse{{ID: 2, Name: "container:create"}, {ID: 3, Name: "container:view"}}, data.Data)
+}
+
+func (s *ScopeHandlerSuite) TestExpandMissingPattern() {
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/scopes/expand", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *ScopeHandlerSuite) TestExpandInvalidPattern() {
+	s.mockScopeSvc.EXPECT().Expand(gomock.Any(), "contain*").Return(nil, apperrors.Validation(dto.CodeInvalidScope, "invalid scope pattern", nil))
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/scopes/expand?pattern=contain*", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeInvalidScope, response.Code)
+	assert.Equal(s.T(), "Failed to expand scope pattern", response.Message)
+}
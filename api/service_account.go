@@ -0,0 +1,369 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
+)
+
+type serviceAccountHandler struct {
+	scopeService          services.IScopeService
+	serviceAccountService services.IServiceAccountService
+	jwtMiddleware         middlewares.IJWTMiddleware
+}
+
+func NewServiceAccountHandler(scopeService services.IScopeService, serviceAccountService services.IServiceAccountService, jwtMiddleware middlewares.IJWTMiddleware) *serviceAccountHandler {
+	return &serviceAccountHandler{scopeService, serviceAccountService, jwtMiddleware}
+}
+
+func (h *serviceAccountHandler) Routes() []Route {
+	manage := middlewares.AllOf("service_account:manage")
+	view := middlewares.AnyOf("service_account:manage", "service_account:view")
+	return []Route{
+		{http.MethodPost, "/service-accounts/create", manage, h.Create},
+		{http.MethodGet, "/service-accounts/list", view, h.ListAll},
+		{http.MethodGet, "/service-accounts/:id", view, h.FindOne},
+		{http.MethodPut, "/service-accounts/update", manage, h.Update},
+		{http.MethodPut, "/service-accounts/update/scope", manage, h.UpdateScope},
+		{http.MethodPost, "/service-accounts/secrets/create", manage, h.CreateSecret},
+		{http.MethodDelete, "/service-accounts/secrets/delete", manage, h.DeleteSecret},
+		{http.MethodDelete, "/service-accounts/delete", manage, h.Delete},
+	}
+}
+
+func (h *serviceAccountHandler) SetupRoutes(r *gin.Engine) {
+	registerRoutes(r, h.jwtMiddleware, h.Routes())
+}
+
+// Create godoc
+// @Summary Create a service account
+// @Description Create a non-human identity that obtains tokens with the client_credentials grant at /oauth/token (requires service_account:manage). The caller must hold every scope it gives the account. The owner defaults to the caller. The first client secret is only returned here.
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateServiceAccountRequest true "Name, owner, scopes and optional expiry"
+// @Success 201 {object} dto.APIResponse{data=dto.CreateServiceAccountResponse} "Service account created successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Caller does not hold a scope it gives"
+// @Failure 404 {object} dto.APIResponse "Owner or scope not found"
+// @Failure 409 {object} dto.APIResponse "Service account name is taken"
+// @Failure 422 {object} dto.APIResponse "Expiry is in the past"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /service-accounts/create [post]
+func (h *serviceAccountHandler) Create(c *gin.Context) {
+	var req dto.CreateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	var scopes []*entities.UserScope
+	if len(req.Scopes) > 0 {
+		var err error
+		scopes, err = h.scopeService.FindMany(c.Request.Context(), req.Scopes)
+		if err != nil {
+			abortWithError(c, err, "Failed to find scopes")
+			return
+		}
+	}
+
+	ownerId := req.OwnerID
+	if ownerId == "" {
+		ownerId = c.GetString("userId")
+	}
+	account, secret, err := h.serviceAccountService.Create(c.Request.Context(), req.Name, ownerId, scopes, req.ExpiresAt, c.GetStringSlice("scopes"), c.GetString("userId"))
+	if err != nil {
+		abortWithError(c, err, "Failed to create service account")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Code:    "SERVICE_ACCOUNT_CREATED",
+		Message: "Service account created successfully",
+		Data:    dto.CreateServiceAccountResponse{ServiceAccountResponse: dto.NewServiceAccountResponse(account), ClientSecret: secret},
+	})
+}
+
+// ListAll godoc
+// @Summary List service accounts
+// @Description Retrieve a cursor-paginated page of service accounts with their scopes and secret hints (requires service_account:manage or service_account:view)
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param cursor query string false "Cursor returned as paging.next_cursor by the previous page"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param owner_id query string false "Only accounts owned by this user"
+// @Param sort_by query string false "Sort field" Enums(id, name)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} dto.APIResponse{data=[]dto.ServiceAccountResponse} "Service accounts retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /service-accounts/list [get]
+func (h *serviceAccountHandler) ListAll(c *gin.Context) {
+	var req dto.ListServiceAccountsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	accounts, paging, err := h.serviceAccountService.FindAll(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve service accounts")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SERVICE_ACCOUNTS_RETRIEVED",
+		Message: "Service accounts retrieved successfully",
+		Data:    dto.NewServiceAccountResponses(accounts),
+		Paging:  paging,
+	})
+}
+
+// FindOne godoc
+// @Summary Get a service account
+// @Description Retrieve a service account by ID, which is also its client_id (requires service_account:manage or service_account:view)
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param id path string true "Service account ID"
+// @Success 200 {object} dto.APIResponse{data=dto.ServiceAccountResponse} "Service account retrieved successfully"
+// @Failure 404 {object} dto.APIResponse "Service account not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /service-accounts/{id} [get]
+func (h *serviceAccountHandler) FindOne(c *gin.Context) {
+	account, err := h.serviceAccountService.FindById(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve service account")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SERVICE_ACCOUNT_RETRIEVED",
+		Message: "Service account retrieved successfully",
+		Data:    dto.NewServiceAccountResponse(account),
+	})
+}
+
+// Update godoc
+// @Summary Update a service account
+// @Description Rename a service account, change its owner or expiry, or make it permanent (requires service_account:manage). Bringing the expiry forward revokes its access tokens.
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param body body dto.UpdateServiceAccountRequest true "Service account ID and the fields to change"
+// @Success 200 {object} dto.APIResponse{data=dto.ServiceAccountResponse} "Service account updated successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Service account or owner not found"
+// @Failure 409 {object} dto.APIResponse "Service account name is taken"
+// @Failure 422 {object} dto.APIResponse "Expiry is in the past"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /service-accounts/update [put]
+func (h *serviceAccountHandler) Update(c *gin.Context) {
+	var req dto.UpdateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	account, err := h.serviceAccountService.Update(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err, "Failed to update service account")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SERVICE_ACCOUNT_UPDATED",
+		Message: "Service account updated successfully",
+		Data:    dto.NewServiceAccountResponse(account),
+	})
+}
+
+// UpdateScope godoc
+// @Summary Update a service account's scope
+// @Description Add or remove a scope of a service account (requires service_account:manage). The caller must hold a scope it adds. Removing a scope revokes the account's access tokens.
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param body body dto.UpdateServiceAccountScopeRequest true "Service account ID, scope, and whether to add or remove"
+// @Success 200 {object} dto.APIResponse "Service account scope updated successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Caller does not hold the scope"
+// @Failure 404 {object} dto.APIResponse "Service account or scope not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /service-accounts/update/scope [put]
+func (h *serviceAccountHandler) UpdateScope(c *gin.Context) {
+	var req dto.UpdateServiceAccountScopeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	scope, err := h.scopeService.FindOne(c.Request.Context(), req.Scope)
+	if err != nil {
+		abortWithError(c, err, "Failed to find scope")
+		return
+	}
+
+	if err := h.serviceAccountService.UpdateScope(c.Request.Context(), req.ServiceAccountID, scope, req.IsAdded, c.GetStringSlice("scopes")); err != nil {
+		abortWithError(c, err, "Failed to update service account scope")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SERVICE_ACCOUNT_SCOPE_UPDATED",
+		Message: "Service account scope updated successfully",
+	})
+}
+
+// CreateSecret godoc
+// @Summary Add a service account secret
+// @Description Add a client secret next to the existing ones, so clients can move to it before the old one is deleted (requires service_account:manage). The secret is only returned here.
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateServiceAccountSecretRequest true "Service account ID"
+// @Success 201 {object} dto.APIResponse{data=dto.ServiceAccountCredentialsResponse} "Service account secret created successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Service account not found"
+// @Failure 409 {object} dto.APIResponse "Service account already holds the maximum number of secrets"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /service-accounts/secrets/create [post]
+func (h *serviceAccountHandler) CreateSecret(c *gin.Context) {
+	var req dto.CreateServiceAccountSecretRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	secret, plaintext, err := h.serviceAccountService.AddSecret(c.Request.Context(), req.ServiceAccountID, c.GetString("userId"))
+	if err != nil {
+		abortWithError(c, err, "Failed to create service account secret")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Code:    "SERVICE_ACCOUNT_SECRET_CREATED",
+		Message: "Service account secret created successfully",
+		Data: dto.ServiceAccountCredentialsResponse{
+			ClientID:     req.ServiceAccountID,
+			ClientSecret: plaintext,
+			Secret:       dto.NewServiceAccountSecretResponse(secret),
+		},
+	})
+}
+
+// DeleteSecret godoc
+// @Summary Delete a service account secret
+// @Description Delete a client secret and revoke the account's access tokens, which may have been obtained with it (requires service_account:manage)
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param body body dto.DeleteServiceAccountSecretRequest true "Service account ID and secret ID"
+// @Success 200 {object} dto.APIResponse "Service account secret deleted successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Service account or secret not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /service-accounts/secrets/delete [delete]
+func (h *serviceAccountHandler) DeleteSecret(c *gin.Context) {
+	var req dto.DeleteServiceAccountSecretRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := h.serviceAccountService.DeleteSecret(c.Request.Context(), req.ServiceAccountID, req.SecretID); err != nil {
+		abortWithError(c, err, "Failed to delete service account secret")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SERVICE_ACCOUNT_SECRET_DELETED",
+		Message: "Service account secret deleted successfully",
+	})
+}
+
+// Delete godoc
+// @Summary Delete a service account
+// @Description Delete a service account with its secrets and revoke its access tokens (requires service_account:manage)
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param body body dto.DeleteServiceAccountRequest true "Service account ID"
+// @Success 200 {object} dto.APIResponse "Service account deleted successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Service account not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /service-accounts/delete [delete]
+func (h *serviceAccountHandler) Delete(c *gin.Context) {
+	var req dto.DeleteServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := h.serviceAccountService.Delete(c.Request.Context(), req.ServiceAccountID); err != nil {
+		abortWithError(c, err, "Failed to delete service account")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SERVICE_ACCOUNT_DELETED",
+		Message: "Service account deleted successfully",
+	})
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+)
+
+type ServiceAccountHandlerSuite struct {
+	suite.Suite
+	ctrl                  *gomock.Controller
+	serviceAccountHandler *serviceAccountHandler
+	mockScopeSvc          *services.MockIScopeService
+	mockAccountSvc        *services.MockIServiceAccountService
+	mockJWT               *middlewares.MockIJWTMiddleware
+	mockLogger            *logger.MockILogger
+	router                *gin.Engine
+	scopes                []string
+}
+
+func (s *ServiceAccountHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.ctrl = gomock.NewController(s.T())
+	s.mockScopeSvc = services.NewMockIScopeService(s.ctrl)
+	s.mockAccountSvc = services.NewMockIServiceAccountService(s.ctrl)
+	s.mockJWT = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+	s.scopes = []string{"service_account:manage", "container:*"}
+
+	s.serviceAccountHandler = NewServiceAccountHandler(s.mockScopeSvc, s.mockAccountSvc, s.mockJWT)
+	s.router = gin.New()
+	s.router.Use(pkgmiddlewares.ErrorHandler(s.mockLogger))
+
+	s.mockJWT.EXPECT().Require(gomock.Any()).Return(func(c *gin.Context) {
+		c.Set("userId", "admin")
+		c.Set("scopes", s.scopes)
+		c.Next()
+	}).AnyTimes()
+
+	s.serviceAccountHandler.SetupRoutes(s.router)
+}
+
+func (s *ServiceAccountHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestServiceAccountHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ServiceAccountHandlerSuite))
+}
+
+func (s *ServiceAccountHandlerSuite) serve(method, path string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest(method, path, &buf)
+	httpReq.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(w, httpReq)
+	return w
+}
+
+func (s *ServiceAccountHandlerSuite) account() *entities.ServiceAccount {
+	return &entities.ServiceAccount{
+		ID:        "sa1",
+		Name:      "ci",
+		OwnerID:   "admin",
+		Scopes:    []*entities.UserScope{{ID: 1, Name: "container:view"}},
+		Secrets:   []*entities.ServiceAccountSecret{{ID: 1, Hint: "abcd", CreatedBy: "admin", CreatedAt: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		CreatedBy: "admin",
+		CreatedAt: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (s *ServiceAccountHandlerSuite) TestCreate() {
+	account := s.account()
+	s.mockScopeSvc.EXPECT().FindMany(gomock.Any(), []string{"container:view"}).Return(account.Scopes, nil)
+	s.mockAccountSvc.EXPECT().Create(gomock.Any(), "ci", "admin", account.Scopes, nil, s.scopes, "admin").Return(account, "sasec_secret", nil)
+
+	w := s.serve("POST", "/service-accounts/create", dto.CreateServiceAccountRequest{Name: "ci", Scopes: []string{"container:view"}})
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+
+	var data struct {
+		Code string                           `json:"code"`
+		Data dto.CreateServiceAccountResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "SERVICE_ACCOUNT_CREATED", data.Code)
+	assert.Equal(s.T(), "sasec_secret", data.Data.ClientSecret)
+	assert.Equal(s.T(), dto.NewServiceAccountResponse(account), data.Data.ServiceAccountResponse)
+}
+
+func (s *ServiceAccountHandlerSuite) TestCreateForOwner() {
+	account := s.account()
+	account.OwnerID = "user1"
+	s.mockAccountSvc.EXPECT().Create(gomock.Any(), "ci", "user1", nil, nil, s.scopes, "admin").Return(account, "sasec_secret", nil)
+
+	w := s.serve("POST", "/service-accounts/create", dto.CreateServiceAccountRequest{Name: "ci", OwnerID: "user1"})
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+}
+
+func (s *ServiceAccountHandlerSuite) TestCreateInvalidInput() {
+	w := s.serve("POST", "/service-accounts/create", dto.CreateServiceAccountRequest{})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *ServiceAccountHandlerSuite) TestCreateScopeNotHeld() {
+	scopes := []*entities.UserScope{{ID: 2, Name: "user:manage"}}
+	s.mockScopeSvc.EXPECT().FindMany(gomock.Any(), []string{"user:manage"}).Return(scopes, nil)
+	s.mockAccountSvc.EXPECT().Create(gomock.Any(), "ci", "admin", scopes, nil, s.scopes, "admin").
+		Return(nil, "", apperrors.Forbidden(dto.CodeInsufficientScope, "cannot grant a scope the caller does not hold", nil))
+
+	w := s.serve("POST", "/service-accounts/create", dto.CreateServiceAccountRequest{Name: "ci", Scopes: []string{"user:manage"}})
+	assert.Equal(s.T(), http.StatusForbidden, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeInsufficientScope, response.Code)
+	assert.Equal(s.T(), "Failed to create service account", response.Message)
+}
+
+func (s *ServiceAccountHandlerSuite) TestListAll() {
+	accounts := []*entities.ServiceAccount{s.account()}
+	paging := &dto.Paging{Limit: 20}
+	s.mockAccountSvc.EXPECT().FindAll(gomock.Any(), dto.ListServiceAccountsRequest{OwnerID: "admin", SortBy: "name"}).Return(accounts, paging, nil)
+
+	w := s.serve("GET", "/service-accounts/list?owner_id=admin&sort_by=name", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Code   string                       `json:"code"`
+		Data   []dto.ServiceAccountResponse `json:"data"`
+		Paging *dto.Paging                  `json:"paging"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "SERVICE_ACCOUNTS_RETRIEVED", data.Code)
+	assert.Equal(s.T(), dto.NewServiceAccountResponses(accounts), data.Data)
+	assert.Equal(s.T(), paging, data.Paging)
+}
+
+func (s *ServiceAccountHandlerSuite) TestListAllInvalidSort() {
+	w := s.serve("GET", "/service-accounts/list?sort_by=secret", nil)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *ServiceAccountHandlerSuite) TestFindOne() {
+	s.mockAccountSvc.EXPECT().FindById(gomock.Any(), "sa1").Return(s.account(), nil)
+
+	w := s.serve("GET", "/service-accounts/sa1", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+func (s *ServiceAccountHandlerSuite) TestFindOneNotFound() {
+	s.mockAccountSvc.EXPECT().FindById(gomock.Any(), "sa1").Return(nil, apperrors.NotFound(dto.CodeServiceAccountNotFound, "record not found", nil))
+
+	w := s.serve("GET", "/service-accounts/sa1", nil)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+func (s *ServiceAccountHandlerSuite) TestUpdate() {
+	name := "pipeline"
+	req := dto.UpdateServiceAccountRequest{ServiceAccountID: "sa1", Name: &name, ClearExpiry: true}
+	account := s.account()
+	account.Name = name
+	s.mockAccountSvc.EXPECT().Update(gomock.Any(), req).Return(account, nil)
+
+	w := s.serve("PUT", "/service-accounts/update", req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+func (s *ServiceAccountHandlerSuite) TestUpdateExpiryAndClear() {
+	expiresAt := time.Now().Add(time.Hour)
+	w := s.serve("PUT", "/service-accounts/update", dto.UpdateServiceAccountRequest{ServiceAccountID: "sa1", ExpiresAt: &expiresAt, ClearExpiry: true})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *ServiceAccountHandlerSuite) TestUpdateScope() {
+	scope := &entities.UserScope{ID: 2, Name: "container:update"}
+	s.mockScopeSvc.EXPECT().FindOne(gomock.Any(), "container:update").Return(scope, nil)
+	s.mockAccountSvc.EXPECT().UpdateScope(gomock.Any(), "sa1", scope, true, s.scopes).Return(nil)
+
+	w := s.serve("PUT", "/service-accounts/update/scope", dto.UpdateServiceAccountScopeRequest{ServiceAccountID: "sa1", IsAdded: true, Scope: "container:update"})
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+func (s *ServiceAccountHandlerSuite) TestUpdateScopeNotFound() {
+	s.mockScopeSvc.EXPECT().FindOne(gomock.Any(), "container:ghost").Return(nil, apperrors.NotFound(dto.CodeScopeNotFound, "record not found", nil))
+
+	w := s.serve("PUT", "/service-accounts/update/scope", dto.UpdateServiceAccountScopeRequest{ServiceAccountID: "sa1", IsAdded: true, Scope: "container:ghost"})
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+func (s *ServiceAccountHandlerSuite) TestCreateSecret() {
+	secret := &entities.ServiceAccountSecret{ID: 2, ServiceAccountID: "sa1", Hint: "wxyz", CreatedBy: "admin"}
+	s.mockAccountSvc.EXPECT().AddSecret(gomock.Any(), "sa1", "admin").Return(secret, "sasec_wxyz", nil)
+
+	w := s.serve("POST", "/service-accounts/secrets/create", dto.CreateServiceAccountSecretRequest{ServiceAccountID: "sa1"})
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+
+	var data struct {
+		Code string                                `json:"code"`
+		Data dto.ServiceAccountCredentialsResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "SERVICE_ACCOUNT_SECRET_CREATED", data.Code)
+	assert.Equal(s.T(), dto.ServiceAccountCredentialsResponse{
+		ClientID:     "sa1",
+		ClientSecret: "sasec_wxyz",
+		Secret:       dto.NewServiceAccountSecretResponse(secret),
+	}, data.Data)
+}
+
+func (s *ServiceAccountHandlerSuite) TestCreateSecretLimitReached() {
+	s.mockAccountSvc.EXPECT().AddSecret(gomock.Any(), "sa1", "admin").
+		Return(nil, "", apperrors.Conflict(dto.CodeSecretLimitReached, "service account already holds the maximum number of secrets", nil))
+
+	w := s.serve("POST", "/service-accounts/secrets/create", dto.CreateServiceAccountSecretRequest{ServiceAccountID: "sa1"})
+	assert.Equal(s.T(), http.StatusConflict, w.Code)
+}
+
+func (s *ServiceAccountHandlerSuite) TestDeleteSecret() {
+	s.mockAccountSvc.EXPECT().DeleteSecret(gomock.Any(), "sa1", uint(1)).Return(nil)
+
+	w := s.serve("DELETE", "/service-accounts/secrets/delete", dto.DeleteServiceAccountSecretRequest{ServiceAccountID: "sa1", SecretID: 1})
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+func (s *ServiceAccountHandlerSuite) TestDeleteSecretInvalidInput() {
+	w := s.serve("DELETE", "/service-accounts/secrets/delete", dto.DeleteServiceAccountSecretRequest{ServiceAccountID: "sa1"})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *ServiceAccountHandlerSuite) TestDelete() {
+	s.mockAccountSvc.EXPECT().Delete(gomock.Any(), "sa1").Return(nil)
+
+	w := s.serve("DELETE", "/service-accounts/delete", dto.DeleteServiceAccountRequest{ServiceAccountID: "sa1"})
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+func (s *ServiceAccountHandlerSuite) TestDeleteNotFound() {
+	s.mockAccountSvc.EXPECT().Delete(gomock.Any(), "sa1").Return(apperrors.NotFound(dto.CodeServiceAccountNotFound, "record not found", nil))
+
+	w := s.serve("DELETE", "/service-accounts/delete", dto.DeleteServiceAccountRequest{ServiceAccountID: "sa1"})
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,273 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
+)
+
+type sessionHandler struct {
+	sessionService services.ISessionService
+	jwtMiddleware  middlewares.IJWTMiddleware
+}
+
+func NewSessionHandler(sessionService services.ISessionService, jwtMiddleware middlewares.IJWTMiddleware) *sessionHandler {
+	return &sessionHandler{sessionService, jwtMiddleware}
+}
+
+func (h *sessionHandler) Routes() []Route {
+	manage := middlewares.AllOf("user:manage")
+	view := middlewares.AnyOf("user:manage", "user:view")
+	return []Route{
+		{http.MethodGet, "/me/sessions", middlewares.Authenticated(), h.ListOwn},
+		{http.MethodDelete, "/me/sessions/revoke", middlewares.Authenticated(), h.RevokeOwn},
+		{http.MethodDelete, "/me/sessions/revoke-all", middlewares.Authenticated(), h.RevokeAllOwn},
+		{http.MethodGet, "/users/:id/sessions", view, h.ListAll},
+		{http.MethodDelete, "/users/sessions/revoke", manage, h.Revoke},
+		{http.MethodDelete, "/users/sessions/revoke-all", manage, h.RevokeAll},
+		{http.MethodPost, "/sessions/create", middlewares.AllOf("session:create"), h.Create},
+		{http.MethodPost, "/sessions/revoke-everything", middlewares.AllOf("organization:manage"), h.RevokeEverything},
+	}
+}
+
+func (h *sessionHandler) SetupRoutes(r *gin.Engine) {
+	registerRoutes(r, h.jwtMiddleware, h.Routes())
+}
+
+// Create godoc
+// @Summary Register a refresh session
+// @Description Called by the token issuer whenever it hands out a refresh token, so that the session can be listed and revoked; the issuer puts the returned id in the sid claim of the tokens it issues for the session (requires session:create)
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateSessionRequest true "User, device, IP and refresh token lifetime"
+// @Success 201 {object} dto.APIResponse{data=dto.SessionResponse} "Session created successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "User not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /sessions/create [post]
+func (h *sessionHandler) Create(c *gin.Context) {
+	var req dto.CreateSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	session, err := h.sessionService.Create(c.Request.Context(), req.UserID, req.Device, req.IP, time.Duration(req.ExpiresIn)*time.Second)
+	if err != nil {
+		abortWithError(c, err, "Failed to create session")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Code:    "SESSION_CREATED",
+		Message: "Session created successfully",
+		Data:    dto.NewSessionResponse(session),
+	})
+}
+
+// ListOwn godoc
+// @Summary List own sessions
+// @Description Retrieve the active refresh sessions of the authenticated user, newest first
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.SessionResponse} "Sessions retrieved successfully"
+// @Failure 404 {object} dto.APIResponse "User not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /me/sessions [get]
+func (h *sessionHandler) ListOwn(c *gin.Context) {
+	h.list(c, c.GetString("userId"))
+}
+
+// RevokeOwn godoc
+// @Summary Revoke an own session
+// @Description Sign the authenticated user out of one device: the refresh session is deleted and the access tokens it issued are refused
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param body body dto.RevokeSessionRequest true "Session ID"
+// @Success 200 {object} dto.APIResponse "Session revoked successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Session not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /me/sessions/revoke [delete]
+func (h *sessionHandler) RevokeOwn(c *gin.Context) {
+	var req dto.RevokeSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	h.revoke(c, c.GetString("userId"), req.SessionID)
+}
+
+// RevokeAllOwn godoc
+// @Summary Revoke all own sessions
+// @Description Sign the authenticated user out of every device, including the one making the request
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse "Sessions revoked successfully"
+// @Failure 404 {object} dto.APIResponse "User not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /me/sessions/revoke-all [delete]
+func (h *sessionHandler) RevokeAllOwn(c *gin.Context) {
+	h.revokeAll(c, c.GetString("userId"))
+}
+
+// ListAll godoc
+// @Summary List a user's sessions
+// @Description Retrieve the active refresh sessions of a user, newest first (requires user:manage or user:view)
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.APIResponse{data=[]dto.SessionResponse} "Sessions retrieved successfully"
+// @Failure 404 {object} dto.APIResponse "User not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /users/{id}/sessions [get]
+func (h *sessionHandler) ListAll(c *gin.Context) {
+	h.list(c, c.Param("id"))
+}
+
+// Revoke godoc
+// @Summary Revoke a user's session
+// @Description Sign a user out of one device (requires user:manage)
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param body body dto.RevokeUserSessionRequest true "User ID and session ID"
+// @Success 200 {object} dto.APIResponse "Session revoked successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "User or session not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /users/sessions/revoke [delete]
+func (h *sessionHandler) Revoke(c *gin.Context) {
+	var req dto.RevokeUserSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	h.revoke(c, req.UserID, req.SessionID)
+}
+
+// RevokeAll godoc
+// @Summary Revoke all of a user's sessions
+// @Description Sign a user out of every device and refuse every access token issued to them so far (requires user:manage)
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param body body dto.RevokeUserSessionsRequest true "User ID"
+// @Success 200 {object} dto.APIResponse "Sessions revoked successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "User not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /users/sessions/revoke-all [delete]
+func (h *sessionHandler) RevokeAll(c *gin.Context) {
+	var req dto.RevokeUserSessionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	h.revokeAll(c, req.UserID)
+}
+
+// RevokeEverything godoc
+// @Summary Revoke every session
+// @Description Emergency switch: delete every refresh session and refuse every access token issued so far, in every organization, including the one making the request (super-admin only)
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse "Every session revoked successfully"
+// @Failure 403 {object} dto.APIResponse "Caller is not a super-admin"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /sessions/revoke-everything [post]
+func (h *sessionHandler) RevokeEverything(c *gin.Context) {
+	if err := h.sessionService.RevokeEverything(c.Request.Context()); err != nil {
+		abortWithError(c, err, "Failed to revoke every session")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "ALL_SESSIONS_REVOKED",
+		Message: "Every session revoked successfully",
+	})
+}
+
+func (h *sessionHandler) list(c *gin.Context, userId string) {
+	sessions, err := h.sessionService.FindAll(c.Request.Context(), userId)
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve sessions")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SESSIONS_RETRIEVED",
+		Message: "Sessions retrieved successfully",
+		Data:    dto.NewSessionResponses(sessions),
+	})
+}
+
+func (h *sessionHandler) revoke(c *gin.Context, userId, sessionId string) {
+	if err := h.sessionService.Revoke(c.Request.Context(), userId, sessionId); err != nil {
+		abortWithError(c, err, "Failed to revoke session")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SESSION_REVOKED",
+		Message: "Session revoked successfully",
+	})
+}
+
+func (h *sessionHandler) revokeAll(c *gin.Context, userId string) {
+	if err := h.sessionService.RevokeAll(c.Request.Context(), userId); err != nil {
+		abortWithError(c, err, "Failed to revoke sessions")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SESSIONS_REVOKED",
+		Message: "Sessions revoked successfully",
+	})
+}
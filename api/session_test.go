@@ -0,0 +1,339 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	usecases "github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
+)
+
+type SessionHandlerSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	sessionHandler *sessionHandler
+	mockSessionSvc *services.MockISessionService
+	mockJWT        *middlewares.MockIJWTMiddleware
+	mockLogger     *logger.MockILogger
+	router         *gin.Engine
+}
+
+func (s *SessionHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.ctrl = gomock.NewController(s.T())
+	s.mockSessionSvc = services.NewMockISessionService(s.ctrl)
+	s.mockJWT = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.sessionHandler = NewSessionHandler(s.mockSessionSvc, s.mockJWT)
+	s.router = gin.New()
+	s.router.Use(pkgmiddlewares.ErrorHandler(s.mockLogger))
+
+	s.mockJWT.EXPECT().Require(gomock.Any()).Return(func(c *gin.Context) {
+		c.Set("userId", "user-123")
+		c.Next()
+	}).AnyTimes()
+
+	s.sessionHandler.SetupRoutes(s.router)
+}
+
+func (s *SessionHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestSessionHandlerSuite(t *testing.T) {
+	suite.Run(t, new(SessionHandlerSuite))
+}
+
+func (s *SessionHandlerSuite) serve(method, path string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest(method, path, &buf)
+	httpReq.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(w, httpReq)
+	return w
+}
+
+func (s *SessionHandlerSuite) assertCode(w *httptest.ResponseRecorder, status int, code string) {
+	assert.Equal(s.T(), status, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), code, response.Code)
+}
+
+func (s *SessionHandlerSuite) TestCreate() {
+	createdAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	session := &entities.Session{ID: "s1", UserID: "user-456", Device: "laptop", IP: "203.0.113.7", CreatedAt: createdAt, ExpiresAt: createdAt.Add(time.Hour)}
+	s.mockSessionSvc.EXPECT().Create(gomock.Any(), "user-456", "laptop", "203.0.113.7", time.Hour).Return(session, nil)
+
+	w := s.serve("POST", "/sessions/create", dto.CreateSessionRequest{UserID: "user-456", Device: "laptop", IP: "203.0.113.7", ExpiresIn: 3600})
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+
+	var data struct {
+		Code string              `json:"code"`
+		Data dto.SessionResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "SESSION_CREATED", data.Code)
+	assert.Equal(s.T(), dto.NewSessionResponse(session), data.Data)
+}
+
+func (s *SessionHandlerSuite) TestCreateInvalidInput() {
+	w := s.serve("POST", "/sessions/create", dto.CreateSessionRequest{UserID: "user-456", IP: "not-an-ip", ExpiresIn: 3600})
+	s.assertCode(w, http.StatusBadRequest, "BAD_REQUEST")
+
+	w = s.serve("POST", "/sessions/create", dto.CreateSessionRequest{UserID: "user-456"})
+	s.assertCode(w, http.StatusBadRequest, "BAD_REQUEST")
+
+	w = s.serve("POST", "/sessions/create", dto.CreateSessionRequest{UserID: "user-456", ExpiresIn: math.MaxInt64})
+	s.assertCode(w, http.StatusBadRequest, "BAD_REQUEST")
+}
+
+func (s *SessionHandlerSuite) TestCreateUserNotFound() {
+	s.mockSessionSvc.EXPECT().Create(gomock.Any(), "user-456", "", "", time.Hour).Return(nil, apperrors.NotFound(dto.CodeUserNotFound, "user not found", nil))
+
+	w := s.serve("POST", "/sessions/create", dto.CreateSessionRequest{UserID: "user-456", ExpiresIn: 3600})
+	s.assertCode(w, http.StatusNotFound, dto.CodeUserNotFound)
+}
+
+func (s *SessionHandlerSuite) TestListOwn() {
+	createdAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	sessions := []*entities.Session{{ID: "s1", UserID: "user-123", Device: "laptop", IP: "203.0.113.7", CreatedAt: createdAt, ExpiresAt: createdAt.Add(time.Hour)}}
+	s.mockSessionSvc.EXPECT().FindAll(gomock.Any(), "user-123").Return(sessions, nil)
+
+	w := s.serve("GET", "/me/sessions", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Code string                `json:"code"`
+		Data []dto.SessionResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "SESSIONS_RETRIEVED", data.Code)
+	assert.Equal(s.T(), dto.NewSessionResponses(sessions), data.Data)
+}
+
+func (s *SessionHandlerSuite) TestRevokeOwn() {
+	s.mockSessionSvc.EXPECT().Revoke(gomock.Any(), "user-123", "s1").Return(nil)
+
+	w := s.serve("DELETE", "/me/sessions/revoke", dto.RevokeSessionRequest{SessionID: "s1"})
+	s.assertCode(w, http.StatusOK, "SESSION_REVOKED")
+}
+
+func (s *SessionHandlerSuite) TestRevokeOwnInvalidInput() {
+	w := s.serve("DELETE", "/me/sessions/revoke", nil)
+	s.assertCode(w, http.StatusBadRequest, "BAD_REQUEST")
+}
+
+func (s *SessionHandlerSuite) TestRevokeOwnNotFound() {
+	s.mockSessionSvc.EXPECT().Revoke(gomock.Any(), "user-123", "s1").Return(apperrors.NotFound(dto.CodeSessionNotFound, "session not found", nil))
+
+	w := s.serve("DELETE", "/me/sessions/revoke", dto.RevokeSessionRequest{SessionID: "s1"})
+	s.assertCode(w, http.StatusNotFound, dto.CodeSessionNotFound)
+}
+
+func (s *SessionHandlerSuite) TestRevokeAllOwn() {
+	s.mockSessionSvc.EXPECT().RevokeAll(gomock.Any(), "user-123").Return(nil)
+
+	w := s.serve("DELETE", "/me/sessions/revoke-all", nil)
+	s.assertCode(w, http.StatusOK, "SESSIONS_REVOKED")
+}
+
+func (s *SessionHandlerSuite) TestListAll() {
+	s.mockSessionSvc.EXPECT().FindAll(gomock.Any(), "user-456").Return([]*entities.Session{}, nil)
+
+	w := s.serve("GET", "/users/user-456/sessions", nil)
+	s.assertCode(w, http.StatusOK, "SESSIONS_RETRIEVED")
+}
+
+func (s *SessionHandlerSuite) TestListAllUserNotFound() {
+	s.mockSessionSvc.EXPECT().FindAll(gomock.Any(), "user-456").Return(nil, apperrors.NotFound(dto.CodeUserNotFound, "user not found", nil))
+
+	w := s.serve("GET", "/users/user-456/sessions", nil)
+	s.assertCode(w, http.StatusNotFound, dto.CodeUserNotFound)
+}
+
+func (s *SessionHandlerSuite) TestRevoke() {
+	s.mockSessionSvc.EXPECT().Revoke(gomock.Any(), "user-456", "s1").Return(nil)
+
+	w := s.serve("DELETE", "/users/sessions/revoke", dto.RevokeUserSessionRequest{UserID: "user-456", SessionID: "s1"})
+	s.assertCode(w, http.StatusOK, "SESSION_REVOKED")
+}
+
+func (s *SessionHandlerSuite) TestRevokeInvalidInput() {
+	w := s.serve("DELETE", "/users/sessions/revoke", dto.RevokeUserSessionRequest{UserID: "user-456"})
+	s.assertCode(w, http.StatusBadRequest, "BAD_REQUEST")
+}
+
+func (s *SessionHandlerSuite) TestRevokeAll() {
+	s.mockSessionSvc.EXPECT().RevokeAll(gomock.Any(), "user-456").Return(nil)
+
+	w := s.serve("DELETE", "/users/sessions/revoke-all", dto.RevokeUserSessionsRequest{UserID: "user-456"})
+	s.assertCode(w, http.StatusOK, "SESSIONS_REVOKED")
+}
+
+func (s *SessionHandlerSuite) TestRevokeEverything() {
+	s.mockSessionSvc.EXPECT().RevokeEverything(gomock.Any()).Return(nil)
+
+	w := s.serve("POST", "/sessions/revoke-everything", nil)
+	s.assertCode(w, http.StatusOK, "ALL_SESSIONS_REVOKED")
+}
+
+func (s *SessionHandlerSuite) TestRevokeEverythingForbidden() {
+	s.mockSessionSvc.EXPECT().RevokeEverything(gomock.Any()).Return(apperrors.Forbidden(dto.CodeTenantForbidden, "only super-admins revoke every session", nil))
+
+	w := s.serve("POST", "/sessions/revoke-everything", nil)
+	s.assertCode(w, http.StatusForbidden, dto.CodeTenantForbidden)
+}
+
+// memoryRedis keeps the hashes and sets the session service uses, so that a
+// session created through the API can be read back through it.
+type memoryRedis struct {
+	interfaces.IRedisClient
+	hashes map[string]map[string]string
+	sets   map[string]map[string]bool
+}
+
+func (r *memoryRedis) HSet(_ context.Context, key string, values map[string]interface{}) error {
+	r.hashes[key] = make(map[string]string, len(values))
+	for field, value := range values {
+		r.hashes[key][field] = value.(string)
+	}
+	return nil
+}
+
+func (r *memoryRedis) HGetAll(_ context.Context, key string) (map[string]string, error) {
+	return r.hashes[key], nil
+}
+
+func (r *memoryRedis) Expire(context.Context, string, time.Duration) error {
+	return nil
+}
+
+func (r *memoryRedis) TTL(context.Context, string) (time.Duration, error) {
+	return -1, nil
+}
+
+func (r *memoryRedis) SAdd(_ context.Context, key string, members ...string) error {
+	if r.sets[key] == nil {
+		r.sets[key] = make(map[string]bool)
+	}
+	for _, member := range members {
+		r.sets[key][member] = true
+	}
+	return nil
+}
+
+func (r *memoryRedis) SRem(_ context.Context, key string, members ...string) error {
+	for _, member := range members {
+		delete(r.sets[key], member)
+	}
+	return nil
+}
+
+func (r *memoryRedis) SMembers(_ context.Context, key string) ([]string, error) {
+	members := make([]string, 0, len(r.sets[key]))
+	for member := range r.sets[key] {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func (r *memoryRedis) Del(_ context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(r.hashes, key)
+		delete(r.sets, key)
+	}
+	return nil
+}
+
+// TestSessionLifecycle registers a session the way the token issuer does and
+// checks that the user sees it and can revoke it, through the session
+// service rather than a mock of it.
+func TestSessionLifecycle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	userRepo := repositories.NewMockIUserRepository(ctrl)
+	userRepo.EXPECT().FindById(gomock.Any(), "user-123").Return(&entities.User{ID: "user-123"}, nil).AnyTimes()
+	revocations := revocation.NewMockIStore(ctrl)
+	log := logger.NewMockILogger(ctrl)
+	log.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	jwt := middlewares.NewMockIJWTMiddleware(ctrl)
+	jwt.EXPECT().Require(gomock.Any()).Return(func(c *gin.Context) {
+		c.Set("userId", "user-123")
+		c.Next()
+	}).AnyTimes()
+
+	redis := &memoryRedis{hashes: map[string]map[string]string{}, sets: map[string]map[string]bool{}}
+	router := gin.New()
+	router.Use(pkgmiddlewares.ErrorHandler(log))
+	NewSessionHandler(usecases.NewSessionService(userRepo, redis, revocations, log), jwt).SetupRoutes(router)
+
+	serve := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			json.NewEncoder(&buf).Encode(body)
+		}
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+	listOwn := func() []dto.SessionResponse {
+		w := serve("GET", "/me/sessions", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var data struct {
+			Data []dto.SessionResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &data))
+		return data.Data
+	}
+
+	w := serve("POST", "/sessions/create", dto.CreateSessionRequest{UserID: "user-123", Device: "laptop", IP: "203.0.113.7", ExpiresIn: 3600})
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created struct {
+		Data dto.SessionResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.NotEmpty(t, created.Data.ID)
+
+	sessions := listOwn()
+	require.Len(t, sessions, 1)
+	assert.Equal(t, created.Data.ID, sessions[0].ID)
+	assert.Equal(t, "laptop", sessions[0].Device)
+	assert.Equal(t, "203.0.113.7", sessions[0].IP)
+
+	revocations.EXPECT().RevokeSession(gomock.Any(), created.Data.ID).Return(nil)
+	w = serve("DELETE", "/me/sessions/revoke", dto.RevokeSessionRequest{SessionID: created.Data.ID})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, listOwn())
+}
@@ -5,30 +5,40 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
 	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
 	"github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
 )
 
 type userHandler struct {
 	scopeService  services.IScopeService
+	roleService   services.IRoleService
 	userService   services.IUserService
 	jwtMiddleware middlewares.IJWTMiddleware
 }
 
-func NewUserHandler(scopeService services.IScopeService, userService services.IUserService, jwtMiddleware middlewares.IJWTMiddleware) *userHandler {
-	return &userHandler{scopeService, userService, jwtMiddleware}
+func NewUserHandler(scopeService services.IScopeService, roleService services.IRoleService, userService services.IUserService, jwtMiddleware middlewares.IJWTMiddleware) *userHandler {
+	return &userHandler{scopeService, roleService, userService, jwtMiddleware}
 }
 
-func (h *userHandler) SetupRoutes(r *gin.Engine) {
-	userRoutes := r.Group("/users", h.jwtMiddleware.RequireScope("user:manage"))
-	{
-		userRoutes.POST("/create", h.Create)
-		userRoutes.GET("/list", h.ListAll)
-		userRoutes.PUT("/update/scope", h.UpdateScope)
-		userRoutes.DELETE("/delete", h.Delete)
+func (h *userHandler) Routes() []Route {
+	manage := middlewares.AllOf("user:manage")
+	view := middlewares.AnyOf("user:manage", "user:view")
+	return []Route{
+		{http.MethodPost, "/users/create", manage, h.Create},
+		{http.MethodGet, "/users/list", view, h.ListAll},
+		{http.MethodGet, "/users/:id", view, h.FindById},
+		{http.MethodGet, "/users/:id/scopes/explain", view, h.ExplainScope},
+		{http.MethodPut, "/users/update/scope", manage, h.UpdateScope},
+		{http.MethodPut, "/users/update/role", manage, h.UpdateRole},
+		{http.MethodDelete, "/users/delete", manage, h.Delete},
 	}
 }
 
+func (h *userHandler) SetupRoutes(r *gin.Engine) {
+	registerRoutes(r, h.jwtMiddleware, h.Routes())
+}
+
 // Create godoc
 // @Summary Create a new user
 // @Description Create a user (admin only)
@@ -36,8 +46,11 @@ func (h *userHandler) SetupRoutes(r *gin.Engine) {
 // @Accept json
 // @Produce json
 // @Param body body dto.CreateUserRequest true "User creation request"
-// @Success 201 {object} dto.APIResponse "New user created successfully"
+// @Success 201 {object} dto.APIResponse{data=dto.UserResponse} "New user created successfully"
 // @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Scope or role not found"
+// @Failure 409 {object} dto.APIResponse "Username or email already exists"
+// @Failure 422 {object} dto.APIResponse "Invalid email, password rejected by the password policy or invalid scope expiry"
 // @Failure 500 {object} dto.APIResponse "Internal server error"
 // @Security BearerAuth
 // @Router /users/create [post]
@@ -55,71 +68,156 @@ func (h *userHandler) Create(c *gin.Context) {
 
 	scopes, err := h.scopeService.FindMany(c.Request.Context(), req.Scopes)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.APIResponse{
-			Success: false,
-			Code:    "INTERNAL_SERVER_ERROR",
-			Message: "Failed to find scopes",
-			Error:   err.Error(),
-		})
+		abortWithError(c, err, "Failed to find scopes")
 		return
 	}
 
-	_, err = h.userService.Create(req.Username, req.Password, req.Email, scopes)
+	var roles []*entities.Role
+	if len(req.Roles) > 0 {
+		roles, err = h.roleService.FindMany(c.Request.Context(), req.Roles)
+		if err != nil {
+			abortWithError(c, err, "Failed to find roles")
+			return
+		}
+	}
+
+	user, err := h.userService.Create(c.Request.Context(), req.Username, req.Password, req.Email, scopes, req.ScopeExpiries, roles)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.APIResponse{
-			Success: false,
-			Code:    "INTERNAL_SERVER_ERROR",
-			Message: "Failed to register user",
-			Error:   err.Error(),
-		})
+		abortWithError(c, err, "Failed to register user")
 		return
 	}
 	c.JSON(http.StatusCreated, dto.APIResponse{
 		Success: true,
 		Code:    "USER_CREATED",
 		Message: "New user created successfully",
+		Data:    dto.NewUserResponse(user),
 	})
 }
 
 // ListAll godoc
-// @Summary List all users
-// @Description Retrieve all users (admin only)
+// @Summary List users
+// @Description Retrieve a cursor-paginated page of users (requires user:manage or user:view)
 // @Tags users
 // @Accept json
 // @Produce json
-// @Success 200 {object} dto.APIResponse "Users retrieved successfully"
+// @Param cursor query string false "Cursor returned as paging.next_cursor by the previous page"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param username_prefix query string false "Only users whose username starts with this prefix"
+// @Param email_domain query string false "Only users whose email is in this domain"
+// @Param has_scope query string false "Only users holding this scope, directly or through a wildcard"
+// @Param lacks_scope query string false "Only users holding this scope neither directly nor through a wildcard"
+// @Param sort_by query string false "Sort field" Enums(id, username, email)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} dto.APIResponse{data=[]dto.UserResponse} "Users retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
 // @Failure 500 {object} dto.APIResponse "Internal server error"
 // @Security BearerAuth
 // @Router /users/list [get]
 func (h *userHandler) ListAll(c *gin.Context) {
-	users, err := h.userService.FindAll(c.Request.Context())
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+	var req dto.ListUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
 			Success: false,
-			Code:    "INTERNAL_SERVER_ERROR",
-			Message: "Failed to retrieve users",
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
 			Error:   err.Error(),
 		})
 		return
 	}
 
+	users, paging, err := h.userService.FindAll(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve users")
+		return
+	}
+
 	c.JSON(http.StatusOK, dto.APIResponse{
 		Success: true,
 		Code:    "USERS_RETRIEVED",
 		Message: "All users retrieved successfully",
-		Data:    users,
+		Data:    dto.NewUserResponses(users),
+		Paging:  paging,
+	})
+}
+
+// FindById godoc
+// @Summary Get a user
+// @Description Retrieve a single user by ID (requires user:manage or user:view)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.APIResponse{data=dto.UserResponse} "User retrieved successfully"
+// @Failure 404 {object} dto.APIResponse "User not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /users/{id} [get]
+func (h *userHandler) FindById(c *gin.Context) {
+	user, err := h.userService.FindById(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve user")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "USER_RETRIEVED",
+		Message: "User retrieved successfully",
+		Data:    dto.NewUserResponse(user),
+	})
+}
+
+// ExplainScope godoc
+// @Summary Explain a user's scope
+// @Description List every direct, role and group grant that gives a user a scope, with the path of groups for inherited grants (requires user:manage or user:view)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param scope query string true "Scope to explain"
+// @Success 200 {object} dto.APIResponse{data=[]dto.ScopeGrantResponse} "Scope explained successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "User not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /users/{id}/scopes/explain [get]
+func (h *userHandler) ExplainScope(c *gin.Context) {
+	var req dto.ExplainScopeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	grants, err := h.userService.ExplainScope(c.Request.Context(), c.Param("id"), req.Scope)
+	if err != nil {
+		abortWithError(c, err, "Failed to explain scope")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SCOPE_EXPLAINED",
+		Message: "Scope explained successfully",
+		Data:    dto.NewScopeGrantResponses(grants),
 	})
 }
 
 // UpdateScope godoc
 // @Summary Update a user's scope
-// @Description Update permission scope of a user (admin only)
+// @Description Update permission scope of a user (admin only). A granted scope may carry an expiry, after which it is revoked automatically.
 // @Tags users
 // @Accept json
 // @Produce json
-// @Param body body dto.UpdateScopeRequest true "User ID, scopes, and whether to add or remove"
+// @Param body body dto.UpdateScopeRequest true "User ID, scopes, whether to add or remove, and an optional expiry"
 // @Success 200 {object} dto.APIResponse "Scope updated successfully"
 // @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "User or scope not found"
+// @Failure 422 {object} dto.APIResponse "Expiry is in the past"
 // @Failure 500 {object} dto.APIResponse "Internal server error"
 // @Security BearerAuth
 // @Router /users/update/scope [put]
@@ -137,29 +235,62 @@ func (h *userHandler) UpdateScope(c *gin.Context) {
 
 	scope, err := h.scopeService.FindOne(c.Request.Context(), req.Scope)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.APIResponse{
-			Success: false,
-			Code:    "INTERNAL_SERVER_ERROR",
-			Message: "Failed to find scope",
-			Error:   err.Error(),
-		})
+		abortWithError(c, err, "Failed to find scope")
+		return
+	}
+
+	if err := h.userService.UpdateScope(c.Request.Context(), req.UserId, scope, req.IsAdded, req.ExpiresAt); err != nil {
+		abortWithError(c, err, "Failed to update user scope")
 		return
 	}
 
-	if err := h.userService.UpdateScope(c.Request.Context(), req.UserId, scope, req.IsAdded); err != nil {
-		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "USER_SCOPE_UPDATED",
+		Message: "User scope updated successfully",
+	})
+}
+
+// UpdateRole godoc
+// @Summary Update a user's role
+// @Description Grant or revoke a role for a user (admin only)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param body body dto.UpdateRoleRequest true "User ID, role, and whether to add or remove"
+// @Success 200 {object} dto.APIResponse "Role updated successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "User or role not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /users/update/role [put]
+func (h *userHandler) UpdateRole(c *gin.Context) {
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
 			Success: false,
-			Code:    "INTERNAL_SERVER_ERROR",
-			Message: "Failed to update user scope",
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
 			Error:   err.Error(),
 		})
 		return
 	}
 
+	role, err := h.roleService.FindOne(c.Request.Context(), req.Role)
+	if err != nil {
+		abortWithError(c, err, "Failed to find role")
+		return
+	}
+
+	if err := h.userService.UpdateRole(c.Request.Context(), req.UserId, role, req.IsAdded); err != nil {
+		abortWithError(c, err, "Failed to update user role")
+		return
+	}
+
 	c.JSON(http.StatusOK, dto.APIResponse{
 		Success: true,
-		Code:    "USER_SCOPE_UPDATED",
-		Message: "User scope updated successfully",
+		Code:    "USER_ROLE_UPDATED",
+		Message: "User role updated successfully",
 	})
 }
 
@@ -188,12 +319,7 @@ func (h *userHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.userService.Delete(c.Request.Context(), req.UserId); err != nil {
-		c.JSON(http.StatusInternalServerError, dto.APIResponse{
-			Success: false,
-			Code:    "INTERNAL_SERVER_ERROR",
-			Message: "Failed to delete user",
-			Error:   err.Error(),
-		})
+		abortWithError(c, err, "Failed to delete user")
 		return
 	}
 
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -15,8 +16,11 @@ import (
 
 	"github.com/vnFuhung2903/vcs-user-management-service/dto"
 	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
 	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
 	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
 )
 
 type UserHandlerSuite struct {
@@ -25,7 +29,9 @@ type UserHandlerSuite struct {
 	userHandler  *userHandler
 	mockUserSvc  *services.MockIUserService
 	mockScopeSvc *services.MockIScopeService
+	mockRoleSvc  *services.MockIRoleService
 	mockJWT      *middlewares.MockIJWTMiddleware
+	mockLogger   *logger.MockILogger
 	router       *gin.Engine
 }
 
@@ -34,13 +40,16 @@ func (s *UserHandlerSuite) SetupTest() {
 	s.ctrl = gomock.NewController(s.T())
 	s.mockUserSvc = services.NewMockIUserService(s.ctrl)
 	s.mockScopeSvc = services.NewMockIScopeService(s.ctrl)
+	s.mockRoleSvc = services.NewMockIRoleService(s.ctrl)
 	s.mockJWT = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
 
-	s.userHandler = NewUserHandler(s.mockScopeSvc, s.mockUserSvc, s.mockJWT)
+	s.userHandler = NewUserHandler(s.mockScopeSvc, s.mockRoleSvc, s.mockUserSvc, s.mockJWT)
 	s.router = gin.New()
+	s.router.Use(pkgmiddlewares.ErrorHandler(s.mockLogger))
 
 	// Mock the middleware to always pass
-	s.mockJWT.EXPECT().RequireScope("user:manage").Return(func(c *gin.Context) {
+	s.mockJWT.EXPECT().Require(gomock.Any()).Return(func(c *gin.Context) {
 		c.Next()
 	}).AnyTimes()
 
@@ -71,12 +80,13 @@ func (s *UserHandlerSuite) TestCreate() {
 	expectedUser := &entities.User{
 		ID:       "user-123",
 		Username: "testuser",
+		Hash:     "$2a$10$secret",
 		Email:    "test@example.com",
 		Scopes:   expectedScopes,
 	}
 
 	s.mockScopeSvc.EXPECT().FindMany(gomock.Any(), req.Scopes).Return(expectedScopes, nil)
-	s.mockUserSvc.EXPECT().Create(req.Username, req.Password, req.Email, expectedScopes).Return(expectedUser, nil)
+	s.mockUserSvc.EXPECT().Create(gomock.Any(), req.Username, req.Password, req.Email, expectedScopes, nil, nil).Return(expectedUser, nil)
 
 	body, _ := json.Marshal(req)
 	w := httptest.NewRecorder()
@@ -93,6 +103,71 @@ func (s *UserHandlerSuite) TestCreate() {
 	assert.True(s.T(), response.Success)
 	assert.Equal(s.T(), "USER_CREATED", response.Code)
 	assert.Equal(s.T(), "New user created successfully", response.Message)
+	assert.NotContains(s.T(), w.Body.String(), "$2a$10$secret")
+	assert.Contains(s.T(), w.Body.String(), `"id":"user-123"`)
+}
+
+func (s *UserHandlerSuite) TestCreateWithExpiry() {
+	expiresAt := time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+	req := dto.CreateUserRequest{
+		Username:      "contractor",
+		Password:      "password123",
+		Email:         "contractor@example.com",
+		Scopes:        []string{"deploy"},
+		ScopeExpiries: map[string]time.Time{"deploy": expiresAt},
+	}
+	scopes := []*entities.UserScope{{ID: 1, Name: "deploy"}}
+	expectedUser := &entities.User{
+		ID:            "user-123",
+		Username:      "contractor",
+		Email:         "contractor@example.com",
+		Scopes:        scopes,
+		ScopeMappings: []*entities.UserScopeMapping{{UserID: "user-123", UserScopeID: 1, ExpiresAt: &expiresAt}},
+	}
+
+	s.mockScopeSvc.EXPECT().FindMany(gomock.Any(), req.Scopes).Return(scopes, nil)
+	s.mockUserSvc.EXPECT().Create(gomock.Any(), req.Username, req.Password, req.Email, scopes, req.ScopeExpiries, nil).Return(expectedUser, nil)
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/users/create", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+
+	var data struct {
+		Data dto.UserResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []dto.ScopeExpiryResponse{{Scope: "deploy", ExpiresAt: expiresAt}}, data.Data.ExpiringScopes)
+}
+
+func (s *UserHandlerSuite) TestCreateInvalidExpiry() {
+	req := dto.CreateUserRequest{
+		Username:      "contractor",
+		Password:      "password123",
+		Email:         "contractor@example.com",
+		Scopes:        []string{"deploy"},
+		ScopeExpiries: map[string]time.Time{"deploy": time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	scopes := []*entities.UserScope{{ID: 1, Name: "deploy"}}
+
+	s.mockScopeSvc.EXPECT().FindMany(gomock.Any(), req.Scopes).Return(scopes, nil)
+	s.mockUserSvc.EXPECT().Create(gomock.Any(), req.Username, req.Password, req.Email, scopes, req.ScopeExpiries, nil).
+		Return(nil, apperrors.Validation(dto.CodeInvalidGrantExpiry, "grant expiry must be in the future", nil))
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/users/create", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `"code":"INVALID_GRANT_EXPIRY"`)
 }
 
 func (s *UserHandlerSuite) TestCreateInvalidInput() {
@@ -159,7 +234,7 @@ func (s *UserHandlerSuite) TestCreateUserServiceError() {
 	}
 
 	s.mockScopeSvc.EXPECT().FindMany(gomock.Any(), req.Scopes).Return(expectedScopes, nil)
-	s.mockUserSvc.EXPECT().Create(req.Username, req.Password, req.Email, expectedScopes).Return(nil, errors.New("user creation failed"))
+	s.mockUserSvc.EXPECT().Create(gomock.Any(), req.Username, req.Password, req.Email, expectedScopes, nil, nil).Return(nil, errors.New("user creation failed"))
 
 	body, _ := json.Marshal(req)
 	w := httptest.NewRecorder()
@@ -178,6 +253,29 @@ func (s *UserHandlerSuite) TestCreateUserServiceError() {
 	assert.Equal(s.T(), "Failed to register user", response.Message)
 }
 
+func (s *UserHandlerSuite) TestUpdateScopeWithExpiry() {
+	expiresAt := time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+	req := dto.UpdateScopeRequest{
+		UserId:    "user-123",
+		Scope:     "deploy",
+		IsAdded:   true,
+		ExpiresAt: &expiresAt,
+	}
+	scope := &entities.UserScope{ID: 1, Name: "deploy"}
+
+	s.mockScopeSvc.EXPECT().FindOne(gomock.Any(), req.Scope).Return(scope, nil)
+	s.mockUserSvc.EXPECT().UpdateScope(gomock.Any(), req.UserId, scope, true, &expiresAt).Return(nil)
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("PUT", "/users/update/scope", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
 func (s *UserHandlerSuite) TestUpdateScope() {
 	req := dto.UpdateScopeRequest{
 		UserId:  "user-123",
@@ -191,7 +289,7 @@ func (s *UserHandlerSuite) TestUpdateScope() {
 	}
 
 	s.mockScopeSvc.EXPECT().FindOne(gomock.Any(), req.Scope).Return(expectedScope, nil)
-	s.mockUserSvc.EXPECT().UpdateScope(gomock.Any(), req.UserId, expectedScope, req.IsAdded).Return(nil)
+	s.mockUserSvc.EXPECT().UpdateScope(gomock.Any(), req.UserId, expectedScope, req.IsAdded, nil).Return(nil)
 
 	body, _ := json.Marshal(req)
 	w := httptest.NewRecorder()
@@ -265,7 +363,7 @@ func (s *UserHandlerSuite) TestUpdateScopeUserServiceError() {
 	}
 
 	s.mockScopeSvc.EXPECT().FindOne(gomock.Any(), req.Scope).Return(expectedScope, nil)
-	s.mockUserSvc.EXPECT().UpdateScope(gomock.Any(), req.UserId, expectedScope, req.IsAdded).Return(errors.New("update failed"))
+	s.mockUserSvc.EXPECT().UpdateScope(gomock.Any(), req.UserId, expectedScope, req.IsAdded, nil).Return(errors.New("update failed"))
 
 	body, _ := json.Marshal(req)
 	w := httptest.NewRecorder()
@@ -358,18 +456,20 @@ func (s *UserHandlerSuite) TestListAll() {
 		{
 			ID:       "user-1",
 			Username: "user1",
+			Hash:     "$2a$10$secret",
 			Email:    "user1@example.com",
 			Scopes:   []*entities.UserScope{{ID: 1, Name: "read"}},
 		},
 		{
 			ID:       "user-2",
 			Username: "user2",
+			Hash:     "$2a$10$secret",
 			Email:    "user2@example.com",
 			Scopes:   []*entities.UserScope{{ID: 2, Name: "write"}},
 		},
 	}
 
-	s.mockUserSvc.EXPECT().FindAll(gomock.Any()).Return(expectedUsers, nil)
+	s.mockUserSvc.EXPECT().FindAll(gomock.Any(), dto.ListUsersRequest{}).Return(expectedUsers, &dto.Paging{Limit: 20}, nil)
 
 	w := httptest.NewRecorder()
 	httpReq, _ := http.NewRequest("GET", "/users/list", nil)
@@ -384,11 +484,21 @@ func (s *UserHandlerSuite) TestListAll() {
 	assert.True(s.T(), response.Success)
 	assert.Equal(s.T(), "USERS_RETRIEVED", response.Code)
 	assert.Equal(s.T(), "All users retrieved successfully", response.Message)
-	assert.NotNil(s.T(), response.Data)
+	assert.NotContains(s.T(), w.Body.String(), "$2a$10$secret")
+
+	var data struct {
+		Data []dto.UserResponse `json:"data"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []dto.UserResponse{
+		{ID: "user-1", Username: "user1", Email: "user1@example.com", Scopes: []dto.ScopeResponse{{ID: 1, Name: "read"}}, ExpiringScopes: []dto.ScopeExpiryResponse{}, Roles: []string{}, Groups: []string{}, EffectiveScopes: []string{"read"}},
+		{ID: "user-2", Username: "user2", Email: "user2@example.com", Scopes: []dto.ScopeResponse{{ID: 2, Name: "write"}}, ExpiringScopes: []dto.ScopeExpiryResponse{}, Roles: []string{}, Groups: []string{}, EffectiveScopes: []string{"write"}},
+	}, data.Data)
 }
 
 func (s *UserHandlerSuite) TestListAllServiceError() {
-	s.mockUserSvc.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("database error"))
+	s.mockUserSvc.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("database error"))
 
 	w := httptest.NewRecorder()
 	httpReq, _ := http.NewRequest("GET", "/users/list", nil)
@@ -404,3 +514,371 @@ func (s *UserHandlerSuite) TestListAllServiceError() {
 	assert.Equal(s.T(), "INTERNAL_SERVER_ERROR", response.Code)
 	assert.Equal(s.T(), "Failed to retrieve users", response.Message)
 }
+
+func (s *UserHandlerSuite) TestFindById() {
+	expectedUser := &entities.User{
+		ID:       "user-123",
+		Username: "testuser",
+		Hash:     "$2a$10$secret",
+		Email:    "test@example.com",
+		Scopes:   []*entities.UserScope{{ID: 1, Name: "read"}},
+	}
+
+	s.mockUserSvc.EXPECT().FindById(gomock.Any(), "user-123").Return(expectedUser, nil)
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/users/user-123", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		dto.APIResponse
+		Data dto.UserResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), response.Success)
+	assert.Equal(s.T(), "USER_RETRIEVED", response.Code)
+	assert.Equal(s.T(), dto.NewUserResponse(expectedUser), response.Data)
+	assert.NotContains(s.T(), w.Body.String(), "$2a$10$secret")
+}
+
+func (s *UserHandlerSuite) TestFindByIdNotFound() {
+	s.mockUserSvc.EXPECT().FindById(gomock.Any(), "missing").Return(nil, apperrors.NotFound(dto.CodeUserNotFound, "record not found", nil))
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/users/missing", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), response.Success)
+	assert.Equal(s.T(), "USER_NOT_FOUND", response.Code)
+}
+
+func (s *UserHandlerSuite) TestFindByIdServiceError() {
+	s.mockUserSvc.EXPECT().FindById(gomock.Any(), "user-123").Return(nil, errors.New("database error"))
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/users/user-123", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusInternalServerError, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), response.Success)
+	assert.Equal(s.T(), "INTERNAL_SERVER_ERROR", response.Code)
+	assert.Equal(s.T(), "Failed to retrieve user", response.Message)
+}
+
+func (s *UserHandlerSuite) TestListAllWithQuery() {
+	query := dto.ListUsersRequest{
+		Cursor:         "abc",
+		Limit:          10,
+		UsernamePrefix: "ops",
+		EmailDomain:    "corp.com",
+		HasScope:       "container:view",
+		SortBy:         "username",
+		Order:          "desc",
+	}
+	paging := &dto.Paging{Limit: 10, NextCursor: "next", HasMore: true}
+
+	s.mockUserSvc.EXPECT().FindAll(gomock.Any(), query).Return([]*entities.User{}, paging, nil)
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/users/list?cursor=abc&limit=10&username_prefix=ops&email_domain=corp.com&has_scope=container:view&sort_by=username&order=desc", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), paging, response.Paging)
+}
+
+func (s *UserHandlerSuite) TestListAllInvalidQuery() {
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/users/list?limit=1000&sort_by=hash", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "BAD_REQUEST", response.Code)
+}
+
+func (s *UserHandlerSuite) TestListAllInvalidCursor() {
+	s.mockUserSvc.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(nil, nil, apperrors.BadRequest(dto.CodeInvalidPagination, "invalid pagination parameters", nil))
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/users/list?cursor=bad", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "INVALID_PAGINATION", response.Code)
+	assert.Equal(s.T(), "Failed to retrieve users", response.Message)
+}
+
+func (s *UserHandlerSuite) TestCreateUnknownScope() {
+	req := dto.CreateUserRequest{
+		Username: "testuser",
+		Password: "password123",
+		Email:    "test@example.com",
+		Scopes:   []string{"missing"},
+	}
+
+	s.mockScopeSvc.EXPECT().FindMany(gomock.Any(), req.Scopes).Return(nil, apperrors.NotFound(dto.CodeScopeNotFound, "record not found", nil))
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/users/create", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "SCOPE_NOT_FOUND", response.Code)
+}
+
+func (s *UserHandlerSuite) TestCreateConflict() {
+	req := dto.CreateUserRequest{
+		Username: "testuser",
+		Password: "password123",
+		Email:    "test@example.com",
+		Scopes:   []string{},
+	}
+
+	s.mockScopeSvc.EXPECT().FindMany(gomock.Any(), req.Scopes).Return([]*entities.UserScope{}, nil)
+	s.mockUserSvc.EXPECT().Create(gomock.Any(), req.Username, req.Password, req.Email, []*entities.UserScope{}, nil, nil).Return(nil, apperrors.Conflict(dto.CodeUserAlreadyExists, "record already exists", nil))
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/users/create", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusConflict, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "USER_ALREADY_EXISTS", response.Code)
+	assert.Equal(s.T(), "Failed to register user", response.Message)
+}
+
+func (s *UserHandlerSuite) TestCreateWithRoles() {
+	req := dto.CreateUserRequest{
+		Username: "operator1",
+		Password: "password123",
+		Email:    "operator1@example.com",
+		Scopes:   []string{"report:mail"},
+		Roles:    []string{"operator"},
+	}
+
+	scopes := []*entities.UserScope{{ID: 1, Name: "report:mail"}}
+	roles := []*entities.Role{{ID: 1, Name: "operator", Scopes: []*entities.UserScope{{ID: 2, Name: "container:view"}}}}
+	expectedUser := &entities.User{
+		ID:       "user-123",
+		Username: req.Username,
+		Email:    req.Email,
+		Scopes:   scopes,
+		Roles:    roles,
+	}
+
+	s.mockScopeSvc.EXPECT().FindMany(gomock.Any(), req.Scopes).Return(scopes, nil)
+	s.mockRoleSvc.EXPECT().FindMany(gomock.Any(), req.Roles).Return(roles, nil)
+	s.mockUserSvc.EXPECT().Create(gomock.Any(), req.Username, req.Password, req.Email, scopes, nil, roles).Return(expectedUser, nil)
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/users/create", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+
+	var data struct {
+		Data dto.UserResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"operator"}, data.Data.Roles)
+	assert.Equal(s.T(), []string{"report:mail", "container:view"}, data.Data.EffectiveScopes)
+}
+
+func (s *UserHandlerSuite) TestCreateRoleNotFound() {
+	req := dto.CreateUserRequest{
+		Username: "operator1",
+		Password: "password123",
+		Email:    "operator1@example.com",
+		Scopes:   []string{},
+		Roles:    []string{"missing"},
+	}
+
+	s.mockScopeSvc.EXPECT().FindMany(gomock.Any(), req.Scopes).Return([]*entities.UserScope{}, nil)
+	s.mockRoleSvc.EXPECT().FindMany(gomock.Any(), req.Roles).Return(nil, apperrors.NotFound(dto.CodeRoleNotFound, "record not found", nil))
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/users/create", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeRoleNotFound, response.Code)
+	assert.Equal(s.T(), "Failed to find roles", response.Message)
+}
+
+func (s *UserHandlerSuite) TestUpdateRole() {
+	req := dto.UpdateRoleRequest{
+		UserId:  "user-123",
+		IsAdded: true,
+		Role:    "operator",
+	}
+	role := &entities.Role{ID: 1, Name: "operator"}
+
+	s.mockRoleSvc.EXPECT().FindOne(gomock.Any(), req.Role).Return(role, nil)
+	s.mockUserSvc.EXPECT().UpdateRole(gomock.Any(), req.UserId, role, true).Return(nil)
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("PUT", "/users/update/role", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), response.Success)
+	assert.Equal(s.T(), "USER_ROLE_UPDATED", response.Code)
+}
+
+func (s *UserHandlerSuite) TestUpdateRoleInvalidJSON() {
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("PUT", "/users/update/role", bytes.NewBufferString("{"))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *UserHandlerSuite) TestUpdateRoleNotFound() {
+	req := dto.UpdateRoleRequest{UserId: "user-123", IsAdded: true, Role: "missing"}
+
+	s.mockRoleSvc.EXPECT().FindOne(gomock.Any(), req.Role).Return(nil, apperrors.NotFound(dto.CodeRoleNotFound, "record not found", nil))
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("PUT", "/users/update/role", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+func (s *UserHandlerSuite) TestUpdateRoleUserServiceError() {
+	req := dto.UpdateRoleRequest{UserId: "missing", IsAdded: false, Role: "operator"}
+	role := &entities.Role{ID: 1, Name: "operator"}
+
+	s.mockRoleSvc.EXPECT().FindOne(gomock.Any(), req.Role).Return(role, nil)
+	s.mockUserSvc.EXPECT().UpdateRole(gomock.Any(), req.UserId, role, false).Return(apperrors.NotFound(dto.CodeUserNotFound, "record not found", nil))
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("PUT", "/users/update/role", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeUserNotFound, response.Code)
+	assert.Equal(s.T(), "Failed to update user role", response.Message)
+}
+
+func (s *UserHandlerSuite) TestExplainScope() {
+	grants := []entities.ScopeGrant{
+		{Scope: "container:*", Source: entities.GrantGroup, Path: []string{"backend", "engineering"}},
+	}
+
+	s.mockUserSvc.EXPECT().ExplainScope(gomock.Any(), "user-123", "container:view").Return(grants, nil)
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/users/user-123/scopes/explain?scope=container:view", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Code string                   `json:"code"`
+		Data []dto.ScopeGrantResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "SCOPE_EXPLAINED", response.Code)
+	assert.Equal(s.T(), dto.NewScopeGrantResponses(grants), response.Data)
+}
+
+func (s *UserHandlerSuite) TestExplainScopeMissingScope() {
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/users/user-123/scopes/explain", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *UserHandlerSuite) TestExplainScopeUserNotFound() {
+	s.mockUserSvc.EXPECT().ExplainScope(gomock.Any(), "missing", "container:view").Return(nil, apperrors.NotFound(dto.CodeUserNotFound, "record not found", nil))
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("GET", "/users/missing/scopes/explain?scope=container:view", nil)
+
+	s.router.ServeHTTP(w, httpReq)
+
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "Failed to explain scope", response.Message)
+}
@@ -0,0 +1,290 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
+)
+
+type webhookHandler struct {
+	webhookService services.IWebhookService
+	jwtMiddleware  middlewares.IJWTMiddleware
+}
+
+func NewWebhookHandler(webhookService services.IWebhookService, jwtMiddleware middlewares.IJWTMiddleware) *webhookHandler {
+	return &webhookHandler{webhookService, jwtMiddleware}
+}
+
+func (h *webhookHandler) Routes() []Route {
+	manage := middlewares.AllOf("webhook:manage")
+	return []Route{
+		{http.MethodPost, "/webhooks/create", manage, h.Create},
+		{http.MethodGet, "/webhooks/list", manage, h.ListAll},
+		{http.MethodGet, "/webhooks/:id", manage, h.FindOne},
+		{http.MethodPut, "/webhooks/update", manage, h.Update},
+		{http.MethodDelete, "/webhooks/delete", manage, h.Delete},
+		{http.MethodGet, "/webhooks/:id/deliveries", manage, h.ListDeliveries},
+	}
+}
+
+func (h *webhookHandler) SetupRoutes(r *gin.Engine) {
+	registerRoutes(r, h.jwtMiddleware, h.Routes())
+}
+
+// Create godoc
+// @Summary Register a webhook
+// @Description Register an endpoint that receives the given user and scope events as signed POST requests (requires webhook:manage). The signing secret is only returned here.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateWebhookRequest true "Endpoint URL and event types"
+// @Success 201 {object} dto.APIResponse{data=dto.WebhookSecretResponse} "Webhook created successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 422 {object} dto.APIResponse "Invalid URL or unknown event type"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /webhooks/create [post]
+func (h *webhookHandler) Create(c *gin.Context) {
+	var req dto.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	webhook, err := h.webhookService.Create(c.Request.Context(), req.URL, req.EventTypes, c.GetString("userId"))
+	if err != nil {
+		abortWithError(c, err, "Failed to create webhook")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Code:    "WEBHOOK_CREATED",
+		Message: "Webhook created successfully",
+		Data:    dto.NewWebhookSecretResponse(webhook),
+	})
+}
+
+// ListAll godoc
+// @Summary List webhooks
+// @Description Retrieve a cursor-paginated page of webhooks (requires webhook:manage)
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param cursor query string false "Cursor returned as paging.next_cursor by the previous page"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param active query bool false "Only active or only disabled webhooks"
+// @Param sort_by query string false "Sort field" Enums(id)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} dto.APIResponse{data=[]dto.WebhookResponse} "Webhooks retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /webhooks/list [get]
+func (h *webhookHandler) ListAll(c *gin.Context) {
+	var req dto.ListWebhooksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	webhooks, paging, err := h.webhookService.FindAll(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve webhooks")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "WEBHOOKS_RETRIEVED",
+		Message: "Webhooks retrieved successfully",
+		Data:    dto.NewWebhookResponses(webhooks),
+		Paging:  paging,
+	})
+}
+
+// FindOne godoc
+// @Summary Get a webhook
+// @Description Retrieve a webhook by ID (requires webhook:manage)
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} dto.APIResponse{data=dto.WebhookResponse} "Webhook retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Webhook not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /webhooks/{id} [get]
+func (h *webhookHandler) FindOne(c *gin.Context) {
+	webhookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	webhook, err := h.webhookService.FindById(c.Request.Context(), uint(webhookId))
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "WEBHOOK_RETRIEVED",
+		Message: "Webhook retrieved successfully",
+		Data:    dto.NewWebhookResponse(webhook),
+	})
+}
+
+// Update godoc
+// @Summary Update a webhook
+// @Description Change a webhook's URL, event types or active flag (requires webhook:manage). Activating a webhook disabled after repeated failures resets its failure count.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param body body dto.UpdateWebhookRequest true "Webhook ID and the fields to change"
+// @Success 200 {object} dto.APIResponse{data=dto.WebhookResponse} "Webhook updated successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Webhook not found"
+// @Failure 422 {object} dto.APIResponse "Invalid URL or unknown event type"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /webhooks/update [put]
+func (h *webhookHandler) Update(c *gin.Context) {
+	var req dto.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	webhook, err := h.webhookService.Update(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err, "Failed to update webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "WEBHOOK_UPDATED",
+		Message: "Webhook updated successfully",
+		Data:    dto.NewWebhookResponse(webhook),
+	})
+}
+
+// Delete godoc
+// @Summary Delete a webhook
+// @Description Delete a webhook and its delivery history (requires webhook:manage)
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param body body dto.DeleteWebhookRequest true "Webhook ID"
+// @Success 200 {object} dto.APIResponse "Webhook deleted successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Webhook not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /webhooks/delete [delete]
+func (h *webhookHandler) Delete(c *gin.Context) {
+	var req dto.DeleteWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := h.webhookService.Delete(c.Request.Context(), req.WebhookID); err != nil {
+		abortWithError(c, err, "Failed to delete webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "WEBHOOK_DELETED",
+		Message: "Webhook deleted successfully",
+	})
+}
+
+// ListDeliveries godoc
+// @Summary List webhook deliveries
+// @Description Retrieve a cursor-paginated page of a webhook's deliveries with their payloads and every attempt's status code and latency (requires webhook:manage)
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param cursor query string false "Cursor returned as paging.next_cursor by the previous page"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param status query string false "Only deliveries in this state" Enums(pending, succeeded, failed)
+// @Param sort_by query string false "Sort field" Enums(id)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} dto.APIResponse{data=[]dto.WebhookDeliveryResponse} "Webhook deliveries retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Webhook not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /webhooks/{id}/deliveries [get]
+func (h *webhookHandler) ListDeliveries(c *gin.Context) {
+	webhookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	var req dto.ListWebhookDeliveriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	deliveries, paging, err := h.webhookService.FindDeliveries(c.Request.Context(), uint(webhookId), req)
+	if err != nil {
+		abortWithError(c, err, "Failed to retrieve webhook deliveries")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "WEBHOOK_DELIVERIES_RETRIEVED",
+		Message: "Webhook deliveries retrieved successfully",
+		Data:    dto.NewWebhookDeliveryResponses(deliveries),
+		Paging:  paging,
+	})
+}
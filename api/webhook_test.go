@@ -0,0 +1,208 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+)
+
+type WebhookHandlerSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	webhookHandler *webhookHandler
+	mockWebhookSvc *services.MockIWebhookService
+	mockJWT        *middlewares.MockIJWTMiddleware
+	mockLogger     *logger.MockILogger
+	router         *gin.Engine
+}
+
+func (s *WebhookHandlerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.ctrl = gomock.NewController(s.T())
+	s.mockWebhookSvc = services.NewMockIWebhookService(s.ctrl)
+	s.mockJWT = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.webhookHandler = NewWebhookHandler(s.mockWebhookSvc, s.mockJWT)
+	s.router = gin.New()
+	s.router.Use(pkgmiddlewares.ErrorHandler(s.mockLogger))
+
+	s.mockJWT.EXPECT().Require(gomock.Any()).Return(func(c *gin.Context) {
+		c.Set("userId", "admin")
+		c.Next()
+	}).AnyTimes()
+
+	s.webhookHandler.SetupRoutes(s.router)
+}
+
+func (s *WebhookHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestWebhookHandlerSuite(t *testing.T) {
+	suite.Run(t, new(WebhookHandlerSuite))
+}
+
+func (s *WebhookHandlerSuite) serve(method, path string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest(method, path, &buf)
+	httpReq.Header.Set("Content-Type", "application/json")
+	s.router.ServeHTTP(w, httpReq)
+	return w
+}
+
+func (s *WebhookHandlerSuite) webhook() *entities.Webhook {
+	return &entities.Webhook{
+		ID:         1,
+		URL:        "https://example.com/hook",
+		Secret:     "whsec_test",
+		EventTypes: "user.created,user.deleted",
+		Active:     true,
+		CreatedBy:  "admin",
+		CreatedAt:  time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (s *WebhookHandlerSuite) TestCreate() {
+	webhook := s.webhook()
+	s.mockWebhookSvc.EXPECT().Create(gomock.Any(), "https://example.com/hook", []string{"user.created", "user.deleted"}, "admin").Return(webhook, nil)
+
+	w := s.serve("POST", "/webhooks/create", dto.CreateWebhookRequest{URL: "https://example.com/hook", EventTypes: []string{"user.created", "user.deleted"}})
+	assert.Equal(s.T(), http.StatusCreated, w.Code)
+
+	var data struct {
+		Code string                    `json:"code"`
+		Data dto.WebhookSecretResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "WEBHOOK_CREATED", data.Code)
+	assert.Equal(s.T(), dto.NewWebhookSecretResponse(webhook), data.Data)
+}
+
+func (s *WebhookHandlerSuite) TestCreateInvalidInput() {
+	w := s.serve("POST", "/webhooks/create", dto.CreateWebhookRequest{URL: "https://example.com/hook"})
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *WebhookHandlerSuite) TestCreateUnknownEventType() {
+	s.mockWebhookSvc.EXPECT().Create(gomock.Any(), "https://example.com/hook", []string{"user.renamed"}, "admin").
+		Return(nil, apperrors.Validation(dto.CodeInvalidEventType, `unknown event type "user.renamed"`, nil))
+
+	w := s.serve("POST", "/webhooks/create", dto.CreateWebhookRequest{URL: "https://example.com/hook", EventTypes: []string{"user.renamed"}})
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), dto.CodeInvalidEventType, response.Code)
+	assert.Equal(s.T(), "Failed to create webhook", response.Message)
+}
+
+func (s *WebhookHandlerSuite) TestListAll() {
+	webhooks := []*entities.Webhook{s.webhook()}
+	active := true
+	s.mockWebhookSvc.EXPECT().FindAll(gomock.Any(), dto.ListWebhooksRequest{Active: &active}).Return(webhooks, &dto.Paging{}, nil)
+
+	w := s.serve("GET", "/webhooks/list?active=true", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Code string                `json:"code"`
+		Data []dto.WebhookResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "WEBHOOKS_RETRIEVED", data.Code)
+	assert.Equal(s.T(), dto.NewWebhookResponses(webhooks), data.Data)
+	assert.NotContains(s.T(), w.Body.String(), "whsec_test")
+}
+
+func (s *WebhookHandlerSuite) TestFindOneNotFound() {
+	s.mockWebhookSvc.EXPECT().FindById(gomock.Any(), uint(1)).
+		Return(nil, apperrors.NotFound(dto.CodeWebhookNotFound, "record not found", nil))
+
+	w := s.serve("GET", "/webhooks/1", nil)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+func (s *WebhookHandlerSuite) TestFindOneInvalidID() {
+	w := s.serve("GET", "/webhooks/abc", nil)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *WebhookHandlerSuite) TestUpdate() {
+	webhook := s.webhook()
+	active := true
+	req := dto.UpdateWebhookRequest{WebhookID: 1, Active: &active}
+	s.mockWebhookSvc.EXPECT().Update(gomock.Any(), req).Return(webhook, nil)
+
+	w := s.serve("PUT", "/webhooks/update", req)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+func (s *WebhookHandlerSuite) TestDelete() {
+	s.mockWebhookSvc.EXPECT().Delete(gomock.Any(), uint(1)).Return(nil)
+
+	w := s.serve("DELETE", "/webhooks/delete", dto.DeleteWebhookRequest{WebhookID: 1})
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+}
+
+func (s *WebhookHandlerSuite) TestListDeliveries() {
+	status := http.StatusInternalServerError
+	deliveries := []*entities.WebhookDelivery{{
+		ID:             3,
+		WebhookID:      1,
+		EventID:        "event1",
+		EventType:      "user.created",
+		Payload:        `{"id":"event1","type":"user.created"}`,
+		Status:         entities.WebhookDeliveryPending,
+		AttemptCount:   1,
+		LastStatusCode: &status,
+		Attempts:       []*entities.WebhookAttempt{{StatusCode: status, LatencyMs: 42, Error: "500 Internal Server Error"}},
+	}}
+	s.mockWebhookSvc.EXPECT().FindDeliveries(gomock.Any(), uint(1), dto.ListWebhookDeliveriesRequest{Status: "pending"}).Return(deliveries, &dto.Paging{}, nil)
+
+	w := s.serve("GET", "/webhooks/1/deliveries?status=pending", nil)
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+
+	var data struct {
+		Code string `json:"code"`
+		Data []struct {
+			Payload  json.RawMessage              `json:"payload"`
+			Attempts []dto.WebhookAttemptResponse `json:"attempts"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &data)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "WEBHOOK_DELIVERIES_RETRIEVED", data.Code)
+	assert.Len(s.T(), data.Data, 1)
+	assert.JSONEq(s.T(), deliveries[0].Payload, string(data.Data[0].Payload))
+	assert.Equal(s.T(), int64(42), data.Data[0].Attempts[0].LatencyMs)
+	assert.Equal(s.T(), status, data.Data[0].Attempts[0].StatusCode)
+}
+
+func (s *WebhookHandlerSuite) TestListDeliveriesInvalidStatus() {
+	w := s.serve("GET", "/webhooks/1/deliveries?status=retrying", nil)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
@@ -15,12 +15,17 @@ import (
 	swagger "github.com/swaggo/gin-swagger"
 	"github.com/vnFuhung2903/vcs-user-management-service/api"
 	_ "github.com/vnFuhung2903/vcs-user-management-service/docs"
-	"github.com/vnFuhung2903/vcs-user-management-service/entities"
 	"github.com/vnFuhung2903/vcs-user-management-service/infrastructures/databases"
 	"github.com/vnFuhung2903/vcs-user-management-service/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/migration"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/audit"
 	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
 	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
 	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/password"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
 	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
 	"github.com/vnFuhung2903/vcs-user-management-service/usecases/services"
 	"go.uber.org/zap"
@@ -35,6 +40,9 @@ import (
 // @in header
 // @name Authorization
 func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	env, err := env.LoadEnv()
 	if err != nil {
 		log.Fatalf("Failed to retrieve env: %v", err)
@@ -49,39 +57,130 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to create docker client: %v", err)
 	}
-	postgresDb.AutoMigrate(&entities.User{}, &entities.UserScope{})
 
-	sqlBytes, err := os.ReadFile("migration/init.sql")
-	if err != nil {
-		log.Fatalf("Error reading SQL file: %v", err)
+	if err := postgresDb.Use(tenancy.Plugin{}); err != nil {
+		log.Fatalf("Failed to install tenancy plugin: %v", err)
+	}
+
+	migrator := databases.NewMigrator(postgresDb, migration.Versions(), migration.Seed)
+	if err := migrator.Up(ctx); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
 	}
-	execTrigger := postgresDb.Exec(string(sqlBytes))
-	if execTrigger.Error != nil {
-		log.Fatalf("Failed to execute trigger SQL: %v", execTrigger.Error)
+	if err := migrator.Seed(ctx); err != nil {
+		log.Fatalf("Failed to seed database: %v", err)
 	}
 
 	redisRawClient := databases.NewRedisFactory(env.RedisEnv).ConnectRedis()
 	defer redisRawClient.Close()
 	redisClient := interfaces.NewRedisClient(redisRawClient)
 
-	jwtMiddleware := middlewares.NewJWTMiddleware(env.AuthEnv)
+	passwordHasher, err := password.LoadHasher(env.PasswordHashEnv)
+	if err != nil {
+		log.Fatalf("Failed to load password hasher: %v", err)
+	}
+	passwordPolicy, err := password.LoadPolicy(env.PasswordPolicyEnv, passwordHasher)
+	if err != nil {
+		log.Fatalf("Failed to load password policy: %v", err)
+	}
+
+	var keySet middlewares.IKeySet
+	if env.AuthEnv.JWKSURL != "" || env.AuthEnv.JWKSFile != "" {
+		keySet, err = middlewares.LoadJWKS(ctx, env.AuthEnv, logger)
+		if err != nil {
+			log.Fatalf("Failed to load JWKS: %v", err)
+		}
+	}
+
+	revocationStore := revocation.NewStore(redisClient, env.RevocationEnv)
+	jwtMiddleware := middlewares.NewJWTMiddleware(env.AuthEnv, keySet, revocationStore)
+	organizationRepository := repositories.NewOrganizationRepository(postgresDb)
 	scopeRepository := repositories.NewScopeRepository(postgresDb)
+	roleRepository := repositories.NewRoleRepository(postgresDb)
+	groupRepository := repositories.NewGroupRepository(postgresDb)
 	userRepository := repositories.NewUserRepository(postgresDb)
+	accessRequestRepository := repositories.NewAccessRequestRepository(postgresDb)
+	auditRepository := repositories.NewAuditRepository(postgresDb)
+	outboxRepository := repositories.NewOutboxRepository(postgresDb)
+	webhookRepository := repositories.NewWebhookRepository(postgresDb)
+	serviceAccountRepository := repositories.NewServiceAccountRepository(postgresDb)
 
-	scopeService := services.NewScopeService(scopeRepository, logger)
-	userService := services.NewUserService(userRepository, redisClient, logger)
+	organizationService := services.NewOrganizationService(organizationRepository, logger)
+	scopeService := services.NewScopeService(scopeRepository, userRepository, auditRepository, outboxRepository, redisClient, revocationStore, logger)
+	roleService := services.NewRoleService(roleRepository, userRepository, outboxRepository, redisClient, revocationStore, logger)
+	groupService := services.NewGroupService(groupRepository, userRepository, outboxRepository, redisClient, revocationStore, logger)
+	userService := services.NewUserService(userRepository, auditRepository, outboxRepository, redisClient, revocationStore, passwordHasher, passwordPolicy, logger)
+	sessionService := services.NewSessionService(userRepository, redisClient, revocationStore, logger)
+	authzService := services.NewAuthzService(userRepository, redisClient, env.AuthzEnv.CacheTTL, logger)
+	serviceAccountService := services.NewServiceAccountService(serviceAccountRepository, userRepository, revocationStore, env.AuthEnv, env.ServiceAccountEnv, logger)
+	introspectionService := services.NewIntrospectionService(jwtMiddleware, userRepository, serviceAccountRepository, logger)
+	auditService := services.NewAuditService(auditRepository, logger)
+	grantReaper := services.NewGrantReaper(userRepository, auditRepository, outboxRepository, redisClient, revocationStore, logger)
+	go grantReaper.Run(ctx, env.WorkerEnv.GrantReapInterval)
+	accessRequestService := services.NewAccessRequestService(accessRequestRepository, scopeService, userService, env.AccessRequestEnv.TTL, logger)
+	accessRequestReaper := services.NewAccessRequestReaper(accessRequestRepository, logger)
+	go accessRequestReaper.Run(ctx, env.WorkerEnv.AccessRequestReapInterval)
+	outboxRelay := services.NewOutboxRelay(outboxRepository, redisClient, env.EventsEnv, logger)
+	go outboxRelay.Run(ctx, env.WorkerEnv.OutboxRelayInterval)
+	webhookService := services.NewWebhookService(webhookRepository, logger)
+	webhookSender := services.NewWebhookSender(webhookRepository, env.WebhookEnv, logger)
+	go webhookSender.Run(ctx, env.WorkerEnv.WebhookSendInterval)
+	webhookConsumer := events.NewConsumer(redisRawClient, env.EventsEnv.Stream, "webhooks", env.EventsEnv.ConsumerName, env.EventsEnv.ClaimIdle, logger)
+	go func() {
+		// The consumer leaves the events it fails to dispatch pending,
+		// to be claimed again, and stops only when redis fails; restart it
+		// once redis is reachable again.
+		for {
+			err := webhookConsumer.Run(ctx, webhookService.Dispatch)
+			if err == nil {
+				return
+			}
+			logger.Error("webhook event consumer stopped", zap.Error(err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(env.WorkerEnv.WebhookSendInterval):
+			}
+		}
+	}()
+	organizationHandler := api.NewOrganizationHandler(organizationService, jwtMiddleware)
 	scopeHandler := api.NewScopeHandler(scopeService, jwtMiddleware)
-	userHandler := api.NewUserHandler(scopeService, userService, jwtMiddleware)
+	roleHandler := api.NewRoleHandler(scopeService, roleService, jwtMiddleware)
+	groupHandler := api.NewGroupHandler(scopeService, groupService, userService, jwtMiddleware)
+	userHandler := api.NewUserHandler(scopeService, roleService, userService, jwtMiddleware)
+	meHandler := api.NewMeHandler(userService, jwtMiddleware)
+	accessRequestHandler := api.NewAccessRequestHandler(accessRequestService, jwtMiddleware)
+	auditHandler := api.NewAuditHandler(auditService, jwtMiddleware)
+	webhookHandler := api.NewWebhookHandler(webhookService, jwtMiddleware)
+	sessionHandler := api.NewSessionHandler(sessionService, jwtMiddleware)
+	authzHandler := api.NewAuthzHandler(authzService, jwtMiddleware)
+	serviceAccountHandler := api.NewServiceAccountHandler(scopeService, serviceAccountService, jwtMiddleware)
+	oauthHandler := api.NewOAuthHandler(introspectionService, serviceAccountService, jwtMiddleware)
 
-	r := gin.Default()
+	r := gin.New()
+	if err := r.SetTrustedProxies(env.ServerEnv.TrustedProxies); err != nil {
+		log.Fatalf("Failed to set trusted proxies: %v", err)
+	}
+	r.Use(gin.Logger(), middlewares.RequestOrigin(), middlewares.ErrorHandler(logger))
 	r.Use(cors.New(cors.Config{
-		AllowOrigins: []string{"http://user.localhost", "http://swagger.localhost", "http://frontend.localhost"},
-		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders: []string{"Origin", "Content-Type", "Authorization"},
+		AllowOrigins:  []string{"http://user.localhost", "http://swagger.localhost", "http://frontend.localhost"},
+		AllowMethods:  []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Authorization", tenancy.Header, audit.RequestIDHeader},
+		ExposeHeaders: []string{audit.RequestIDHeader},
 	}))
 
+	organizationHandler.SetupRoutes(r)
 	scopeHandler.SetupRoutes(r)
+	roleHandler.SetupRoutes(r)
+	groupHandler.SetupRoutes(r)
 	userHandler.SetupRoutes(r)
+	meHandler.SetupRoutes(r)
+	accessRequestHandler.SetupRoutes(r)
+	auditHandler.SetupRoutes(r)
+	webhookHandler.SetupRoutes(r)
+	sessionHandler.SetupRoutes(r)
+	authzHandler.SetupRoutes(r)
+	serviceAccountHandler.SetupRoutes(r)
+	oauthHandler.SetupRoutes(r)
 	r.GET("/swagger/*any", swagger.WrapHandler(swaggerFiles.Handler))
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -97,9 +196,10 @@ func main() {
 
 	go func() {
 		<-quit
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-		if err := server.Shutdown(ctx); err != nil {
+		cancel()
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer shutdownCancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
 			logger.Error("HTTP server shutdown failed", zap.Error(err))
 		}
 		logger.Info("User management service stopped gracefully")
@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+// CreateAccessRequestRequest asks for Scope. GrantExpiresAt, when set, makes
+// the grant made on approval temporary.
+type CreateAccessRequestRequest struct {
+	Scope          string     `json:"scope" binding:"required"`
+	Justification  string     `json:"justification" binding:"required,max=1000"`
+	GrantExpiresAt *time.Time `json:"grant_expires_at"`
+}
+
+type ListAccessRequestsRequest struct {
+	Cursor      string `form:"cursor"`
+	Limit       int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Status      string `form:"status" binding:"omitempty,oneof=pending approved rejected cancelled expired"`
+	Scope       string `form:"scope"`
+	RequesterID string `form:"requester_id"`
+	SortBy      string `form:"sort_by" binding:"omitempty,oneof=id"`
+	Order       string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+type ReviewAccessRequestRequest struct {
+	RequestID uint   `json:"request_id" binding:"required"`
+	Comment   string `json:"comment" binding:"max=1000"`
+}
+
+type CancelAccessRequestRequest struct {
+	RequestID uint `json:"request_id" binding:"required"`
+}
+
+type CommentAccessRequestRequest struct {
+	RequestID uint   `json:"request_id" binding:"required"`
+	Body      string `json:"body" binding:"required,max=1000"`
+}
+
+type AccessRequestResponse struct {
+	ID             uint                           `json:"id"`
+	RequesterID    string                         `json:"requester_id"`
+	Scope          string                         `json:"scope"`
+	Justification  string                         `json:"justification"`
+	Status         string                         `json:"status"`
+	GrantExpiresAt *time.Time                     `json:"grant_expires_at,omitempty"`
+	ExpiresAt      time.Time                      `json:"expires_at"`
+	DecidedBy      *string                        `json:"decided_by,omitempty"`
+	DecidedAt      *time.Time                     `json:"decided_at,omitempty"`
+	CreatedAt      time.Time                      `json:"created_at"`
+	Comments       []AccessRequestCommentResponse `json:"comments"`
+}
+
+type AccessRequestCommentResponse struct {
+	ID        uint      `json:"id"`
+	AuthorID  string    `json:"author_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewAccessRequestResponse(request *entities.AccessRequest) AccessRequestResponse {
+	comments := make([]AccessRequestCommentResponse, 0, len(request.Comments))
+	for _, comment := range request.Comments {
+		comments = append(comments, NewAccessRequestCommentResponse(comment))
+	}
+	return AccessRequestResponse{
+		ID:             request.ID,
+		RequesterID:    request.RequesterID,
+		Scope:          request.Scope,
+		Justification:  request.Justification,
+		Status:         request.Status,
+		GrantExpiresAt: request.GrantExpiresAt,
+		ExpiresAt:      request.ExpiresAt,
+		DecidedBy:      request.DecidedBy,
+		DecidedAt:      request.DecidedAt,
+		CreatedAt:      request.CreatedAt,
+		Comments:       comments,
+	}
+}
+
+func NewAccessRequestResponses(requests []*entities.AccessRequest) []AccessRequestResponse {
+	responses := make([]AccessRequestResponse, 0, len(requests))
+	for _, request := range requests {
+		responses = append(responses, NewAccessRequestResponse(request))
+	}
+	return responses
+}
+
+func NewAccessRequestCommentResponse(comment *entities.AccessRequestComment) AccessRequestCommentResponse {
+	return AccessRequestCommentResponse{
+		ID:        comment.ID,
+		AuthorID:  comment.AuthorID,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+	}
+}
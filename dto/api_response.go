@@ -5,5 +5,7 @@ type APIResponse struct {
 	Code    string      `json:"code"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
+	Paging  *Paging     `json:"paging,omitempty"`
+	Details interface{} `json:"details,omitempty"`
 	Error   string      `json:"error,omitempty"`
 }
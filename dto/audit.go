@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+// ListAuditEntriesRequest filters the audit log. Since and Until bound
+// created_at and take RFC 3339 timestamps; Since is inclusive and Until
+// exclusive.
+type ListAuditEntriesRequest struct {
+	Cursor     string     `form:"cursor"`
+	Limit      int        `form:"limit" binding:"omitempty,min=1,max=100"`
+	ActorID    string     `form:"actor_id"`
+	Action     string     `form:"action"`
+	TargetType string     `form:"target_type"`
+	TargetID   string     `form:"target_id"`
+	RequestID  string     `form:"request_id"`
+	Since      *time.Time `form:"since"`
+	Until      *time.Time `form:"until"`
+	SortBy     string     `form:"sort_by" binding:"omitempty,oneof=id"`
+	Order      string     `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+type AuditEntryResponse struct {
+	ID         uint            `json:"id"`
+	ActorID    string          `json:"actor_id,omitempty"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	Before     json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	RequestID  string          `json:"request_id"`
+	SourceIP   string          `json:"source_ip"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func NewAuditEntryResponse(entry *entities.AuditEntry) AuditEntryResponse {
+	return AuditEntryResponse{
+		ID:         entry.ID,
+		ActorID:    entry.ActorID,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Before:     rawJSON(entry.Before),
+		After:      rawJSON(entry.After),
+		RequestID:  entry.RequestID,
+		SourceIP:   entry.SourceIP,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
+
+func NewAuditEntryResponses(entries []*entities.AuditEntry) []AuditEntryResponse {
+	responses := make([]AuditEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		responses = append(responses, NewAuditEntryResponse(entry))
+	}
+	return responses
+}
+
+func rawJSON(value *string) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	return json.RawMessage(*value)
+}
@@ -0,0 +1,29 @@
+package dto
+
+// Reasons given in AuthzDecision.Reason.
+const (
+	AuthzReasonGranted        = "granted"
+	AuthzReasonMissingScope   = "missing_scope"
+	AuthzReasonUnknownSubject = "unknown_subject"
+)
+
+// AuthzCheckRequest asks whether Subject, a user ID, holds every scope in
+// Scopes.
+type AuthzCheckRequest struct {
+	Subject string   `json:"subject" binding:"required"`
+	Scopes  []string `json:"scopes" binding:"required,min=1,max=50,dive,required"`
+}
+
+type AuthzBatchCheckRequest struct {
+	Checks []AuthzCheckRequest `json:"checks" binding:"required,min=1,max=100,dive"`
+}
+
+// AuthzDecision answers an AuthzCheckRequest. Missing lists the required
+// scopes the subject does not hold.
+type AuthzDecision struct {
+	Subject string   `json:"subject"`
+	Scopes  []string `json:"scopes"`
+	Allowed bool     `json:"allowed"`
+	Reason  string   `json:"reason"`
+	Missing []string `json:"missing,omitempty"`
+}
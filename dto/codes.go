@@ -0,0 +1,111 @@
+package dto
+
+// Error codes rendered into APIResponse.Code. Clients may branch on these, so
+// existing values must never change meaning.
+//
+//	BAD_REQUEST                  400  malformed body or query parameters
+//	INVALID_PAGINATION           400  unknown cursor or cursor issued for another sort
+//	ORGANIZATION_REQUIRED        400  a super-admin looked a scope, role or group up by name without picking an organization
+//	TOKEN_MISSING                401  no bearer token in the Authorization header
+//	TOKEN_INVALID                401  malformed token, bad signature or disallowed alg
+//	INVALID_CLAIMS               401  claim has the wrong type or sub is missing; 403 for scope
+//	TOKEN_EXPIRY_MISSING         401  exp is required but absent
+//	TOKEN_EXPIRED                401  exp is in the past, beyond the clock skew
+//	TOKEN_NOT_YET_VALID          401  nbf is in the future, beyond the clock skew
+//	TOKEN_ISSUED_IN_FUTURE       401  iat is in the future, beyond the clock skew
+//	TOKEN_ISSUED_AT_MISSING      401  a maximum token age is set but iat is absent
+//	TOKEN_TOO_OLD                401  iat is older than the maximum token age
+//	TOKEN_INVALID_ISSUER         401  iss differs from the expected issuer
+//	TOKEN_INVALID_AUDIENCE       401  aud names none of the expected audiences
+//	TENANT_MISSING               401  token has no tenant claim
+//	TOKEN_REVOKED                401  token, its session, its subject or every token was revoked
+//	INVALID_CLIENT               401  client credentials match no active service account
+//	FORBIDDEN                    403  authenticated but not allowed
+//	INSUFFICIENT_SCOPE           403  token lacks the scope the route requires
+//	INVALID_CREDENTIALS          403  current password did not match
+//	TENANT_FORBIDDEN             403  caller may not act in the requested organization
+//	USER_NOT_FOUND               404  no user with the given id
+//	SCOPE_NOT_FOUND              404  no scope with the given name
+//	ROLE_NOT_FOUND               404  no role with the given name
+//	GROUP_NOT_FOUND              404  no group with the given name
+//	ORGANIZATION_NOT_FOUND       404  no organization with the given id
+//	ACCESS_REQUEST_NOT_FOUND     404  no access request with the given id
+//	WEBHOOK_NOT_FOUND            404  no webhook with the given id
+//	SESSION_NOT_FOUND            404  user has no active session with the given id
+//	SERVICE_ACCOUNT_NOT_FOUND    404  no service account with the given id
+//	SECRET_NOT_FOUND             404  service account has no secret with the given id
+//	USER_ALREADY_EXISTS          409  username or email is taken
+//	SCOPE_ALREADY_EXISTS         409  scope name is taken
+//	ROLE_ALREADY_EXISTS          409  role name is taken
+//	GROUP_ALREADY_EXISTS         409  group name is taken
+//	ORGANIZATION_ALREADY_EXISTS  409  organization id or name is taken
+//	ACCESS_REQUEST_EXISTS        409  requester already has a pending request for the scope
+//	ACCESS_REQUEST_NOT_PENDING   409  access request was already decided, cancelled or expired
+//	SERVICE_ACCOUNT_EXISTS       409  service account name is taken
+//	SECRET_LIMIT_REACHED         409  service account already holds the maximum number of secrets
+//	VALIDATION_FAILED            422  well-formed request rejected by a business rule
+//	INVALID_EMAIL                422  email address cannot be parsed
+//	WEAK_PASSWORD                422  password policy violated; details lists each rule
+//	INVALID_SCOPE                422  scope name or pattern is malformed
+//	GROUP_CYCLE                  422  the new parent is the group itself or one of its descendants
+//	INVALID_ORGANIZATION         422  organization id is malformed
+//	INVALID_GRANT_EXPIRY         422  grant expiry is in the past or names a scope not granted
+//	INVALID_WEBHOOK_URL          422  webhook url is not an absolute http or https url of a public host
+//	INVALID_EVENT_TYPE           422  webhook subscribes to an unknown event type
+//	INVALID_ACCOUNT_EXPIRY       422  service account expiry is in the past
+//	INTERNAL_SERVER_ERROR        500  unexpected failure, including recovered panics
+//	REVOCATION_UNAVAILABLE       503  revocation state could not be read, so the token was refused
+const (
+	CodeBadRequest                = "BAD_REQUEST"
+	CodeInvalidPagination         = "INVALID_PAGINATION"
+	CodeOrganizationRequired      = "ORGANIZATION_REQUIRED"
+	CodeTokenMissing              = "TOKEN_MISSING"
+	CodeTokenInvalid              = "TOKEN_INVALID"
+	CodeInvalidClaims             = "INVALID_CLAIMS"
+	CodeTokenExpiryMissing        = "TOKEN_EXPIRY_MISSING"
+	CodeTokenExpired              = "TOKEN_EXPIRED"
+	CodeTokenNotYetValid          = "TOKEN_NOT_YET_VALID"
+	CodeTokenIssuedInFuture       = "TOKEN_ISSUED_IN_FUTURE"
+	CodeTokenIssuedAtMissing      = "TOKEN_ISSUED_AT_MISSING"
+	CodeTokenTooOld               = "TOKEN_TOO_OLD"
+	CodeTokenInvalidIssuer        = "TOKEN_INVALID_ISSUER"
+	CodeTokenInvalidAudience      = "TOKEN_INVALID_AUDIENCE"
+	CodeTenantMissing             = "TENANT_MISSING"
+	CodeTokenRevoked              = "TOKEN_REVOKED"
+	CodeInvalidClient             = "INVALID_CLIENT"
+	CodeForbidden                 = "FORBIDDEN"
+	CodeInsufficientScope         = "INSUFFICIENT_SCOPE"
+	CodeInvalidCredentials        = "INVALID_CREDENTIALS"
+	CodeTenantForbidden           = "TENANT_FORBIDDEN"
+	CodeUserNotFound              = "USER_NOT_FOUND"
+	CodeScopeNotFound             = "SCOPE_NOT_FOUND"
+	CodeRoleNotFound              = "ROLE_NOT_FOUND"
+	CodeGroupNotFound             = "GROUP_NOT_FOUND"
+	CodeOrganizationNotFound      = "ORGANIZATION_NOT_FOUND"
+	CodeAccessRequestNotFound     = "ACCESS_REQUEST_NOT_FOUND"
+	CodeWebhookNotFound           = "WEBHOOK_NOT_FOUND"
+	CodeSessionNotFound           = "SESSION_NOT_FOUND"
+	CodeServiceAccountNotFound    = "SERVICE_ACCOUNT_NOT_FOUND"
+	CodeSecretNotFound            = "SECRET_NOT_FOUND"
+	CodeUserAlreadyExists         = "USER_ALREADY_EXISTS"
+	CodeScopeAlreadyExists        = "SCOPE_ALREADY_EXISTS"
+	CodeRoleAlreadyExists         = "ROLE_ALREADY_EXISTS"
+	CodeGroupAlreadyExists        = "GROUP_ALREADY_EXISTS"
+	CodeOrganizationAlreadyExists = "ORGANIZATION_ALREADY_EXISTS"
+	CodeAccessRequestExists       = "ACCESS_REQUEST_EXISTS"
+	CodeAccessRequestNotPending   = "ACCESS_REQUEST_NOT_PENDING"
+	CodeServiceAccountExists      = "SERVICE_ACCOUNT_EXISTS"
+	CodeSecretLimitReached        = "SECRET_LIMIT_REACHED"
+	CodeValidationFailed          = "VALIDATION_FAILED"
+	CodeInvalidEmail              = "INVALID_EMAIL"
+	CodeWeakPassword              = "WEAK_PASSWORD"
+	CodeInvalidScope              = "INVALID_SCOPE"
+	CodeGroupCycle                = "GROUP_CYCLE"
+	CodeInvalidOrganization       = "INVALID_ORGANIZATION"
+	CodeInvalidGrantExpiry        = "INVALID_GRANT_EXPIRY"
+	CodeInvalidWebhookURL         = "INVALID_WEBHOOK_URL"
+	CodeInvalidEventType          = "INVALID_EVENT_TYPE"
+	CodeInvalidAccountExpiry      = "INVALID_ACCOUNT_EXPIRY"
+	CodeInternalServerError       = "INTERNAL_SERVER_ERROR"
+	CodeRevocationUnavailable     = "REVOCATION_UNAVAILABLE"
+)
@@ -0,0 +1,67 @@
+package dto
+
+import "github.com/vnFuhung2903/vcs-user-management-service/entities"
+
+type CreateGroupRequest struct {
+	GroupName string   `json:"group_name" binding:"required"`
+	Parent    string   `json:"parent"`
+	Scopes    []string `json:"scopes"`
+}
+
+type ListGroupsRequest struct {
+	Cursor     string `form:"cursor"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	NamePrefix string `form:"name_prefix"`
+	SortBy     string `form:"sort_by" binding:"omitempty,oneof=id name"`
+	Order      string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+type UpdateGroupScopeRequest struct {
+	GroupName string `json:"group_name" binding:"required"`
+	IsAdded   bool   `json:"is_added"`
+	Scope     string `json:"scope" binding:"required"`
+}
+
+// UpdateGroupParentRequest moves a group under Parent, or to the top level
+// when Parent is empty.
+type UpdateGroupParentRequest struct {
+	GroupName string `json:"group_name" binding:"required"`
+	Parent    string `json:"parent"`
+}
+
+type UpdateGroupMemberRequest struct {
+	GroupName string `json:"group_name" binding:"required"`
+	UserId    string `json:"user_id" binding:"required"`
+	IsAdded   bool   `json:"is_added"`
+}
+
+type DeleteGroupRequest struct {
+	GroupName string `json:"group_name" binding:"required"`
+}
+
+type GroupResponse struct {
+	ID     uint            `json:"id"`
+	Name   string          `json:"name"`
+	Parent string          `json:"parent,omitempty"`
+	Scopes []ScopeResponse `json:"scopes"`
+}
+
+func NewGroupResponse(group *entities.Group) GroupResponse {
+	response := GroupResponse{
+		ID:     group.ID,
+		Name:   group.Name,
+		Scopes: NewScopeResponses(group.Scopes),
+	}
+	if group.Parent != nil {
+		response.Parent = group.Parent.Name
+	}
+	return response
+}
+
+func NewGroupResponses(groups []*entities.Group) []GroupResponse {
+	responses := make([]GroupResponse, 0, len(groups))
+	for _, group := range groups {
+		responses = append(responses, NewGroupResponse(group))
+	}
+	return responses
+}
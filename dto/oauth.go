@@ -0,0 +1,45 @@
+package dto
+
+// IntrospectRequest is the form body of an RFC 7662 introspection request.
+// Only access tokens are issued as JWTs, so TokenTypeHint is accepted and
+// ignored.
+type IntrospectRequest struct {
+	Token         string `form:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+}
+
+// IntrospectionResponse is the RFC 7662 introspection response. An inactive
+// token carries Active alone, so nothing is disclosed about why.
+type IntrospectionResponse struct {
+	Active   bool   `json:"active"`
+	Scope    string `json:"scope,omitempty"`
+	Sub      string `json:"sub,omitempty"`
+	Exp      int64  `json:"exp,omitempty"`
+	ClientID string `json:"client_id,omitempty"`
+}
+
+// OAuthError is the RFC 6749 error response the OAuth endpoints send instead
+// of an APIResponse.
+type OAuthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// TokenRequest is the form body of an RFC 6749 token request. Only the
+// client_credentials grant is supported. The client may authenticate with
+// HTTP Basic instead of ClientID and ClientSecret. Scope is space-delimited
+// and defaults to every scope of the client.
+type TokenRequest struct {
+	GrantType    string `form:"grant_type" binding:"required"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	Scope        string `form:"scope"`
+}
+
+// TokenResponse is the RFC 6749 access token response.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "github.com/vnFuhung2903/vcs-user-management-service/entities"
+
+type CreateOrganizationRequest struct {
+	OrganizationID string `json:"organization_id" binding:"required"`
+	Name           string `json:"name" binding:"required"`
+}
+
+type ListOrganizationsRequest struct {
+	Cursor     string `form:"cursor"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	NamePrefix string `form:"name_prefix"`
+	SortBy     string `form:"sort_by" binding:"omitempty,oneof=id name"`
+	Order      string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+type OrganizationResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func NewOrganizationResponse(organization *entities.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		ID:   organization.ID,
+		Name: organization.Name,
+	}
+}
+
+func NewOrganizationResponses(organizations []*entities.Organization) []OrganizationResponse {
+	responses := make([]OrganizationResponse, 0, len(organizations))
+	for _, organization := range organizations {
+		responses = append(responses, NewOrganizationResponse(organization))
+	}
+	return responses
+}
@@ -0,0 +1,14 @@
+package dto
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Paging describes a keyset page. NextCursor is opaque to clients and is
+// passed back unchanged as the cursor query parameter to fetch the next page.
+type Paging struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
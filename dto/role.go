@@ -0,0 +1,48 @@
+package dto
+
+import "github.com/vnFuhung2903/vcs-user-management-service/entities"
+
+type CreateRoleRequest struct {
+	RoleName string   `json:"role_name" binding:"required"`
+	Scopes   []string `json:"scopes"`
+}
+
+type ListRolesRequest struct {
+	Cursor     string `form:"cursor"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	NamePrefix string `form:"name_prefix"`
+	SortBy     string `form:"sort_by" binding:"omitempty,oneof=id name"`
+	Order      string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+type UpdateRoleScopeRequest struct {
+	RoleName string `json:"role_name" binding:"required"`
+	IsAdded  bool   `json:"is_added"`
+	Scope    string `json:"scope" binding:"required"`
+}
+
+type DeleteRoleRequest struct {
+	RoleName string `json:"role_name" binding:"required"`
+}
+
+type RoleResponse struct {
+	ID     uint            `json:"id"`
+	Name   string          `json:"name"`
+	Scopes []ScopeResponse `json:"scopes"`
+}
+
+func NewRoleResponse(role *entities.Role) RoleResponse {
+	return RoleResponse{
+		ID:     role.ID,
+		Name:   role.Name,
+		Scopes: NewScopeResponses(role.Scopes),
+	}
+}
+
+func NewRoleResponses(roles []*entities.Role) []RoleResponse {
+	responses := make([]RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		responses = append(responses, NewRoleResponse(role))
+	}
+	return responses
+}
@@ -1,9 +1,43 @@
 package dto
 
+import "github.com/vnFuhung2903/vcs-user-management-service/entities"
+
 type CreateScopeRequest struct {
 	ScopeName string `json:"scope_name" binding:"required"`
 }
 
+type ListScopesRequest struct {
+	Cursor     string `form:"cursor"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	NamePrefix string `form:"name_prefix"`
+	SortBy     string `form:"sort_by" binding:"omitempty,oneof=id name"`
+	Order      string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+type ExpandScopeRequest struct {
+	Pattern string `form:"pattern" binding:"required"`
+}
+
 type DeleteScopeRequest struct {
 	ScopeName string `json:"scope_name" binding:"required"`
 }
+
+type ScopeResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+func NewScopeResponse(scope *entities.UserScope) ScopeResponse {
+	return ScopeResponse{
+		ID:   scope.ID,
+		Name: scope.Name,
+	}
+}
+
+func NewScopeResponses(scopes []*entities.UserScope) []ScopeResponse {
+	responses := make([]ScopeResponse, 0, len(scopes))
+	for _, scope := range scopes {
+		responses = append(responses, NewScopeResponse(scope))
+	}
+	return responses
+}
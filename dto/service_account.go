@@ -0,0 +1,121 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+// CreateServiceAccountRequest creates an account owned by OwnerID, or by the
+// caller if it is omitted. The account never expires unless ExpiresAt is set.
+type CreateServiceAccountRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	OwnerID   string     `json:"owner_id"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type ListServiceAccountsRequest struct {
+	Cursor  string `form:"cursor"`
+	Limit   int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	OwnerID string `form:"owner_id"`
+	SortBy  string `form:"sort_by" binding:"omitempty,oneof=id name"`
+	Order   string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+// UpdateServiceAccountRequest changes the fields that are set. ClearExpiry
+// makes the account permanent and cannot be combined with ExpiresAt.
+type UpdateServiceAccountRequest struct {
+	ServiceAccountID string     `json:"service_account_id" binding:"required"`
+	Name             *string    `json:"name" binding:"omitempty,min=1,max=100"`
+	OwnerID          *string    `json:"owner_id" binding:"omitempty,min=1"`
+	ExpiresAt        *time.Time `json:"expires_at" binding:"excluded_with=ClearExpiry"`
+	ClearExpiry      bool       `json:"clear_expiry"`
+}
+
+type UpdateServiceAccountScopeRequest struct {
+	ServiceAccountID string `json:"service_account_id" binding:"required"`
+	IsAdded          bool   `json:"is_added"`
+	Scope            string `json:"scope" binding:"required"`
+}
+
+type CreateServiceAccountSecretRequest struct {
+	ServiceAccountID string `json:"service_account_id" binding:"required"`
+}
+
+type DeleteServiceAccountSecretRequest struct {
+	ServiceAccountID string `json:"service_account_id" binding:"required"`
+	SecretID         uint   `json:"secret_id" binding:"required"`
+}
+
+type DeleteServiceAccountRequest struct {
+	ServiceAccountID string `json:"service_account_id" binding:"required"`
+}
+
+// ServiceAccountResponse describes an account. ID is the client_id it
+// authenticates with.
+type ServiceAccountResponse struct {
+	ID        string                         `json:"id"`
+	Name      string                         `json:"name"`
+	OwnerID   string                         `json:"owner_id"`
+	Scopes    []ScopeResponse                `json:"scopes"`
+	Secrets   []ServiceAccountSecretResponse `json:"secrets"`
+	ExpiresAt *time.Time                     `json:"expires_at,omitempty"`
+	CreatedBy string                         `json:"created_by"`
+	CreatedAt time.Time                      `json:"created_at"`
+}
+
+type ServiceAccountSecretResponse struct {
+	ID        uint      `json:"id"`
+	Hint      string    `json:"hint"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ServiceAccountCredentialsResponse is returned once, when an account or a
+// secret is created; the secret cannot be retrieved afterwards.
+type ServiceAccountCredentialsResponse struct {
+	ClientID     string                       `json:"client_id"`
+	ClientSecret string                       `json:"client_secret"`
+	Secret       ServiceAccountSecretResponse `json:"secret"`
+}
+
+// CreateServiceAccountResponse is the new account with its first secret.
+type CreateServiceAccountResponse struct {
+	ServiceAccountResponse
+	ClientSecret string `json:"client_secret"`
+}
+
+func NewServiceAccountResponse(account *entities.ServiceAccount) ServiceAccountResponse {
+	secrets := make([]ServiceAccountSecretResponse, 0, len(account.Secrets))
+	for _, secret := range account.Secrets {
+		secrets = append(secrets, NewServiceAccountSecretResponse(secret))
+	}
+	return ServiceAccountResponse{
+		ID:        account.ID,
+		Name:      account.Name,
+		OwnerID:   account.OwnerID,
+		Scopes:    NewScopeResponses(account.Scopes),
+		Secrets:   secrets,
+		ExpiresAt: account.ExpiresAt,
+		CreatedBy: account.CreatedBy,
+		CreatedAt: account.CreatedAt,
+	}
+}
+
+func NewServiceAccountResponses(accounts []*entities.ServiceAccount) []ServiceAccountResponse {
+	responses := make([]ServiceAccountResponse, 0, len(accounts))
+	for _, account := range accounts {
+		responses = append(responses, NewServiceAccountResponse(account))
+	}
+	return responses
+}
+
+func NewServiceAccountSecretResponse(secret *entities.ServiceAccountSecret) ServiceAccountSecretResponse {
+	return ServiceAccountSecretResponse{
+		ID:        secret.ID,
+		Hint:      secret.Hint,
+		CreatedBy: secret.CreatedBy,
+		CreatedAt: secret.CreatedAt,
+	}
+}
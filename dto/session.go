@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+// CreateSessionRequest registers a refresh session. ExpiresIn is the
+// refresh token's lifetime in seconds, at most a year.
+type CreateSessionRequest struct {
+	UserID    string `json:"user_id" binding:"required"`
+	Device    string `json:"device" binding:"max=255"`
+	IP        string `json:"ip" binding:"omitempty,ip"`
+	ExpiresIn int64  `json:"expires_in" binding:"required,min=1,max=31536000"`
+}
+
+type RevokeSessionRequest struct {
+	SessionID string `json:"session_id" binding:"required"`
+}
+
+type RevokeUserSessionRequest struct {
+	UserID    string `json:"user_id" binding:"required"`
+	SessionID string `json:"session_id" binding:"required"`
+}
+
+type RevokeUserSessionsRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+type SessionResponse struct {
+	ID        string    `json:"id"`
+	Device    string    `json:"device"`
+	IP        string    `json:"ip"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewSessionResponse(session *entities.Session) SessionResponse {
+	return SessionResponse{
+		ID:        session.ID,
+		Device:    session.Device,
+		IP:        session.IP,
+		CreatedAt: session.CreatedAt,
+		ExpiresAt: session.ExpiresAt,
+	}
+}
+
+func NewSessionResponses(sessions []*entities.Session) []SessionResponse {
+	responses := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, NewSessionResponse(session))
+	}
+	return responses
+}
@@ -1,18 +1,133 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+// CreateUserRequest grants Scopes permanently, except those named in
+// ScopeExpiries, which expire at the given time.
 type CreateUserRequest struct {
-	Username string   `json:"username" binding:"required"`
-	Password string   `json:"password" binding:"required"`
-	Email    string   `json:"email" binding:"required,email"`
-	Scopes   []string `json:"scopes" binding:"required"`
+	Username      string               `json:"username" binding:"required"`
+	Password      string               `json:"password" binding:"required"`
+	Email         string               `json:"email" binding:"required,email"`
+	Scopes        []string             `json:"scopes" binding:"required"`
+	ScopeExpiries map[string]time.Time `json:"scope_expiries"`
+	Roles         []string             `json:"roles"`
+}
+
+type ListUsersRequest struct {
+	Cursor         string `form:"cursor"`
+	Limit          int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	UsernamePrefix string `form:"username_prefix"`
+	EmailDomain    string `form:"email_domain"`
+	HasScope       string `form:"has_scope"`
+	LacksScope     string `form:"lacks_scope"`
+	SortBy         string `form:"sort_by" binding:"omitempty,oneof=id username email"`
+	Order          string `form:"order" binding:"omitempty,oneof=asc desc"`
 }
 
+// UpdateScopeRequest grants the scope until ExpiresAt, or permanently if it
+// is omitted. ExpiresAt is ignored when the scope is revoked.
 type UpdateScopeRequest struct {
+	UserId    string     `json:"user_id" binding:"required"`
+	IsAdded   bool       `json:"is_added"`
+	Scope     string     `json:"scopes" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type UpdateRoleRequest struct {
 	UserId  string `json:"user_id" binding:"required"`
 	IsAdded bool   `json:"is_added"`
-	Scope   string `json:"scopes" binding:"required"`
+	Role    string `json:"role" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type ExplainScopeRequest struct {
+	Scope string `form:"scope" binding:"required"`
 }
 
 type DeleteUserRequest struct {
 	UserId string `json:"user_id" binding:"required"`
 }
+
+// UserResponse is the public view of a user. It deliberately has no field
+// for the password hash. Scopes lists direct grants only; EffectiveScopes
+// also includes the scopes of the user's roles and groups. ExpiringScopes
+// lists the direct grants that expire, soonest first.
+type UserResponse struct {
+	ID              string                `json:"id"`
+	OrganizationID  string                `json:"organization_id"`
+	Username        string                `json:"username"`
+	Email           string                `json:"email"`
+	Scopes          []ScopeResponse       `json:"scopes"`
+	ExpiringScopes  []ScopeExpiryResponse `json:"expiring_scopes"`
+	Roles           []string              `json:"roles"`
+	Groups          []string              `json:"groups"`
+	EffectiveScopes []string              `json:"effective_scopes"`
+}
+
+type ScopeExpiryResponse struct {
+	Scope     string    `json:"scope"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewUserResponse(user *entities.User) UserResponse {
+	roles := make([]string, 0, len(user.Roles))
+	for _, role := range user.Roles {
+		roles = append(roles, role.Name)
+	}
+	groups := make([]string, 0, len(user.Groups))
+	for _, group := range user.Groups {
+		groups = append(groups, group.Name)
+	}
+	expiring := make([]ScopeExpiryResponse, 0)
+	for _, scope := range user.ExpiringScopes() {
+		expiring = append(expiring, ScopeExpiryResponse{Scope: scope.Name, ExpiresAt: *user.ScopeExpiry(scope.ID)})
+	}
+	return UserResponse{
+		ID:              user.ID,
+		OrganizationID:  user.OrganizationID,
+		Username:        user.Username,
+		Email:           user.Email,
+		Scopes:          NewScopeResponses(user.Scopes),
+		ExpiringScopes:  expiring,
+		Roles:           roles,
+		Groups:          groups,
+		EffectiveScopes: user.EffectiveScopes(),
+	}
+}
+
+func NewUserResponses(users []*entities.User) []UserResponse {
+	responses := make([]UserResponse, 0, len(users))
+	for _, user := range users {
+		responses = append(responses, NewUserResponse(user))
+	}
+	return responses
+}
+
+// ScopeGrantResponse explains one way a user holds a scope. Source is
+// "direct", "role" or "group"; Path names the role, or the groups from the
+// user's own group up to the one holding the grant.
+type ScopeGrantResponse struct {
+	Scope  string   `json:"scope"`
+	Source string   `json:"source"`
+	Path   []string `json:"path"`
+}
+
+func NewScopeGrantResponses(grants []entities.ScopeGrant) []ScopeGrantResponse {
+	responses := make([]ScopeGrantResponse, 0, len(grants))
+	for _, grant := range grants {
+		responses = append(responses, ScopeGrantResponse{
+			Scope:  grant.Scope,
+			Source: grant.Source,
+			Path:   grant.Path,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,142 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,url,max=2048"`
+	EventTypes []string `json:"event_types" binding:"required,min=1,dive,required"`
+}
+
+type ListWebhooksRequest struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Active *bool  `form:"active"`
+	SortBy string `form:"sort_by" binding:"omitempty,oneof=id"`
+	Order  string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+// UpdateWebhookRequest changes the fields that are set. Setting Active to
+// true re-enables a webhook that was disabled after repeated failures and
+// resets its failure count.
+type UpdateWebhookRequest struct {
+	WebhookID  uint     `json:"webhook_id" binding:"required"`
+	URL        *string  `json:"url" binding:"omitempty,url,max=2048"`
+	EventTypes []string `json:"event_types" binding:"omitempty,min=1,dive,required"`
+	Active     *bool    `json:"active"`
+}
+
+type DeleteWebhookRequest struct {
+	WebhookID uint `json:"webhook_id" binding:"required"`
+}
+
+type ListWebhookDeliveriesRequest struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Status string `form:"status" binding:"omitempty,oneof=pending succeeded failed"`
+	SortBy string `form:"sort_by" binding:"omitempty,oneof=id"`
+	Order  string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+type WebhookResponse struct {
+	ID                  uint       `json:"id"`
+	URL                 string     `json:"url"`
+	EventTypes          []string   `json:"event_types"`
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	CreatedBy           string     `json:"created_by"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// WebhookSecretResponse is returned once, on creation; the secret cannot be
+// retrieved afterwards.
+type WebhookSecretResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uint                     `json:"id"`
+	WebhookID      uint                     `json:"webhook_id"`
+	EventID        string                   `json:"event_id"`
+	EventType      string                   `json:"event_type"`
+	Payload        json.RawMessage          `json:"payload" swaggertype:"object"`
+	Status         string                   `json:"status"`
+	AttemptCount   int                      `json:"attempt_count"`
+	NextAttemptAt  *time.Time               `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int                     `json:"last_status_code,omitempty"`
+	CreatedAt      time.Time                `json:"created_at"`
+	CompletedAt    *time.Time               `json:"completed_at,omitempty"`
+	Attempts       []WebhookAttemptResponse `json:"attempts"`
+}
+
+type WebhookAttemptResponse struct {
+	StatusCode int       `json:"status_code"`
+	LatencyMs  int64     `json:"latency_ms"`
+	Error      string    `json:"error,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func NewWebhookResponse(webhook *entities.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:                  webhook.ID,
+		URL:                 webhook.URL,
+		EventTypes:          webhook.Events(),
+		Active:              webhook.Active,
+		ConsecutiveFailures: webhook.ConsecutiveFailures,
+		DisabledAt:          webhook.DisabledAt,
+		CreatedBy:           webhook.CreatedBy,
+		CreatedAt:           webhook.CreatedAt,
+	}
+}
+
+func NewWebhookResponses(webhooks []*entities.Webhook) []WebhookResponse {
+	responses := make([]WebhookResponse, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		responses = append(responses, NewWebhookResponse(webhook))
+	}
+	return responses
+}
+
+func NewWebhookSecretResponse(webhook *entities.Webhook) WebhookSecretResponse {
+	return WebhookSecretResponse{WebhookResponse: NewWebhookResponse(webhook), Secret: webhook.Secret}
+}
+
+func NewWebhookDeliveryResponse(delivery *entities.WebhookDelivery) WebhookDeliveryResponse {
+	attempts := make([]WebhookAttemptResponse, 0, len(delivery.Attempts))
+	for _, attempt := range delivery.Attempts {
+		attempts = append(attempts, WebhookAttemptResponse{
+			StatusCode: attempt.StatusCode,
+			LatencyMs:  attempt.LatencyMs,
+			Error:      attempt.Error,
+			CreatedAt:  attempt.CreatedAt,
+		})
+	}
+	return WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        json.RawMessage(delivery.Payload),
+		Status:         delivery.Status,
+		AttemptCount:   delivery.AttemptCount,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		CreatedAt:      delivery.CreatedAt,
+		CompletedAt:    delivery.CompletedAt,
+		Attempts:       attempts,
+	}
+}
+
+func NewWebhookDeliveryResponses(deliveries []*entities.WebhookDelivery) []WebhookDeliveryResponse {
+	responses := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, NewWebhookDeliveryResponse(delivery))
+	}
+	return responses
+}
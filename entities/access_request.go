@@ -0,0 +1,44 @@
+package entities
+
+import "time"
+
+const (
+	AccessRequestPending   = "pending"
+	AccessRequestApproved  = "approved"
+	AccessRequestRejected  = "rejected"
+	AccessRequestCancelled = "cancelled"
+	AccessRequestExpired   = "expired"
+)
+
+// AccessRequest is a user's request to be granted Scope. It starts pending
+// and is approved or rejected by a reviewer, cancelled by the requester, or
+// expired once ExpiresAt passes. GrantExpiresAt, when set, is the expiry of
+// the grant made on approval. A requester has at most one pending request
+// per scope.
+type AccessRequest struct {
+	ID             uint   `gorm:"primaryKey"`
+	OrganizationID string `gorm:"type:varchar(50);not null;index"`
+	RequesterID    string `gorm:"not null;uniqueIndex:idx_access_requests_pending,where:status = 'pending'"`
+	Scope          string `gorm:"type:varchar(50);not null;uniqueIndex:idx_access_requests_pending"`
+	Justification  string `gorm:"type:text;not null"`
+	Status         string `gorm:"type:varchar(20);not null;index"`
+	GrantExpiresAt *time.Time
+	ExpiresAt      time.Time `gorm:"not null"`
+	DecidedBy      *string
+	DecidedAt      *time.Time
+	CreatedAt      time.Time               `gorm:"not null;autoCreateTime"`
+	Comments       []*AccessRequestComment `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+type AccessRequestComment struct {
+	ID              uint      `gorm:"primaryKey"`
+	AccessRequestID uint      `gorm:"not null;index"`
+	AuthorID        string    `gorm:"not null"`
+	Body            string    `gorm:"type:text;not null"`
+	CreatedAt       time.Time `gorm:"not null;autoCreateTime"`
+}
+
+// Pending reports whether the request can still be decided at now.
+func (r *AccessRequest) Pending(now time.Time) bool {
+	return r.Status == AccessRequestPending && now.Before(r.ExpiresAt)
+}
@@ -0,0 +1,38 @@
+package entities
+
+import "time"
+
+const (
+	AuditUserCreate      = "user.create"
+	AuditUserDelete      = "user.delete"
+	AuditUserScopeUpdate = "user.scope.update"
+	AuditUserRoleUpdate  = "user.role.update"
+	AuditUserScopeExpire = "user.scope.expire"
+	AuditScopeCreate     = "scope.create"
+	AuditScopeDelete     = "scope.delete"
+
+	AuditTargetUser  = "user"
+	AuditTargetScope = "scope"
+)
+
+// AuditEntry records one administrative mutation. Before and After hold the
+// target's state around the change as JSON, and are nil for the side that
+// did not exist. ActorID is empty for changes made by background jobs.
+// Entries are never updated or deleted.
+type AuditEntry struct {
+	ID             uint      `gorm:"primaryKey"`
+	OrganizationID string    `gorm:"type:varchar(50);not null;index"`
+	ActorID        string    `gorm:"not null;index"`
+	Action         string    `gorm:"type:varchar(50);not null;index"`
+	TargetType     string    `gorm:"type:varchar(30);not null"`
+	TargetID       string    `gorm:"not null;index"`
+	Before         *string   `gorm:"type:jsonb"`
+	After          *string   `gorm:"type:jsonb"`
+	RequestID      string    `gorm:"not null;index"`
+	SourceIP       string    `gorm:"type:varchar(45);not null"`
+	CreatedAt      time.Time `gorm:"not null;autoCreateTime;index"`
+}
+
+func (AuditEntry) TableName() string {
+	return "audit_log"
+}
@@ -0,0 +1,31 @@
+package entities
+
+// Group is a team whose members inherit its scopes. A group nested under a
+// parent also passes on every scope of its ancestors.
+type Group struct {
+	ID             uint         `gorm:"primaryKey"`
+	OrganizationID string       `gorm:"type:varchar(50);not null;uniqueIndex:idx_user_groups_organization_name"`
+	Name           string       `gorm:"type:varchar(50);not null;uniqueIndex:idx_user_groups_organization_name"`
+	ParentID       *uint        `gorm:"index"`
+	Parent         *Group       `gorm:"foreignKey:ParentID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Scopes         []*UserScope `gorm:"many2many:group_scope_mapping;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (Group) TableName() string {
+	return "user_groups"
+}
+
+// Lineage returns the group followed by its loaded ancestors, nearest first.
+// It stops at the first repeated group, so a corrupt cycle cannot loop.
+func (g *Group) Lineage() []*Group {
+	seen := make(map[uint]struct{})
+	var lineage []*Group
+	for group := g; group != nil; group = group.Parent {
+		if _, ok := seen[group.ID]; ok {
+			break
+		}
+		seen[group.ID] = struct{}{}
+		lineage = append(lineage, group)
+	}
+	return lineage
+}
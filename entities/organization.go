@@ -0,0 +1,8 @@
+package entities
+
+// Organization is a tenant. Users, scopes, roles and groups each belong to
+// one organization, and names are only unique within it.
+type Organization struct {
+	ID   string `gorm:"type:varchar(50);primaryKey"`
+	Name string `gorm:"type:varchar(100);unique;not null"`
+}
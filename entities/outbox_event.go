@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// OutboxEvent is a domain event waiting to be published, written in the same
+// transaction as the change it describes. Payload is the encoded
+// events.Event. PublishedAt is set once the relay has added it to the
+// stream.
+type OutboxEvent struct {
+	ID             uint       `gorm:"primaryKey"`
+	OrganizationID string     `gorm:"type:varchar(50);not null;index"`
+	EventID        string     `gorm:"type:varchar(36);not null;uniqueIndex"`
+	Type           string     `gorm:"type:varchar(50);not null"`
+	Payload        string     `gorm:"type:jsonb;not null"`
+	CreatedAt      time.Time  `gorm:"not null;autoCreateTime"`
+	PublishedAt    *time.Time `gorm:"index"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
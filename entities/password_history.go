@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+type PasswordHistory struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    string    `gorm:"not null;index"`
+	Hash      string    `gorm:"type:varchar(255);not null"`
+	CreatedAt time.Time `gorm:"not null;autoCreateTime"`
+}
+
+func (PasswordHistory) TableName() string {
+	return "password_history"
+}
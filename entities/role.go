@@ -0,0 +1,10 @@
+package entities
+
+// Role is a named bundle of scopes. Users holding a role are granted every
+// scope in it in addition to their direct scopes.
+type Role struct {
+	ID             uint         `gorm:"primaryKey"`
+	OrganizationID string       `gorm:"type:varchar(50);not null;uniqueIndex:idx_roles_organization_name"`
+	Name           string       `gorm:"type:varchar(50);not null;uniqueIndex:idx_roles_organization_name"`
+	Scopes         []*UserScope `gorm:"many2many:role_scope_mapping;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -1,6 +1,7 @@
 package entities
 
 type UserScope struct {
-	ID   uint   `gorm:"primaryKey"`
-	Name string `gorm:"type:varchar(50);unique;not null"`
+	ID             uint   `gorm:"primaryKey"`
+	OrganizationID string `gorm:"type:varchar(50);not null;uniqueIndex:idx_user_scopes_organization_name"`
+	Name           string `gorm:"type:varchar(50);not null;uniqueIndex:idx_user_scopes_organization_name"`
 }
@@ -0,0 +1,46 @@
+package entities
+
+import "time"
+
+// ServiceAccount is a non-human identity, such as a CI pipeline, that
+// authenticates with the OAuth2 client_credentials grant. Its ID is the
+// client_id. It acts with Scopes only and stops authenticating at ExpiresAt,
+// when set. OwnerID is the user accountable for it; deleting the owner
+// deletes the account.
+type ServiceAccount struct {
+	ID             string                  `gorm:"primaryKey"`
+	OrganizationID string                  `gorm:"type:varchar(50);not null;uniqueIndex:idx_service_accounts_organization_name"`
+	Name           string                  `gorm:"type:varchar(100);not null;uniqueIndex:idx_service_accounts_organization_name"`
+	OwnerID        string                  `gorm:"not null;index"`
+	Owner          *User                   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Scopes         []*UserScope            `gorm:"many2many:service_account_scope_mapping;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Secrets        []*ServiceAccountSecret `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ExpiresAt      *time.Time
+	CreatedBy      string    `gorm:"not null"`
+	CreatedAt      time.Time `gorm:"not null;autoCreateTime"`
+}
+
+// Active reports whether the account may still authenticate at now.
+func (a *ServiceAccount) Active(now time.Time) bool {
+	return a.ExpiresAt == nil || a.ExpiresAt.After(now)
+}
+
+func (a *ServiceAccount) ScopeNames() []string {
+	names := make([]string, 0, len(a.Scopes))
+	for _, scope := range a.Scopes {
+		names = append(names, scope.Name)
+	}
+	return names
+}
+
+// ServiceAccountSecret is one of the client secrets of an account. Only a
+// hash is stored; Hint keeps the last characters so an operator can tell
+// secrets apart while rotating them.
+type ServiceAccountSecret struct {
+	ID               uint      `gorm:"primaryKey"`
+	ServiceAccountID string    `gorm:"not null;index"`
+	Hash             string    `gorm:"type:varchar(255);not null"`
+	Hint             string    `gorm:"type:varchar(8);not null"`
+	CreatedBy        string    `gorm:"not null"`
+	CreatedAt        time.Time `gorm:"not null;autoCreateTime"`
+}
@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+// Session is a refresh session: one device signed in as the user. Sessions
+// live in Redis rather than the database and disappear once they expire.
+type Session struct {
+	ID        string
+	UserID    string
+	Device    string
+	IP        string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
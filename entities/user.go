@@ -1,9 +1,128 @@
 package entities
 
+import (
+	"sort"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/scopes"
+)
+
 type User struct {
-	ID       string       `gorm:"primaryKey"`
-	Username string       `gorm:"type:varchar(100);unique;not null"`
-	Hash     string       `gorm:"type:varchar(255);not null"`
-	Email    string       `gorm:"type:varchar(100);unique;not null"`
-	Scopes   []*UserScope `gorm:"many2many:user_scope_mapping;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	ID             string       `gorm:"primaryKey"`
+	OrganizationID string       `gorm:"type:varchar(50);not null;uniqueIndex:idx_users_organization_username;uniqueIndex:idx_users_organization_email"`
+	Username       string       `gorm:"type:varchar(100);not null;uniqueIndex:idx_users_organization_username"`
+	Hash           string       `gorm:"type:varchar(255);not null"`
+	Email          string       `gorm:"type:varchar(100);not null;uniqueIndex:idx_users_organization_email"`
+	Scopes         []*UserScope `gorm:"many2many:user_scope_mapping;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Roles          []*Role      `gorm:"many2many:user_role_mapping;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Groups         []*Group     `gorm:"many2many:user_group_mapping;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	// ScopeMappings reads the expiry of each direct grant in Scopes. The
+	// rows are written through Scopes, so the field is not migrated.
+	ScopeMappings []*UserScopeMapping `gorm:"foreignKey:UserID;-:migration"`
+}
+
+const (
+	GrantDirect = "direct"
+	GrantRole   = "role"
+	GrantGroup  = "group"
+)
+
+// ScopeGrant is one way a user holds a scope. Scope is the granted name,
+// which may be a pattern. Path is empty for direct grants, the role name for
+// role grants, and the groups from the user's own group up to the granting
+// ancestor for group grants.
+type ScopeGrant struct {
+	Scope  string
+	Source string
+	Path   []string
+}
+
+// EffectiveScopes merges the user's direct scopes with the scopes of every
+// role it holds and every group it belongs to, including the groups' loaded
+// ancestors. Each name appears once, direct scopes first.
+func (u *User) EffectiveScopes() []string {
+	return scopeNames(u.grants(time.Now()))
+}
+
+// GrantedScopes is EffectiveScopes with the direct grants that have expired
+// but not yet been reaped, as they stand in the database.
+func (u *User) GrantedScopes() []string {
+	return scopeNames(u.grants(time.Time{}))
+}
+
+func scopeNames(grants []ScopeGrant) []string {
+	seen := make(map[string]struct{})
+	names := make([]string, 0, len(grants))
+	for _, grant := range grants {
+		if _, ok := seen[grant.Scope]; ok {
+			continue
+		}
+		seen[grant.Scope] = struct{}{}
+		names = append(names, grant.Scope)
+	}
+	return names
+}
+
+// ExplainScope lists every grant that gives the user scope, including
+// grants of patterns that match it.
+func (u *User) ExplainScope(scope string) []ScopeGrant {
+	grants := []ScopeGrant{}
+	for _, grant := range u.grants(time.Now()) {
+		if scopes.Match(grant.Scope, scope) {
+			grants = append(grants, grant)
+		}
+	}
+	return grants
+}
+
+// ScopeExpiry returns when the user's direct grant of the scope expires, or
+// nil if the grant is permanent or does not exist.
+func (u *User) ScopeExpiry(scopeId uint) *time.Time {
+	for _, mapping := range u.ScopeMappings {
+		if mapping.UserScopeID == scopeId {
+			return mapping.ExpiresAt
+		}
+	}
+	return nil
+}
+
+// ExpiringScopes lists the direct scopes that have an expiry, soonest first.
+func (u *User) ExpiringScopes() []*UserScope {
+	var expiring []*UserScope
+	for _, scope := range u.Scopes {
+		if u.ScopeExpiry(scope.ID) != nil {
+			expiring = append(expiring, scope)
+		}
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return u.ScopeExpiry(expiring[i].ID).Before(*u.ScopeExpiry(expiring[j].ID))
+	})
+	return expiring
+}
+
+// grants skips direct grants that expired at or before now, unless now is
+// zero.
+func (u *User) grants(now time.Time) []ScopeGrant {
+	var grants []ScopeGrant
+	for _, scope := range u.Scopes {
+		if expiresAt := u.ScopeExpiry(scope.ID); expiresAt != nil && !now.IsZero() && !expiresAt.After(now) {
+			continue
+		}
+		grants = append(grants, ScopeGrant{Scope: scope.Name, Source: GrantDirect, Path: []string{}})
+	}
+	for _, role := range u.Roles {
+		for _, scope := range role.Scopes {
+			grants = append(grants, ScopeGrant{Scope: scope.Name, Source: GrantRole, Path: []string{role.Name}})
+		}
+	}
+	for _, group := range u.Groups {
+		var path []string
+		for _, ancestor := range group.Lineage() {
+			path = append(path, ancestor.Name)
+			for _, scope := range ancestor.Scopes {
+				grants = append(grants, ScopeGrant{Scope: scope.Name, Source: GrantGroup, Path: append([]string(nil), path...)})
+			}
+		}
+	}
+	return grants
 }
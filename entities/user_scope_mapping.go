@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// UserScopeMapping is a user's direct grant of a scope. A nil ExpiresAt
+// grants the scope permanently.
+type UserScopeMapping struct {
+	UserID      string     `gorm:"primaryKey"`
+	UserScopeID uint       `gorm:"primaryKey"`
+	ExpiresAt   *time.Time `gorm:"index"`
+}
+
+func (UserScopeMapping) TableName() string {
+	return "user_scope_mapping"
+}
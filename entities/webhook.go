@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook is an endpoint that receives the events named in EventTypes, a
+// comma-separated list. Secret signs every delivery. ConsecutiveFailures
+// counts failed attempts since the last success; the sender deactivates the
+// webhook once it reaches the configured limit and sets DisabledAt.
+type Webhook struct {
+	ID                  uint   `gorm:"primaryKey"`
+	OrganizationID      string `gorm:"type:varchar(50);not null;index"`
+	URL                 string `gorm:"type:varchar(2048);not null"`
+	Secret              string `gorm:"type:varchar(100);not null"`
+	EventTypes          string `gorm:"type:text;not null"`
+	Active              bool   `gorm:"not null"`
+	ConsecutiveFailures int    `gorm:"not null;default:0"`
+	DisabledAt          *time.Time
+	CreatedBy           string    `gorm:"not null"`
+	CreatedAt           time.Time `gorm:"not null;autoCreateTime"`
+	UpdatedAt           time.Time `gorm:"not null;autoUpdateTime"`
+}
+
+// Events splits EventTypes.
+func (w *Webhook) Events() []string {
+	if w.EventTypes == "" {
+		return []string{}
+	}
+	return strings.Split(w.EventTypes, ",")
+}
+
+func (w *Webhook) Subscribes(eventType string) bool {
+	for _, subscribed := range w.Events() {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event to be sent to one webhook. It stays pending,
+// with NextAttemptAt set, until an attempt succeeds or the attempts run out.
+// A webhook receives each event at most once.
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primaryKey"`
+	OrganizationID string     `gorm:"type:varchar(50);not null;index"`
+	WebhookID      uint       `gorm:"not null;uniqueIndex:idx_webhook_deliveries_event"`
+	EventID        string     `gorm:"type:varchar(36);not null;uniqueIndex:idx_webhook_deliveries_event"`
+	EventType      string     `gorm:"type:varchar(50);not null"`
+	Payload        string     `gorm:"type:jsonb;not null"`
+	Status         string     `gorm:"type:varchar(20);not null;index"`
+	AttemptCount   int        `gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time `gorm:"index"`
+	LastStatusCode *int
+	CreatedAt      time.Time `gorm:"not null;autoCreateTime"`
+	CompletedAt    *time.Time
+	Webhook        *Webhook          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Attempts       []*WebhookAttempt `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// WebhookAttempt records one request made for a delivery. StatusCode is 0
+// when no response arrived, and Error then says why.
+type WebhookAttempt struct {
+	ID                uint      `gorm:"primaryKey"`
+	WebhookDeliveryID uint      `gorm:"not null;index"`
+	StatusCode        int       `gorm:"not null"`
+	LatencyMs         int64     `gorm:"not null"`
+	Error             string    `gorm:"type:text;not null"`
+	CreatedAt         time.Time `gorm:"not null;autoCreateTime"`
+}
@@ -0,0 +1,234 @@
+package databases
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrationLockKey is the pg_advisory_lock key shared by every replica, so
+// only one of them migrates during a rolling deploy.
+const migrationLockKey int64 = 7460131
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type IMigrator interface {
+	Up(ctx context.Context) error
+	Down(ctx context.Context, steps int) error
+	Seed(ctx context.Context) error
+	Version(ctx context.Context) (uint, error)
+}
+
+type migrationScript struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+type schemaMigration struct {
+	Version   uint      `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	AppliedAt time.Time `gorm:"not null;autoCreateTime"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type migrator struct {
+	db     *gorm.DB
+	source fs.FS
+	seed   string
+}
+
+func NewMigrator(db *gorm.DB, source fs.FS, seed string) IMigrator {
+	return &migrator{
+		db:     db,
+		source: source,
+		seed:   seed,
+	}
+}
+
+func (m *migrator) Up(ctx context.Context) error {
+	migrations, err := m.load()
+	if err != nil {
+		return err
+	}
+
+	return m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(mig.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&schemaMigration{Version: mig.Version, Name: mig.Name}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("apply migration %06d_%s: %w", mig.Version, mig.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (m *migrator) Down(ctx context.Context, steps int) error {
+	migrations, err := m.load()
+	if err != nil {
+		return err
+	}
+
+	byVersion := make(map[uint]*migrationScript, len(migrations))
+	for _, mig := range migrations {
+		byVersion[mig.Version] = mig
+	}
+
+	return m.withLock(ctx, func(conn *gorm.DB) error {
+		if _, err := m.applied(conn); err != nil {
+			return err
+		}
+
+		var applied []schemaMigration
+		if err := conn.Order("version DESC").Limit(steps).Find(&applied).Error; err != nil {
+			return err
+		}
+
+		for _, row := range applied {
+			mig, ok := byVersion[row.Version]
+			if !ok {
+				return fmt.Errorf("migration %06d_%s is applied but missing from source", row.Version, row.Name)
+			}
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(mig.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&schemaMigration{}, "version = ?", mig.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("revert migration %06d_%s: %w", mig.Version, mig.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (m *migrator) Seed(ctx context.Context) error {
+	if m.seed == "" {
+		return nil
+	}
+
+	return m.withLock(ctx, func(conn *gorm.DB) error {
+		return conn.Transaction(func(tx *gorm.DB) error {
+			return tx.Exec(m.seed).Error
+		})
+	})
+}
+
+func (m *migrator) Version(ctx context.Context) (uint, error) {
+	db := m.db.WithContext(ctx)
+	if _, err := m.applied(db); err != nil {
+		return 0, err
+	}
+
+	var version uint
+	err := db.Model(&schemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, err
+}
+
+// withLock pins a single connection for the whole run, because a Postgres
+// advisory lock belongs to the session that acquired it.
+func (m *migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if conn.Dialector.Name() == "postgres" {
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+				return err
+			}
+			defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey)
+		}
+		return fn(conn)
+	})
+}
+
+func (m *migrator) applied(conn *gorm.DB) (map[uint]struct{}, error) {
+	if err := conn.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, err
+	}
+
+	var rows []schemaMigration
+	if err := conn.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[uint]struct{}, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = struct{}{}
+	}
+	return applied, nil
+}
+
+func (m *migrator) load() ([]*migrationScript, error) {
+	entries, err := fs.ReadDir(m.source, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*migrationScript)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		content, err := fs.ReadFile(m.source, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[uint(version)]
+		if !ok {
+			mig = &migrationScript{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = mig
+		}
+		if mig.Name != match[2] {
+			return nil, fmt.Errorf("migration %06d has conflicting names %q and %q", version, mig.Name, match[2])
+		}
+		if match[3] == "up" {
+			mig.Up = string(content)
+		} else {
+			mig.Down = string(content)
+		}
+	}
+
+	migrations := make([]*migrationScript, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migration %06d_%s must have both up and down scripts", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
@@ -0,0 +1,153 @@
+package databases
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vnFuhung2903/vcs-user-management-service/migration"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type MigratorSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	source fstest.MapFS
+	ctx    context.Context
+}
+
+func (suite *MigratorSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	suite.Require().NoError(err)
+
+	// Every pooled connection to ":memory:" is a separate database.
+	sqlDB, err := gormDB.DB()
+	suite.Require().NoError(err)
+	sqlDB.SetMaxOpenConns(1)
+
+	suite.db = gormDB
+	suite.ctx = context.Background()
+	suite.source = fstest.MapFS{
+		"000001_create_users.up.sql":    {Data: []byte("CREATE TABLE users (id TEXT PRIMARY KEY, username VARCHAR(100) NOT NULL UNIQUE);")},
+		"000001_create_users.down.sql":  {Data: []byte("DROP TABLE users;")},
+		"000002_create_scopes.up.sql":   {Data: []byte("CREATE TABLE user_scopes (id INTEGER PRIMARY KEY, name VARCHAR(50) NOT NULL UNIQUE);")},
+		"000002_create_scopes.down.sql": {Data: []byte("DROP TABLE user_scopes;")},
+	}
+}
+
+func (suite *MigratorSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	suite.NoError(err)
+	sqlDB.Close()
+}
+
+func TestMigratorSuite(t *testing.T) {
+	suite.Run(t, new(MigratorSuite))
+}
+
+func (suite *MigratorSuite) TestUp() {
+	migrator := NewMigrator(suite.db, suite.source, "")
+
+	suite.NoError(migrator.Up(suite.ctx))
+	suite.True(suite.db.Migrator().HasTable("users"))
+	suite.True(suite.db.Migrator().HasTable("user_scopes"))
+
+	version, err := migrator.Version(suite.ctx)
+	suite.NoError(err)
+	suite.Equal(uint(2), version)
+}
+
+func (suite *MigratorSuite) TestUpIsIdempotent() {
+	migrator := NewMigrator(suite.db, suite.source, "")
+
+	suite.NoError(migrator.Up(suite.ctx))
+	suite.NoError(migrator.Up(suite.ctx))
+
+	var count int64
+	suite.NoError(suite.db.Table("schema_migrations").Count(&count).Error)
+	suite.Equal(int64(2), count)
+}
+
+func (suite *MigratorSuite) TestUpAppliesOnlyPending() {
+	suite.NoError(NewMigrator(suite.db, fstest.MapFS{
+		"000001_create_users.up.sql":   suite.source["000001_create_users.up.sql"],
+		"000001_create_users.down.sql": suite.source["000001_create_users.down.sql"],
+	}, "").Up(suite.ctx))
+	suite.NoError(suite.db.Exec("INSERT INTO users (id, username) VALUES ('1', 'kept')").Error)
+
+	migrator := NewMigrator(suite.db, suite.source, "")
+	suite.NoError(migrator.Up(suite.ctx))
+
+	var count int64
+	suite.NoError(suite.db.Table("users").Count(&count).Error)
+	suite.Equal(int64(1), count)
+	suite.True(suite.db.Migrator().HasTable("user_scopes"))
+}
+
+func (suite *MigratorSuite) TestUpFailureRollsBack() {
+	suite.source["000003_broken.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE broken (id INTEGER); NOT SQL;")}
+	suite.source["000003_broken.down.sql"] = &fstest.MapFile{Data: []byte("DROP TABLE broken;")}
+	migrator := NewMigrator(suite.db, suite.source, "")
+
+	err := migrator.Up(suite.ctx)
+	suite.ErrorContains(err, "000003_broken")
+	suite.False(suite.db.Migrator().HasTable("broken"))
+
+	version, err := migrator.Version(suite.ctx)
+	suite.NoError(err)
+	suite.Equal(uint(2), version)
+}
+
+func (suite *MigratorSuite) TestDown() {
+	migrator := NewMigrator(suite.db, suite.source, "")
+	suite.NoError(migrator.Up(suite.ctx))
+
+	suite.NoError(migrator.Down(suite.ctx, 1))
+	suite.True(suite.db.Migrator().HasTable("users"))
+	suite.False(suite.db.Migrator().HasTable("user_scopes"))
+
+	version, err := migrator.Version(suite.ctx)
+	suite.NoError(err)
+	suite.Equal(uint(1), version)
+}
+
+func (suite *MigratorSuite) TestLoadMissingDown() {
+	delete(suite.source, "000002_create_scopes.down.sql")
+	migrator := NewMigrator(suite.db, suite.source, "")
+
+	err := migrator.Up(suite.ctx)
+	suite.ErrorContains(err, "must have both up and down scripts")
+}
+
+func (suite *MigratorSuite) TestLoadInvalidFileName() {
+	suite.source["init.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+	migrator := NewMigrator(suite.db, suite.source, "")
+
+	err := migrator.Up(suite.ctx)
+	suite.ErrorContains(err, "invalid migration file name")
+}
+
+func (suite *MigratorSuite) TestSeedIsIdempotent() {
+	seed := "INSERT INTO user_scopes (name) VALUES ('user:manage') ON CONFLICT (name) DO NOTHING;"
+	migrator := NewMigrator(suite.db, suite.source, seed)
+	suite.NoError(migrator.Up(suite.ctx))
+
+	suite.NoError(migrator.Seed(suite.ctx))
+	suite.NoError(migrator.Seed(suite.ctx))
+
+	var count int64
+	suite.NoError(suite.db.Table("user_scopes").Count(&count).Error)
+	suite.Equal(int64(1), count)
+}
+
+func (suite *MigratorSuite) TestEmbeddedMigrationsLoad() {
+	migrations, err := NewMigrator(suite.db, migration.Versions(), migration.Seed).(*migrator).load()
+	suite.NoError(err)
+	suite.NotEmpty(migrations)
+	suite.Equal(uint(1), migrations[0].Version)
+}
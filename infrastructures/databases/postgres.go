@@ -3,7 +3,6 @@ package databases
 import (
 	"fmt"
 
-	"github.com/vnFuhung2903/vcs-user-management-service/entities"
 	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -12,13 +11,11 @@ import (
 func ConnectPostgresDb(env env.PostgresEnv) (*gorm.DB, error) {
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
 		env.PostgresHost, env.PostgresUser, env.PostgresPassword, env.PostgresName, env.PostgresPort)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		TranslateError: true,
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	if err := db.AutoMigrate(&entities.User{}); err != nil {
-		return nil, err
-	}
 	return db, nil
 }
@@ -2,14 +2,30 @@ package interfaces
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
 type IRedisClient interface {
-	Del(ctx context.Context, key string) error
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+	Del(ctx context.Context, keys ...string) error
+	Expire(ctx context.Context, key string, ttl time.Duration) error
+	TTL(ctx context.Context, key string) (time.Duration, error)
+	Scan(ctx context.Context, match string) ([]string, error)
+	HSet(ctx context.Context, key string, values map[string]interface{}) error
+	HGetAll(ctx context.Context, key string) (map[string]string, error)
+	SAdd(ctx context.Context, key string, members ...string) error
+	SRem(ctx context.Context, key string, members ...string) error
+	SMembers(ctx context.Context, key string) ([]string, error)
+	XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error)
 }
 
+// scanCount is the number of keys Scan asks for per round trip.
+const scanCount = 1000
+
 type redisClient struct {
 	client *redis.Client
 }
@@ -18,6 +34,93 @@ func NewRedisClient(client *redis.Client) IRedisClient {
 	return &redisClient{client: client}
 }
 
-func (c *redisClient) Del(ctx context.Context, key string) error {
-	return c.client.Del(ctx, key).Err()
+// Get returns the value of key, or an empty string when key does not exist.
+func (c *redisClient) Get(ctx context.Context, key string) (string, error) {
+	value, err := c.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return value, err
+}
+
+// Set stores value under key. A zero ttl keeps the key until it is deleted.
+func (c *redisClient) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *redisClient) Del(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
+
+func (c *redisClient) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	return c.client.Expire(ctx, key, ttl).Err()
+}
+
+// TTL returns the remaining time to live of key. It is negative when key
+// does not exist or has no expiry.
+func (c *redisClient) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return c.client.TTL(ctx, key).Result()
+}
+
+// Scan returns every key matching the glob pattern match. It walks the
+// keyspace incrementally, so it does not block the server the way KEYS
+// does, but keys added or removed meanwhile may be missed.
+func (c *redisClient) Scan(ctx context.Context, match string) ([]string, error) {
+	var keys []string
+	var cursor uint64
+	for {
+		page, next, err := c.client.Scan(ctx, cursor, match, scanCount).Result()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, page...)
+		if next == 0 {
+			return keys, nil
+		}
+		cursor = next
+	}
+}
+
+func (c *redisClient) HSet(ctx context.Context, key string, values map[string]interface{}) error {
+	return c.client.HSet(ctx, key, values).Err()
+}
+
+// HGetAll returns the fields of the hash at key, or an empty map when key
+// does not exist.
+func (c *redisClient) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return c.client.HGetAll(ctx, key).Result()
+}
+
+func (c *redisClient) SAdd(ctx context.Context, key string, members ...string) error {
+	return c.client.SAdd(ctx, key, toInterfaces(members)...).Err()
+}
+
+func (c *redisClient) SRem(ctx context.Context, key string, members ...string) error {
+	return c.client.SRem(ctx, key, toInterfaces(members)...).Err()
+}
+
+func (c *redisClient) SMembers(ctx context.Context, key string) ([]string, error) {
+	return c.client.SMembers(ctx, key).Result()
+}
+
+// XAdd appends an entry to stream and returns its ID. A positive maxLen caps
+// the stream at roughly that many entries, trimming the oldest.
+func (c *redisClient) XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error) {
+	return c.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: true,
+		Values: values,
+	}).Result()
+}
+
+func toInterfaces(values []string) []interface{} {
+	converted := make([]interface{}, len(values))
+	for i, value := range values {
+		converted[i] = value
+	}
+	return converted
 }
@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,39 @@ func TestRedisClient(t *testing.T) {
 
 	redisClient := NewRedisClient(rds)
 
-	err := redisClient.Del(context.Background(), "test-key")
+	_, err := redisClient.Get(context.Background(), "test-key")
+	assert.Error(t, err)
+
+	err = redisClient.Set(context.Background(), "test-key", "value", time.Minute)
+	assert.Error(t, err)
+
+	err = redisClient.Del(context.Background(), "test-key")
+	assert.Error(t, err)
+
+	err = redisClient.Expire(context.Background(), "test-key", time.Minute)
+	assert.Error(t, err)
+
+	_, err = redisClient.TTL(context.Background(), "test-key")
+	assert.Error(t, err)
+
+	_, err = redisClient.Scan(context.Background(), "test-*")
+	assert.Error(t, err)
+
+	err = redisClient.HSet(context.Background(), "test-hash", map[string]interface{}{"field": "value"})
+	assert.Error(t, err)
+
+	_, err = redisClient.HGetAll(context.Background(), "test-hash")
+	assert.Error(t, err)
+
+	err = redisClient.SAdd(context.Background(), "test-set", "member")
+	assert.Error(t, err)
+
+	err = redisClient.SRem(context.Background(), "test-set", "member")
+	assert.Error(t, err)
+
+	_, err = redisClient.SMembers(context.Background(), "test-set")
+	assert.Error(t, err)
+
+	_, err = redisClient.XAdd(context.Background(), "test-stream", 100, map[string]interface{}{"event": "{}"})
 	assert.Error(t, err)
 }
@@ -0,0 +1,25 @@
+package migration
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed versions/*.sql
+var versions embed.FS
+
+// Seed is the idempotent script that loads the default scopes and the
+// bootstrap admin account. It is safe to run on every start.
+//
+//go:embed seed.sql
+var Seed string
+
+// Versions returns the numbered up/down migrations, rooted so that file
+// names look like 000001_create_users.up.sql.
+func Versions() fs.FS {
+	sub, err := fs.Sub(versions, "versions")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
@@ -0,0 +1,42 @@
+INSERT INTO organizations (id, name) VALUES ('default', 'Default') ON CONFLICT DO NOTHING;
+
+INSERT INTO user_scopes (organization_id, name)
+SELECT 'default', name FROM (VALUES
+('container:create'),
+('container:view'),
+('container:update'),
+('container:delete'),
+('scope:manage'),
+('scope:view'),
+('role:manage'),
+('role:view'),
+('group:manage'),
+('group:view'),
+('user:manage'),
+('user:view'),
+('organization:manage'),
+('audit:view'),
+('webhook:manage'),
+('authz:check'),
+('token:introspect'),
+('session:create'),
+('service_account:manage'),
+('service_account:view'),
+('report:mail')
+) AS catalogue (name)
+ON CONFLICT (organization_id, name) DO NOTHING;
+
+-- The admin only receives the default scopes when it is first created, so
+-- scopes revoked by an operator are not granted back on the next start.
+-- Scopes added to the catalogue later are granted to an existing admin by
+-- the versioned migration that introduces them.
+WITH admin AS (
+    INSERT INTO users (id, organization_id, username, hash, email)
+    VALUES ('ADMIN', 'default', 'admin', '$2a$10$bSo5pXXwb/jcdoZ6RlMdgO9nSNgBKb6DP3MnStijMM2dVHlw.6bl.', 'admin@test.com')
+    ON CONFLICT DO NOTHING
+    RETURNING id
+)
+INSERT INTO user_scope_mapping (user_id, user_scope_id)
+SELECT admin.id, user_scopes.id FROM admin
+JOIN user_scopes ON user_scopes.organization_id = 'default'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_scope_mapping;
+DROP TABLE IF EXISTS user_scopes;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY,
+    username VARCHAR(100) NOT NULL UNIQUE,
+    hash VARCHAR(255) NOT NULL,
+    email VARCHAR(100) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS user_scopes (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS user_scope_mapping (
+    user_id TEXT NOT NULL REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_scope_id BIGINT NOT NULL REFERENCES user_scopes (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (user_id, user_scope_id)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_role_mapping;
+DROP TABLE IF EXISTS role_scope_mapping;
+DROP TABLE IF EXISTS roles;
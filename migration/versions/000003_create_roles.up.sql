@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS roles (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS role_scope_mapping (
+    role_id BIGINT NOT NULL REFERENCES roles (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_scope_id BIGINT NOT NULL REFERENCES user_scopes (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (role_id, user_scope_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_role_mapping (
+    user_id TEXT NOT NULL REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    role_id BIGINT NOT NULL REFERENCES roles (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_role_mapping_role_id ON user_role_mapping (role_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_group_mapping;
+DROP TABLE IF EXISTS group_scope_mapping;
+DROP TABLE IF EXISTS user_groups;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS user_groups (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    parent_id BIGINT REFERENCES user_groups (id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_groups_parent_id ON user_groups (parent_id);
+
+CREATE TABLE IF NOT EXISTS group_scope_mapping (
+    group_id BIGINT NOT NULL REFERENCES user_groups (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_scope_id BIGINT NOT NULL REFERENCES user_scopes (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (group_id, user_scope_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_group_mapping (
+    user_id TEXT NOT NULL REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    group_id BIGINT NOT NULL REFERENCES user_groups (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (user_id, group_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_group_mapping_group_id ON user_group_mapping (group_id);
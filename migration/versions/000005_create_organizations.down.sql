@@ -0,0 +1,21 @@
+-- Names are only unique per organization while this migration is applied,
+-- so restoring the global constraints fails if two organizations share one.
+DROP INDEX IF EXISTS idx_user_groups_organization_name;
+ALTER TABLE user_groups DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE user_groups ADD CONSTRAINT user_groups_name_key UNIQUE (name);
+
+DROP INDEX IF EXISTS idx_roles_organization_name;
+ALTER TABLE roles DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE roles ADD CONSTRAINT roles_name_key UNIQUE (name);
+
+DROP INDEX IF EXISTS idx_user_scopes_organization_name;
+ALTER TABLE user_scopes DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE user_scopes ADD CONSTRAINT user_scopes_name_key UNIQUE (name);
+
+DROP INDEX IF EXISTS idx_users_organization_email;
+DROP INDEX IF EXISTS idx_users_organization_username;
+ALTER TABLE users DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+
+DROP TABLE IF EXISTS organizations;
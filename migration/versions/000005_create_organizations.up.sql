@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS organizations (
+    id VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE
+);
+
+-- Existing rows move into the default organization, which is also the
+-- platform organization unless PLATFORM_ORGANIZATION says otherwise.
+INSERT INTO organizations (id, name) VALUES ('default', 'Default') ON CONFLICT DO NOTHING;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS organization_id VARCHAR(50) NOT NULL DEFAULT 'default'
+    REFERENCES organizations (id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE users ALTER COLUMN organization_id DROP DEFAULT;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_organization_username ON users (organization_id, username);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_organization_email ON users (organization_id, email);
+
+ALTER TABLE user_scopes ADD COLUMN IF NOT EXISTS organization_id VARCHAR(50) NOT NULL DEFAULT 'default'
+    REFERENCES organizations (id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE user_scopes ALTER COLUMN organization_id DROP DEFAULT;
+ALTER TABLE user_scopes DROP CONSTRAINT IF EXISTS user_scopes_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_scopes_organization_name ON user_scopes (organization_id, name);
+
+ALTER TABLE roles ADD COLUMN IF NOT EXISTS organization_id VARCHAR(50) NOT NULL DEFAULT 'default'
+    REFERENCES organizations (id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE roles ALTER COLUMN organization_id DROP DEFAULT;
+ALTER TABLE roles DROP CONSTRAINT IF EXISTS roles_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_organization_name ON roles (organization_id, name);
+
+ALTER TABLE user_groups ADD COLUMN IF NOT EXISTS organization_id VARCHAR(50) NOT NULL DEFAULT 'default'
+    REFERENCES organizations (id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE user_groups ALTER COLUMN organization_id DROP DEFAULT;
+ALTER TABLE user_groups DROP CONSTRAINT IF EXISTS user_groups_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_groups_organization_name ON user_groups (organization_id, name);
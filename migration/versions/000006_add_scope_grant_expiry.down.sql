@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_user_scope_mapping_expires_at;
+
+ALTER TABLE user_scope_mapping DROP COLUMN IF EXISTS expires_at;
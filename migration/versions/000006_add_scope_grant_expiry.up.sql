@@ -0,0 +1,3 @@
+ALTER TABLE user_scope_mapping ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_user_scope_mapping_expires_at ON user_scope_mapping (expires_at) WHERE expires_at IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS access_request_comments;
+DROP TABLE IF EXISTS access_requests;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS access_requests (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id VARCHAR(50) NOT NULL REFERENCES organizations (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    requester_id TEXT NOT NULL REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    scope VARCHAR(50) NOT NULL,
+    justification TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    grant_expires_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL,
+    decided_by TEXT,
+    decided_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_requests_organization_id ON access_requests (organization_id);
+CREATE INDEX IF NOT EXISTS idx_access_requests_status ON access_requests (status);
+-- A requester may have only one pending request per scope.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_access_requests_pending ON access_requests (requester_id, scope) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS access_request_comments (
+    id BIGSERIAL PRIMARY KEY,
+    access_request_id BIGINT NOT NULL REFERENCES access_requests (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    author_id TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_request_comments_access_request_id ON access_request_comments (access_request_id);
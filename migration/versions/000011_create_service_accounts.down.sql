@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS service_account_secrets;
+DROP TABLE IF EXISTS service_account_scope_mapping;
+DROP TABLE IF EXISTS service_accounts;
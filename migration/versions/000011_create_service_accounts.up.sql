@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS service_accounts (
+    id TEXT PRIMARY KEY,
+    organization_id VARCHAR(50) NOT NULL REFERENCES organizations (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    owner_id TEXT NOT NULL REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_service_accounts_organization_name ON service_accounts (organization_id, name);
+CREATE INDEX IF NOT EXISTS idx_service_accounts_owner_id ON service_accounts (owner_id);
+
+CREATE TABLE IF NOT EXISTS service_account_scope_mapping (
+    service_account_id TEXT NOT NULL REFERENCES service_accounts (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_scope_id BIGINT NOT NULL REFERENCES user_scopes (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (service_account_id, user_scope_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_service_account_scope_mapping_user_scope_id ON service_account_scope_mapping (user_scope_id);
+
+CREATE TABLE IF NOT EXISTS service_account_secrets (
+    id BIGSERIAL PRIMARY KEY,
+    service_account_id TEXT NOT NULL REFERENCES service_accounts (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    hash VARCHAR(255) NOT NULL,
+    hint VARCHAR(8) NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_service_account_secrets_service_account_id ON service_account_secrets (service_account_id);
@@ -0,0 +1,3 @@
+-- The grants are left in place: the admin may have held some of these
+-- scopes before the migration, and telling them apart is not possible.
+SELECT 1;
@@ -0,0 +1,33 @@
+-- Scopes added to the default catalogue after the bootstrap admin was first
+-- seeded. An existing admin is granted them once, here, rather than by the
+-- seed, which would also hand back the scopes an operator revoked. On a new
+-- database there is no admin yet and the seed grants the whole catalogue.
+INSERT INTO user_scopes (organization_id, name)
+SELECT 'default', name FROM (VALUES
+('scope:view'),
+('role:manage'),
+('role:view'),
+('group:manage'),
+('group:view'),
+('user:view'),
+('organization:manage'),
+('audit:view'),
+('webhook:manage'),
+('authz:check'),
+('token:introspect'),
+('session:create'),
+('service_account:manage'),
+('service_account:view')
+) AS added (name)
+ON CONFLICT (organization_id, name) DO NOTHING;
+
+INSERT INTO user_scope_mapping (user_id, user_scope_id)
+SELECT users.id, user_scopes.id FROM users
+JOIN user_scopes ON user_scopes.organization_id = users.organization_id
+WHERE users.id = 'ADMIN' AND users.organization_id = 'default'
+AND user_scopes.name IN (
+    'scope:view', 'role:manage', 'role:view', 'group:manage', 'group:view', 'user:view',
+    'organization:manage', 'audit:view', 'webhook:manage', 'authz:check', 'token:introspect',
+    'session:create', 'service_account:manage', 'service_account:view'
+)
+ON CONFLICT DO NOTHING;
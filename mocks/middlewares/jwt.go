@@ -5,12 +5,53 @@
 package middlewares
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gin "github.com/gin-gonic/gin"
+	jwt "github.com/golang-jwt/jwt/v5"
 	gomock "github.com/golang/mock/gomock"
+	middlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
 )
 
+// MockITokenVerifier is a mock of ITokenVerifier interface.
+type MockITokenVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockITokenVerifierMockRecorder
+}
+
+// MockITokenVerifierMockRecorder is the mock recorder for MockITokenVerifier.
+type MockITokenVerifierMockRecorder struct {
+	mock *MockITokenVerifier
+}
+
+// NewMockITokenVerifier creates a new mock instance.
+func NewMockITokenVerifier(ctrl *gomock.Controller) *MockITokenVerifier {
+	mock := &MockITokenVerifier{ctrl: ctrl}
+	mock.recorder = &MockITokenVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITokenVerifier) EXPECT() *MockITokenVerifierMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockITokenVerifier) Verify(ctx context.Context, tokenString string) (jwt.MapClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, tokenString)
+	ret0, _ := ret[0].(jwt.MapClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockITokenVerifierMockRecorder) Verify(ctx, tokenString interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockITokenVerifier)(nil).Verify), ctx, tokenString)
+}
+
 // MockIJWTMiddleware is a mock of IJWTMiddleware interface.
 type MockIJWTMiddleware struct {
 	ctrl     *gomock.Controller
@@ -34,6 +75,56 @@ func (m *MockIJWTMiddleware) EXPECT() *MockIJWTMiddlewareMockRecorder {
 	return m.recorder
 }
 
+// Require mocks base method.
+func (m *MockIJWTMiddleware) Require(requirement middlewares.ScopeRequirement) gin.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Require", requirement)
+	ret0, _ := ret[0].(gin.HandlerFunc)
+	return ret0
+}
+
+// Require indicates an expected call of Require.
+func (mr *MockIJWTMiddlewareMockRecorder) Require(requirement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Require", reflect.TypeOf((*MockIJWTMiddleware)(nil).Require), requirement)
+}
+
+// RequireAllScopes mocks base method.
+func (m *MockIJWTMiddleware) RequireAllScopes(scopes ...string) gin.HandlerFunc {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range scopes {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RequireAllScopes", varargs...)
+	ret0, _ := ret[0].(gin.HandlerFunc)
+	return ret0
+}
+
+// RequireAllScopes indicates an expected call of RequireAllScopes.
+func (mr *MockIJWTMiddlewareMockRecorder) RequireAllScopes(scopes ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequireAllScopes", reflect.TypeOf((*MockIJWTMiddleware)(nil).RequireAllScopes), scopes...)
+}
+
+// RequireAnyScope mocks base method.
+func (m *MockIJWTMiddleware) RequireAnyScope(scopes ...string) gin.HandlerFunc {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range scopes {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RequireAnyScope", varargs...)
+	ret0, _ := ret[0].(gin.HandlerFunc)
+	return ret0
+}
+
+// RequireAnyScope indicates an expected call of RequireAnyScope.
+func (mr *MockIJWTMiddlewareMockRecorder) RequireAnyScope(scopes ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequireAnyScope", reflect.TypeOf((*MockIJWTMiddleware)(nil).RequireAnyScope), scopes...)
+}
+
 // RequireScope mocks base method.
 func (m *MockIJWTMiddleware) RequireScope(requiredScope string) gin.HandlerFunc {
 	m.ctrl.T.Helper()
@@ -47,3 +138,18 @@ func (mr *MockIJWTMiddlewareMockRecorder) RequireScope(requiredScope interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequireScope", reflect.TypeOf((*MockIJWTMiddleware)(nil).RequireScope), requiredScope)
 }
+
+// Verify mocks base method.
+func (m *MockIJWTMiddleware) Verify(ctx context.Context, tokenString string) (jwt.MapClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, tokenString)
+	ret0, _ := ret[0].(jwt.MapClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockIJWTMiddlewareMockRecorder) Verify(ctx, tokenString interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockIJWTMiddleware)(nil).Verify), ctx, tokenString)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/repositories/access_request.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
+	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+// MockIAccessRequestRepository is a mock of IAccessRequestRepository interface.
+type MockIAccessRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccessRequestRepositoryMockRecorder
+}
+
+// MockIAccessRequestRepositoryMockRecorder is the mock recorder for MockIAccessRequestRepository.
+type MockIAccessRequestRepositoryMockRecorder struct {
+	mock *MockIAccessRequestRepository
+}
+
+// NewMockIAccessRequestRepository creates a new mock instance.
+func NewMockIAccessRequestRepository(ctrl *gomock.Controller) *MockIAccessRequestRepository {
+	mock := &MockIAccessRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockIAccessRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccessRequestRepository) EXPECT() *MockIAccessRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddComment mocks base method.
+func (m *MockIAccessRequestRepository) AddComment(ctx context.Context, requestId uint, authorId, body string) (*entities.AccessRequestComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddComment", ctx, requestId, authorId, body)
+	ret0, _ := ret[0].(*entities.AccessRequestComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddComment indicates an expected call of AddComment.
+func (mr *MockIAccessRequestRepositoryMockRecorder) AddComment(ctx, requestId, authorId, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockIAccessRequestRepository)(nil).AddComment), ctx, requestId, authorId, body)
+}
+
+// Create mocks base method.
+func (m *MockIAccessRequestRepository) Create(ctx context.Context, requesterId, scope, justification string, grantExpiresAt *time.Time, expiresAt time.Time) (*entities.AccessRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, requesterId, scope, justification, grantExpiresAt, expiresAt)
+	ret0, _ := ret[0].(*entities.AccessRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAccessRequestRepositoryMockRecorder) Create(ctx, requesterId, scope, justification, grantExpiresAt, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAccessRequestRepository)(nil).Create), ctx, requesterId, scope, justification, grantExpiresAt, expiresAt)
+}
+
+// Decide mocks base method.
+func (m *MockIAccessRequestRepository) Decide(ctx context.Context, requestId uint, status, actorId, comment string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decide", ctx, requestId, status, actorId, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockIAccessRequestRepositoryMockRecorder) Decide(ctx, requestId, status, actorId, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockIAccessRequestRepository)(nil).Decide), ctx, requestId, status, actorId, comment)
+}
+
+// ExpireStale mocks base method.
+func (m *MockIAccessRequestRepository) ExpireStale(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireStale", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireStale indicates an expected call of ExpireStale.
+func (mr *MockIAccessRequestRepositoryMockRecorder) ExpireStale(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireStale", reflect.TypeOf((*MockIAccessRequestRepository)(nil).ExpireStale), ctx, now)
+}
+
+// FindAll mocks base method.
+func (m *MockIAccessRequestRepository) FindAll(ctx context.Context, query dto.ListAccessRequestsRequest) ([]*entities.AccessRequest, *dto.Paging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*entities.AccessRequest)
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIAccessRequestRepositoryMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIAccessRequestRepository)(nil).FindAll), ctx, query)
+}
+
+// FindById mocks base method.
+func (m *MockIAccessRequestRepository) FindById(ctx context.Context, requestId uint) (*entities.AccessRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, requestId)
+	ret0, _ := ret[0].(*entities.AccessRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockIAccessRequestRepositoryMockRecorder) FindById(ctx, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIAccessRequestRepository)(nil).FindById), ctx, requestId)
+}
@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/repositories/group.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
+	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
+	repositories "github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	gorm "gorm.io/gorm"
+)
+
+// MockIGroupRepository is a mock of IGroupRepository interface.
+type MockIGroupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIGroupRepositoryMockRecorder
+}
+
+// MockIGroupRepositoryMockRecorder is the mock recorder for MockIGroupRepository.
+type MockIGroupRepositoryMockRecorder struct {
+	mock *MockIGroupRepository
+}
+
+// NewMockIGroupRepository creates a new mock instance.
+func NewMockIGroupRepository(ctrl *gomock.Controller) *MockIGroupRepository {
+	mock := &MockIGroupRepository{ctrl: ctrl}
+	mock.recorder = &MockIGroupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIGroupRepository) EXPECT() *MockIGroupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BeginTransaction mocks base method.
+func (m *MockIGroupRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction", ctx)
+	ret0, _ := ret[0].(*gorm.DB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockIGroupRepositoryMockRecorder) BeginTransaction(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockIGroupRepository)(nil).BeginTransaction), ctx)
+}
+
+// Create mocks base method.
+func (m *MockIGroupRepository) Create(ctx context.Context, name string, parentId *uint, scopes []*entities.UserScope) (*entities.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, name, parentId, scopes)
+	ret0, _ := ret[0].(*entities.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIGroupRepositoryMockRecorder) Create(ctx, name, parentId, scopes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIGroupRepository)(nil).Create), ctx, name, parentId, scopes)
+}
+
+// Delete mocks base method.
+func (m *MockIGroupRepository) Delete(ctx context.Context, groupId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, groupId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIGroupRepositoryMockRecorder) Delete(ctx, groupId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIGroupRepository)(nil).Delete), ctx, groupId)
+}
+
+// FindAll mocks base method.
+func (m *MockIGroupRepository) FindAll(ctx context.Context, query dto.ListGroupsRequest) ([]*entities.Group, *dto.Paging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*entities.Group)
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIGroupRepositoryMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIGroupRepository)(nil).FindAll), ctx, query)
+}
+
+// FindByName mocks base method.
+func (m *MockIGroupRepository) FindByName(ctx context.Context, name string) (*entities.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].(*entities.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockIGroupRepositoryMockRecorder) FindByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockIGroupRepository)(nil).FindByName), ctx, name)
+}
+
+// FindMemberIds mocks base method.
+func (m *MockIGroupRepository) FindMemberIds(ctx context.Context, groupId uint) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMemberIds", ctx, groupId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMemberIds indicates an expected call of FindMemberIds.
+func (mr *MockIGroupRepositoryMockRecorder) FindMemberIds(ctx, groupId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMemberIds", reflect.TypeOf((*MockIGroupRepository)(nil).FindMemberIds), ctx, groupId)
+}
+
+// UpdateMember mocks base method.
+func (m *MockIGroupRepository) UpdateMember(ctx context.Context, group *entities.Group, user *entities.User, isAdded bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMember", ctx, group, user, isAdded)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMember indicates an expected call of UpdateMember.
+func (mr *MockIGroupRepositoryMockRecorder) UpdateMember(ctx, group, user, isAdded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMember", reflect.TypeOf((*MockIGroupRepository)(nil).UpdateMember), ctx, group, user, isAdded)
+}
+
+// UpdateParent mocks base method.
+func (m *MockIGroupRepository) UpdateParent(ctx context.Context, groupId uint, parentId *uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateParent", ctx, groupId, parentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateParent indicates an expected call of UpdateParent.
+func (mr *MockIGroupRepositoryMockRecorder) UpdateParent(ctx, groupId, parentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateParent", reflect.TypeOf((*MockIGroupRepository)(nil).UpdateParent), ctx, groupId, parentId)
+}
+
+// UpdateScope mocks base method.
+func (m *MockIGroupRepository) UpdateScope(ctx context.Context, group *entities.Group, scopes []*entities.UserScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScope", ctx, group, scopes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScope indicates an expected call of UpdateScope.
+func (mr *MockIGroupRepositoryMockRecorder) UpdateScope(ctx, group, scopes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScope", reflect.TypeOf((*MockIGroupRepository)(nil).UpdateScope), ctx, group, scopes)
+}
+
+// WithTransaction mocks base method.
+func (m *MockIGroupRepository) WithTransaction(tx *gorm.DB) repositories.IGroupRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", tx)
+	ret0, _ := ret[0].(repositories.IGroupRepository)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockIGroupRepositoryMockRecorder) WithTransaction(tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockIGroupRepository)(nil).WithTransaction), tx)
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/repositories/organization.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
+	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+// MockIOrganizationRepository is a mock of IOrganizationRepository interface.
+type MockIOrganizationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOrganizationRepositoryMockRecorder
+}
+
+// MockIOrganizationRepositoryMockRecorder is the mock recorder for MockIOrganizationRepository.
+type MockIOrganizationRepositoryMockRecorder struct {
+	mock *MockIOrganizationRepository
+}
+
+// NewMockIOrganizationRepository creates a new mock instance.
+func NewMockIOrganizationRepository(ctrl *gomock.Controller) *MockIOrganizationRepository {
+	mock := &MockIOrganizationRepository{ctrl: ctrl}
+	mock.recorder = &MockIOrganizationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOrganizationRepository) EXPECT() *MockIOrganizationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIOrganizationRepository) Create(ctx context.Context, organizationId, name string) (*entities.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, organizationId, name)
+	ret0, _ := ret[0].(*entities.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIOrganizationRepositoryMockRecorder) Create(ctx, organizationId, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIOrganizationRepository)(nil).Create), ctx, organizationId, name)
+}
+
+// FindAll mocks base method.
+func (m *MockIOrganizationRepository) FindAll(ctx context.Context, query dto.ListOrganizationsRequest) ([]*entities.Organization, *dto.Paging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*entities.Organization)
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIOrganizationRepositoryMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIOrganizationRepository)(nil).FindAll), ctx, query)
+}
+
+// FindById mocks base method.
+func (m *MockIOrganizationRepository) FindById(ctx context.Context, organizationId string) (*entities.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, organizationId)
+	ret0, _ := ret[0].(*entities.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockIOrganizationRepositoryMockRecorder) FindById(ctx, organizationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIOrganizationRepository)(nil).FindById), ctx, organizationId)
+}
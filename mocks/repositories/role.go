@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/repositories/role.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
+	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
+	repositories "github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	gorm "gorm.io/gorm"
+)
+
+// MockIRoleRepository is a mock of IRoleRepository interface.
+type MockIRoleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRoleRepositoryMockRecorder
+}
+
+// MockIRoleRepositoryMockRecorder is the mock recorder for MockIRoleRepository.
+type MockIRoleRepositoryMockRecorder struct {
+	mock *MockIRoleRepository
+}
+
+// NewMockIRoleRepository creates a new mock instance.
+func NewMockIRoleRepository(ctrl *gomock.Controller) *MockIRoleRepository {
+	mock := &MockIRoleRepository{ctrl: ctrl}
+	mock.recorder = &MockIRoleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRoleRepository) EXPECT() *MockIRoleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BeginTransaction mocks base method.
+func (m *MockIRoleRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction", ctx)
+	ret0, _ := ret[0].(*gorm.DB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockIRoleRepositoryMockRecorder) BeginTransaction(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockIRoleRepository)(nil).BeginTransaction), ctx)
+}
+
+// Create mocks base method.
+func (m *MockIRoleRepository) Create(ctx context.Context, name string, scopes []*entities.UserScope) (*entities.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, name, scopes)
+	ret0, _ := ret[0].(*entities.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIRoleRepositoryMockRecorder) Create(ctx, name, scopes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIRoleRepository)(nil).Create), ctx, name, scopes)
+}
+
+// Delete mocks base method.
+func (m *MockIRoleRepository) Delete(ctx context.Context, roleId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, roleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIRoleRepositoryMockRecorder) Delete(ctx, roleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIRoleRepository)(nil).Delete), ctx, roleId)
+}
+
+// FindAll mocks base method.
+func (m *MockIRoleRepository) FindAll(ctx context.Context, query dto.ListRolesRequest) ([]*entities.Role, *dto.Paging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*entities.Role)
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIRoleRepositoryMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIRoleRepository)(nil).FindAll), ctx, query)
+}
+
+// FindByName mocks base method.
+func (m *MockIRoleRepository) FindByName(ctx context.Context, name string) (*entities.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].(*entities.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockIRoleRepositoryMockRecorder) FindByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockIRoleRepository)(nil).FindByName), ctx, name)
+}
+
+// FindUserIds mocks base method.
+func (m *MockIRoleRepository) FindUserIds(ctx context.Context, roleId uint) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserIds", ctx, roleId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserIds indicates an expected call of FindUserIds.
+func (mr *MockIRoleRepositoryMockRecorder) FindUserIds(ctx, roleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserIds", reflect.TypeOf((*MockIRoleRepository)(nil).FindUserIds), ctx, roleId)
+}
+
+// UpdateScope mocks base method.
+func (m *MockIRoleRepository) UpdateScope(ctx context.Context, role *entities.Role, scopes []*entities.UserScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScope", ctx, role, scopes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScope indicates an expected call of UpdateScope.
+func (mr *MockIRoleRepositoryMockRecorder) UpdateScope(ctx, role, scopes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScope", reflect.TypeOf((*MockIRoleRepository)(nil).UpdateScope), ctx, role, scopes)
+}
+
+// WithTransaction mocks base method.
+func (m *MockIRoleRepository) WithTransaction(tx *gorm.DB) repositories.IRoleRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", tx)
+	ret0, _ := ret[0].(repositories.IRoleRepository)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockIRoleRepositoryMockRecorder) WithTransaction(tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockIRoleRepository)(nil).WithTransaction), tx)
+}
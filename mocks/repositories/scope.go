@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
 	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
 	repositories "github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
 	gorm "gorm.io/gorm"
@@ -53,77 +54,108 @@ func (mr *MockIScopeRepositoryMockRecorder) BeginTransaction(ctx interface{}) *g
 }
 
 // Create mocks base method.
-func (m *MockIScopeRepository) Create(name string) (*entities.UserScope, error) {
+func (m *MockIScopeRepository) Create(ctx context.Context, name string) (*entities.UserScope, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", name)
+	ret := m.ctrl.Call(m, "Create", ctx, name)
 	ret0, _ := ret[0].(*entities.UserScope)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIScopeRepositoryMockRecorder) Create(name interface{}) *gomock.Call {
+func (mr *MockIScopeRepositoryMockRecorder) Create(ctx, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIScopeRepository)(nil).Create), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIScopeRepository)(nil).Create), ctx, name)
 }
 
 // Delete mocks base method.
-func (m *MockIScopeRepository) Delete(name string) error {
+func (m *MockIScopeRepository) Delete(ctx context.Context, name string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", name)
+	ret := m.ctrl.Call(m, "Delete", ctx, name)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIScopeRepositoryMockRecorder) Delete(name interface{}) *gomock.Call {
+func (mr *MockIScopeRepositoryMockRecorder) Delete(ctx, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIScopeRepository)(nil).Delete), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIScopeRepository)(nil).Delete), ctx, name)
 }
 
 // FindAll mocks base method.
-func (m *MockIScopeRepository) FindAll() ([]*entities.UserScope, error) {
+func (m *MockIScopeRepository) FindAll(ctx context.Context, query dto.ListScopesRequest) ([]*entities.UserScope, *dto.Paging, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
 	ret0, _ := ret[0].([]*entities.UserScope)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockIScopeRepositoryMockRecorder) FindAll() *gomock.Call {
+func (mr *MockIScopeRepositoryMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIScopeRepository)(nil).FindAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIScopeRepository)(nil).FindAll), ctx, query)
 }
 
 // FindById mocks base method.
-func (m *MockIScopeRepository) FindById(scopeId uint) (*entities.UserScope, error) {
+func (m *MockIScopeRepository) FindById(ctx context.Context, scopeId uint) (*entities.UserScope, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindById", scopeId)
+	ret := m.ctrl.Call(m, "FindById", ctx, scopeId)
 	ret0, _ := ret[0].(*entities.UserScope)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindById indicates an expected call of FindById.
-func (mr *MockIScopeRepositoryMockRecorder) FindById(scopeId interface{}) *gomock.Call {
+func (mr *MockIScopeRepositoryMockRecorder) FindById(ctx, scopeId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIScopeRepository)(nil).FindById), scopeId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIScopeRepository)(nil).FindById), ctx, scopeId)
 }
 
 // FindByName mocks base method.
-func (m *MockIScopeRepository) FindByName(name string) (*entities.UserScope, error) {
+func (m *MockIScopeRepository) FindByName(ctx context.Context, name string) (*entities.UserScope, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByName", name)
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
 	ret0, _ := ret[0].(*entities.UserScope)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByName indicates an expected call of FindByName.
-func (mr *MockIScopeRepositoryMockRecorder) FindByName(name interface{}) *gomock.Call {
+func (mr *MockIScopeRepositoryMockRecorder) FindByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockIScopeRepository)(nil).FindByName), ctx, name)
+}
+
+// FindHolderIds mocks base method.
+func (m *MockIScopeRepository) FindHolderIds(ctx context.Context, scopeId uint) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHolderIds", ctx, scopeId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindHolderIds indicates an expected call of FindHolderIds.
+func (mr *MockIScopeRepositoryMockRecorder) FindHolderIds(ctx, scopeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHolderIds", reflect.TypeOf((*MockIScopeRepository)(nil).FindHolderIds), ctx, scopeId)
+}
+
+// ListNames mocks base method.
+func (m *MockIScopeRepository) ListNames(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNames", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNames indicates an expected call of ListNames.
+func (mr *MockIScopeRepositoryMockRecorder) ListNames(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockIScopeRepository)(nil).FindByName), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNames", reflect.TypeOf((*MockIScopeRepository)(nil).ListNames), ctx)
 }
 
 // WithTransaction mocks base method.
@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/repositories/service_account.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
+	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+// MockIServiceAccountRepository is a mock of IServiceAccountRepository interface.
+type MockIServiceAccountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIServiceAccountRepositoryMockRecorder
+}
+
+// MockIServiceAccountRepositoryMockRecorder is the mock recorder for MockIServiceAccountRepository.
+type MockIServiceAccountRepositoryMockRecorder struct {
+	mock *MockIServiceAccountRepository
+}
+
+// NewMockIServiceAccountRepository creates a new mock instance.
+func NewMockIServiceAccountRepository(ctrl *gomock.Controller) *MockIServiceAccountRepository {
+	mock := &MockIServiceAccountRepository{ctrl: ctrl}
+	mock.recorder = &MockIServiceAccountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIServiceAccountRepository) EXPECT() *MockIServiceAccountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddSecret mocks base method.
+func (m *MockIServiceAccountRepository) AddSecret(ctx context.Context, secret *entities.ServiceAccountSecret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSecret", ctx, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSecret indicates an expected call of AddSecret.
+func (mr *MockIServiceAccountRepositoryMockRecorder) AddSecret(ctx, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSecret", reflect.TypeOf((*MockIServiceAccountRepository)(nil).AddSecret), ctx, secret)
+}
+
+// Create mocks base method.
+func (m *MockIServiceAccountRepository) Create(ctx context.Context, account *entities.ServiceAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIServiceAccountRepositoryMockRecorder) Create(ctx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIServiceAccountRepository)(nil).Create), ctx, account)
+}
+
+// Delete mocks base method.
+func (m *MockIServiceAccountRepository) Delete(ctx context.Context, accountId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, accountId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIServiceAccountRepositoryMockRecorder) Delete(ctx, accountId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIServiceAccountRepository)(nil).Delete), ctx, accountId)
+}
+
+// DeleteSecret mocks base method.
+func (m *MockIServiceAccountRepository) DeleteSecret(ctx context.Context, accountId string, secretId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", ctx, accountId, secretId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret.
+func (mr *MockIServiceAccountRepositoryMockRecorder) DeleteSecret(ctx, accountId, secretId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockIServiceAccountRepository)(nil).DeleteSecret), ctx, accountId, secretId)
+}
+
+// FindAll mocks base method.
+func (m *MockIServiceAccountRepository) FindAll(ctx context.Context, query dto.ListServiceAccountsRequest) ([]*entities.ServiceAccount, *dto.Paging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*entities.ServiceAccount)
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIServiceAccountRepositoryMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIServiceAccountRepository)(nil).FindAll), ctx, query)
+}
+
+// FindById mocks base method.
+func (m *MockIServiceAccountRepository) FindById(ctx context.Context, accountId string) (*entities.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, accountId)
+	ret0, _ := ret[0].(*entities.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockIServiceAccountRepositoryMockRecorder) FindById(ctx, accountId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIServiceAccountRepository)(nil).FindById), ctx, accountId)
+}
+
+// Update mocks base method.
+func (m *MockIServiceAccountRepository) Update(ctx context.Context, account *entities.ServiceAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIServiceAccountRepositoryMockRecorder) Update(ctx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIServiceAccountRepository)(nil).Update), ctx, account)
+}
+
+// UpdateScope mocks base method.
+func (m *MockIServiceAccountRepository) UpdateScope(ctx context.Context, account *entities.ServiceAccount, scopes []*entities.UserScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScope", ctx, account, scopes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScope indicates an expected call of UpdateScope.
+func (mr *MockIServiceAccountRepositoryMockRecorder) UpdateScope(ctx, account, scopes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScope", reflect.TypeOf((*MockIServiceAccountRepository)(nil).UpdateScope), ctx, account, scopes)
+}
@@ -7,8 +7,10 @@ package repositories
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
 	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
 	repositories "github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
 	gorm "gorm.io/gorm"
@@ -37,6 +39,20 @@ func (m *MockIUserRepository) EXPECT() *MockIUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddPasswordHistory mocks base method.
+func (m *MockIUserRepository) AddPasswordHistory(ctx context.Context, userId, hash string, keep int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPasswordHistory", ctx, userId, hash, keep)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPasswordHistory indicates an expected call of AddPasswordHistory.
+func (mr *MockIUserRepositoryMockRecorder) AddPasswordHistory(ctx, userId, hash, keep interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPasswordHistory", reflect.TypeOf((*MockIUserRepository)(nil).AddPasswordHistory), ctx, userId, hash, keep)
+}
+
 // BeginTransaction mocks base method.
 func (m *MockIUserRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
 	m.ctrl.T.Helper()
@@ -53,76 +69,165 @@ func (mr *MockIUserRepositoryMockRecorder) BeginTransaction(ctx interface{}) *go
 }
 
 // Create mocks base method.
-func (m *MockIUserRepository) Create(username, hash, email string, scopes []*entities.UserScope) (*entities.User, error) {
+func (m *MockIUserRepository) Create(ctx context.Context, username, hash, email string, scopes []*entities.UserScope, expiries map[string]time.Time, roles []*entities.Role) (*entities.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", username, hash, email, scopes)
+	ret := m.ctrl.Call(m, "Create", ctx, username, hash, email, scopes, expiries, roles)
 	ret0, _ := ret[0].(*entities.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIUserRepositoryMockRecorder) Create(username, hash, email, scopes interface{}) *gomock.Call {
+func (mr *MockIUserRepositoryMockRecorder) Create(ctx, username, hash, email, scopes, expiries, roles interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserRepository)(nil).Create), username, hash, email, scopes)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserRepository)(nil).Create), ctx, username, hash, email, scopes, expiries, roles)
 }
 
 // Delete mocks base method.
-func (m *MockIUserRepository) Delete(userId string) error {
+func (m *MockIUserRepository) Delete(ctx context.Context, userId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userId)
+	ret := m.ctrl.Call(m, "Delete", ctx, userId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIUserRepositoryMockRecorder) Delete(userId interface{}) *gomock.Call {
+func (mr *MockIUserRepositoryMockRecorder) Delete(ctx, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIUserRepository)(nil).Delete), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIUserRepository)(nil).Delete), ctx, userId)
 }
 
-// FindAll mocks base method.
-func (m *MockIUserRepository) FindAll() ([]*entities.User, error) {
+// DeleteExpiredScopes mocks base method.
+func (m *MockIUserRepository) DeleteExpiredScopes(ctx context.Context, now time.Time) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
-	ret0, _ := ret[0].([]*entities.User)
+	ret := m.ctrl.Call(m, "DeleteExpiredScopes", ctx, now)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// DeleteExpiredScopes indicates an expected call of DeleteExpiredScopes.
+func (mr *MockIUserRepositoryMockRecorder) DeleteExpiredScopes(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredScopes", reflect.TypeOf((*MockIUserRepository)(nil).DeleteExpiredScopes), ctx, now)
+}
+
+// FindAll mocks base method.
+func (m *MockIUserRepository) FindAll(ctx context.Context, query dto.ListUsersRequest) ([]*entities.User, *dto.Paging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*entities.User)
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // FindAll indicates an expected call of FindAll.
-func (mr *MockIUserRepositoryMockRecorder) FindAll() *gomock.Call {
+func (mr *MockIUserRepositoryMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIUserRepository)(nil).FindAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIUserRepository)(nil).FindAll), ctx, query)
 }
 
 // FindById mocks base method.
-func (m *MockIUserRepository) FindById(userId string) (*entities.User, error) {
+func (m *MockIUserRepository) FindById(ctx context.Context, userId string) (*entities.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindById", userId)
+	ret := m.ctrl.Call(m, "FindById", ctx, userId)
 	ret0, _ := ret[0].(*entities.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindById indicates an expected call of FindById.
-func (mr *MockIUserRepositoryMockRecorder) FindById(userId interface{}) *gomock.Call {
+func (mr *MockIUserRepositoryMockRecorder) FindById(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIUserRepository)(nil).FindById), ctx, userId)
+}
+
+// FindByIds mocks base method.
+func (m *MockIUserRepository) FindByIds(ctx context.Context, userIds []string) ([]*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIds", ctx, userIds)
+	ret0, _ := ret[0].([]*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIds indicates an expected call of FindByIds.
+func (mr *MockIUserRepositoryMockRecorder) FindByIds(ctx, userIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIds", reflect.TypeOf((*MockIUserRepository)(nil).FindByIds), ctx, userIds)
+}
+
+// FindExpiredScopeHolders mocks base method.
+func (m *MockIUserRepository) FindExpiredScopeHolders(ctx context.Context, now time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpiredScopeHolders", ctx, now)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpiredScopeHolders indicates an expected call of FindExpiredScopeHolders.
+func (mr *MockIUserRepositoryMockRecorder) FindExpiredScopeHolders(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpiredScopeHolders", reflect.TypeOf((*MockIUserRepository)(nil).FindExpiredScopeHolders), ctx, now)
+}
+
+// FindPasswordHistory mocks base method.
+func (m *MockIUserRepository) FindPasswordHistory(ctx context.Context, userId string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPasswordHistory", ctx, userId, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPasswordHistory indicates an expected call of FindPasswordHistory.
+func (mr *MockIUserRepositoryMockRecorder) FindPasswordHistory(ctx, userId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPasswordHistory", reflect.TypeOf((*MockIUserRepository)(nil).FindPasswordHistory), ctx, userId, limit)
+}
+
+// UpdateHash mocks base method.
+func (m *MockIUserRepository) UpdateHash(ctx context.Context, userId, hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHash", ctx, userId, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHash indicates an expected call of UpdateHash.
+func (mr *MockIUserRepositoryMockRecorder) UpdateHash(ctx, userId, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHash", reflect.TypeOf((*MockIUserRepository)(nil).UpdateHash), ctx, userId, hash)
+}
+
+// UpdateRole mocks base method.
+func (m *MockIUserRepository) UpdateRole(ctx context.Context, user *entities.User, roles []*entities.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, user, roles)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockIUserRepositoryMockRecorder) UpdateRole(ctx, user, roles interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIUserRepository)(nil).FindById), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockIUserRepository)(nil).UpdateRole), ctx, user, roles)
 }
 
 // UpdateScope mocks base method.
-func (m *MockIUserRepository) UpdateScope(user *entities.User, scopes []*entities.UserScope) error {
+func (m *MockIUserRepository) UpdateScope(ctx context.Context, user *entities.User, scopes []*entities.UserScope, expiries map[string]time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateScope", user, scopes)
+	ret := m.ctrl.Call(m, "UpdateScope", ctx, user, scopes, expiries)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateScope indicates an expected call of UpdateScope.
-func (mr *MockIUserRepositoryMockRecorder) UpdateScope(user, scopes interface{}) *gomock.Call {
+func (mr *MockIUserRepositoryMockRecorder) UpdateScope(ctx, user, scopes, expiries interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScope", reflect.TypeOf((*MockIUserRepository)(nil).UpdateScope), user, scopes)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScope", reflect.TypeOf((*MockIUserRepository)(nil).UpdateScope), ctx, user, scopes, expiries)
 }
 
 // WithTransaction mocks base method.
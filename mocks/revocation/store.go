@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/revocation/store.go
+
+// Package revocation is a generated GoMock package.
+package revocation
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	revocation "github.com/vnFuhung2903/vcs-user-management-service/pkg/revocation"
+)
+
+// MockIStore is a mock of IStore interface.
+type MockIStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStoreMockRecorder
+}
+
+// MockIStoreMockRecorder is the mock recorder for MockIStore.
+type MockIStoreMockRecorder struct {
+	mock *MockIStore
+}
+
+// NewMockIStore creates a new mock instance.
+func NewMockIStore(ctrl *gomock.Controller) *MockIStore {
+	mock := &MockIStore{ctrl: ctrl}
+	mock.recorder = &MockIStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStore) EXPECT() *MockIStoreMockRecorder {
+	return m.recorder
+}
+
+// IsRevoked mocks base method.
+func (m *MockIStore) IsRevoked(ctx context.Context, token revocation.Token) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, token)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockIStoreMockRecorder) IsRevoked(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockIStore)(nil).IsRevoked), ctx, token)
+}
+
+// RevokeEveryone mocks base method.
+func (m *MockIStore) RevokeEveryone(ctx context.Context, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeEveryone", ctx, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeEveryone indicates an expected call of RevokeEveryone.
+func (mr *MockIStoreMockRecorder) RevokeEveryone(ctx, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeEveryone", reflect.TypeOf((*MockIStore)(nil).RevokeEveryone), ctx, at)
+}
+
+// RevokeSession mocks base method.
+func (m *MockIStore) RevokeSession(ctx context.Context, sessionId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockIStoreMockRecorder) RevokeSession(ctx, sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockIStore)(nil).RevokeSession), ctx, sessionId)
+}
+
+// RevokeToken mocks base method.
+func (m *MockIStore) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, jti, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockIStoreMockRecorder) RevokeToken(ctx, jti, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockIStore)(nil).RevokeToken), ctx, jti, expiresAt)
+}
+
+// RevokeUser mocks base method.
+func (m *MockIStore) RevokeUser(ctx context.Context, userId string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUser", ctx, userId, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MockIStoreMockRecorder) RevokeUser(ctx, userId, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MockIStore)(nil).RevokeUser), ctx, userId, at)
+}
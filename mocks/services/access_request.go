@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/access_request.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
+	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+// MockIAccessRequestService is a mock of IAccessRequestService interface.
+type MockIAccessRequestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccessRequestServiceMockRecorder
+}
+
+// MockIAccessRequestServiceMockRecorder is the mock recorder for MockIAccessRequestService.
+type MockIAccessRequestServiceMockRecorder struct {
+	mock *MockIAccessRequestService
+}
+
+// NewMockIAccessRequestService creates a new mock instance.
+func NewMockIAccessRequestService(ctrl *gomock.Controller) *MockIAccessRequestService {
+	mock := &MockIAccessRequestService{ctrl: ctrl}
+	mock.recorder = &MockIAccessRequestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccessRequestService) EXPECT() *MockIAccessRequestServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockIAccessRequestService) Approve(ctx context.Context, requestId uint, reviewerId string, reviewerScopes []string, comment string) (*entities.AccessRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, requestId, reviewerId, reviewerScopes, comment)
+	ret0, _ := ret[0].(*entities.AccessRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockIAccessRequestServiceMockRecorder) Approve(ctx, requestId, reviewerId, reviewerScopes, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockIAccessRequestService)(nil).Approve), ctx, requestId, reviewerId, reviewerScopes, comment)
+}
+
+// Cancel mocks base method.
+func (m *MockIAccessRequestService) Cancel(ctx context.Context, requestId uint, requesterId string) (*entities.AccessRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, requestId, requesterId)
+	ret0, _ := ret[0].(*entities.AccessRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockIAccessRequestServiceMockRecorder) Cancel(ctx, requestId, requesterId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockIAccessRequestService)(nil).Cancel), ctx, requestId, requesterId)
+}
+
+// Comment mocks base method.
+func (m *MockIAccessRequestService) Comment(ctx context.Context, requestId uint, authorId string, reviewer bool, body string) (*entities.AccessRequestComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Comment", ctx, requestId, authorId, reviewer, body)
+	ret0, _ := ret[0].(*entities.AccessRequestComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Comment indicates an expected call of Comment.
+func (mr *MockIAccessRequestServiceMockRecorder) Comment(ctx, requestId, authorId, reviewer, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Comment", reflect.TypeOf((*MockIAccessRequestService)(nil).Comment), ctx, requestId, authorId, reviewer, body)
+}
+
+// Create mocks base method.
+func (m *MockIAccessRequestService) Create(ctx context.Context, requesterId, scopeName, justification string, grantExpiresAt *time.Time) (*entities.AccessRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, requesterId, scopeName, justification, grantExpiresAt)
+	ret0, _ := ret[0].(*entities.AccessRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAccessRequestServiceMockRecorder) Create(ctx, requesterId, scopeName, justification, grantExpiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAccessRequestService)(nil).Create), ctx, requesterId, scopeName, justification, grantExpiresAt)
+}
+
+// FindAll mocks base method.
+func (m *MockIAccessRequestService) FindAll(ctx context.Context, query dto.ListAccessRequestsRequest) ([]*entities.AccessRequest, *dto.Paging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*entities.AccessRequest)
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIAccessRequestServiceMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIAccessRequestService)(nil).FindAll), ctx, query)
+}
+
+// FindOne mocks base method.
+func (m *MockIAccessRequestService) FindOne(ctx context.Context, requestId uint, callerId string, reviewer bool) (*entities.AccessRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, requestId, callerId, reviewer)
+	ret0, _ := ret[0].(*entities.AccessRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockIAccessRequestServiceMockRecorder) FindOne(ctx, requestId, callerId, reviewer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockIAccessRequestService)(nil).FindOne), ctx, requestId, callerId, reviewer)
+}
+
+// Reject mocks base method.
+func (m *MockIAccessRequestService) Reject(ctx context.Context, requestId uint, reviewerId, comment string) (*entities.AccessRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, requestId, reviewerId, comment)
+	ret0, _ := ret[0].(*entities.AccessRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockIAccessRequestServiceMockRecorder) Reject(ctx, requestId, reviewerId, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockIAccessRequestService)(nil).Reject), ctx, requestId, reviewerId, comment)
+}
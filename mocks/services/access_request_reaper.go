@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/access_request_reaper.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAccessRequestReaper is a mock of IAccessRequestReaper interface.
+type MockIAccessRequestReaper struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccessRequestReaperMockRecorder
+}
+
+// MockIAccessRequestReaperMockRecorder is the mock recorder for MockIAccessRequestReaper.
+type MockIAccessRequestReaperMockRecorder struct {
+	mock *MockIAccessRequestReaper
+}
+
+// NewMockIAccessRequestReaper creates a new mock instance.
+func NewMockIAccessRequestReaper(ctrl *gomock.Controller) *MockIAccessRequestReaper {
+	mock := &MockIAccessRequestReaper{ctrl: ctrl}
+	mock.recorder = &MockIAccessRequestReaperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccessRequestReaper) EXPECT() *MockIAccessRequestReaperMockRecorder {
+	return m.recorder
+}
+
+// Reap mocks base method.
+func (m *MockIAccessRequestReaper) Reap(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reap", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reap indicates an expected call of Reap.
+func (mr *MockIAccessRequestReaperMockRecorder) Reap(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reap", reflect.TypeOf((*MockIAccessRequestReaper)(nil).Reap), ctx)
+}
+
+// Run mocks base method.
+func (m *MockIAccessRequestReaper) Run(ctx context.Context, interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx, interval)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIAccessRequestReaperMockRecorder) Run(ctx, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIAccessRequestReaper)(nil).Run), ctx, interval)
+}
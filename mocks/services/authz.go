@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/authz.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
+)
+
+// MockIAuthzService is a mock of IAuthzService interface.
+type MockIAuthzService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuthzServiceMockRecorder
+}
+
+// MockIAuthzServiceMockRecorder is the mock recorder for MockIAuthzService.
+type MockIAuthzServiceMockRecorder struct {
+	mock *MockIAuthzService
+}
+
+// NewMockIAuthzService creates a new mock instance.
+func NewMockIAuthzService(ctrl *gomock.Controller) *MockIAuthzService {
+	mock := &MockIAuthzService{ctrl: ctrl}
+	mock.recorder = &MockIAuthzServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuthzService) EXPECT() *MockIAuthzServiceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockIAuthzService) Check(ctx context.Context, check dto.AuthzCheckRequest) (*dto.AuthzDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, check)
+	ret0, _ := ret[0].(*dto.AuthzDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockIAuthzServiceMockRecorder) Check(ctx, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockIAuthzService)(nil).Check), ctx, check)
+}
+
+// CheckBatch mocks base method.
+func (m *MockIAuthzService) CheckBatch(ctx context.Context, checks []dto.AuthzCheckRequest) ([]*dto.AuthzDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckBatch", ctx, checks)
+	ret0, _ := ret[0].([]*dto.AuthzDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckBatch indicates an expected call of CheckBatch.
+func (mr *MockIAuthzServiceMockRecorder) CheckBatch(ctx, checks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckBatch", reflect.TypeOf((*MockIAuthzService)(nil).CheckBatch), ctx, checks)
+}
@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/grant_reaper.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIGrantReaper is a mock of IGrantReaper interface.
+type MockIGrantReaper struct {
+	ctrl     *gomock.Controller
+	recorder *MockIGrantReaperMockRecorder
+}
+
+// MockIGrantReaperMockRecorder is the mock recorder for MockIGrantReaper.
+type MockIGrantReaperMockRecorder struct {
+	mock *MockIGrantReaper
+}
+
+// NewMockIGrantReaper creates a new mock instance.
+func NewMockIGrantReaper(ctrl *gomock.Controller) *MockIGrantReaper {
+	mock := &MockIGrantReaper{ctrl: ctrl}
+	mock.recorder = &MockIGrantReaperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIGrantReaper) EXPECT() *MockIGrantReaperMockRecorder {
+	return m.recorder
+}
+
+// Reap mocks base method.
+func (m *MockIGrantReaper) Reap(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reap", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reap indicates an expected call of Reap.
+func (mr *MockIGrantReaperMockRecorder) Reap(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reap", reflect.TypeOf((*MockIGrantReaper)(nil).Reap), ctx)
+}
+
+// Run mocks base method.
+func (m *MockIGrantReaper) Run(ctx context.Context, interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx, interval)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIGrantReaperMockRecorder) Run(ctx, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIGrantReaper)(nil).Run), ctx, interval)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/group.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
+	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+// MockIGroupService is a mock of IGroupService interface.
+type MockIGroupService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIGroupServiceMockRecorder
+}
+
+// MockIGroupServiceMockRecorder is the mock recorder for MockIGroupService.
+type MockIGroupServiceMockRecorder struct {
+	mock *MockIGroupService
+}
+
+// NewMockIGroupService creates a new mock instance.
+func NewMockIGroupService(ctrl *gomock.Controller) *MockIGroupService {
+	mock := &MockIGroupService{ctrl: ctrl}
+	mock.recorder = &MockIGroupServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIGroupService) EXPECT() *MockIGroupServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIGroupService) Create(ctx context.Context, groupName, parentName string, scopes []*entities.UserScope) (*entities.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, groupName, parentName, scopes)
+	ret0, _ := ret[0].(*entities.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIGroupServiceMockRecorder) Create(ctx, groupName, parentName, scopes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIGroupService)(nil).Create), ctx, groupName, parentName, scopes)
+}
+
+// Delete mocks base method.
+func (m *MockIGroupService) Delete(ctx context.Context, groupName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, groupName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIGroupServiceMockRecorder) Delete(ctx, groupName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIGroupService)(nil).Delete), ctx, groupName)
+}
+
+// FindAll mocks base method.
+func (m *MockIGroupService) FindAll(ctx context.Context, query dto.ListGroupsRequest) ([]*entities.Group, *dto.Paging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*entities.Group)
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIGroupServiceMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIGroupService)(nil).FindAll), ctx, query)
+}
+
+// FindOne mocks base method.
+func (m *MockIGroupService) FindOne(ctx context.Context, groupName string) (*entities.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, groupName)
+	ret0, _ := ret[0].(*entities.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockIGroupServiceMockRecorder) FindOne(ctx, groupName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockIGroupService)(nil).FindOne), ctx, groupName)
+}
+
+// UpdateMember mocks base method.
+func (m *MockIGroupService) UpdateMember(ctx context.Context, groupName string, user *entities.User, isAdded bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMember", ctx, groupName, user, isAdded)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMember indicates an expected call of UpdateMember.
+func (mr *MockIGroupServiceMockRecorder) UpdateMember(ctx, groupName, user, isAdded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMember", reflect.TypeOf((*MockIGroupService)(nil).UpdateMember), ctx, groupName, user, isAdded)
+}
+
+// UpdateParent mocks base method.
+func (m *MockIGroupService) UpdateParent(ctx context.Context, groupName, parentName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateParent", ctx, groupName, parentName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateParent indicates an expected call of UpdateParent.
+func (mr *MockIGroupServiceMockRecorder) UpdateParent(ctx, groupName, parentName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateParent", reflect.TypeOf((*MockIGroupService)(nil).UpdateParent), ctx, groupName, parentName)
+}
+
+// UpdateScope mocks base method.
+func (m *MockIGroupService) UpdateScope(ctx context.Context, groupName string, scope *entities.UserScope, isAdded bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScope", ctx, groupName, scope, isAdded)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScope indicates an expected call of UpdateScope.
+func (mr *MockIGroupServiceMockRecorder) UpdateScope(ctx, groupName, scope, isAdded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScope", reflect.TypeOf((*MockIGroupService)(nil).UpdateScope), ctx, groupName, scope, isAdded)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/introspection.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
+)
+
+// MockIIntrospectionService is a mock of IIntrospectionService interface.
+type MockIIntrospectionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIntrospectionServiceMockRecorder
+}
+
+// MockIIntrospectionServiceMockRecorder is the mock recorder for MockIIntrospectionService.
+type MockIIntrospectionServiceMockRecorder struct {
+	mock *MockIIntrospectionService
+}
+
+// NewMockIIntrospectionService creates a new mock instance.
+func NewMockIIntrospectionService(ctrl *gomock.Controller) *MockIIntrospectionService {
+	mock := &MockIIntrospectionService{ctrl: ctrl}
+	mock.recorder = &MockIIntrospectionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIntrospectionService) EXPECT() *MockIIntrospectionServiceMockRecorder {
+	return m.recorder
+}
+
+// Introspect mocks base method.
+func (m *MockIIntrospectionService) Introspect(ctx context.Context, token string) (*dto.IntrospectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Introspect", ctx, token)
+	ret0, _ := ret[0].(*dto.IntrospectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Introspect indicates an expected call of Introspect.
+func (mr *MockIIntrospectionServiceMockRecorder) Introspect(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Introspect", reflect.TypeOf((*MockIIntrospectionService)(nil).Introspect), ctx, token)
+}
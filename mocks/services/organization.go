@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/organization.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
+	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+// MockIOrganizationService is a mock of IOrganizationService interface.
+type MockIOrganizationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOrganizationServiceMockRecorder
+}
+
+// MockIOrganizationServiceMockRecorder is the mock recorder for MockIOrganizationService.
+type MockIOrganizationServiceMockRecorder struct {
+	mock *MockIOrganizationService
+}
+
+// NewMockIOrganizationService creates a new mock instance.
+func NewMockIOrganizationService(ctrl *gomock.Controller) *MockIOrganizationService {
+	mock := &MockIOrganizationService{ctrl: ctrl}
+	mock.recorder = &MockIOrganizationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOrganizationService) EXPECT() *MockIOrganizationServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIOrganizationService) Create(ctx context.Context, organizationId, name string) (*entities.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, organizationId, name)
+	ret0, _ := ret[0].(*entities.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIOrganizationServiceMockRecorder) Create(ctx, organizationId, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIOrganizationService)(nil).Create), ctx, organizationId, name)
+}
+
+// FindAll mocks base method.
+func (m *MockIOrganizationService) FindAll(ctx context.Context, query dto.ListOrganizationsRequest) ([]*entities.Organization, *dto.Paging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*entities.Organization)
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIOrganizationServiceMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIOrganizationService)(nil).FindAll), ctx, query)
+}
+
+// FindOne mocks base method.
+func (m *MockIOrganizationService) FindOne(ctx context.Context, organizationId string) (*entities.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, organizationId)
+	ret0, _ := ret[0].(*entities.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockIOrganizationServiceMockRecorder) FindOne(ctx, organizationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockIOrganizationService)(nil).FindOne), ctx, organizationId)
+}
@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/outbox_relay.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIOutboxRelay is a mock of IOutboxRelay interface.
+type MockIOutboxRelay struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOutboxRelayMockRecorder
+}
+
+// MockIOutboxRelayMockRecorder is the mock recorder for MockIOutboxRelay.
+type MockIOutboxRelayMockRecorder struct {
+	mock *MockIOutboxRelay
+}
+
+// NewMockIOutboxRelay creates a new mock instance.
+func NewMockIOutboxRelay(ctrl *gomock.Controller) *MockIOutboxRelay {
+	mock := &MockIOutboxRelay{ctrl: ctrl}
+	mock.recorder = &MockIOutboxRelayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOutboxRelay) EXPECT() *MockIOutboxRelayMockRecorder {
+	return m.recorder
+}
+
+// Relay mocks base method.
+func (m *MockIOutboxRelay) Relay(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Relay", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Relay indicates an expected call of Relay.
+func (mr *MockIOutboxRelayMockRecorder) Relay(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Relay", reflect.TypeOf((*MockIOutboxRelay)(nil).Relay), ctx)
+}
+
+// Run mocks base method.
+func (m *MockIOutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx, interval)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIOutboxRelayMockRecorder) Run(ctx, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIOutboxRelay)(nil).Run), ctx, interval)
+}
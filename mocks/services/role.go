@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/role.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
+	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+// MockIRoleService is a mock of IRoleService interface.
+type MockIRoleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRoleServiceMockRecorder
+}
+
+// MockIRoleServiceMockRecorder is the mock recorder for MockIRoleService.
+type MockIRoleServiceMockRecorder struct {
+	mock *MockIRoleService
+}
+
+// NewMockIRoleService creates a new mock instance.
+func NewMockIRoleService(ctrl *gomock.Controller) *MockIRoleService {
+	mock := &MockIRoleService{ctrl: ctrl}
+	mock.recorder = &MockIRoleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRoleService) EXPECT() *MockIRoleServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIRoleService) Create(ctx context.Context, roleName string, scopes []*entities.UserScope) (*entities.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, roleName, scopes)
+	ret0, _ := ret[0].(*entities.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIRoleServiceMockRecorder) Create(ctx, roleName, scopes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIRoleService)(nil).Create), ctx, roleName, scopes)
+}
+
+// Delete mocks base method.
+func (m *MockIRoleService) Delete(ctx context.Context, roleName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, roleName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIRoleServiceMockRecorder) Delete(ctx, roleName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIRoleService)(nil).Delete), ctx, roleName)
+}
+
+// FindAll mocks base method.
+func (m *MockIRoleService) FindAll(ctx context.Context, query dto.ListRolesRequest) ([]*entities.Role, *dto.Paging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*entities.Role)
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIRoleServiceMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIRoleService)(nil).FindAll), ctx, query)
+}
+
+// FindMany mocks base method.
+func (m *MockIRoleService) FindMany(ctx context.Context, roleNames []string) ([]*entities.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMany", ctx, roleNames)
+	ret0, _ := ret[0].([]*entities.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMany indicates an expected call of FindMany.
+func (mr *MockIRoleServiceMockRecorder) FindMany(ctx, roleNames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMany", reflect.TypeOf((*MockIRoleService)(nil).FindMany), ctx, roleNames)
+}
+
+// FindOne mocks base method.
+func (m *MockIRoleService) FindOne(ctx context.Context, roleName string) (*entities.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, roleName)
+	ret0, _ := ret[0].(*entities.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockIRoleServiceMockRecorder) FindOne(ctx, roleName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockIRoleService)(nil).FindOne), ctx, roleName)
+}
+
+// UpdateScope mocks base method.
+func (m *MockIRoleService) UpdateScope(ctx context.Context, roleName string, scope *entities.UserScope, isAdded bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScope", ctx, roleName, scope, isAdded)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScope indicates an expected call of UpdateScope.
+func (mr *MockIRoleServiceMockRecorder) UpdateScope(ctx, roleName, scope, isAdded interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScope", reflect.TypeOf((*MockIRoleService)(nil).UpdateScope), ctx, roleName, scope, isAdded)
+}
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
 	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
 )
 
@@ -64,19 +65,35 @@ func (mr *MockIScopeServiceMockRecorder) Delete(ctx, scopeName interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIScopeService)(nil).Delete), ctx, scopeName)
 }
 
-// FindAll mocks base method.
-func (m *MockIScopeService) FindAll(ctx context.Context) ([]*entities.UserScope, error) {
+// Expand mocks base method.
+func (m *MockIScopeService) Expand(ctx context.Context, pattern string) ([]*entities.UserScope, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret := m.ctrl.Call(m, "Expand", ctx, pattern)
 	ret0, _ := ret[0].([]*entities.UserScope)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// Expand indicates an expected call of Expand.
+func (mr *MockIScopeServiceMockRecorder) Expand(ctx, pattern interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expand", reflect.TypeOf((*MockIScopeService)(nil).Expand), ctx, pattern)
+}
+
+// FindAll mocks base method.
+func (m *MockIScopeService) FindAll(ctx context.Context, query dto.ListScopesRequest) ([]*entities.UserScope, *dto.Paging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*entities.UserScope)
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // FindAll indicates an expected call of FindAll.
-func (mr *MockIScopeServiceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+func (mr *MockIScopeServiceMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIScopeService)(nil).FindAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIScopeService)(nil).FindAll), ctx, query)
 }
 
 // FindMany mocks base method.
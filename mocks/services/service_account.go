@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/service_account.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
+	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+// MockIServiceAccountService is a mock of IServiceAccountService interface.
+type MockIServiceAccountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIServiceAccountServiceMockRecorder
+}
+
+// MockIServiceAccountServiceMockRecorder is the mock recorder for MockIServiceAccountService.
+type MockIServiceAccountServiceMockRecorder struct {
+	mock *MockIServiceAccountService
+}
+
+// NewMockIServiceAccountService creates a new mock instance.
+func NewMockIServiceAccountService(ctrl *gomock.Controller) *MockIServiceAccountService {
+	mock := &MockIServiceAccountService{ctrl: ctrl}
+	mock.recorder = &MockIServiceAccountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIServiceAccountService) EXPECT() *MockIServiceAccountServiceMockRecorder {
+	return m.recorder
+}
+
+// AddSecret mocks base method.
+func (m *MockIServiceAccountService) AddSecret(ctx context.Context, accountId, createdBy string) (*entities.ServiceAccountSecret, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSecret", ctx, accountId, createdBy)
+	ret0, _ := ret[0].(*entities.ServiceAccountSecret)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddSecret indicates an expected call of AddSecret.
+func (mr *MockIServiceAccountServiceMockRecorder) AddSecret(ctx, accountId, createdBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSecret", reflect.TypeOf((*MockIServiceAccountService)(nil).AddSecret), ctx, accountId, createdBy)
+}
+
+// Create mocks base method.
+func (m *MockIServiceAccountService) Create(ctx context.Context, name, ownerId string, scopes []*entities.UserScope, expiresAt *time.Time, granted []string, createdBy string) (*entities.ServiceAccount, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, name, ownerId, scopes, expiresAt, granted, createdBy)
+	ret0, _ := ret[0].(*entities.ServiceAccount)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIServiceAccountServiceMockRecorder) Create(ctx, name, ownerId, scopes, expiresAt, granted, createdBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIServiceAccountService)(nil).Create), ctx, name, ownerId, scopes, expiresAt, granted, createdBy)
+}
+
+// Delete mocks base method.
+func (m *MockIServiceAccountService) Delete(ctx context.Context, accountId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, accountId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIServiceAccountServiceMockRecorder) Delete(ctx, accountId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIServiceAccountService)(nil).Delete), ctx, accountId)
+}
+
+// DeleteSecret mocks base method.
+func (m *MockIServiceAccountService) DeleteSecret(ctx context.Context, accountId string, secretId uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", ctx, accountId, secretId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret.
+func (mr *MockIServiceAccountServiceMockRecorder) DeleteSecret(ctx, accountId, secretId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockIServiceAccountService)(nil).DeleteSecret), ctx, accountId, secretId)
+}
+
+// FindAll mocks base method.
+func (m *MockIServiceAccountService) FindAll(ctx context.Context, query dto.ListServiceAccountsRequest) ([]*entities.ServiceAccount, *dto.Paging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*entities.ServiceAccount)
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIServiceAccountServiceMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIServiceAccountService)(nil).FindAll), ctx, query)
+}
+
+// FindById mocks base method.
+func (m *MockIServiceAccountService) FindById(ctx context.Context, accountId string) (*entities.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, accountId)
+	ret0, _ := ret[0].(*entities.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockIServiceAccountServiceMockRecorder) FindById(ctx, accountId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIServiceAccountService)(nil).FindById), ctx, accountId)
+}
+
+// IssueToken mocks base method.
+func (m *MockIServiceAccountService) IssueToken(ctx context.Context, clientId, clientSecret string, requested []string) (*dto.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueToken", ctx, clientId, clientSecret, requested)
+	ret0, _ := ret[0].(*dto.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueToken indicates an expected call of IssueToken.
+func (mr *MockIServiceAccountServiceMockRecorder) IssueToken(ctx, clientId, clientSecret, requested interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueToken", reflect.TypeOf((*MockIServiceAccountService)(nil).IssueToken), ctx, clientId, clientSecret, requested)
+}
+
+// Update mocks base method.
+func (m *MockIServiceAccountService) Update(ctx context.Context, req dto.UpdateServiceAccountRequest) (*entities.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, req)
+	ret0, _ := ret[0].(*entities.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIServiceAccountServiceMockRecorder) Update(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIServiceAccountService)(nil).Update), ctx, req)
+}
+
+// UpdateScope mocks base method.
+func (m *MockIServiceAccountService) UpdateScope(ctx context.Context, accountId string, scope *entities.UserScope, isAdded bool, granted []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScope", ctx, accountId, scope, isAdded, granted)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScope indicates an expected call of UpdateScope.
+func (mr *MockIServiceAccountServiceMockRecorder) UpdateScope(ctx, accountId, scope, isAdded, granted interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScope", reflect.TypeOf((*MockIServiceAccountService)(nil).UpdateScope), ctx, accountId, scope, isAdded, granted)
+}
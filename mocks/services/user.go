@@ -7,8 +7,10 @@ package services
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-user-management-service/dto"
 	entities "github.com/vnFuhung2903/vcs-user-management-service/entities"
 )
 
@@ -35,19 +37,33 @@ func (m *MockIUserService) EXPECT() *MockIUserServiceMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockIUserService) ChangePassword(ctx context.Context, userId, currentPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userId, currentPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockIUserServiceMockRecorder) ChangePassword(ctx, userId, currentPassword, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockIUserService)(nil).ChangePassword), ctx, userId, currentPassword, newPassword)
+}
+
 // Create mocks base method.
-func (m *MockIUserService) Create(username, password, email string, scopes []*entities.UserScope) (*entities.User, error) {
+func (m *MockIUserService) Create(ctx context.Context, username, password, email string, scopes []*entities.UserScope, expiries map[string]time.Time, roles []*entities.Role) (*entities.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", username, password, email, scopes)
+	ret := m.ctrl.Call(m, "Create", ctx, username, password, email, scopes, expiries, roles)
 	ret0, _ := ret[0].(*entities.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIUserServiceMockRecorder) Create(username, password, email, scopes interface{}) *gomock.Call {
+func (mr *MockIUserServiceMockRecorder) Create(ctx, username, password, email, scopes, expiries, roles interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserService)(nil).Create), username, password, email, scopes)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserService)(nil).Create), ctx, username, password, email, scopes, expiries, roles)
 }
 
 // Delete mocks base method.
@@ -64,31 +80,90 @@ func (mr *MockIUserServiceMockRecorder) Delete(ctx, userId interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIUserService)(nil).Delete), ctx, userId)
 }
 
+// ExplainScope mocks base method.
+func (m *MockIUserService) ExplainScope(ctx context.Context, userId, scope string) ([]entities.ScopeGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainScope", ctx, userId, scope)
+	ret0, _ := ret[0].([]entities.ScopeGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainScope indicates an expected call of ExplainScope.
+func (mr *MockIUserServiceMockRecorder) ExplainScope(ctx, userId, scope interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainScope", reflect.TypeOf((*MockIUserService)(nil).ExplainScope), ctx, userId, scope)
+}
+
 // FindAll mocks base method.
-func (m *MockIUserService) FindAll(ctx context.Context) ([]*entities.User, error) {
+func (m *MockIUserService) FindAll(ctx context.Context, query dto.ListUsersRequest) ([]*entities.User, *dto.Paging, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
 	ret0, _ := ret[0].([]*entities.User)
+	ret1, _ := ret[1].(*dto.Paging)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIUserServiceMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIUserService)(nil).FindAll), ctx, query)
+}
+
+// FindById mocks base method.
+func (m *MockIUserService) FindById(ctx context.Context, userId string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, userId)
+	ret0, _ := ret[0].(*entities.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAll indicates an expected call of FindAll.
-func (mr *MockIUserServiceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+// FindById indicates an expected call of FindById.
+func (mr *MockIUserServiceMockRecorder) FindById(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIUserService)(nil).FindById), ctx, userId)
+}
+
+// Logout mocks base method.
+func (m *MockIUserService) Logout(ctx context.Context, userId, tokenId, sessionId string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, userId, tokenId, sessionId, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockIUserServiceMockRecorder) Logout(ctx, userId, tokenId, sessionId, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockIUserService)(nil).Logout), ctx, userId, tokenId, sessionId, expiresAt)
+}
+
+// UpdateRole mocks base method.
+func (m *MockIUserService) UpdateRole(ctx context.Context, userId string, role *entities.Role, isAdded bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, userId, role, isAdded)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockIUserServiceMockRecorder) UpdateRole(ctx, userId, role, isAdded interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIUserService)(nil).FindAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockIUserService)(nil).UpdateRole), ctx, userId, role, isAdded)
 }
 
 // UpdateScope mocks base method.
-func (m *MockIUserService) UpdateScope(ctx context.Context, userId string, scope *entities.UserScope, isAdded bool) error {
+func (m *MockIUserService) UpdateScope(ctx context.Context, userId string, scope *entities.UserScope, isAdded bool, expiresAt *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateScope", ctx, userId, scope, isAdded)
+	ret := m.ctrl.Call(m, "UpdateScope", ctx, userId, scope, isAdded, expiresAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateScope indicates an expected call of UpdateScope.
-func (mr *MockIUserServiceMockRecorder) UpdateScope(ctx, userId, scope, isAdded interface{}) *gomock.Call {
+func (mr *MockIUserServiceMockRecorder) UpdateScope(ctx, userId, scope, isAdded, expiresAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScope", reflect.TypeOf((*MockIUserService)(nil).UpdateScope), ctx, userId, scope, isAdded)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScope", reflect.TypeOf((*MockIUserService)(nil).UpdateScope), ctx, userId, scope, isAdded, expiresAt)
 }
@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/webhook_sender.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIWebhookSender is a mock of IWebhookSender interface.
+type MockIWebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookSenderMockRecorder
+}
+
+// MockIWebhookSenderMockRecorder is the mock recorder for MockIWebhookSender.
+type MockIWebhookSenderMockRecorder struct {
+	mock *MockIWebhookSender
+}
+
+// NewMockIWebhookSender creates a new mock instance.
+func NewMockIWebhookSender(ctrl *gomock.Controller) *MockIWebhookSender {
+	mock := &MockIWebhookSender{ctrl: ctrl}
+	mock.recorder = &MockIWebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookSender) EXPECT() *MockIWebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockIWebhookSender) Run(ctx context.Context, interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx, interval)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIWebhookSenderMockRecorder) Run(ctx, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIWebhookSender)(nil).Run), ctx, interval)
+}
+
+// Send mocks base method.
+func (m *MockIWebhookSender) Send(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockIWebhookSenderMockRecorder) Send(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockIWebhookSender)(nil).Send), ctx)
+}
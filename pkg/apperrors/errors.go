@@ -0,0 +1,89 @@
+package apperrors
+
+import "errors"
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindBadRequest
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindForbidden
+	KindUnauthorized
+)
+
+// Error is the domain error returned by services. Code is one of the
+// dto.Code* constants and is rendered unchanged into dto.APIResponse.Code.
+// Details, when set, is rendered into dto.APIResponse.Details.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Details interface{}
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) WithDetails(details interface{}) *Error {
+	e.Details = details
+	return e
+}
+
+func New(kind Kind, code, message string, err error) *Error {
+	return &Error{
+		Kind:    kind,
+		Code:    code,
+		Message: message,
+		Err:     err,
+	}
+}
+
+func BadRequest(code, message string, err error) *Error {
+	return New(KindBadRequest, code, message, err)
+}
+
+func NotFound(code, message string, err error) *Error {
+	return New(KindNotFound, code, message, err)
+}
+
+func Conflict(code, message string, err error) *Error {
+	return New(KindConflict, code, message, err)
+}
+
+func Validation(code, message string, err error) *Error {
+	return New(KindValidation, code, message, err)
+}
+
+func Forbidden(code, message string, err error) *Error {
+	return New(KindForbidden, code, message, err)
+}
+
+func Unauthorized(code, message string, err error) *Error {
+	return New(KindUnauthorized, code, message, err)
+}
+
+// As returns the first domain error in err's chain.
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+func IsKind(err error, kind Kind) bool {
+	appErr, ok := As(err)
+	return ok && appErr.Kind == kind
+}
@@ -0,0 +1,47 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorMessage(t *testing.T) {
+	err := NotFound("USER_NOT_FOUND", "user not found", errors.New("record not found"))
+	assert.Equal(t, "user not found: record not found", err.Error())
+
+	err = Forbidden("FORBIDDEN", "not allowed", nil)
+	assert.Equal(t, "not allowed", err.Error())
+}
+
+func TestConstructorsSetKind(t *testing.T) {
+	assert.Equal(t, KindBadRequest, BadRequest("C", "m", nil).Kind)
+	assert.Equal(t, KindNotFound, NotFound("C", "m", nil).Kind)
+	assert.Equal(t, KindConflict, Conflict("C", "m", nil).Kind)
+	assert.Equal(t, KindValidation, Validation("C", "m", nil).Kind)
+	assert.Equal(t, KindForbidden, Forbidden("C", "m", nil).Kind)
+	assert.Equal(t, KindUnauthorized, Unauthorized("C", "m", nil).Kind)
+}
+
+func TestWithDetails(t *testing.T) {
+	err := Validation("WEAK_PASSWORD", "weak password", nil).WithDetails([]string{"min_length"})
+	assert.Equal(t, KindValidation, err.Kind)
+	assert.Equal(t, []string{"min_length"}, err.Details)
+}
+
+func TestAsUnwrapsChain(t *testing.T) {
+	cause := errors.New("duplicated key")
+	wrapped := fmt.Errorf("create user: %w", Conflict("USER_ALREADY_EXISTS", "user already exists", cause))
+
+	appErr, ok := As(wrapped)
+	assert.True(t, ok)
+	assert.Equal(t, "USER_ALREADY_EXISTS", appErr.Code)
+	assert.True(t, errors.Is(wrapped, cause))
+	assert.True(t, IsKind(wrapped, KindConflict))
+	assert.False(t, IsKind(wrapped, KindNotFound))
+
+	_, ok = As(cause)
+	assert.False(t, ok)
+}
@@ -0,0 +1,40 @@
+// Package audit carries the origin of a request, meaning who made it, its
+// request ID and the address it came from, to the services that record
+// audit entries. The request middleware stores an Origin in the request
+// context and the JWT middleware adds the actor. Contexts without an Origin,
+// such as background jobs, record changes with no actor.
+package audit
+
+import "context"
+
+// RequestIDHeader carries the request ID in both directions. A caller may
+// supply its own ID to correlate the audit log with its logs.
+const RequestIDHeader = "X-Request-ID"
+
+type Origin struct {
+	ActorID   string
+	RequestID string
+	SourceIP  string
+}
+
+type contextKey struct{}
+
+func WithOrigin(ctx context.Context, origin Origin) context.Context {
+	return context.WithValue(ctx, contextKey{}, origin)
+}
+
+func FromContext(ctx context.Context) (Origin, bool) {
+	if ctx == nil {
+		return Origin{}, false
+	}
+	origin, ok := ctx.Value(contextKey{}).(Origin)
+	return origin, ok
+}
+
+// WithActor records actorId as the origin's actor, keeping the request ID and
+// source IP already in ctx.
+func WithActor(ctx context.Context, actorId string) context.Context {
+	origin, _ := FromContext(ctx)
+	origin.ActorID = actorId
+	return WithOrigin(ctx, origin)
+}
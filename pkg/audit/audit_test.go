@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	origin := Origin{RequestID: "req-1", SourceIP: "10.0.0.1"}
+	found, ok := FromContext(WithOrigin(context.Background(), origin))
+	assert.True(t, ok)
+	assert.Equal(t, origin, found)
+}
+
+func TestWithActor(t *testing.T) {
+	ctx := WithOrigin(context.Background(), Origin{RequestID: "req-1", SourceIP: "10.0.0.1"})
+
+	found, ok := FromContext(WithActor(ctx, "alice"))
+	assert.True(t, ok)
+	assert.Equal(t, Origin{ActorID: "alice", RequestID: "req-1", SourceIP: "10.0.0.1"}, found)
+
+	found, ok = FromContext(WithActor(context.Background(), "alice"))
+	assert.True(t, ok)
+	assert.Equal(t, Origin{ActorID: "alice"}, found)
+}
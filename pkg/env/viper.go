@@ -2,12 +2,35 @@ package env
 
 import (
 	"errors"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+// AuthEnv configures token verification. JWTSecret enables HMAC tokens, and
+// JWKSURL or JWKSFile enables asymmetric tokens; both modes may be active at
+// once. An empty JWTAlgorithms allows HS256 with a secret and RS256, ES256 and
+// EdDSA with a key set. An empty JWTIssuer or JWTAudiences disables that check,
+// and a zero JWTMaxTokenAge disables the age limit. Callers from
+// PlatformOrganization holding organization:manage are super-admins; an empty
+// PlatformOrganization disables super-admins. Service account tokens are
+// signed HS256 with JWTSecret, so a JWTSecret needs JWTAlgorithms to be empty
+// or to include HS256, or the service would refuse the tokens it issues.
 type AuthEnv struct {
-	JWTSecret string
+	JWTSecret            string
+	JWKSURL              string
+	JWKSFile             string
+	JWKSRefreshInterval  time.Duration
+	JWTAlgorithms        []string
+	JWTIssuer            string
+	JWTAudiences         []string
+	JWTRequireExpiry     bool
+	JWTMaxTokenAge       time.Duration
+	JWTClockSkew         time.Duration
+	PlatformOrganization string
 }
 
 type PostgresEnv struct {
@@ -32,17 +55,135 @@ type LoggerEnv struct {
 	MaxBackups int
 }
 
+type PasswordPolicyEnv struct {
+	MinLength          int
+	MaxLength          int
+	RequireUpper       bool
+	RequireLower       bool
+	RequireDigit       bool
+	RequireSymbol      bool
+	ForbidPersonalInfo bool
+	BreachedListPath   string
+	HistorySize        int
+}
+
+type PasswordHashEnv struct {
+	Algorithm         string
+	BcryptCost        int
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	Argon2SaltLength  uint32
+	Argon2KeyLength   uint32
+}
+
+// AccessRequestEnv configures the access request workflow. TTL is how long a
+// request may stay pending before it expires.
+type AccessRequestEnv struct {
+	TTL time.Duration
+}
+
+// EventsEnv configures domain event publishing. Stream is the Redis stream
+// events are added to, trimmed to about StreamMaxLen entries. The relay
+// publishes up to RelayBatchSize outbox events per pass and deletes
+// published ones after OutboxRetention. ConsumerName names this replica in
+// the stream's consumer groups and should survive restarts, which the
+// hostname of a pod does not; entries a replica read but never acknowledged
+// are claimed by another once they have been idle for ClaimIdle.
+type EventsEnv struct {
+	Stream          string
+	StreamMaxLen    int64
+	RelayBatchSize  int
+	OutboxRetention time.Duration
+	ConsumerName    string
+	ClaimIdle       time.Duration
+}
+
+// RevocationEnv configures access-token revocation. Retention is how long a
+// user's revocation cutoff is kept and must outlast the longest-lived access
+// token. Lookups are cached in each replica for CacheTTL, which bounds how
+// long another replica may still accept a revoked token; zero disables the
+// cache.
+type RevocationEnv struct {
+	CacheTTL  time.Duration
+	Retention time.Duration
+}
+
+// AuthzEnv configures the authorization decision endpoint. Effective scopes
+// are cached in Redis for CacheTTL and dropped whenever they change, so the
+// TTL only bounds how long a missed invalidation can linger; zero disables
+// the cache.
+type AuthzEnv struct {
+	CacheTTL time.Duration
+}
+
+// ServiceAccountEnv configures service accounts. Their client_credentials
+// tokens live for TokenTTL, and each account holds at most MaxSecrets client
+// secrets at once, enough to rotate one without downtime.
+type ServiceAccountEnv struct {
+	TokenTTL   time.Duration
+	MaxSecrets int
+}
+
+// WebhookEnv configures outbound webhook delivery. Each request is bounded by
+// Timeout. A failed delivery is retried after BackoffBase, doubling per
+// attempt up to BackoffMax, and given up after MaxAttempts. An endpoint is
+// disabled after DisableAfter consecutive failed attempts. The sender
+// handles up to BatchSize due deliveries per pass.
+type WebhookEnv struct {
+	Timeout      time.Duration
+	MaxAttempts  int
+	BackoffBase  time.Duration
+	BackoffMax   time.Duration
+	DisableAfter int
+	BatchSize    int
+}
+
+// WorkerEnv configures the background jobs. GrantReapInterval is how often
+// expired scope grants are removed, AccessRequestReapInterval how often
+// stale access requests are expired, OutboxRelayInterval how often pending
+// domain events are published, and WebhookSendInterval how often due
+// webhook deliveries are sent.
+type WorkerEnv struct {
+	GrantReapInterval         time.Duration
+	AccessRequestReapInterval time.Duration
+	OutboxRelayInterval       time.Duration
+	WebhookSendInterval       time.Duration
+}
+
+// ServerEnv configures the HTTP server. TrustedProxies lists the addresses
+// and CIDR ranges of the proxies whose X-Forwarded-For header is believed;
+// when empty the client address is always the peer address.
+type ServerEnv struct {
+	TrustedProxies []string
+}
+
 type Env struct {
-	AuthEnv     AuthEnv
-	PostgresEnv PostgresEnv
-	RedisEnv    RedisEnv
-	LoggerEnv   LoggerEnv
+	ServerEnv         ServerEnv
+	AuthEnv           AuthEnv
+	PostgresEnv       PostgresEnv
+	RedisEnv          RedisEnv
+	LoggerEnv         LoggerEnv
+	PasswordPolicyEnv PasswordPolicyEnv
+	PasswordHashEnv   PasswordHashEnv
+	AccessRequestEnv  AccessRequestEnv
+	EventsEnv         EventsEnv
+	RevocationEnv     RevocationEnv
+	AuthzEnv          AuthzEnv
+	ServiceAccountEnv ServiceAccountEnv
+	WebhookEnv        WebhookEnv
+	WorkerEnv         WorkerEnv
 }
 
 func LoadEnv() (*Env, error) {
 	v := viper.New()
 	v.AutomaticEnv()
 
+	v.SetDefault("JWT_JWKS_REFRESH_INTERVAL", "5m")
+	v.SetDefault("JWT_REQUIRE_EXPIRY", true)
+	v.SetDefault("JWT_MAX_TOKEN_AGE", "0s")
+	v.SetDefault("JWT_CLOCK_SKEW", "30s")
+	v.SetDefault("PLATFORM_ORGANIZATION", "default")
 	v.SetDefault("POSTGRES_HOST", "localhost")
 	v.SetDefault("POSTGRES_USER", "postgres")
 	v.SetDefault("POSTGRES_PASSWORD", "postgres")
@@ -56,13 +197,70 @@ func LoadEnv() (*Env, error) {
 	v.SetDefault("ZAP_MAXSIZE", 100)
 	v.SetDefault("ZAP_MAXAGE", 10)
 	v.SetDefault("ZAP_MAXBACKUPS", 30)
+	v.SetDefault("PASSWORD_MIN_LENGTH", 8)
+	v.SetDefault("PASSWORD_MAX_LENGTH", 72)
+	v.SetDefault("PASSWORD_REQUIRE_UPPER", true)
+	v.SetDefault("PASSWORD_REQUIRE_LOWER", true)
+	v.SetDefault("PASSWORD_REQUIRE_DIGIT", true)
+	v.SetDefault("PASSWORD_REQUIRE_SYMBOL", false)
+	v.SetDefault("PASSWORD_FORBID_PERSONAL_INFO", true)
+	v.SetDefault("PASSWORD_BREACHED_LIST_PATH", "")
+	v.SetDefault("PASSWORD_HISTORY_SIZE", 5)
+	v.SetDefault("PASSWORD_HASH_ALGORITHM", "argon2id")
+	v.SetDefault("PASSWORD_BCRYPT_COST", 10)
+	v.SetDefault("PASSWORD_ARGON2_MEMORY", 64*1024)
+	v.SetDefault("PASSWORD_ARGON2_ITERATIONS", 3)
+	v.SetDefault("PASSWORD_ARGON2_PARALLELISM", 2)
+	v.SetDefault("PASSWORD_ARGON2_SALT_LENGTH", 16)
+	v.SetDefault("PASSWORD_ARGON2_KEY_LENGTH", 32)
+	v.SetDefault("ACCESS_REQUEST_TTL", "168h")
+	v.SetDefault("GRANT_REAP_INTERVAL", "1m")
+	v.SetDefault("ACCESS_REQUEST_REAP_INTERVAL", "5m")
+	v.SetDefault("EVENTS_STREAM", "vcs:user-management:events")
+	v.SetDefault("EVENTS_STREAM_MAXLEN", 100000)
+	v.SetDefault("OUTBOX_RELAY_BATCH_SIZE", 100)
+	v.SetDefault("OUTBOX_RETENTION", "168h")
+	v.SetDefault("OUTBOX_RELAY_INTERVAL", "1s")
+	v.SetDefault("EVENTS_CLAIM_IDLE", "1m")
+	v.SetDefault("REVOCATION_CACHE_TTL", "5s")
+	v.SetDefault("REVOCATION_RETENTION", "24h")
+	v.SetDefault("AUTHZ_CACHE_TTL", "5m")
+	v.SetDefault("SERVICE_ACCOUNT_TOKEN_TTL", "15m")
+	v.SetDefault("SERVICE_ACCOUNT_MAX_SECRETS", 3)
+	v.SetDefault("WEBHOOK_TIMEOUT", "10s")
+	v.SetDefault("WEBHOOK_MAX_ATTEMPTS", 8)
+	v.SetDefault("WEBHOOK_BACKOFF_BASE", "30s")
+	v.SetDefault("WEBHOOK_BACKOFF_MAX", "1h")
+	v.SetDefault("WEBHOOK_DISABLE_AFTER", 20)
+	v.SetDefault("WEBHOOK_BATCH_SIZE", 50)
+	v.SetDefault("WEBHOOK_SEND_INTERVAL", "5s")
+
+	serverEnv := ServerEnv{
+		TrustedProxies: splitList(v.GetString("TRUSTED_PROXIES")),
+	}
 
 	authEnv := AuthEnv{
-		JWTSecret: v.GetString("JWT_SECRET_KEY"),
+		JWTSecret:            v.GetString("JWT_SECRET_KEY"),
+		JWKSURL:              v.GetString("JWT_JWKS_URL"),
+		JWKSFile:             v.GetString("JWT_JWKS_FILE"),
+		JWKSRefreshInterval:  v.GetDuration("JWT_JWKS_REFRESH_INTERVAL"),
+		JWTAlgorithms:        splitList(v.GetString("JWT_ALGORITHMS")),
+		JWTIssuer:            v.GetString("JWT_ISSUER"),
+		JWTAudiences:         splitList(v.GetString("JWT_AUDIENCES")),
+		JWTRequireExpiry:     v.GetBool("JWT_REQUIRE_EXPIRY"),
+		JWTMaxTokenAge:       v.GetDuration("JWT_MAX_TOKEN_AGE"),
+		JWTClockSkew:         v.GetDuration("JWT_CLOCK_SKEW"),
+		PlatformOrganization: v.GetString("PLATFORM_ORGANIZATION"),
 	}
-	if authEnv.JWTSecret == "" {
+	if authEnv.JWTSecret == "" && authEnv.JWKSURL == "" && authEnv.JWKSFile == "" {
 		return nil, errors.New("auth environment variables are empty")
 	}
+	if (authEnv.JWKSURL != "" && authEnv.JWKSFile != "") || authEnv.JWKSRefreshInterval < 0 || authEnv.JWTMaxTokenAge < 0 || authEnv.JWTClockSkew < 0 {
+		return nil, errors.New("auth environment variables are invalid")
+	}
+	if authEnv.JWTSecret != "" && len(authEnv.JWTAlgorithms) > 0 && !slices.Contains(authEnv.JWTAlgorithms, "HS256") {
+		return nil, errors.New("JWT_ALGORITHMS must include HS256 to accept the service account tokens signed with JWT_SECRET_KEY")
+	}
 
 	postgresEnv := PostgresEnv{
 		PostgresHost:     v.GetString("POSTGRES_HOST"),
@@ -95,10 +293,129 @@ func LoadEnv() (*Env, error) {
 		return nil, errors.New("logger environment variables are empty or invalid")
 	}
 
+	passwordPolicyEnv := PasswordPolicyEnv{
+		MinLength:          v.GetInt("PASSWORD_MIN_LENGTH"),
+		MaxLength:          v.GetInt("PASSWORD_MAX_LENGTH"),
+		RequireUpper:       v.GetBool("PASSWORD_REQUIRE_UPPER"),
+		RequireLower:       v.GetBool("PASSWORD_REQUIRE_LOWER"),
+		RequireDigit:       v.GetBool("PASSWORD_REQUIRE_DIGIT"),
+		RequireSymbol:      v.GetBool("PASSWORD_REQUIRE_SYMBOL"),
+		ForbidPersonalInfo: v.GetBool("PASSWORD_FORBID_PERSONAL_INFO"),
+		BreachedListPath:   v.GetString("PASSWORD_BREACHED_LIST_PATH"),
+		HistorySize:        v.GetInt("PASSWORD_HISTORY_SIZE"),
+	}
+	if passwordPolicyEnv.MinLength <= 0 || passwordPolicyEnv.MaxLength < 0 || (passwordPolicyEnv.MaxLength > 0 && passwordPolicyEnv.MaxLength < passwordPolicyEnv.MinLength) || passwordPolicyEnv.HistorySize < 0 {
+		return nil, errors.New("password policy environment variables are invalid")
+	}
+
+	passwordHashEnv := PasswordHashEnv{
+		Algorithm:         v.GetString("PASSWORD_HASH_ALGORITHM"),
+		BcryptCost:        v.GetInt("PASSWORD_BCRYPT_COST"),
+		Argon2Memory:      v.GetUint32("PASSWORD_ARGON2_MEMORY"),
+		Argon2Iterations:  v.GetUint32("PASSWORD_ARGON2_ITERATIONS"),
+		Argon2Parallelism: v.GetUint8("PASSWORD_ARGON2_PARALLELISM"),
+		Argon2SaltLength:  v.GetUint32("PASSWORD_ARGON2_SALT_LENGTH"),
+		Argon2KeyLength:   v.GetUint32("PASSWORD_ARGON2_KEY_LENGTH"),
+	}
+	if (passwordHashEnv.Algorithm != "bcrypt" && passwordHashEnv.Algorithm != "argon2id") || passwordHashEnv.BcryptCost < 4 || passwordHashEnv.BcryptCost > 31 ||
+		passwordHashEnv.Argon2Memory == 0 || passwordHashEnv.Argon2Iterations == 0 || passwordHashEnv.Argon2Parallelism == 0 ||
+		passwordHashEnv.Argon2SaltLength < 8 || passwordHashEnv.Argon2KeyLength < 16 {
+		return nil, errors.New("password hash environment variables are invalid")
+	}
+
+	accessRequestEnv := AccessRequestEnv{
+		TTL: v.GetDuration("ACCESS_REQUEST_TTL"),
+	}
+	if accessRequestEnv.TTL <= 0 {
+		return nil, errors.New("access request environment variables are invalid")
+	}
+
+	eventsEnv := EventsEnv{
+		Stream:          v.GetString("EVENTS_STREAM"),
+		StreamMaxLen:    v.GetInt64("EVENTS_STREAM_MAXLEN"),
+		RelayBatchSize:  v.GetInt("OUTBOX_RELAY_BATCH_SIZE"),
+		OutboxRetention: v.GetDuration("OUTBOX_RETENTION"),
+		ConsumerName:    v.GetString("EVENTS_CONSUMER_NAME"),
+		ClaimIdle:       v.GetDuration("EVENTS_CLAIM_IDLE"),
+	}
+	if eventsEnv.ConsumerName == "" {
+		eventsEnv.ConsumerName, _ = os.Hostname()
+	}
+	if eventsEnv.Stream == "" || eventsEnv.StreamMaxLen < 0 || eventsEnv.RelayBatchSize <= 0 || eventsEnv.OutboxRetention <= 0 || eventsEnv.ConsumerName == "" || eventsEnv.ClaimIdle <= 0 {
+		return nil, errors.New("events environment variables are invalid")
+	}
+
+	revocationEnv := RevocationEnv{
+		CacheTTL:  v.GetDuration("REVOCATION_CACHE_TTL"),
+		Retention: v.GetDuration("REVOCATION_RETENTION"),
+	}
+	if revocationEnv.CacheTTL < 0 || revocationEnv.Retention <= 0 {
+		return nil, errors.New("revocation environment variables are invalid")
+	}
+
+	authzEnv := AuthzEnv{
+		CacheTTL: v.GetDuration("AUTHZ_CACHE_TTL"),
+	}
+	if authzEnv.CacheTTL < 0 {
+		return nil, errors.New("authz environment variables are invalid")
+	}
+
+	serviceAccountEnv := ServiceAccountEnv{
+		TokenTTL:   v.GetDuration("SERVICE_ACCOUNT_TOKEN_TTL"),
+		MaxSecrets: v.GetInt("SERVICE_ACCOUNT_MAX_SECRETS"),
+	}
+	if serviceAccountEnv.TokenTTL <= 0 || serviceAccountEnv.MaxSecrets <= 0 {
+		return nil, errors.New("service account environment variables are invalid")
+	}
+
+	webhookEnv := WebhookEnv{
+		Timeout:      v.GetDuration("WEBHOOK_TIMEOUT"),
+		MaxAttempts:  v.GetInt("WEBHOOK_MAX_ATTEMPTS"),
+		BackoffBase:  v.GetDuration("WEBHOOK_BACKOFF_BASE"),
+		BackoffMax:   v.GetDuration("WEBHOOK_BACKOFF_MAX"),
+		DisableAfter: v.GetInt("WEBHOOK_DISABLE_AFTER"),
+		BatchSize:    v.GetInt("WEBHOOK_BATCH_SIZE"),
+	}
+	if webhookEnv.Timeout <= 0 || webhookEnv.MaxAttempts <= 0 || webhookEnv.BackoffBase <= 0 || webhookEnv.BackoffMax < webhookEnv.BackoffBase ||
+		webhookEnv.DisableAfter <= 0 || webhookEnv.BatchSize <= 0 {
+		return nil, errors.New("webhook environment variables are invalid")
+	}
+
+	workerEnv := WorkerEnv{
+		GrantReapInterval:         v.GetDuration("GRANT_REAP_INTERVAL"),
+		AccessRequestReapInterval: v.GetDuration("ACCESS_REQUEST_REAP_INTERVAL"),
+		OutboxRelayInterval:       v.GetDuration("OUTBOX_RELAY_INTERVAL"),
+		WebhookSendInterval:       v.GetDuration("WEBHOOK_SEND_INTERVAL"),
+	}
+	if workerEnv.GrantReapInterval <= 0 || workerEnv.AccessRequestReapInterval <= 0 || workerEnv.OutboxRelayInterval <= 0 || workerEnv.WebhookSendInterval <= 0 {
+		return nil, errors.New("worker environment variables are invalid")
+	}
+
 	return &Env{
-		AuthEnv:     authEnv,
-		PostgresEnv: postgresEnv,
-		RedisEnv:    redisEnv,
-		LoggerEnv:   loggerEnv,
+		ServerEnv:         serverEnv,
+		AuthEnv:           authEnv,
+		PostgresEnv:       postgresEnv,
+		RedisEnv:          redisEnv,
+		LoggerEnv:         loggerEnv,
+		PasswordPolicyEnv: passwordPolicyEnv,
+		PasswordHashEnv:   passwordHashEnv,
+		AccessRequestEnv:  accessRequestEnv,
+		EventsEnv:         eventsEnv,
+		RevocationEnv:     revocationEnv,
+		AuthzEnv:          authzEnv,
+		ServiceAccountEnv: serviceAccountEnv,
+		WebhookEnv:        webhookEnv,
+		WorkerEnv:         workerEnv,
 	}, nil
 }
+
+// splitList parses a comma-separated variable, dropping blank entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
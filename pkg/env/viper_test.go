@@ -3,6 +3,7 @@ package env
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -18,6 +19,15 @@ func TestViperSuite(t *testing.T) {
 func (suite *ViperSuite) SetupTest() {
 	envVars := []string{
 		"JWT_SECRET_KEY",
+		"JWT_JWKS_URL",
+		"JWT_JWKS_FILE",
+		"JWT_JWKS_REFRESH_INTERVAL",
+		"JWT_ALGORITHMS",
+		"JWT_ISSUER",
+		"JWT_AUDIENCES",
+		"JWT_MAX_TOKEN_AGE",
+		"JWT_CLOCK_SKEW",
+		"PLATFORM_ORGANIZATION",
 		"POSTGRES_USER",
 		"POSTGRES_PASSWORD",
 		"POSTGRES_USER_DB",
@@ -29,6 +39,36 @@ func (suite *ViperSuite) SetupTest() {
 		"ZAP_MAXSIZE",
 		"ZAP_MAXAGE",
 		"ZAP_MAXBACKUPS",
+		"PASSWORD_MIN_LENGTH",
+		"PASSWORD_MAX_LENGTH",
+		"PASSWORD_REQUIRE_SYMBOL",
+		"PASSWORD_BREACHED_LIST_PATH",
+		"PASSWORD_HISTORY_SIZE",
+		"PASSWORD_HASH_ALGORITHM",
+		"PASSWORD_BCRYPT_COST",
+		"ACCESS_REQUEST_TTL",
+		"GRANT_REAP_INTERVAL",
+		"ACCESS_REQUEST_REAP_INTERVAL",
+		"EVENTS_STREAM",
+		"EVENTS_STREAM_MAXLEN",
+		"OUTBOX_RELAY_BATCH_SIZE",
+		"OUTBOX_RETENTION",
+		"OUTBOX_RELAY_INTERVAL",
+		"TRUSTED_PROXIES",
+		"EVENTS_CONSUMER_NAME",
+		"EVENTS_CLAIM_IDLE",
+		"REVOCATION_CACHE_TTL",
+		"REVOCATION_RETENTION",
+		"AUTHZ_CACHE_TTL",
+		"SERVICE_ACCOUNT_TOKEN_TTL",
+		"SERVICE_ACCOUNT_MAX_SECRETS",
+		"WEBHOOK_TIMEOUT",
+		"WEBHOOK_MAX_ATTEMPTS",
+		"WEBHOOK_BACKOFF_BASE",
+		"WEBHOOK_BACKOFF_MAX",
+		"WEBHOOK_DISABLE_AFTER",
+		"WEBHOOK_BATCH_SIZE",
+		"WEBHOOK_SEND_INTERVAL",
 	}
 
 	for _, env := range envVars {
@@ -58,13 +98,100 @@ func (suite *ViperSuite) TestLoadEnv() {
 	suite.NoError(err)
 	suite.NotNil(env)
 
+	suite.Nil(env.ServerEnv.TrustedProxies)
 	suite.Equal("test_jwt_secret", env.AuthEnv.JWTSecret)
+	suite.True(env.AuthEnv.JWTRequireExpiry)
+	suite.Equal(30*time.Second, env.AuthEnv.JWTClockSkew)
+	suite.Zero(env.AuthEnv.JWTMaxTokenAge)
+	suite.Equal("default", env.AuthEnv.PlatformOrganization)
 
 	suite.Equal("info", env.LoggerEnv.Level)
 	suite.Equal("/tmp/app.log", env.LoggerEnv.FilePath)
 	suite.Equal(100, env.LoggerEnv.MaxSize)
 	suite.Equal(30, env.LoggerEnv.MaxAge)
 	suite.Equal(5, env.LoggerEnv.MaxBackups)
+
+	suite.Equal(8, env.PasswordPolicyEnv.MinLength)
+	suite.Equal(72, env.PasswordPolicyEnv.MaxLength)
+	suite.True(env.PasswordPolicyEnv.RequireUpper)
+	suite.False(env.PasswordPolicyEnv.RequireSymbol)
+	suite.Equal(5, env.PasswordPolicyEnv.HistorySize)
+
+	suite.Equal("argon2id", env.PasswordHashEnv.Algorithm)
+	suite.Equal(10, env.PasswordHashEnv.BcryptCost)
+	suite.Equal(uint32(64*1024), env.PasswordHashEnv.Argon2Memory)
+	suite.Equal(uint8(2), env.PasswordHashEnv.Argon2Parallelism)
+
+	suite.Equal(7*24*time.Hour, env.AccessRequestEnv.TTL)
+	suite.Equal(time.Minute, env.WorkerEnv.GrantReapInterval)
+	suite.Equal(5*time.Minute, env.WorkerEnv.AccessRequestReapInterval)
+	suite.Equal(time.Second, env.WorkerEnv.OutboxRelayInterval)
+	suite.Equal(5*time.Second, env.WorkerEnv.WebhookSendInterval)
+
+	suite.Equal("vcs:user-management:events", env.EventsEnv.Stream)
+	suite.Equal(int64(100000), env.EventsEnv.StreamMaxLen)
+	suite.Equal(100, env.EventsEnv.RelayBatchSize)
+	suite.Equal(7*24*time.Hour, env.EventsEnv.OutboxRetention)
+	hostname, _ := os.Hostname()
+	suite.Equal(hostname, env.EventsEnv.ConsumerName)
+	suite.Equal(time.Minute, env.EventsEnv.ClaimIdle)
+
+	suite.Equal(5*time.Second, env.RevocationEnv.CacheTTL)
+	suite.Equal(24*time.Hour, env.RevocationEnv.Retention)
+
+	suite.Equal(5*time.Minute, env.AuthzEnv.CacheTTL)
+
+	suite.Equal(15*time.Minute, env.ServiceAccountEnv.TokenTTL)
+	suite.Equal(3, env.ServiceAccountEnv.MaxSecrets)
+
+	suite.Equal(10*time.Second, env.WebhookEnv.Timeout)
+	suite.Equal(8, env.WebhookEnv.MaxAttempts)
+	suite.Equal(30*time.Second, env.WebhookEnv.BackoffBase)
+	suite.Equal(time.Hour, env.WebhookEnv.BackoffMax)
+	suite.Equal(20, env.WebhookEnv.DisableAfter)
+	suite.Equal(50, env.WebhookEnv.BatchSize)
+}
+
+func (suite *ViperSuite) TestLoadEnvTrustedProxies() {
+	suite.createEnvVars(map[string]string{
+		"JWT_SECRET_KEY":  "test_jwt_secret",
+		"TRUSTED_PROXIES": "10.0.0.0/8, 192.168.1.1",
+	})
+	env, err := LoadEnv()
+
+	suite.NoError(err)
+	suite.Equal([]string{"10.0.0.0/8", "192.168.1.1"}, env.ServerEnv.TrustedProxies)
+}
+
+func (suite *ViperSuite) TestLoadEnvPasswordPolicy() {
+	envContent := map[string]string{
+		"JWT_SECRET_KEY":              "test_jwt_secret",
+		"PASSWORD_MIN_LENGTH":         "12",
+		"PASSWORD_REQUIRE_SYMBOL":     "true",
+		"PASSWORD_BREACHED_LIST_PATH": "/etc/breached.txt",
+		"PASSWORD_HISTORY_SIZE":       "0",
+	}
+	suite.createEnvVars(envContent)
+	env, err := LoadEnv()
+
+	suite.NoError(err)
+	suite.Equal(12, env.PasswordPolicyEnv.MinLength)
+	suite.True(env.PasswordPolicyEnv.RequireSymbol)
+	suite.Equal("/etc/breached.txt", env.PasswordPolicyEnv.BreachedListPath)
+	suite.Equal(0, env.PasswordPolicyEnv.HistorySize)
+}
+
+func (suite *ViperSuite) TestLoadEnvInvalidPasswordPolicyValues() {
+	envContent := map[string]string{
+		"JWT_SECRET_KEY":      "test_jwt_secret",
+		"PASSWORD_MIN_LENGTH": "16",
+		"PASSWORD_MAX_LENGTH": "10",
+	}
+	suite.createEnvVars(envContent)
+	env, err := LoadEnv()
+
+	suite.Error(err)
+	suite.Nil(env)
 }
 
 func (suite *ViperSuite) TestLoadEnvInvalidJwtValues() {
@@ -78,6 +205,70 @@ func (suite *ViperSuite) TestLoadEnvInvalidJwtValues() {
 	suite.Nil(env)
 }
 
+func (suite *ViperSuite) TestLoadEnvJWKS() {
+	envContent := map[string]string{
+		"JWT_JWKS_URL":              "https://auth.example.com/.well-known/jwks.json",
+		"JWT_JWKS_REFRESH_INTERVAL": "1m",
+		"JWT_ALGORITHMS":            "RS256, ES256,",
+	}
+	suite.createEnvVars(envContent)
+	env, err := LoadEnv()
+
+	suite.NoError(err)
+	suite.Empty(env.AuthEnv.JWTSecret)
+	suite.Equal("https://auth.example.com/.well-known/jwks.json", env.AuthEnv.JWKSURL)
+	suite.Equal(time.Minute, env.AuthEnv.JWKSRefreshInterval)
+	suite.Equal([]string{"RS256", "ES256"}, env.AuthEnv.JWTAlgorithms)
+}
+
+func (suite *ViperSuite) TestLoadEnvServiceAccountAlgorithm() {
+	suite.createEnvVars(map[string]string{
+		"JWT_SECRET_KEY": "test_jwt_secret",
+		"JWT_JWKS_URL":   "https://auth.example.com/.well-known/jwks.json",
+		"JWT_ALGORITHMS": "RS256,ES256",
+	})
+	env, err := LoadEnv()
+	suite.ErrorContains(err, "HS256")
+	suite.Nil(env)
+
+	suite.createEnvVars(map[string]string{"JWT_ALGORITHMS": "RS256,HS256"})
+	env, err = LoadEnv()
+	suite.NoError(err)
+	suite.Equal([]string{"RS256", "HS256"}, env.AuthEnv.JWTAlgorithms)
+}
+
+func (suite *ViperSuite) TestLoadEnvClaimValidation() {
+	envContent := map[string]string{
+		"JWT_SECRET_KEY":        "test_jwt_secret",
+		"JWT_ISSUER":            "vcs-auth",
+		"JWT_AUDIENCES":         "vcs-user-management,vcs-admin",
+		"JWT_MAX_TOKEN_AGE":     "24h",
+		"JWT_CLOCK_SKEW":        "5s",
+		"PLATFORM_ORGANIZATION": "ops",
+	}
+	suite.createEnvVars(envContent)
+	env, err := LoadEnv()
+
+	suite.NoError(err)
+	suite.Equal("vcs-auth", env.AuthEnv.JWTIssuer)
+	suite.Equal([]string{"vcs-user-management", "vcs-admin"}, env.AuthEnv.JWTAudiences)
+	suite.Equal(24*time.Hour, env.AuthEnv.JWTMaxTokenAge)
+	suite.Equal(5*time.Second, env.AuthEnv.JWTClockSkew)
+	suite.Equal("ops", env.AuthEnv.PlatformOrganization)
+}
+
+func (suite *ViperSuite) TestLoadEnvConflictingJWKSSources() {
+	envContent := map[string]string{
+		"JWT_JWKS_URL":  "https://auth.example.com/.well-known/jwks.json",
+		"JWT_JWKS_FILE": "/etc/jwks.json",
+	}
+	suite.createEnvVars(envContent)
+	env, err := LoadEnv()
+
+	suite.Error(err)
+	suite.Nil(env)
+}
+
 func (suite *ViperSuite) TestLoadEnvInvalidLoggerValues() {
 	envContent := map[string]string{
 		"JWT_SECRET_KEY": "test_jwt_secret",
@@ -104,3 +295,109 @@ func (suite *ViperSuite) TestLoadEnvInvalidRedisValues() {
 	suite.Error(err)
 	suite.Nil(env)
 }
+
+func (suite *ViperSuite) TestLoadEnvInvalidPasswordHashValues() {
+	envContent := map[string]string{
+		"JWT_SECRET_KEY":          "test_jwt_secret",
+		"PASSWORD_HASH_ALGORITHM": "md5",
+	}
+	suite.createEnvVars(envContent)
+	env, err := LoadEnv()
+
+	suite.Error(err)
+	suite.Nil(env)
+
+	suite.createEnvVars(map[string]string{
+		"PASSWORD_HASH_ALGORITHM": "bcrypt",
+		"PASSWORD_BCRYPT_COST":    "3",
+	})
+	env, err = LoadEnv()
+
+	suite.Error(err)
+	suite.Nil(env)
+}
+
+func (suite *ViperSuite) TestLoadEnvInvalidWorkerValues() {
+	envContent := map[string]string{
+		"JWT_SECRET_KEY":      "test_jwt_secret",
+		"GRANT_REAP_INTERVAL": "0s",
+	}
+	suite.createEnvVars(envContent)
+	env, err := LoadEnv()
+
+	suite.Error(err)
+	suite.Nil(env)
+}
+
+func (suite *ViperSuite) TestLoadEnvInvalidAccessRequestValues() {
+	envContent := map[string]string{
+		"JWT_SECRET_KEY":     "test_jwt_secret",
+		"ACCESS_REQUEST_TTL": "-1h",
+	}
+	suite.createEnvVars(envContent)
+	env, err := LoadEnv()
+
+	suite.Error(err)
+	suite.Nil(env)
+}
+
+func (suite *ViperSuite) TestLoadEnvInvalidEventsValues() {
+	envContent := map[string]string{
+		"JWT_SECRET_KEY":          "test_jwt_secret",
+		"OUTBOX_RELAY_BATCH_SIZE": "0",
+	}
+	suite.createEnvVars(envContent)
+	env, err := LoadEnv()
+
+	suite.Error(err)
+	suite.Nil(env)
+}
+
+func (suite *ViperSuite) TestLoadEnvInvalidRevocationValues() {
+	envContent := map[string]string{
+		"JWT_SECRET_KEY":       "test_jwt_secret",
+		"REVOCATION_RETENTION": "0s",
+	}
+	suite.createEnvVars(envContent)
+	env, err := LoadEnv()
+
+	suite.Error(err)
+	suite.Nil(env)
+}
+
+func (suite *ViperSuite) TestLoadEnvInvalidAuthzValues() {
+	envContent := map[string]string{
+		"JWT_SECRET_KEY":  "test_jwt_secret",
+		"AUTHZ_CACHE_TTL": "-1s",
+	}
+	suite.createEnvVars(envContent)
+	env, err := LoadEnv()
+
+	suite.Error(err)
+	suite.Nil(env)
+}
+
+func (suite *ViperSuite) TestLoadEnvInvalidServiceAccountValues() {
+	envContent := map[string]string{
+		"JWT_SECRET_KEY":              "test_jwt_secret",
+		"SERVICE_ACCOUNT_MAX_SECRETS": "0",
+	}
+	suite.createEnvVars(envContent)
+	env, err := LoadEnv()
+
+	suite.Error(err)
+	suite.Nil(env)
+}
+
+func (suite *ViperSuite) TestLoadEnvInvalidWebhookValues() {
+	envContent := map[string]string{
+		"JWT_SECRET_KEY":       "test_jwt_secret",
+		"WEBHOOK_BACKOFF_BASE": "2h",
+		"WEBHOOK_BACKOFF_MAX":  "1h",
+	}
+	suite.createEnvVars(envContent)
+	env, err := LoadEnv()
+
+	suite.Error(err)
+	suite.Nil(env)
+}
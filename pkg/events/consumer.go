@@ -0,0 +1,210 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Handler processes one event. An error leaves the event pending, and it is
+// handed to a handler again once it has been idle for the claim threshold.
+// An error wrapping ErrMalformedEvent is not retried.
+type Handler func(ctx context.Context, event *Event) error
+
+// Consumer reads a stream as one member of a consumer group. Each consumer
+// of a group needs a name that is stable across restarts, so that the
+// entries it had read but not acknowledged are handed back to it. Entries
+// another consumer left pending for longer than minIdle, because it failed
+// to handle them or stopped, are claimed and handled again; an entry that
+// keeps failing is therefore retried about every minIdle until it succeeds.
+//
+// An entry that cannot be parsed, has an unknown type or is rejected as
+// malformed is copied to the dead-letter stream, with the reason in its
+// error field, and acknowledged, since handling it again cannot succeed.
+type Consumer struct {
+	client     redis.Cmdable
+	logger     logger.ILogger
+	stream     string
+	deadLetter string
+	group      string
+	name       string
+	count      int64
+	block      time.Duration
+	minIdle    time.Duration
+}
+
+func NewConsumer(client redis.Cmdable, stream, group, name string, minIdle time.Duration, logger logger.ILogger) *Consumer {
+	return &Consumer{
+		client:     client,
+		logger:     logger,
+		stream:     stream,
+		deadLetter: DeadLetterStream(stream),
+		group:      group,
+		name:       name,
+		count:      100,
+		block:      5 * time.Second,
+		minIdle:    minIdle,
+	}
+}
+
+// DeadLetterStream names the stream the entries of stream that no consumer
+// could handle are moved to.
+func DeadLetterStream(stream string) string {
+	return stream + ":dead-letter"
+}
+
+// Run creates the group if it does not exist, which starts it at the
+// beginning of the stream, then hands every event to handle and
+// acknowledges it once handle succeeds. Entries left pending by a previous
+// run come first, and entries idle for longer than minIdle are claimed
+// every minIdle. Run returns nil when ctx is cancelled, and an error only
+// when the stream cannot be read or written.
+func (c *Consumer) Run(ctx context.Context, handle Handler) error {
+	err := c.client.XGroupCreateMkStream(ctx, c.stream, c.group, "0").Err()
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	start := "0"
+	var claimed time.Time
+	for {
+		if time.Since(claimed) >= c.minIdle {
+			if err := c.claim(ctx, handle); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return err
+			}
+			claimed = time.Now()
+		}
+
+		streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.group,
+			Consumer: c.name,
+			Streams:  []string{c.stream, start},
+			Count:    c.count,
+			Block:    c.block,
+		}).Result()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		messages := streams[0].Messages
+		if start != ">" {
+			// The entries this consumer had pending are walked once;
+			// those it fails to handle again are left to be claimed.
+			if len(messages) == 0 {
+				start = ">"
+				continue
+			}
+			start = messages[len(messages)-1].ID
+		}
+		if err := c.handleAll(ctx, messages, handle); err != nil {
+			return err
+		}
+	}
+}
+
+// claim takes over the entries of any consumer of the group, this one
+// included, that have been pending for longer than minIdle, and handles them.
+func (c *Consumer) claim(ctx context.Context, handle Handler) error {
+	cursor := "0-0"
+	for {
+		messages, next, err := c.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   c.stream,
+			Group:    c.group,
+			Consumer: c.name,
+			MinIdle:  c.minIdle,
+			Start:    cursor,
+			Count:    c.count,
+		}).Result()
+		if err != nil {
+			return err
+		}
+		if err := c.handleAll(ctx, messages, handle); err != nil {
+			return err
+		}
+		if next == "0-0" || next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func (c *Consumer) handleAll(ctx context.Context, messages []redis.XMessage, handle Handler) error {
+	for _, message := range messages {
+		if err := c.handle(ctx, message, handle); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Consumer) handle(ctx context.Context, message redis.XMessage, handle Handler) error {
+	// A pending entry that was trimmed from the stream comes back without
+	// fields; there is nothing left to handle.
+	if len(message.Values) == 0 {
+		return c.ack(ctx, message.ID)
+	}
+
+	event, err := Parse(message.Values)
+	if err == nil && !Known(event.Type) {
+		err = fmt.Errorf("%w: unknown type %q", ErrMalformedEvent, event.Type)
+	}
+	if err != nil {
+		c.logger.Error("failed to parse stream entry", zap.String("entry_id", message.ID), zap.Error(err))
+		return c.deadLetterEntry(ctx, message, err)
+	}
+
+	err = handle(ctx, event)
+	if err == nil {
+		return c.ack(ctx, message.ID)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if errors.Is(err, ErrMalformedEvent) {
+		c.logger.Error("failed to handle event", zap.String("entry_id", message.ID), zap.String("event_id", event.ID), zap.Error(err))
+		return c.deadLetterEntry(ctx, message, err)
+	}
+	c.logger.Warn("failed to handle event, leaving it pending", zap.String("entry_id", message.ID), zap.String("event_id", event.ID), zap.Error(err))
+	return nil
+}
+
+// deadLetterEntry copies the entry to the dead-letter stream, with the
+// reason it was given up on, then acknowledges it.
+func (c *Consumer) deadLetterEntry(ctx context.Context, message redis.XMessage, reason error) error {
+	values := make(map[string]interface{}, len(message.Values)+3)
+	for key, value := range message.Values {
+		values[key] = value
+	}
+	values["entry_id"] = message.ID
+	values["group"] = c.group
+	values["error"] = reason.Error()
+	if err := c.client.XAdd(ctx, &redis.XAddArgs{Stream: c.deadLetter, Values: values}).Err(); err != nil {
+		return fmt.Errorf("stream entry %s: %w", message.ID, err)
+	}
+	return c.ack(ctx, message.ID)
+}
+
+func (c *Consumer) ack(ctx context.Context, id string) error {
+	return c.client.XAck(ctx, c.stream, c.group, id).Err()
+}
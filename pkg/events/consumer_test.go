@@ -0,0 +1,179 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+)
+
+// fakeStream hands out entries once, then cancels the run, and records what
+// the consumer claims, acknowledges and dead-letters.
+type fakeStream struct {
+	redis.Cmdable
+	cancel     context.CancelFunc
+	messages   []redis.XMessage
+	idle       []redis.XMessage
+	reads      int
+	claims     []*redis.XAutoClaimArgs
+	acked      []string
+	deadLetter []*redis.XAddArgs
+}
+
+func (f *fakeStream) XGroupCreateMkStream(context.Context, string, string, string) *redis.StatusCmd {
+	return redis.NewStatusResult("OK", nil)
+}
+
+func (f *fakeStream) XReadGroup(_ context.Context, a *redis.XReadGroupArgs) *redis.XStreamSliceCmd {
+	f.reads++
+	if f.reads > 1 {
+		f.cancel()
+		return redis.NewXStreamSliceCmdResult(nil, context.Canceled)
+	}
+	return redis.NewXStreamSliceCmdResult([]redis.XStream{{Stream: a.Streams[0], Messages: f.messages}}, nil)
+}
+
+func (f *fakeStream) XAutoClaim(_ context.Context, a *redis.XAutoClaimArgs) *redis.XAutoClaimCmd {
+	f.claims = append(f.claims, a)
+	cmd := redis.NewXAutoClaimCmd(context.Background())
+	cmd.SetVal(f.idle, "0-0")
+	f.idle = nil
+	return cmd
+}
+
+func (f *fakeStream) XAck(_ context.Context, _, _ string, ids ...string) *redis.IntCmd {
+	f.acked = append(f.acked, ids...)
+	return redis.NewIntResult(int64(len(ids)), nil)
+}
+
+func (f *fakeStream) XAdd(_ context.Context, a *redis.XAddArgs) *redis.StringCmd {
+	f.deadLetter = append(f.deadLetter, a)
+	return redis.NewStringResult("9-0", nil)
+}
+
+func newFakeConsumer(t *testing.T, messages ...redis.XMessage) (*Consumer, *fakeStream, *logger.MockILogger, context.Context) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	stream := &fakeStream{cancel: cancel, messages: messages}
+	log := logger.NewMockILogger(gomock.NewController(t))
+	consumer := NewConsumer(stream, DefaultStream, "test-group", "test-consumer", time.Minute, log)
+	return consumer, stream, log, ctx
+}
+
+func entry(t *testing.T, id, eventType string) redis.XMessage {
+	event, err := New(eventType, "acme", UserData{UserID: "alice"})
+	require.NoError(t, err)
+	values, err := event.Values()
+	require.NoError(t, err)
+	return redis.XMessage{ID: id, Values: values}
+}
+
+func TestConsumerRunUnreachable(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:1"})
+	defer client.Close()
+
+	consumer := NewConsumer(client, DefaultStream, "test-group", "test-consumer", time.Minute, logger.NewMockILogger(gomock.NewController(t)))
+	err := consumer.Run(context.Background(), func(context.Context, *Event) error {
+		t.Fatal("handler called without a stream")
+		return nil
+	})
+	assert.Error(t, err)
+}
+
+func TestConsumerRunCancelled(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:1"})
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	consumer := NewConsumer(client, DefaultStream, "test-group", "test-consumer", time.Minute, logger.NewMockILogger(gomock.NewController(t)))
+	err := consumer.Run(ctx, func(context.Context, *Event) error {
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestConsumerRunDeadLettersUnparseableEntries(t *testing.T) {
+	consumer, stream, log, ctx := newFakeConsumer(t,
+		redis.XMessage{ID: "1-0", Values: Encoded("not json")},
+		entry(t, "2-0", "user.renamed"),
+		entry(t, "3-0", UserCreated),
+	)
+	log.EXPECT().Error("failed to parse stream entry", gomock.Any(), gomock.Any()).Times(2)
+
+	var handled []string
+	err := consumer.Run(ctx, func(_ context.Context, event *Event) error {
+		handled = append(handled, event.Type)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{UserCreated}, handled)
+	assert.Equal(t, []string{"1-0", "2-0", "3-0"}, stream.acked)
+
+	require.Len(t, stream.deadLetter, 2)
+	assert.Equal(t, DefaultStream+":dead-letter", stream.deadLetter[0].Stream)
+	values := stream.deadLetter[1].Values.(map[string]interface{})
+	assert.Equal(t, "2-0", values["entry_id"])
+	assert.Equal(t, "test-group", values["group"])
+	assert.Contains(t, values["error"], "user.renamed")
+}
+
+func TestConsumerRunLeavesFailedEventsPending(t *testing.T) {
+	consumer, stream, log, ctx := newFakeConsumer(t, entry(t, "1-0", UserCreated), entry(t, "2-0", UserDeleted))
+	log.EXPECT().Warn("failed to handle event, leaving it pending", gomock.Any()).Times(1)
+
+	var handled []string
+	err := consumer.Run(ctx, func(_ context.Context, event *Event) error {
+		handled = append(handled, event.Type)
+		if event.Type == UserCreated {
+			return errors.New("db error")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{UserCreated, UserDeleted}, handled)
+	assert.Equal(t, []string{"2-0"}, stream.acked)
+	assert.Empty(t, stream.deadLetter)
+}
+
+func TestConsumerRunClaimsIdleEntries(t *testing.T) {
+	consumer, stream, _, ctx := newFakeConsumer(t)
+	stream.idle = []redis.XMessage{entry(t, "1-0", UserCreated), {ID: "2-0"}}
+
+	var handled []string
+	err := consumer.Run(ctx, func(_ context.Context, event *Event) error {
+		handled = append(handled, event.Type)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{UserCreated}, handled)
+	assert.Equal(t, []string{"1-0", "2-0"}, stream.acked)
+
+	require.Len(t, stream.claims, 1)
+	assert.Equal(t, "test-consumer", stream.claims[0].Consumer)
+	assert.Equal(t, time.Minute, stream.claims[0].MinIdle)
+	assert.Equal(t, "0-0", stream.claims[0].Start)
+}
+
+func TestConsumerRunDoesNotRetryMalformedEvents(t *testing.T) {
+	consumer, stream, log, ctx := newFakeConsumer(t, entry(t, "1-0", UserCreated))
+	log.EXPECT().Error("failed to handle event", gomock.Any()).Times(1)
+
+	attempts := 0
+	err := consumer.Run(ctx, func(context.Context, *Event) error {
+		attempts++
+		return ErrMalformedEvent
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, []string{"1-0"}, stream.acked)
+	assert.Len(t, stream.deadLetter, 1)
+}
@@ -0,0 +1,146 @@
+// Package events defines the domain events this service publishes to Redis
+// Streams and helps other services consume them. Every stream entry carries
+// one Event, JSON-encoded under the "event" field. Delivery is at least
+// once, so consumers must tolerate duplicates; Event.ID is stable across
+// redeliveries and may be used to drop them.
+//
+// Event.Version is the schema version of Event.Data. Fields are only ever
+// added within a version; removing or changing one bumps it. The JSON
+// Schema of every version lives in schema/.
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultStream is the stream events are published to unless configured
+// otherwise.
+const DefaultStream = "vcs:user-management:events"
+
+// SchemaVersion is the version of the events this package produces and the
+// newest it can decode.
+const SchemaVersion = 1
+
+// field is the stream entry field that holds the encoded event.
+const field = "event"
+
+const (
+	UserCreated       = "user.created"
+	UserDeleted       = "user.deleted"
+	UserScopesChanged = "user.scopes_changed"
+	ScopeCreated      = "scope.created"
+	ScopeDeleted      = "scope.deleted"
+)
+
+// Types lists every event type, in the order they are documented.
+var Types = []string{UserCreated, UserDeleted, UserScopesChanged, ScopeCreated, ScopeDeleted}
+
+// Known reports whether eventType is one of Types.
+func Known(eventType string) bool {
+	for _, known := range Types {
+		if known == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	ErrMalformedEvent     = errors.New("malformed event")
+	ErrUnsupportedVersion = errors.New("unsupported event schema version")
+)
+
+type Event struct {
+	ID             string          `json:"id"`
+	Type           string          `json:"type"`
+	Version        int             `json:"version"`
+	OrganizationID string          `json:"organization_id"`
+	OccurredAt     time.Time       `json:"occurred_at"`
+	Data           json.RawMessage `json:"data"`
+}
+
+// UserData is the payload of user.created and user.deleted.
+type UserData struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// UserScopesChangedData is the payload of user.scopes_changed. Scopes lists
+// every scope the user holds afterwards, directly or through a role or
+// group; Added and Removed are the difference from before.
+type UserScopesChangedData struct {
+	UserID  string   `json:"user_id"`
+	Scopes  []string `json:"scopes"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// ScopeData is the payload of scope.created and scope.deleted. Deleting a
+// scope also emits user.scopes_changed for every user who held it.
+type ScopeData struct {
+	ScopeID uint   `json:"scope_id"`
+	Name    string `json:"name"`
+}
+
+// New builds an event of the current schema version with a fresh ID.
+func New(eventType, organizationId string, data interface{}) (*Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		ID:             uuid.NewString(),
+		Type:           eventType,
+		Version:        SchemaVersion,
+		OrganizationID: organizationId,
+		OccurredAt:     time.Now().UTC(),
+		Data:           raw,
+	}, nil
+}
+
+// Values encodes the event as the fields of a stream entry.
+func (e *Event) Values() (map[string]interface{}, error) {
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return Encoded(string(raw)), nil
+}
+
+// Encoded wraps an already encoded event as the fields of a stream entry.
+func Encoded(event string) map[string]interface{} {
+	return map[string]interface{}{field: event}
+}
+
+// Parse decodes the fields of a stream entry. It rejects events newer than
+// SchemaVersion, whose data this package cannot describe.
+func Parse(values map[string]interface{}) (*Event, error) {
+	raw, ok := values[field].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %q field", ErrMalformedEvent, field)
+	}
+
+	var event Event
+	if err := json.Unmarshal([]byte(raw), &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedEvent, err)
+	}
+	if event.ID == "" || event.Type == "" {
+		return nil, fmt.Errorf("%w: missing id or type", ErrMalformedEvent)
+	}
+	if event.Version < 1 || event.Version > SchemaVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, event.Version)
+	}
+	return &event, nil
+}
+
+// Decode unmarshals the event's data into v, which should be the payload
+// type of its Type.
+func (e *Event) Decode(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundTrip(t *testing.T) {
+	event, err := New(UserScopesChanged, "acme", UserScopesChangedData{
+		UserID:  "user1",
+		Scopes:  []string{"user:view"},
+		Added:   []string{"user:view"},
+		Removed: []string{},
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, event.ID)
+	assert.Equal(t, SchemaVersion, event.Version)
+
+	values, err := event.Values()
+	assert.NoError(t, err)
+
+	parsed, err := Parse(values)
+	assert.NoError(t, err)
+	assert.Equal(t, event.ID, parsed.ID)
+	assert.Equal(t, UserScopesChanged, parsed.Type)
+	assert.Equal(t, "acme", parsed.OrganizationID)
+	assert.True(t, event.OccurredAt.Equal(parsed.OccurredAt))
+
+	var data UserScopesChangedData
+	assert.NoError(t, parsed.Decode(&data))
+	assert.Equal(t, "user1", data.UserID)
+	assert.Equal(t, []string{"user:view"}, data.Added)
+}
+
+func TestParseMalformed(t *testing.T) {
+	_, err := Parse(map[string]interface{}{})
+	assert.ErrorIs(t, err, ErrMalformedEvent)
+
+	_, err = Parse(Encoded("not json"))
+	assert.ErrorIs(t, err, ErrMalformedEvent)
+
+	_, err = Parse(Encoded(`{"version":1}`))
+	assert.ErrorIs(t, err, ErrMalformedEvent)
+}
+
+func TestParseUnsupportedVersion(t *testing.T) {
+	_, err := Parse(Encoded(`{"id":"1","type":"user.created","version":2,"data":{}}`))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	_, err = Parse(Encoded(`{"id":"1","type":"user.created","data":{}}`))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestSchemaListsEveryType(t *testing.T) {
+	raw, err := os.ReadFile("schema/v1.json")
+	assert.NoError(t, err)
+
+	var schema struct {
+		Properties struct {
+			Type struct {
+				Enum []string `json:"enum"`
+			} `json:"type"`
+			Version struct {
+				Const int `json:"const"`
+			} `json:"version"`
+		} `json:"properties"`
+	}
+	assert.NoError(t, json.Unmarshal(raw, &schema))
+	assert.Equal(t, SchemaVersion, schema.Properties.Version.Const)
+	assert.Equal(t, Types, schema.Properties.Type.Enum)
+}
+
+func TestKnown(t *testing.T) {
+	assert.True(t, Known(UserScopesChanged))
+	assert.False(t, Known("user.renamed"))
+}
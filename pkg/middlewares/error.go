@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var errorStatus = map[apperrors.Kind]int{
+	apperrors.KindInternal:     http.StatusInternalServerError,
+	apperrors.KindBadRequest:   http.StatusBadRequest,
+	apperrors.KindNotFound:     http.StatusNotFound,
+	apperrors.KindConflict:     http.StatusConflict,
+	apperrors.KindValidation:   http.StatusUnprocessableEntity,
+	apperrors.KindForbidden:    http.StatusForbidden,
+	apperrors.KindUnauthorized: http.StatusUnauthorized,
+}
+
+// ErrorHandler renders the last error attached with c.Error as a
+// dto.APIResponse and turns panics into the same envelope. Handlers may set a
+// string Meta on the error to describe the operation that failed.
+func ErrorHandler(logger logger.ILogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("panic recovered", zap.Any("panic", r), zap.String("path", c.Request.URL.Path))
+				c.AbortWithStatusJSON(http.StatusInternalServerError, dto.APIResponse{
+					Success: false,
+					Code:    dto.CodeInternalServerError,
+					Message: "Internal server error",
+				})
+			}
+		}()
+
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		ginErr := c.Errors.Last()
+		status := http.StatusInternalServerError
+		response := dto.APIResponse{
+			Success: false,
+			Code:    dto.CodeInternalServerError,
+			Message: "Internal server error",
+			Error:   ginErr.Err.Error(),
+		}
+		if appErr, ok := apperrors.As(ginErr.Err); ok {
+			status = errorStatus[appErr.Kind]
+			response.Code = appErr.Code
+			response.Message = appErr.Message
+			response.Details = appErr.Details
+		}
+		if message, ok := ginErr.Meta.(string); ok {
+			response.Message = message
+		}
+		c.JSON(status, response)
+	}
+}
@@ -0,0 +1,137 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+)
+
+type ErrorHandlerSuite struct {
+	suite.Suite
+	ctrl   *gomock.Controller
+	logger *logger.MockILogger
+	router *gin.Engine
+}
+
+func (s *ErrorHandlerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.logger = logger.NewMockILogger(s.ctrl)
+
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.router.Use(ErrorHandler(s.logger))
+}
+
+func (s *ErrorHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestErrorHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ErrorHandlerSuite))
+}
+
+func (s *ErrorHandlerSuite) serve() (*httptest.ResponseRecorder, dto.APIResponse) {
+	req, _ := http.NewRequest("GET", "/test", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	var response dto.APIResponse
+	s.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	return w, response
+}
+
+func (s *ErrorHandlerSuite) TestDomainErrorStatuses() {
+	cases := []struct {
+		err    error
+		status int
+	}{
+		{apperrors.BadRequest(dto.CodeInvalidPagination, "bad cursor", nil), http.StatusBadRequest},
+		{apperrors.NotFound(dto.CodeUserNotFound, "user not found", nil), http.StatusNotFound},
+		{apperrors.Conflict(dto.CodeUserAlreadyExists, "user already exists", nil), http.StatusConflict},
+		{apperrors.Validation(dto.CodeInvalidEmail, "invalid email", nil), http.StatusUnprocessableEntity},
+		{apperrors.Forbidden(dto.CodeForbidden, "forbidden", nil), http.StatusForbidden},
+		{apperrors.Unauthorized(dto.CodeInvalidClient, "invalid client", nil), http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		s.SetupTest()
+		appErr, _ := apperrors.As(tc.err)
+		s.router.GET("/test", func(c *gin.Context) {
+			_ = c.Error(tc.err)
+		})
+
+		w, response := s.serve()
+		s.Equal(tc.status, w.Code)
+		s.False(response.Success)
+		s.Equal(appErr.Code, response.Code)
+		s.Equal(appErr.Message, response.Message)
+	}
+}
+
+func (s *ErrorHandlerSuite) TestMetaOverridesMessage() {
+	s.router.GET("/test", func(c *gin.Context) {
+		_ = c.Error(apperrors.NotFound(dto.CodeScopeNotFound, "scope not found", nil)).SetMeta("Failed to find scope")
+	})
+
+	w, response := s.serve()
+	s.Equal(http.StatusNotFound, w.Code)
+	s.Equal(dto.CodeScopeNotFound, response.Code)
+	s.Equal("Failed to find scope", response.Message)
+}
+
+func (s *ErrorHandlerSuite) TestDetailsAreRendered() {
+	s.router.GET("/test", func(c *gin.Context) {
+		_ = c.Error(apperrors.Validation(dto.CodeWeakPassword, "weak password", nil).WithDetails([]string{"min_length"}))
+	})
+
+	w, response := s.serve()
+	s.Equal(http.StatusUnprocessableEntity, w.Code)
+	s.Equal(dto.CodeWeakPassword, response.Code)
+	s.Equal([]interface{}{"min_length"}, response.Details)
+}
+
+func (s *ErrorHandlerSuite) TestUnknownErrorIsInternal() {
+	s.router.GET("/test", func(c *gin.Context) {
+		_ = c.Error(errors.New("database error")).SetMeta("Failed to retrieve users")
+	})
+
+	w, response := s.serve()
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.Equal(dto.CodeInternalServerError, response.Code)
+	s.Equal("Failed to retrieve users", response.Message)
+	s.Equal("database error", response.Error)
+}
+
+func (s *ErrorHandlerSuite) TestWrittenResponseIsKept() {
+	s.router.GET("/test", func(c *gin.Context) {
+		_ = c.Error(errors.New("ignored"))
+		c.JSON(http.StatusTeapot, dto.APIResponse{Code: "TEAPOT"})
+	})
+
+	w, response := s.serve()
+	s.Equal(http.StatusTeapot, w.Code)
+	s.Equal("TEAPOT", response.Code)
+}
+
+func (s *ErrorHandlerSuite) TestRecoversPanic() {
+	s.logger.EXPECT().Error("panic recovered", gomock.Any(), gomock.Any()).Times(1)
+	s.router.GET("/test", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	w, response := s.serve()
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.False(response.Success)
+	s.Equal(dto.CodeInternalServerError, response.Code)
+	s.Empty(response.Error)
+}
@@ -0,0 +1,319 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrUnknownKey      = errors.New("no key matches the token kid")
+	ErrKeyAlgMismatch  = errors.New("key cannot verify the token algorithm")
+	ErrAmbiguousKey    = errors.New("token has no kid and the key set holds several candidate keys")
+	ErrUnsupportedJWKS = errors.New("unsupported JSON web key")
+)
+
+// minUnknownKidRefresh bounds how often a token with an unknown kid can force
+// a refresh, so a flood of forged tokens cannot hammer the JWKS endpoint.
+const minUnknownKidRefresh = 10 * time.Second
+
+// IKeySet resolves the public key that verifies a token signed with alg. kid
+// may be empty when the key set holds a single suitable key.
+type IKeySet interface {
+	Key(kid, alg string) (interface{}, error)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type publicKey struct {
+	alg string
+	key interface{}
+}
+
+type jwksKeySet struct {
+	fetch  func(ctx context.Context) ([]byte, error)
+	logger logger.ILogger
+
+	mu          sync.RWMutex
+	keys        map[string]publicKey
+	lastAttempt time.Time
+}
+
+// LoadJWKS reads the key set from env.JWKSURL or env.JWKSFile and keeps it
+// fresh until ctx is cancelled. Keys that appear or disappear in the source
+// take effect on the next refresh, which is how keys are rotated.
+func LoadJWKS(ctx context.Context, env env.AuthEnv, logger logger.ILogger) (IKeySet, error) {
+	ks := &jwksKeySet{logger: logger}
+	switch {
+	case env.JWKSURL != "":
+		client := &http.Client{Timeout: 10 * time.Second}
+		ks.fetch = func(ctx context.Context) ([]byte, error) {
+			return fetchJWKS(ctx, client, env.JWKSURL)
+		}
+	case env.JWKSFile != "":
+		ks.fetch = func(context.Context) ([]byte, error) {
+			return os.ReadFile(env.JWKSFile)
+		}
+	default:
+		return nil, errors.New("no JWKS source configured")
+	}
+
+	if err := ks.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if env.JWKSRefreshInterval > 0 {
+		go ks.refreshLoop(ctx, env.JWKSRefreshInterval)
+	}
+	return ks, nil
+}
+
+func fetchJWKS(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch JWKS: unexpected status %d", res.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(res.Body, 1<<20))
+}
+
+func (ks *jwksKeySet) refreshLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ks.refresh(ctx); err != nil {
+				ks.logger.Error("failed to refresh JWKS", zap.Error(err))
+			}
+		}
+	}
+}
+
+// refresh replaces the key set atomically. On failure the previous keys stay
+// in use.
+func (ks *jwksKeySet) refresh(ctx context.Context) error {
+	ks.mu.Lock()
+	ks.lastAttempt = time.Now()
+	ks.mu.Unlock()
+
+	raw, err := ks.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	keys, err := ks.parse(raw)
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.mu.Unlock()
+	return nil
+}
+
+// Key refreshes the key set when no key matches kid, unless a refresh was
+// attempted within minUnknownKidRefresh, successful or not, so that a
+// failing source is not fetched on every request either.
+func (ks *jwksKeySet) Key(kid, alg string) (interface{}, error) {
+	key, err := ks.lookup(kid, alg)
+	if !errors.Is(err, ErrUnknownKey) {
+		return key, err
+	}
+
+	ks.mu.Lock()
+	recent := time.Since(ks.lastAttempt) < minUnknownKidRefresh
+	if !recent {
+		ks.lastAttempt = time.Now()
+	}
+	ks.mu.Unlock()
+	if recent {
+		return nil, err
+	}
+	if err := ks.refresh(context.Background()); err != nil {
+		ks.logger.Error("failed to refresh JWKS", zap.Error(err))
+		return nil, ErrUnknownKey
+	}
+	return ks.lookup(kid, alg)
+}
+
+func (ks *jwksKeySet) lookup(kid, alg string) (interface{}, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if kid != "" {
+		pk, ok := ks.keys[kid]
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+		if !pk.accepts(alg) {
+			return nil, ErrKeyAlgMismatch
+		}
+		return pk.key, nil
+	}
+
+	var match interface{}
+	for _, pk := range ks.keys {
+		if !pk.accepts(alg) {
+			continue
+		}
+		if match != nil {
+			return nil, ErrAmbiguousKey
+		}
+		match = pk.key
+	}
+	if match == nil {
+		return nil, ErrUnknownKey
+	}
+	return match, nil
+}
+
+// accepts reports whether the key type fits alg and, when the JWK pins an
+// alg, whether it is that one.
+func (pk publicKey) accepts(alg string) bool {
+	if pk.alg != "" && pk.alg != alg {
+		return false
+	}
+	switch pk.key.(type) {
+	case *rsa.PublicKey:
+		return alg == "RS256" || alg == "RS384" || alg == "RS512" || alg == "PS256" || alg == "PS384" || alg == "PS512"
+	case *ecdsa.PublicKey:
+		return alg == "ES256" || alg == "ES384" || alg == "ES512"
+	case ed25519.PublicKey:
+		return alg == "EdDSA"
+	}
+	return false
+}
+
+// parse reads the signing keys of a key set. Keys that are not usable for
+// signatures or that this service cannot parse are skipped, so a provider
+// publishing a new kind of key does not break the keys already in use; the
+// set is only rejected when no usable key remains.
+func (ks *jwksKeySet) parse(raw []byte) (map[string]publicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, fmt.Errorf("parse JWKS: %w", err)
+	}
+
+	keys := make(map[string]publicKey, len(set.Keys))
+	for i, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			ks.logger.Warn("failed to parse JSON web key, skipping it", zap.Int("index", i), zap.String("kid", jwk.Kid), zap.String("kty", jwk.Kty), zap.Error(err))
+			continue
+		}
+		kid := jwk.Kid
+		if kid == "" {
+			kid = fmt.Sprintf("#%d", i)
+		}
+		keys[kid] = publicKey{alg: jwk.Alg, key: key}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("parse JWKS: %w: no usable signing key", ErrUnsupportedJWKS)
+	}
+	return keys, nil
+}
+
+func (jwk jsonWebKey) publicKey() (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, ErrUnsupportedJWKS
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, ErrUnsupportedJWKS
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("EC point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, ErrUnsupportedJWKS
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, ErrUnsupportedJWKS
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
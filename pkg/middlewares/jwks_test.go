@@ -0,0 +1,332 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+)
+
+type JWKSSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	logger     *logger.MockILogger
+	ctx        context.Context
+	cancel     context.CancelFunc
+	rsaKey     *rsa.PrivateKey
+	ecKey      *ecdsa.PrivateKey
+	edKey      ed25519.PrivateKey
+	rotatedKey *rsa.PrivateKey
+}
+
+func (s *JWKSSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	var err error
+	s.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.rotatedKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	_, s.edKey, err = ed25519.GenerateKey(rand.Reader)
+	s.Require().NoError(err)
+
+	gin.SetMode(gin.TestMode)
+}
+
+func (s *JWKSSuite) TearDownTest() {
+	s.cancel()
+	s.ctrl.Finish()
+}
+
+func TestJWKSSuite(t *testing.T) {
+	suite.Run(t, new(JWKSSuite))
+}
+
+func encodeInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA", "kid": kid, "use": "sig", "alg": "RS256",
+		"n": encodeInt(key.N), "e": encodeInt(big.NewInt(int64(key.E))),
+	}
+}
+
+func (s *JWKSSuite) jwks(keys ...map[string]string) []byte {
+	raw, err := json.Marshal(map[string]interface{}{"keys": keys})
+	s.Require().NoError(err)
+	return raw
+}
+
+func (s *JWKSSuite) defaultJWKS() []byte {
+	return s.jwks(
+		rsaJWK("rsa-1", s.rsaKey),
+		map[string]string{
+			"kty": "EC", "kid": "ec-1", "crv": "P-256",
+			"x": encodeInt(s.ecKey.X), "y": encodeInt(s.ecKey.Y),
+		},
+		map[string]string{
+			"kty": "OKP", "kid": "ed-1", "crv": "Ed25519",
+			"x": base64.RawURLEncoding.EncodeToString(s.edKey.Public().(ed25519.PublicKey)),
+		},
+	)
+}
+
+func (s *JWKSSuite) writeJWKS(raw []byte) string {
+	path := filepath.Join(s.T().TempDir(), "jwks.json")
+	s.Require().NoError(os.WriteFile(path, raw, 0o600))
+	return path
+}
+
+func (s *JWKSSuite) sign(method jwt.SigningMethod, kid string, key interface{}) string {
+	token := jwt.NewWithClaims(method, jwt.MapClaims{
+		"sub":    "123",
+		"tenant": "acme",
+		"scope":  []interface{}{"read"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	s.Require().NoError(err)
+	return signed
+}
+
+func (s *JWKSSuite) serve(middleware IJWTMiddleware, token string) int {
+	router := gin.New()
+	router.GET("/test", middleware.RequireScope("read"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func (s *JWKSSuite) TestVerifiesEveryKeyType() {
+	authEnv := env.AuthEnv{JWKSFile: s.writeJWKS(s.defaultJWKS())}
+	keySet, err := LoadJWKS(s.ctx, authEnv, s.logger)
+	s.Require().NoError(err)
+	middleware := NewJWTMiddleware(authEnv, keySet, nil)
+
+	s.Equal(http.StatusOK, s.serve(middleware, s.sign(jwt.SigningMethodRS256, "rsa-1", s.rsaKey)))
+	s.Equal(http.StatusOK, s.serve(middleware, s.sign(jwt.SigningMethodES256, "ec-1", s.ecKey)))
+	s.Equal(http.StatusOK, s.serve(middleware, s.sign(jwt.SigningMethodEdDSA, "ed-1", s.edKey)))
+}
+
+func (s *JWKSSuite) TestRejectsWrongKeyForKid() {
+	authEnv := env.AuthEnv{JWKSFile: s.writeJWKS(s.defaultJWKS())}
+	keySet, err := LoadJWKS(s.ctx, authEnv, s.logger)
+	s.Require().NoError(err)
+	middleware := NewJWTMiddleware(authEnv, keySet, nil)
+
+	s.Equal(http.StatusUnauthorized, s.serve(middleware, s.sign(jwt.SigningMethodRS256, "rsa-1", s.rotatedKey)))
+	s.Equal(http.StatusUnauthorized, s.serve(middleware, s.sign(jwt.SigningMethodES256, "rsa-1", s.ecKey)))
+}
+
+func (s *JWKSSuite) TestPinsAlgorithms() {
+	authEnv := env.AuthEnv{JWKSFile: s.writeJWKS(s.defaultJWKS()), JWTAlgorithms: []string{"ES256"}}
+	keySet, err := LoadJWKS(s.ctx, authEnv, s.logger)
+	s.Require().NoError(err)
+	middleware := NewJWTMiddleware(authEnv, keySet, nil)
+
+	s.Equal(http.StatusOK, s.serve(middleware, s.sign(jwt.SigningMethodES256, "ec-1", s.ecKey)))
+	s.Equal(http.StatusUnauthorized, s.serve(middleware, s.sign(jwt.SigningMethodRS256, "rsa-1", s.rsaKey)))
+}
+
+func (s *JWKSSuite) TestRejectsAlgorithmConfusion() {
+	authEnv := env.AuthEnv{JWKSFile: s.writeJWKS(s.defaultJWKS())}
+	keySet, err := LoadJWKS(s.ctx, authEnv, s.logger)
+	s.Require().NoError(err)
+	middleware := NewJWTMiddleware(authEnv, keySet, nil)
+
+	// An attacker who knows the public key must not be able to use it as an
+	// HMAC secret.
+	publicKey, err := x509.MarshalPKIXPublicKey(&s.rsaKey.PublicKey)
+	s.Require().NoError(err)
+	s.Equal(http.StatusUnauthorized, s.serve(middleware, s.sign(jwt.SigningMethodHS256, "rsa-1", publicKey)))
+	s.Equal(http.StatusUnauthorized, s.serve(middleware, s.sign(jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType)))
+}
+
+func (s *JWKSSuite) TestHMACAndJWKSTogether() {
+	authEnv := env.AuthEnv{JWTSecret: "test-secret-key", JWKSFile: s.writeJWKS(s.defaultJWKS())}
+	keySet, err := LoadJWKS(s.ctx, authEnv, s.logger)
+	s.Require().NoError(err)
+	middleware := NewJWTMiddleware(authEnv, keySet, nil)
+
+	s.Equal(http.StatusOK, s.serve(middleware, s.sign(jwt.SigningMethodHS256, "", []byte("test-secret-key"))))
+	s.Equal(http.StatusOK, s.serve(middleware, s.sign(jwt.SigningMethodRS256, "rsa-1", s.rsaKey)))
+}
+
+func (s *JWKSSuite) TestSingleKeyWithoutKid() {
+	authEnv := env.AuthEnv{JWKSFile: s.writeJWKS(s.jwks(rsaJWK("", s.rsaKey)))}
+	keySet, err := LoadJWKS(s.ctx, authEnv, s.logger)
+	s.Require().NoError(err)
+	middleware := NewJWTMiddleware(authEnv, keySet, nil)
+
+	s.Equal(http.StatusOK, s.serve(middleware, s.sign(jwt.SigningMethodRS256, "", s.rsaKey)))
+}
+
+func (s *JWKSSuite) TestAmbiguousKeyWithoutKid() {
+	keySet, err := LoadJWKS(s.ctx, env.AuthEnv{JWKSFile: s.writeJWKS(s.jwks(rsaJWK("a", s.rsaKey), rsaJWK("b", s.rotatedKey)))}, s.logger)
+	s.Require().NoError(err)
+
+	_, err = keySet.Key("", "RS256")
+	s.ErrorIs(err, ErrAmbiguousKey)
+}
+
+func (s *JWKSSuite) TestRotationOverURL() {
+	var current atomic.Value
+	current.Store(s.jwks(rsaJWK("old", s.rsaKey)))
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		_, _ = w.Write(current.Load().([]byte))
+	}))
+	defer server.Close()
+
+	authEnv := env.AuthEnv{JWKSURL: server.URL, JWKSRefreshInterval: 20 * time.Millisecond}
+	keySet, err := LoadJWKS(s.ctx, authEnv, s.logger)
+	s.Require().NoError(err)
+	middleware := NewJWTMiddleware(authEnv, keySet, nil)
+	s.Equal(http.StatusOK, s.serve(middleware, s.sign(jwt.SigningMethodRS256, "old", s.rsaKey)))
+
+	// Both keys are published during the overlap window.
+	current.Store(s.jwks(rsaJWK("old", s.rsaKey), rsaJWK("new", s.rotatedKey)))
+	s.Eventually(func() bool {
+		return s.serve(middleware, s.sign(jwt.SigningMethodRS256, "new", s.rotatedKey)) == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+	s.Equal(http.StatusOK, s.serve(middleware, s.sign(jwt.SigningMethodRS256, "old", s.rsaKey)))
+
+	current.Store(s.jwks(rsaJWK("new", s.rotatedKey)))
+	s.Eventually(func() bool {
+		return s.serve(middleware, s.sign(jwt.SigningMethodRS256, "old", s.rsaKey)) == http.StatusUnauthorized
+	}, time.Second, 10*time.Millisecond)
+	s.Greater(fetches.Load(), int32(2))
+}
+
+func (s *JWKSSuite) TestUnknownKidForcesRefresh() {
+	path := s.writeJWKS(s.jwks(rsaJWK("old", s.rsaKey)))
+	keySet, err := LoadJWKS(s.ctx, env.AuthEnv{JWKSFile: path}, s.logger)
+	s.Require().NoError(err)
+
+	s.Require().NoError(os.WriteFile(path, s.jwks(rsaJWK("new", s.rotatedKey)), 0o600))
+	_, err = keySet.Key("new", "RS256")
+	s.ErrorIs(err, ErrUnknownKey, "refresh is rate limited right after loading")
+
+	keySet.(*jwksKeySet).lastAttempt = time.Now().Add(-minUnknownKidRefresh)
+	key, err := keySet.Key("new", "RS256")
+	s.NoError(err)
+	s.Equal(&s.rotatedKey.PublicKey, key)
+}
+
+func (s *JWKSSuite) TestRefreshFailureKeepsKeys() {
+	var fail atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(s.jwks(rsaJWK("old", s.rsaKey)))
+	}))
+	defer server.Close()
+
+	s.logger.EXPECT().Error("failed to refresh JWKS", gomock.Any()).MinTimes(1)
+	keySet, err := LoadJWKS(s.ctx, env.AuthEnv{JWKSURL: server.URL, JWKSRefreshInterval: 10 * time.Millisecond}, s.logger)
+	s.Require().NoError(err)
+
+	fail.Store(true)
+	time.Sleep(50 * time.Millisecond)
+	_, err = keySet.Key("old", "RS256")
+	s.NoError(err)
+	s.cancel()
+}
+
+func (s *JWKSSuite) TestUnsupportedKeysAreSkipped() {
+	raw := s.jwks(
+		map[string]string{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"},
+		map[string]string{"kty": "EC", "kid": "secp256k1", "crv": "secp256k1", "x": "AQAB", "y": "AQAB"},
+		map[string]string{"kty": "RSA", "kid": "encryption", "use": "enc", "n": "AQAB", "e": "AQAB"},
+		rsaJWK("rsa-1", s.rsaKey),
+	)
+	s.logger.EXPECT().Warn("failed to parse JSON web key, skipping it", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+	keySet, err := LoadJWKS(s.ctx, env.AuthEnv{JWKSFile: s.writeJWKS(raw)}, s.logger)
+	s.Require().NoError(err)
+
+	key, err := keySet.Key("rsa-1", "RS256")
+	s.NoError(err)
+	s.Equal(&s.rsaKey.PublicKey, key)
+}
+
+func (s *JWKSSuite) TestFailedRefreshIsRateLimited() {
+	var fail atomic.Bool
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(s.jwks(rsaJWK("old", s.rsaKey)))
+	}))
+	defer server.Close()
+
+	keySet, err := LoadJWKS(s.ctx, env.AuthEnv{JWKSURL: server.URL}, s.logger)
+	s.Require().NoError(err)
+
+	fail.Store(true)
+	keySet.(*jwksKeySet).lastAttempt = time.Now().Add(-minUnknownKidRefresh)
+	s.logger.EXPECT().Error("failed to refresh JWKS", gomock.Any()).Times(1)
+	for i := 0; i < 3; i++ {
+		_, err = keySet.Key("unknown", "RS256")
+		s.ErrorIs(err, ErrUnknownKey)
+	}
+	s.Equal(int32(2), fetches.Load())
+}
+
+func (s *JWKSSuite) TestLoadErrors() {
+	_, err := LoadJWKS(s.ctx, env.AuthEnv{}, s.logger)
+	s.Error(err)
+
+	_, err = LoadJWKS(s.ctx, env.AuthEnv{JWKSFile: filepath.Join(s.T().TempDir(), "missing.json")}, s.logger)
+	s.Error(err)
+
+	s.logger.EXPECT().Warn("failed to parse JSON web key, skipping it", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+	_, err = LoadJWKS(s.ctx, env.AuthEnv{JWKSFile: s.writeJWKS([]byte(`{"keys":[{"kty":"oct","k":"c2VjcmV0"}]}`))}, s.logger)
+	s.ErrorIs(err, ErrUnsupportedJWKS)
+
+	_, err = LoadJWKS(s.ctx, env.AuthEnv{JWKSFile: s.writeJWKS([]byte(`{"keys":[{"kty":"RSA","use":"enc","n":"AQAB","e":"AQAB"}]}`))}, s.logger)
+	s.ErrorIs(err, ErrUnsupportedJWKS)
+
+	_, err = LoadJWKS(s.ctx, env.AuthEnv{JWKSFile: s.writeJWKS([]byte(`not json`))}, s.logger)
+	s.Error(err)
+}
@@ -1,59 +1,307 @@
 package middlewares
 
 import (
+	"context"
+	"errors"
+	"math"
 	"net/http"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/audit"
 	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/scopes"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
 )
 
+// ErrRevocationUnavailable is returned by Verify when the revocation state
+// cannot be read, so whether the token is still good is unknown.
+var ErrRevocationUnavailable = errors.New("token revocation state is unavailable")
+
+// ITokenVerifier checks a token the way the middleware does before looking
+// at its scopes: signature, registered claims and revocation. Any error but
+// ErrRevocationUnavailable means the token is not good.
+type ITokenVerifier interface {
+	Verify(ctx context.Context, tokenString string) (jwt.MapClaims, error)
+}
+
 type IJWTMiddleware interface {
+	ITokenVerifier
 	RequireScope(requiredScope string) gin.HandlerFunc
+	RequireAllScopes(scopes ...string) gin.HandlerFunc
+	RequireAnyScope(scopes ...string) gin.HandlerFunc
+	Require(requirement ScopeRequirement) gin.HandlerFunc
 }
 
 type jwtMiddleware struct {
-	jwtSecret []byte
+	jwtSecret     []byte
+	keySet        IKeySet
+	algorithms    []string
+	issuer        string
+	audiences     []string
+	requireExpiry bool
+	maxTokenAge   time.Duration
+	clockSkew     time.Duration
+	platformOrg   string
+	revocations   revocation.IStore
 }
 
-func NewJWTMiddleware(env env.AuthEnv) IJWTMiddleware {
+// NewJWTMiddleware verifies HMAC tokens with env.JWTSecret and asymmetric
+// tokens with keySet, which may be nil when only HMAC is in use. Tokens
+// revoked in revocations are refused; a nil store skips the check.
+func NewJWTMiddleware(env env.AuthEnv, keySet IKeySet, revocations revocation.IStore) IJWTMiddleware {
+	algorithms := env.JWTAlgorithms
+	if len(algorithms) == 0 {
+		if env.JWTSecret != "" {
+			algorithms = append(algorithms, "HS256")
+		}
+		if keySet != nil {
+			algorithms = append(algorithms, "RS256", "ES256", "EdDSA")
+		}
+	}
+
 	return &jwtMiddleware{
-		jwtSecret: []byte(env.JWTSecret),
+		jwtSecret:     []byte(env.JWTSecret),
+		keySet:        keySet,
+		algorithms:    algorithms,
+		issuer:        env.JWTIssuer,
+		audiences:     env.JWTAudiences,
+		requireExpiry: env.JWTRequireExpiry,
+		maxTokenAge:   env.JWTMaxTokenAge,
+		clockSkew:     env.JWTClockSkew,
+		platformOrg:   env.PlatformOrganization,
+		revocations:   revocations,
+	}
+}
+
+// keyFunc picks the verification key by token family. The parser has already
+// rejected algorithms outside m.algorithms, so an RSA public key can never be
+// used as an HMAC secret.
+func (m *jwtMiddleware) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if len(m.jwtSecret) == 0 {
+			return nil, errors.New("HMAC tokens are not accepted")
+		}
+		return m.jwtSecret, nil
+	}
+
+	if m.keySet == nil {
+		return nil, errors.New("asymmetric tokens are not accepted")
+	}
+	kid, _ := token.Header["kid"].(string)
+	return m.keySet.Key(kid, token.Method.Alg())
+}
+
+// claimError is a registered-claim rejection. Code is one of the dto.CodeToken*
+// constants.
+type claimError struct {
+	code    string
+	message string
+}
+
+func (e *claimError) Error() string {
+	return e.message
+}
+
+// validateClaims checks the registered claims. The parser only verifies the
+// signature, because its own validation reports every missing claim with the
+// same error and so cannot produce a distinct code per rejection.
+func (m *jwtMiddleware) validateClaims(claims jwt.MapClaims, now time.Time) *claimError {
+	exp, err := claims.GetExpirationTime()
+	if err != nil {
+		return &claimError{dto.CodeInvalidClaims, "Malformed exp claim"}
+	}
+	if exp == nil && m.requireExpiry {
+		return &claimError{dto.CodeTokenExpiryMissing, "Token has no expiry"}
+	}
+	if exp != nil && !now.Before(exp.Add(m.clockSkew)) {
+		return &claimError{dto.CodeTokenExpired, "Token has expired"}
+	}
+
+	nbf, err := claims.GetNotBefore()
+	if err != nil {
+		return &claimError{dto.CodeInvalidClaims, "Malformed nbf claim"}
+	}
+	if nbf != nil && now.Add(m.clockSkew).Before(nbf.Time) {
+		return &claimError{dto.CodeTokenNotYetValid, "Token is not valid yet"}
+	}
+
+	iat, err := claims.GetIssuedAt()
+	if err != nil {
+		return &claimError{dto.CodeInvalidClaims, "Malformed iat claim"}
+	}
+	if iat != nil && now.Add(m.clockSkew).Before(iat.Time) {
+		return &claimError{dto.CodeTokenIssuedInFuture, "Token was issued in the future"}
+	}
+	if m.maxTokenAge > 0 {
+		if iat == nil {
+			return &claimError{dto.CodeTokenIssuedAtMissing, "Token has no issue time"}
+		}
+		if now.Sub(iat.Time) > m.maxTokenAge+m.clockSkew {
+			return &claimError{dto.CodeTokenTooOld, "Token is too old"}
+		}
+	}
+
+	if m.issuer != "" {
+		iss, err := claims.GetIssuer()
+		if err != nil || iss != m.issuer {
+			return &claimError{dto.CodeTokenInvalidIssuer, "Token was issued by an untrusted issuer"}
+		}
+	}
+
+	if len(m.audiences) > 0 {
+		aud, err := claims.GetAudience()
+		if err != nil || !slices.ContainsFunc(aud, func(a string) bool { return slices.Contains(m.audiences, a) }) {
+			return &claimError{dto.CodeTokenInvalidAudience, "Token is not intended for this service"}
+		}
+	}
+	return nil
+}
+
+// issuedAt reads iat to the millisecond, which NumericDate rounds to the
+// second, so that a token issued right after a revocation is told apart
+// from those it revoked.
+func issuedAt(claims jwt.MapClaims) *time.Time {
+	if iat, ok := claims["iat"].(float64); ok {
+		t := time.UnixMilli(int64(math.Round(iat * 1e3)))
+		return &t
+	}
+	if iat, err := claims.GetIssuedAt(); err == nil && iat != nil {
+		return &iat.Time
+	}
+	return nil
+}
+
+// checkRevocation refuses tokens revoked by jti, by the refresh session
+// named in sid, or by their subject's or the global cutoff. It fails closed: when the revocation state cannot be read the
+// token is refused with a 503 rather than let through.
+func (m *jwtMiddleware) checkRevocation(ctx context.Context, claims jwt.MapClaims) (int, *claimError) {
+	sub, _ := claims["sub"].(string)
+	if m.revocations == nil || sub == "" {
+		return 0, nil
+	}
+
+	token := revocation.Token{UserID: sub}
+	token.ID, _ = claims["jti"].(string)
+	token.SessionID, _ = claims["sid"].(string)
+	token.IssuedAt = issuedAt(claims)
+
+	revoked, err := m.revocations.IsRevoked(ctx, token)
+	if err != nil {
+		return http.StatusServiceUnavailable, &claimError{dto.CodeRevocationUnavailable, "Token revocation state is unavailable"}
+	}
+	if revoked {
+		return http.StatusUnauthorized, &claimError{dto.CodeTokenRevoked, "Token has been revoked"}
 	}
+	return 0, nil
+}
+
+// resolveTenant reads the tenant claim. A super-admin acts across every
+// organization unless it picks one with the X-Organization-ID header; anyone
+// else may only name their own organization there.
+func (m *jwtMiddleware) resolveTenant(c *gin.Context, claims jwt.MapClaims, granted []string) (tenancy.Tenant, *claimError) {
+	organizationId, _ := claims[tenancy.Claim].(string)
+	if organizationId == "" {
+		return tenancy.Tenant{}, &claimError{dto.CodeTenantMissing, "Token has no tenant"}
+	}
+
+	super := m.platformOrg != "" && organizationId == m.platformOrg && scopes.Grants(granted, tenancy.SuperAdminScope)
+	requested := c.GetHeader(tenancy.Header)
+	switch {
+	case requested == "" || requested == organizationId:
+		return tenancy.Tenant{OrganizationID: organizationId, Super: super, CrossTenant: super && requested == ""}, nil
+	case super:
+		return tenancy.Tenant{OrganizationID: requested, Super: true}, nil
+	}
+	return tenancy.Tenant{}, &claimError{dto.CodeTenantForbidden, "Token cannot act in another organization"}
+}
+
+// abortAuth keeps the historical "error" strings, which clients already match
+// on, and adds a code per rejection reason.
+func abortAuth(c *gin.Context, status int, code, message, legacyError string) {
+	c.AbortWithStatusJSON(status, dto.APIResponse{
+		Success: false,
+		Code:    code,
+		Message: message,
+		Error:   legacyError,
+	})
 }
 
+// RequireScope requires a single scope; an empty scope admits any
+// authenticated caller.
 func (m *jwtMiddleware) RequireScope(requiredScope string) gin.HandlerFunc {
+	if requiredScope == "" {
+		return m.Require(Authenticated())
+	}
+	return m.Require(AllOf(requiredScope))
+}
+
+func (m *jwtMiddleware) RequireAllScopes(scopes ...string) gin.HandlerFunc {
+	return m.Require(AllOf(scopes...))
+}
+
+func (m *jwtMiddleware) RequireAnyScope(scopes ...string) gin.HandlerFunc {
+	return m.Require(AnyOf(scopes...))
+}
+
+// Verify lets a handler check a token that is not its caller's own, such as
+// one submitted for introspection.
+func (m *jwtMiddleware) Verify(ctx context.Context, tokenString string) (jwt.MapClaims, error) {
+	claims, status, claimErr := m.verify(ctx, tokenString)
+	if status == http.StatusServiceUnavailable {
+		return nil, ErrRevocationUnavailable
+	}
+	if claimErr != nil {
+		return nil, claimErr
+	}
+	return claims, nil
+}
+
+// verify returns the token's claims, or the status and reason to reject it
+// with.
+func (m *jwtMiddleware) verify(ctx context.Context, tokenString string) (jwt.MapClaims, int, *claimError) {
+	jwtToken, err := jwt.Parse(tokenString, m.keyFunc, jwt.WithValidMethods(m.algorithms), jwt.WithoutClaimsValidation())
+	if err != nil || !jwtToken.Valid {
+		return nil, http.StatusUnauthorized, &claimError{dto.CodeTokenInvalid, "Token signature or format is invalid"}
+	}
+
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, http.StatusUnauthorized, &claimError{dto.CodeInvalidClaims, "Token claims are invalid"}
+	}
+
+	if claimErr := m.validateClaims(claims, time.Now()); claimErr != nil {
+		return nil, http.StatusUnauthorized, claimErr
+	}
+
+	if status, claimErr := m.checkRevocation(ctx, claims); claimErr != nil {
+		return nil, status, claimErr
+	}
+	return claims, 0, nil
+}
+
+func (m *jwtMiddleware) Require(requirement ScopeRequirement) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if !strings.HasPrefix(authHeader, "Bearer ") {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing or invalid token"})
-			c.Abort()
+			abortAuth(c, http.StatusUnauthorized, dto.CodeTokenMissing, "Missing bearer token", "Missing or invalid token")
 			return
 		}
 
-		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-		jwtToken, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			return m.jwtSecret, nil
-		})
-		if err != nil || !jwtToken.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
-			return
-		}
-
-		claims, ok := jwtToken.Claims.(jwt.MapClaims)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid claims"})
-			c.Abort()
+		claims, status, claimErr := m.verify(c.Request.Context(), strings.TrimPrefix(authHeader, "Bearer "))
+		if claimErr != nil {
+			abortAuth(c, status, claimErr.code, claimErr.message, "Invalid token")
 			return
 		}
 
 		rawScopes, ok := claims["scope"].([]interface{})
 		if !ok {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid scope format"})
-			c.Abort()
+			abortAuth(c, http.StatusForbidden, dto.CodeInvalidClaims, "Token scope claim is not a list", "Invalid scope format")
 			return
 		}
 
@@ -64,18 +312,40 @@ func (m *jwtMiddleware) RequireScope(requiredScope string) gin.HandlerFunc {
 			}
 		}
 
-		if found := (slices.Contains(tokens, requiredScope) || requiredScope == ""); !found {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient scope"})
-			c.Abort()
+		if !requirement.SatisfiedBy(tokens) {
+			abortAuth(c, http.StatusForbidden, dto.CodeInsufficientScope, "Token lacks the required scope", "Insufficient scope")
 			return
 		}
 
 		if sub, ok := claims["sub"].(string); ok {
 			c.Set("userId", sub)
 		} else {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Insufficient userId"})
+			abortAuth(c, http.StatusUnauthorized, dto.CodeInvalidClaims, "Token has no subject", "Insufficient userId")
+			return
+		}
+
+		tenant, claimErr := m.resolveTenant(c, claims, tokens)
+		if claimErr != nil {
+			status := http.StatusUnauthorized
+			if claimErr.code == dto.CodeTenantForbidden {
+				status = http.StatusForbidden
+			}
+			abortAuth(c, status, claimErr.code, claimErr.message, "Invalid tenant")
 			return
 		}
+		c.Set("scopes", tokens)
+		c.Set("tenant", tenant.OrganizationID)
+		if jti, ok := claims["jti"].(string); ok {
+			c.Set("tokenId", jti)
+		}
+		if sid, ok := claims["sid"].(string); ok {
+			c.Set("sessionId", sid)
+		}
+		if exp, _ := claims.GetExpirationTime(); exp != nil {
+			c.Set("tokenExpiresAt", exp.Time)
+		}
+		ctx := tenancy.WithTenant(c.Request.Context(), tenant)
+		c.Request = c.Request.WithContext(audit.WithActor(ctx, c.GetString("userId")))
 		c.Next()
 	}
 }
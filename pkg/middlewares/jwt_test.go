@@ -13,7 +13,12 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/audit"
 	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+	pkgrevocation "github.com/vnFuhung2903/vcs-user-management-service/pkg/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
 )
 
 type JWTMiddlewareSuite struct {
@@ -34,7 +39,7 @@ func (s *JWTMiddlewareSuite) SetupTest() {
 		JWTSecret: s.testSecret,
 	}
 
-	s.jwtMiddleware = NewJWTMiddleware(authEnv)
+	s.jwtMiddleware = NewJWTMiddleware(authEnv, nil, nil)
 
 	gin.SetMode(gin.TestMode)
 	s.router = gin.New()
@@ -50,11 +55,12 @@ func TestJWTMiddlewareSuite(t *testing.T) {
 
 func (s *JWTMiddlewareSuite) TestRequireScope() {
 	claims := jwt.MapClaims{
-		"sub":   "123",
-		"name":  "testuser",
-		"scope": []interface{}{"read", "write"},
-		"exp":   time.Now().Add(time.Hour).Unix(),
-		"iat":   time.Now().Unix(),
+		"sub":    "123",
+		"tenant": "acme",
+		"name":   "testuser",
+		"scope":  []interface{}{"read", "write"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"iat":    time.Now().Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(s.testSecret))
@@ -64,6 +70,10 @@ func (s *JWTMiddlewareSuite) TestRequireScope() {
 		userId, exists := c.Get("userId")
 		s.True(exists)
 		s.Equal("123", userId)
+		s.Equal([]string{"read", "write"}, c.GetStringSlice("scopes"))
+		origin, ok := audit.FromContext(c.Request.Context())
+		s.True(ok)
+		s.Equal("123", origin.ActorID)
 		c.JSON(http.StatusOK, gin.H{"message": "success"})
 	})
 
@@ -135,11 +145,12 @@ func (s *JWTMiddlewareSuite) TestRequireScopeInvalidToken() {
 
 func (s *JWTMiddlewareSuite) TestRequireScopeExpiredToken() {
 	claims := jwt.MapClaims{
-		"sub":   "123",
-		"name":  "testuser",
-		"scope": []interface{}{"read", "write"},
-		"exp":   time.Now().Add(-time.Hour).Unix(),
-		"iat":   time.Now().Add(-time.Hour * 2).Unix(),
+		"sub":    "123",
+		"tenant": "acme",
+		"name":   "testuser",
+		"scope":  []interface{}{"read", "write"},
+		"exp":    time.Now().Add(-time.Hour).Unix(),
+		"iat":    time.Now().Add(-time.Hour * 2).Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(s.testSecret))
@@ -164,11 +175,12 @@ func (s *JWTMiddlewareSuite) TestRequireScopeExpiredToken() {
 
 func (s *JWTMiddlewareSuite) TestRequireScopeWrongSecret() {
 	claims := jwt.MapClaims{
-		"sub":   "123",
-		"name":  "testuser",
-		"scope": []interface{}{"read", "write"},
-		"exp":   time.Now().Add(time.Hour).Unix(),
-		"iat":   time.Now().Unix(),
+		"sub":    "123",
+		"tenant": "acme",
+		"name":   "testuser",
+		"scope":  []interface{}{"read", "write"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"iat":    time.Now().Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte("wrong-secret"))
@@ -193,11 +205,12 @@ func (s *JWTMiddlewareSuite) TestRequireScopeWrongSecret() {
 
 func (s *JWTMiddlewareSuite) TestRequireScopeInvalidScopeFormat() {
 	claims := jwt.MapClaims{
-		"sub":   "123",
-		"name":  "testuser",
-		"scope": "invalid-scope-format",
-		"exp":   time.Now().Add(time.Hour).Unix(),
-		"iat":   time.Now().Unix(),
+		"sub":    "123",
+		"tenant": "acme",
+		"name":   "testuser",
+		"scope":  "invalid-scope-format",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"iat":    time.Now().Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(s.testSecret))
@@ -222,11 +235,12 @@ func (s *JWTMiddlewareSuite) TestRequireScopeInvalidScopeFormat() {
 
 func (s *JWTMiddlewareSuite) TestRequireScopeInsufficientScope() {
 	claims := jwt.MapClaims{
-		"sub":   "123",
-		"name":  "testuser",
-		"scope": []interface{}{"write"},
-		"exp":   time.Now().Add(time.Hour).Unix(),
-		"iat":   time.Now().Unix(),
+		"sub":    "123",
+		"tenant": "acme",
+		"name":   "testuser",
+		"scope":  []interface{}{"write"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"iat":    time.Now().Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(s.testSecret))
@@ -251,11 +265,12 @@ func (s *JWTMiddlewareSuite) TestRequireScopeInsufficientScope() {
 
 func (s *JWTMiddlewareSuite) TestRequireScopeNoScope() {
 	claims := jwt.MapClaims{
-		"sub":   "123",
-		"name":  "testuser",
-		"scope": []interface{}{"write"},
-		"exp":   time.Now().Add(time.Hour).Unix(),
-		"iat":   time.Now().Unix(),
+		"sub":    "123",
+		"tenant": "acme",
+		"name":   "testuser",
+		"scope":  []interface{}{"write"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"iat":    time.Now().Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(s.testSecret))
@@ -308,11 +323,12 @@ func (s *JWTMiddlewareSuite) TestRequireScopeMissingUserId() {
 
 func (s *JWTMiddlewareSuite) TestRequireScopeInvalidUserIdType() {
 	claims := jwt.MapClaims{
-		"sub":   123,
-		"name":  "testuser",
-		"scope": []interface{}{"read", "write"},
-		"exp":   time.Now().Add(time.Hour).Unix(),
-		"iat":   time.Now().Unix(),
+		"sub":    123,
+		"tenant": "acme",
+		"name":   "testuser",
+		"scope":  []interface{}{"read", "write"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"iat":    time.Now().Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(s.testSecret))
@@ -337,11 +353,12 @@ func (s *JWTMiddlewareSuite) TestRequireScopeInvalidUserIdType() {
 
 func (s *JWTMiddlewareSuite) TestRequireScopeWithNonStringScopes() {
 	claims := jwt.MapClaims{
-		"sub":   "123",
-		"name":  "testuser",
-		"scope": []interface{}{"read", 123, "write", nil},
-		"exp":   time.Now().Add(time.Hour).Unix(),
-		"iat":   time.Now().Unix(),
+		"sub":    "123",
+		"tenant": "acme",
+		"name":   "testuser",
+		"scope":  []interface{}{"read", 123, "write", nil},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"iat":    time.Now().Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(s.testSecret))
@@ -366,3 +383,315 @@ func (s *JWTMiddlewareSuite) TestRequireScopeWithNonStringScopes() {
 	s.NoError(err)
 	s.Equal("success", response["message"])
 }
+
+func (s *JWTMiddlewareSuite) TestRequireScopeClaimValidation() {
+	middleware := NewJWTMiddleware(env.AuthEnv{
+		JWTSecret:        s.testSecret,
+		JWTIssuer:        "vcs-auth",
+		JWTAudiences:     []string{"vcs-user-management", "vcs-admin"},
+		JWTRequireExpiry: true,
+		JWTMaxTokenAge:   time.Hour,
+		JWTClockSkew:     30 * time.Second,
+	}, nil, nil)
+	s.router.GET("/test", middleware.RequireScope("read"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	now := time.Now()
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":    "123",
+			"tenant": "acme",
+			"scope":  []interface{}{"read"},
+			"iss":    "vcs-auth",
+			"aud":    []interface{}{"vcs-admin"},
+			"exp":    now.Add(time.Minute).Unix(),
+			"iat":    now.Unix(),
+		}
+	}
+
+	cases := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+		status int
+		code   string
+	}{
+		{"valid", func(jwt.MapClaims) {}, http.StatusOK, ""},
+		{"single string audience", func(c jwt.MapClaims) { c["aud"] = "vcs-user-management" }, http.StatusOK, ""},
+		{"expired within skew", func(c jwt.MapClaims) { c["exp"] = now.Add(-10 * time.Second).Unix() }, http.StatusOK, ""},
+		{"missing exp", func(c jwt.MapClaims) { delete(c, "exp") }, http.StatusUnauthorized, dto.CodeTokenExpiryMissing},
+		{"expired", func(c jwt.MapClaims) { c["exp"] = now.Add(-time.Minute).Unix() }, http.StatusUnauthorized, dto.CodeTokenExpired},
+		{"malformed exp", func(c jwt.MapClaims) { c["exp"] = "tomorrow" }, http.StatusUnauthorized, dto.CodeInvalidClaims},
+		{"not yet valid", func(c jwt.MapClaims) { c["nbf"] = now.Add(time.Minute).Unix() }, http.StatusUnauthorized, dto.CodeTokenNotYetValid},
+		{"issued in future", func(c jwt.MapClaims) { c["iat"] = now.Add(time.Minute).Unix() }, http.StatusUnauthorized, dto.CodeTokenIssuedInFuture},
+		{"missing iat", func(c jwt.MapClaims) { delete(c, "iat") }, http.StatusUnauthorized, dto.CodeTokenIssuedAtMissing},
+		{"too old", func(c jwt.MapClaims) { c["iat"] = now.Add(-2 * time.Hour).Unix() }, http.StatusUnauthorized, dto.CodeTokenTooOld},
+		{"wrong issuer", func(c jwt.MapClaims) { c["iss"] = "other-service" }, http.StatusUnauthorized, dto.CodeTokenInvalidIssuer},
+		{"missing issuer", func(c jwt.MapClaims) { delete(c, "iss") }, http.StatusUnauthorized, dto.CodeTokenInvalidIssuer},
+		{"wrong audience", func(c jwt.MapClaims) { c["aud"] = []interface{}{"vcs-billing"} }, http.StatusUnauthorized, dto.CodeTokenInvalidAudience},
+		{"missing audience", func(c jwt.MapClaims) { delete(c, "aud") }, http.StatusUnauthorized, dto.CodeTokenInvalidAudience},
+	}
+
+	for _, tc := range cases {
+		claims := valid()
+		tc.mutate(claims)
+		tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.testSecret))
+		s.Require().NoError(err)
+
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+
+		s.Equal(tc.status, w.Code, tc.name)
+		if tc.code != "" {
+			var response dto.APIResponse
+			s.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+			s.Equal(tc.code, response.Code, tc.name)
+			s.Equal("Invalid token", response.Error, tc.name)
+		}
+	}
+}
+
+func (s *JWTMiddlewareSuite) TestRequireScopeErrorCodes() {
+	s.router.GET("/test", s.jwtMiddleware.RequireScope("admin"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	serve := func(header string) dto.APIResponse {
+		req, _ := http.NewRequest("GET", "/test", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+
+		var response dto.APIResponse
+		s.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	s.Equal(dto.CodeTokenMissing, serve("").Code)
+	s.Equal(dto.CodeTokenInvalid, serve("Bearer invalid.token.here").Code)
+
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":    "123",
+		"tenant": "acme",
+		"scope":  []interface{}{"read"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(s.testSecret))
+	s.Require().NoError(err)
+	s.Equal(dto.CodeInsufficientScope, serve("Bearer "+tokenString).Code)
+}
+
+func (s *JWTMiddlewareSuite) TestRequireAllAndAnyScopes() {
+	s.router.GET("/all", s.jwtMiddleware.RequireAllScopes("read", "write"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	s.router.GET("/any", s.jwtMiddleware.RequireAnyScope("admin", "write"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	serve := func(path string, scopes ...interface{}) int {
+		tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub":    "123",
+			"tenant": "acme",
+			"scope":  scopes,
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		}).SignedString([]byte(s.testSecret))
+		s.Require().NoError(err)
+
+		req, _ := http.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	s.Equal(http.StatusOK, serve("/all", "read", "write"))
+	s.Equal(http.StatusForbidden, serve("/all", "read"))
+	s.Equal(http.StatusOK, serve("/any", "write"))
+	s.Equal(http.StatusForbidden, serve("/any", "read"))
+}
+
+func (s *JWTMiddlewareSuite) TestRequireTenant() {
+	jwtMiddleware := NewJWTMiddleware(env.AuthEnv{JWTSecret: s.testSecret, PlatformOrganization: "default"}, nil, nil)
+	var resolved tenancy.Tenant
+	s.router.GET("/test", jwtMiddleware.RequireScope("read"), func(c *gin.Context) {
+		resolved, _ = tenancy.FromContext(c.Request.Context())
+		s.Equal(resolved.OrganizationID, c.GetString("tenant"))
+		c.Status(http.StatusOK)
+	})
+
+	serve := func(tenant interface{}, header string, scopes ...interface{}) (int, string) {
+		claims := jwt.MapClaims{
+			"sub":   "123",
+			"scope": scopes,
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+		if tenant != nil {
+			claims["tenant"] = tenant
+		}
+		tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.testSecret))
+		s.Require().NoError(err)
+
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		if header != "" {
+			req.Header.Set(tenancy.Header, header)
+		}
+		w := httptest.NewRecorder()
+		resolved = tenancy.Tenant{}
+		s.router.ServeHTTP(w, req)
+
+		var response dto.APIResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Code
+	}
+
+	status, code := serve(nil, "", "read")
+	s.Equal(http.StatusUnauthorized, status)
+	s.Equal(dto.CodeTenantMissing, code)
+
+	status, code = serve(42, "", "read")
+	s.Equal(http.StatusUnauthorized, status)
+	s.Equal(dto.CodeTenantMissing, code)
+
+	status, _ = serve("acme", "", "read")
+	s.Equal(http.StatusOK, status)
+	s.Equal(tenancy.Tenant{OrganizationID: "acme"}, resolved)
+
+	status, _ = serve("acme", "acme", "read")
+	s.Equal(http.StatusOK, status)
+	s.Equal(tenancy.Tenant{OrganizationID: "acme"}, resolved)
+
+	status, code = serve("acme", "globex", "read", "organization:manage")
+	s.Equal(http.StatusForbidden, status)
+	s.Equal(dto.CodeTenantForbidden, code)
+
+	status, code = serve("default", "globex", "read")
+	s.Equal(http.StatusForbidden, status)
+	s.Equal(dto.CodeTenantForbidden, code)
+
+	status, _ = serve("default", "", "read", "organization:manage")
+	s.Equal(http.StatusOK, status)
+	s.Equal(tenancy.Tenant{OrganizationID: "default", Super: true, CrossTenant: true}, resolved)
+
+	status, _ = serve("default", "globex", "read", "organization:*")
+	s.Equal(http.StatusOK, status)
+	s.Equal(tenancy.Tenant{OrganizationID: "globex", Super: true}, resolved)
+}
+
+func (s *JWTMiddlewareSuite) TestRequireTenantWithoutPlatformOrganization() {
+	s.router.GET("/test", s.jwtMiddleware.RequireScope("read"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":    "123",
+		"tenant": "default",
+		"scope":  []interface{}{"read", "organization:manage"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(s.testSecret))
+	s.Require().NoError(err)
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+	req.Header.Set(tenancy.Header, "globex")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+}
+
+func (s *JWTMiddlewareSuite) TestRequireRevocation() {
+	mockStore := revocation.NewMockIStore(s.ctrl)
+	middleware := NewJWTMiddleware(env.AuthEnv{JWTSecret: s.testSecret}, nil, mockStore)
+	s.router.GET("/test", middleware.RequireScope("read"), func(c *gin.Context) {
+		s.Equal("token1", c.GetString("tokenId"))
+		s.Equal("session1", c.GetString("sessionId"))
+		s.False(c.GetTime("tokenExpiresAt").IsZero())
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	// iat reaches the store to the millisecond.
+	issuedAt := time.UnixMilli(time.Now().UnixMilli())
+	claims := jwt.MapClaims{
+		"sub":    "123",
+		"jti":    "token1",
+		"sid":    "session1",
+		"tenant": "acme",
+		"scope":  []interface{}{"read"},
+		"exp":    issuedAt.Add(time.Hour).Unix(),
+		"iat":    float64(issuedAt.UnixMilli()) / 1e3,
+	}
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.testSecret))
+	s.Require().NoError(err)
+
+	tests := []struct {
+		name    string
+		revoked bool
+		err     error
+		status  int
+		code    string
+	}{
+		{"active", false, nil, http.StatusOK, ""},
+		{"revoked", true, nil, http.StatusUnauthorized, dto.CodeTokenRevoked},
+		{"store unavailable", false, context.DeadlineExceeded, http.StatusServiceUnavailable, dto.CodeRevocationUnavailable},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockStore.EXPECT().IsRevoked(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token pkgrevocation.Token) (bool, error) {
+				s.Equal("token1", token.ID)
+				s.Equal("session1", token.SessionID)
+				s.Equal("123", token.UserID)
+				s.True(issuedAt.Equal(*token.IssuedAt))
+				return tt.revoked, tt.err
+			})
+
+			req, _ := http.NewRequest("GET", "/test", nil)
+			req.Header.Set("Authorization", "Bearer "+tokenString)
+			w := httptest.NewRecorder()
+			s.router.ServeHTTP(w, req)
+
+			s.Equal(tt.status, w.Code)
+			if tt.code != "" {
+				var response dto.APIResponse
+				s.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+				s.Equal(tt.code, response.Code)
+			}
+		})
+	}
+}
+
+func (s *JWTMiddlewareSuite) TestVerify() {
+	mockStore := revocation.NewMockIStore(s.ctrl)
+	middleware := NewJWTMiddleware(env.AuthEnv{JWTSecret: s.testSecret}, nil, mockStore)
+
+	sign := func(claims jwt.MapClaims) string {
+		tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.testSecret))
+		s.Require().NoError(err)
+		return tokenString
+	}
+	active := sign(jwt.MapClaims{"sub": "123", "scope": []interface{}{"read"}, "exp": time.Now().Add(time.Hour).Unix()})
+	expired := sign(jwt.MapClaims{"sub": "123", "exp": time.Now().Add(-time.Hour).Unix()})
+
+	mockStore.EXPECT().IsRevoked(gomock.Any(), gomock.Any()).Return(false, nil)
+	claims, err := middleware.Verify(s.ctx, active)
+	s.NoError(err)
+	s.Equal("123", claims["sub"])
+
+	mockStore.EXPECT().IsRevoked(gomock.Any(), gomock.Any()).Return(true, nil)
+	_, err = middleware.Verify(s.ctx, active)
+	s.ErrorContains(err, "revoked")
+	s.NotErrorIs(err, ErrRevocationUnavailable)
+
+	mockStore.EXPECT().IsRevoked(gomock.Any(), gomock.Any()).Return(false, context.DeadlineExceeded)
+	_, err = middleware.Verify(s.ctx, active)
+	s.ErrorIs(err, ErrRevocationUnavailable)
+
+	_, err = middleware.Verify(s.ctx, expired)
+	s.ErrorContains(err, "expired")
+
+	_, err = middleware.Verify(s.ctx, "not-a-token")
+	s.Error(err)
+}
@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/audit"
+)
+
+// maxRequestIDLength bounds a caller-supplied request ID, which ends up in
+// the audit log.
+const maxRequestIDLength = 128
+
+// RequestOrigin stores the request ID and client address in the request
+// context for the audit log. It keeps the caller's X-Request-ID when one is
+// given, generates one otherwise, and echoes it in the response. The client
+// address is only taken from X-Forwarded-For when the request comes from one
+// of the engine's trusted proxies, so the engine must be configured with
+// SetTrustedProxies; gin trusts every proxy by default.
+func RequestOrigin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestId := c.GetHeader(audit.RequestIDHeader)
+		if requestId == "" || len(requestId) > maxRequestIDLength {
+			requestId = uuid.NewString()
+		}
+
+		c.Header(audit.RequestIDHeader, requestId)
+		c.Set("requestId", requestId)
+		c.Request = c.Request.WithContext(audit.WithOrigin(c.Request.Context(), audit.Origin{
+			RequestID: requestId,
+			SourceIP:  c.ClientIP(),
+		}))
+		c.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/audit"
+)
+
+func serveRequestOrigin(t *testing.T, trustedProxies []string, headers map[string]string) (*httptest.ResponseRecorder, audit.Origin) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	assert.NoError(t, router.SetTrustedProxies(trustedProxies))
+	router.Use(RequestOrigin())
+
+	var origin audit.Origin
+	router.GET("/test", func(c *gin.Context) {
+		var ok bool
+		origin, ok = audit.FromContext(c.Request.Context())
+		assert.True(t, ok)
+		assert.Equal(t, origin.RequestID, c.GetString("requestId"))
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.RemoteAddr = "10.0.0.1:4321"
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w, origin
+}
+
+func TestRequestOriginKeepsCallerID(t *testing.T) {
+	w, origin := serveRequestOrigin(t, nil, map[string]string{audit.RequestIDHeader: "req-1"})
+
+	assert.Equal(t, audit.Origin{RequestID: "req-1", SourceIP: "10.0.0.1"}, origin)
+	assert.Equal(t, "req-1", w.Header().Get(audit.RequestIDHeader))
+}
+
+func TestRequestOriginGeneratesID(t *testing.T) {
+	for _, requestId := range []string{"", strings.Repeat("a", 129)} {
+		w, origin := serveRequestOrigin(t, nil, map[string]string{audit.RequestIDHeader: requestId})
+
+		_, err := uuid.Parse(origin.RequestID)
+		assert.NoError(t, err)
+		assert.Equal(t, origin.RequestID, w.Header().Get(audit.RequestIDHeader))
+	}
+}
+
+func TestRequestOriginIgnoresSpoofedForwardedFor(t *testing.T) {
+	headers := map[string]string{"X-Forwarded-For": "203.0.113.9", "X-Real-IP": "203.0.113.9"}
+
+	_, origin := serveRequestOrigin(t, nil, headers)
+	assert.Equal(t, "10.0.0.1", origin.SourceIP)
+
+	_, origin = serveRequestOrigin(t, []string{"192.168.0.0/16"}, headers)
+	assert.Equal(t, "10.0.0.1", origin.SourceIP)
+}
+
+func TestRequestOriginTrustsConfiguredProxies(t *testing.T) {
+	_, origin := serveRequestOrigin(t, []string{"10.0.0.0/8"}, map[string]string{"X-Forwarded-For": "203.0.113.9"})
+	assert.Equal(t, "203.0.113.9", origin.SourceIP)
+}
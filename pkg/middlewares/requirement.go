@@ -0,0 +1,62 @@
+package middlewares
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/scopes"
+)
+
+type RequirementMode string
+
+const (
+	RequireAll RequirementMode = "all"
+	RequireAny RequirementMode = "any"
+)
+
+// ScopeRequirement is the authorization rule attached to a route. A
+// requirement without scopes admits any authenticated caller.
+type ScopeRequirement struct {
+	Mode   RequirementMode
+	Scopes []string
+}
+
+func Authenticated() ScopeRequirement {
+	return ScopeRequirement{Mode: RequireAll}
+}
+
+func AllOf(scopes ...string) ScopeRequirement {
+	return ScopeRequirement{Mode: RequireAll, Scopes: scopes}
+}
+
+func AnyOf(scopes ...string) ScopeRequirement {
+	return ScopeRequirement{Mode: RequireAny, Scopes: scopes}
+}
+
+// SatisfiedBy matches granted scopes as patterns, so a token carrying
+// "container:*" satisfies a requirement for "container:view".
+func (r ScopeRequirement) SatisfiedBy(granted []string) bool {
+	if len(r.Scopes) == 0 {
+		return true
+	}
+	if r.Mode == RequireAny {
+		return slices.ContainsFunc(r.Scopes, func(scope string) bool {
+			return scopes.Grants(granted, scope)
+		})
+	}
+	for _, scope := range r.Scopes {
+		if !scopes.Grants(granted, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the requirement for route listings, e.g. "any(user:manage,
+// user:view)".
+func (r ScopeRequirement) String() string {
+	if len(r.Scopes) == 0 {
+		return "authenticated"
+	}
+	return string(r.Mode) + "(" + strings.Join(r.Scopes, ", ") + ")"
+}
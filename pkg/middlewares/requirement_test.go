@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopeRequirementSatisfiedBy(t *testing.T) {
+	granted := []string{"user:view", "scope:view"}
+
+	assert.True(t, Authenticated().SatisfiedBy(nil))
+	assert.True(t, AllOf("user:view", "scope:view").SatisfiedBy(granted))
+	assert.False(t, AllOf("user:view", "user:manage").SatisfiedBy(granted))
+	assert.True(t, AnyOf("user:manage", "user:view").SatisfiedBy(granted))
+	assert.False(t, AnyOf("user:manage", "scope:manage").SatisfiedBy(granted))
+}
+
+func TestScopeRequirementSatisfiedByPatterns(t *testing.T) {
+	granted := []string{"container:*", "*:view"}
+
+	assert.True(t, AllOf("container:create", "container:delete").SatisfiedBy(granted))
+	assert.True(t, AllOf("user:view").SatisfiedBy(granted))
+	assert.False(t, AllOf("user:view", "user:manage").SatisfiedBy(granted))
+	assert.True(t, AnyOf("user:manage", "scope:view").SatisfiedBy(granted))
+	assert.False(t, AnyOf("user:manage", "scope:manage").SatisfiedBy(granted))
+}
+
+func TestScopeRequirementString(t *testing.T) {
+	assert.Equal(t, "authenticated", Authenticated().String())
+	assert.Equal(t, "all(user:manage)", AllOf("user:manage").String())
+	assert.Equal(t, "any(user:manage, user:view)", AnyOf("user:manage", "user:view").String())
+}
@@ -0,0 +1,175 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrUnknownHash = errors.New("unrecognised password hash format")
+
+// IHasher produces self-describing hashes, so hashes from several algorithms
+// and cost settings can coexist in entities.User.Hash.
+type IHasher interface {
+	Hash(plaintext string) (string, error)
+	// Verify reports whether plaintext matches hash and, on a match, whether
+	// hash is weaker than what Hash would produce today.
+	Verify(hash, plaintext string) (match bool, needsRehash bool, err error)
+}
+
+type algorithm interface {
+	IHasher
+	identifies(hash string) bool
+}
+
+type hasher struct {
+	preferred  algorithm
+	algorithms []algorithm
+}
+
+func LoadHasher(env env.PasswordHashEnv) (IHasher, error) {
+	bcryptAlg := &bcryptHasher{cost: env.BcryptCost}
+	argon2idAlg := &argon2idHasher{
+		memory:      env.Argon2Memory,
+		iterations:  env.Argon2Iterations,
+		parallelism: env.Argon2Parallelism,
+		saltLength:  env.Argon2SaltLength,
+		keyLength:   env.Argon2KeyLength,
+	}
+
+	h := &hasher{algorithms: []algorithm{bcryptAlg, argon2idAlg}}
+	switch env.Algorithm {
+	case "bcrypt":
+		h.preferred = bcryptAlg
+	case "argon2id":
+		h.preferred = argon2idAlg
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm %q", env.Algorithm)
+	}
+	return h, nil
+}
+
+func (h *hasher) Hash(plaintext string) (string, error) {
+	return h.preferred.Hash(plaintext)
+}
+
+func (h *hasher) Verify(hash, plaintext string) (bool, bool, error) {
+	for _, alg := range h.algorithms {
+		if !alg.identifies(hash) {
+			continue
+		}
+		match, weaker, err := alg.Verify(hash, plaintext)
+		if err != nil || !match {
+			return false, false, err
+		}
+		return true, weaker || alg != h.preferred, nil
+	}
+	return false, false, ErrUnknownHash
+}
+
+type bcryptHasher struct {
+	cost int
+}
+
+func (h *bcryptHasher) identifies(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func (h *bcryptHasher) Hash(plaintext string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(plaintext), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (h *bcryptHasher) Verify(hash, plaintext string) (bool, bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(plaintext))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return false, false, err
+	}
+	return true, cost < h.cost, nil
+}
+
+// argon2idHasher encodes hashes in the PHC string format:
+//
+//	$argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+//
+// with salt and key in unpadded standard base64.
+type argon2idHasher struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	saltLength  uint32
+	keyLength   uint32
+}
+
+func (h *argon2idHasher) identifies(hash string) bool {
+	return strings.HasPrefix(hash, "$argon2id$")
+}
+
+func (h *argon2idHasher) Hash(plaintext string) (string, error) {
+	salt := make([]byte, h.saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(plaintext), salt, h.iterations, h.memory, h.parallelism, h.keyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.memory, h.iterations, h.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *argon2idHasher) Verify(hash, plaintext string) (bool, bool, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return false, false, ErrUnknownHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return false, false, ErrUnknownHash
+	}
+	var memory, iterations uint32
+	var parallelism uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil {
+		return false, false, ErrUnknownHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, false, ErrUnknownHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, false, ErrUnknownHash
+	}
+	if version != argon2.Version {
+		return false, false, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	candidate := argon2.IDKey([]byte(plaintext), salt, iterations, memory, parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, candidate) != 1 {
+		return false, false, nil
+	}
+
+	weaker := memory < h.memory || iterations < h.iterations || parallelism < h.parallelism ||
+		uint32(len(salt)) < h.saltLength || uint32(len(key)) < h.keyLength
+	return true, weaker, nil
+}
@@ -0,0 +1,105 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+)
+
+func testHashEnv(algorithm string) env.PasswordHashEnv {
+	return env.PasswordHashEnv{
+		Algorithm:         algorithm,
+		BcryptCost:        bcrypt.MinCost,
+		Argon2Memory:      1024,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+		Argon2SaltLength:  16,
+		Argon2KeyLength:   32,
+	}
+}
+
+func newTestHasher(t *testing.T, algorithm string) IHasher {
+	hasher, err := LoadHasher(testHashEnv(algorithm))
+	assert.NoError(t, err)
+	return hasher
+}
+
+func TestLoadHasherUnsupportedAlgorithm(t *testing.T) {
+	_, err := LoadHasher(testHashEnv("md5"))
+	assert.Error(t, err)
+}
+
+func TestHashAndVerify(t *testing.T) {
+	for _, algorithm := range []string{"bcrypt", "argon2id"} {
+		hasher := newTestHasher(t, algorithm)
+
+		hash, err := hasher.Hash("correct horse")
+		assert.NoError(t, err)
+
+		match, needsRehash, err := hasher.Verify(hash, "correct horse")
+		assert.NoError(t, err, algorithm)
+		assert.True(t, match, algorithm)
+		assert.False(t, needsRehash, algorithm)
+
+		match, _, err = hasher.Verify(hash, "battery staple")
+		assert.NoError(t, err, algorithm)
+		assert.False(t, match, algorithm)
+	}
+}
+
+func TestArgon2idHashFormat(t *testing.T) {
+	hash, err := newTestHasher(t, "argon2id").Hash("correct horse")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	other, _ := newTestHasher(t, "argon2id").Hash("correct horse")
+	assert.NotEqual(t, hash, other)
+}
+
+func TestVerifyAcrossAlgorithms(t *testing.T) {
+	legacy, _ := newTestHasher(t, "bcrypt").Hash("correct horse")
+
+	match, needsRehash, err := newTestHasher(t, "argon2id").Verify(legacy, "correct horse")
+	assert.NoError(t, err)
+	assert.True(t, match)
+	assert.True(t, needsRehash)
+
+	match, needsRehash, err = newTestHasher(t, "argon2id").Verify(legacy, "wrong")
+	assert.NoError(t, err)
+	assert.False(t, match)
+	assert.False(t, needsRehash)
+}
+
+func TestVerifyWeakerCost(t *testing.T) {
+	hashEnv := testHashEnv("bcrypt")
+	weak, _ := newTestHasher(t, "bcrypt").Hash("correct horse")
+	hashEnv.BcryptCost = bcrypt.MinCost + 1
+	stronger, _ := LoadHasher(hashEnv)
+
+	_, needsRehash, err := stronger.Verify(weak, "correct horse")
+	assert.NoError(t, err)
+	assert.True(t, needsRehash)
+
+	hashEnv = testHashEnv("argon2id")
+	weak, _ = newTestHasher(t, "argon2id").Hash("correct horse")
+	hashEnv.Argon2Iterations = 2
+	stronger, _ = LoadHasher(hashEnv)
+
+	_, needsRehash, err = stronger.Verify(weak, "correct horse")
+	assert.NoError(t, err)
+	assert.True(t, needsRehash)
+}
+
+func TestVerifyUnknownHash(t *testing.T) {
+	hasher := newTestHasher(t, "argon2id")
+
+	_, _, err := hasher.Verify("plaintext", "plaintext")
+	assert.ErrorIs(t, err, ErrUnknownHash)
+
+	_, _, err = hasher.Verify("$argon2id$v=19$garbage", "plaintext")
+	assert.ErrorIs(t, err, ErrUnknownHash)
+}
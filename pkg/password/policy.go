@@ -0,0 +1,236 @@
+package password
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+)
+
+// Violation describes one failed rule. Rule is a stable identifier clients may
+// branch on; Message is meant for humans.
+type Violation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Candidate is a password together with the context rules may need. History
+// holds the hashes of the user's current and previous passwords, newest first.
+type Candidate struct {
+	Password string
+	Username string
+	Email    string
+	History  []string
+}
+
+type Rule interface {
+	Check(candidate Candidate) *Violation
+}
+
+type IPolicy interface {
+	Validate(candidate Candidate) []Violation
+	HistorySize() int
+}
+
+type policy struct {
+	rules       []Rule
+	historySize int
+}
+
+// NewPolicy runs rules in the given order. historySize is the number of
+// hashes the caller should load into Candidate.History.
+func NewPolicy(historySize int, rules ...Rule) IPolicy {
+	return &policy{
+		rules:       rules,
+		historySize: historySize,
+	}
+}
+
+// LoadPolicy builds the policy from env. hasher is used by the history rule to
+// compare the candidate against stored hashes.
+func LoadPolicy(env env.PasswordPolicyEnv, hasher IHasher) (IPolicy, error) {
+	rules := []Rule{Length(env.MinLength, env.MaxLength)}
+	rules = append(rules, CharacterClasses(env.RequireUpper, env.RequireLower, env.RequireDigit, env.RequireSymbol))
+	if env.ForbidPersonalInfo {
+		rules = append(rules, NoPersonalInfo())
+	}
+	if env.BreachedListPath != "" {
+		breached, err := LoadBreachedList(env.BreachedListPath)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, breached)
+	}
+	if env.HistorySize > 0 {
+		rules = append(rules, History(hasher))
+	}
+	return NewPolicy(env.HistorySize, rules...), nil
+}
+
+func (p *policy) Validate(candidate Candidate) []Violation {
+	var violations []Violation
+	for _, rule := range p.rules {
+		if violation := rule.Check(candidate); violation != nil {
+			violations = append(violations, *violation)
+		}
+	}
+	return violations
+}
+
+func (p *policy) HistorySize() int {
+	return p.historySize
+}
+
+type lengthRule struct {
+	min int
+	max int
+}
+
+// Length bounds the password length in characters. A max of zero disables the
+// upper bound.
+func Length(min, max int) Rule {
+	return &lengthRule{min: min, max: max}
+}
+
+func (r *lengthRule) Check(candidate Candidate) *Violation {
+	length := len([]rune(candidate.Password))
+	if length < r.min {
+		return &Violation{Rule: "min_length", Message: fmt.Sprintf("password must be at least %d characters long", r.min)}
+	}
+	if r.max > 0 && length > r.max {
+		return &Violation{Rule: "max_length", Message: fmt.Sprintf("password must be at most %d characters long", r.max)}
+	}
+	return nil
+}
+
+type characterClassesRule struct {
+	upper  bool
+	lower  bool
+	digit  bool
+	symbol bool
+}
+
+func CharacterClasses(upper, lower, digit, symbol bool) Rule {
+	return &characterClassesRule{upper: upper, lower: lower, digit: digit, symbol: symbol}
+}
+
+func (r *characterClassesRule) Check(candidate Candidate) *Violation {
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, c := range candidate.Password {
+		switch {
+		case unicode.IsUpper(c):
+			hasUpper = true
+		case unicode.IsLower(c):
+			hasLower = true
+		case unicode.IsDigit(c):
+			hasDigit = true
+		case unicode.IsPunct(c) || unicode.IsSymbol(c):
+			hasSymbol = true
+		}
+	}
+
+	var missing []string
+	if r.upper && !hasUpper {
+		missing = append(missing, "an uppercase letter")
+	}
+	if r.lower && !hasLower {
+		missing = append(missing, "a lowercase letter")
+	}
+	if r.digit && !hasDigit {
+		missing = append(missing, "a digit")
+	}
+	if r.symbol && !hasSymbol {
+		missing = append(missing, "a symbol")
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &Violation{Rule: "character_classes", Message: "password must contain " + strings.Join(missing, ", ")}
+}
+
+// minPersonalInfoLength keeps very short usernames such as "al" from
+// rejecting most passwords.
+const minPersonalInfoLength = 3
+
+type noPersonalInfoRule struct{}
+
+func NoPersonalInfo() Rule {
+	return noPersonalInfoRule{}
+}
+
+func (noPersonalInfoRule) Check(candidate Candidate) *Violation {
+	password := strings.ToLower(candidate.Password)
+	localPart, _, _ := strings.Cut(candidate.Email, "@")
+	for _, info := range []string{candidate.Username, localPart} {
+		info = strings.ToLower(info)
+		if len(info) >= minPersonalInfoLength && strings.Contains(password, info) {
+			return &Violation{Rule: "personal_info", Message: "password must not contain the username or email"}
+		}
+	}
+	return nil
+}
+
+type breachedListRule struct {
+	passwords map[string]struct{}
+}
+
+// BreachedList rejects passwords that appear in passwords, compared
+// case-insensitively.
+func BreachedList(passwords []string) Rule {
+	set := make(map[string]struct{}, len(passwords))
+	for _, p := range passwords {
+		set[strings.ToLower(p)] = struct{}{}
+	}
+	return &breachedListRule{passwords: set}
+}
+
+// LoadBreachedList reads one password per line. Blank lines and lines starting
+// with '#' are ignored.
+func LoadBreachedList(path string) (Rule, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var passwords []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords = append(passwords, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return BreachedList(passwords), nil
+}
+
+func (r *breachedListRule) Check(candidate Candidate) *Violation {
+	if _, ok := r.passwords[strings.ToLower(candidate.Password)]; ok {
+		return &Violation{Rule: "breached", Message: "password appears in a list of breached passwords"}
+	}
+	return nil
+}
+
+type historyRule struct {
+	hasher IHasher
+}
+
+func History(hasher IHasher) Rule {
+	return &historyRule{hasher: hasher}
+}
+
+func (r *historyRule) Check(candidate Candidate) *Violation {
+	for _, hash := range candidate.History {
+		if match, _, _ := r.hasher.Verify(hash, candidate.Password); match {
+			return &Violation{Rule: "reused", Message: "password was used recently"}
+		}
+	}
+	return nil
+}
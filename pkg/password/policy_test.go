@@ -0,0 +1,97 @@
+package password
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+)
+
+func rules(violations []Violation) []string {
+	names := make([]string, 0, len(violations))
+	for _, v := range violations {
+		names = append(names, v.Rule)
+	}
+	return names
+}
+
+func TestLength(t *testing.T) {
+	rule := Length(8, 12)
+	assert.Equal(t, "min_length", rule.Check(Candidate{Password: "short"}).Rule)
+	assert.Equal(t, "max_length", rule.Check(Candidate{Password: "much-too-long-password"}).Rule)
+	assert.Nil(t, rule.Check(Candidate{Password: "just-right"}))
+	assert.Nil(t, Length(8, 0).Check(Candidate{Password: "no-upper-bound-at-all"}))
+}
+
+func TestCharacterClasses(t *testing.T) {
+	rule := CharacterClasses(true, true, true, true)
+
+	violation := rule.Check(Candidate{Password: "lowercase"})
+	assert.Equal(t, "character_classes", violation.Rule)
+	assert.Equal(t, "password must contain an uppercase letter, a digit, a symbol", violation.Message)
+	assert.Nil(t, rule.Check(Candidate{Password: "Aa1!"}))
+	assert.Nil(t, CharacterClasses(false, false, false, false).Check(Candidate{Password: "x"}))
+}
+
+func TestNoPersonalInfo(t *testing.T) {
+	rule := NoPersonalInfo()
+	assert.NotNil(t, rule.Check(Candidate{Password: "xAliceX", Username: "alice"}))
+	assert.NotNil(t, rule.Check(Candidate{Password: "jdoe2024", Username: "john", Email: "jdoe@example.com"}))
+	assert.Nil(t, rule.Check(Candidate{Password: "albatross", Username: "al"}))
+	assert.Nil(t, rule.Check(Candidate{Password: "unrelated", Username: "alice", Email: "alice@example.com"}))
+}
+
+func TestBreachedList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("# common passwords\n\nPassword1\nqwerty123\n"), 0o600))
+
+	rule, err := LoadBreachedList(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "breached", rule.Check(Candidate{Password: "password1"}).Rule)
+	assert.Nil(t, rule.Check(Candidate{Password: "# common passwords"}))
+	assert.Nil(t, rule.Check(Candidate{Password: "not-in-list"}))
+
+	_, err = LoadBreachedList(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
+
+func TestHistory(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.MinCost)
+	rule := History(newTestHasher(t, "argon2id"))
+
+	assert.Equal(t, "reused", rule.Check(Candidate{Password: "old-password", History: []string{string(hash)}}).Rule)
+	assert.Nil(t, rule.Check(Candidate{Password: "new-password", History: []string{string(hash)}}))
+}
+
+func TestPolicyReportsEveryViolation(t *testing.T) {
+	policy := NewPolicy(0, Length(10, 0), CharacterClasses(true, false, true, false), NoPersonalInfo())
+
+	violations := policy.Validate(Candidate{Password: "alice", Username: "alice"})
+	assert.Equal(t, []string{"min_length", "character_classes", "personal_info"}, rules(violations))
+	assert.Empty(t, policy.Validate(Candidate{Password: "Str0ng-enough", Username: "alice"}))
+}
+
+func TestLoadPolicy(t *testing.T) {
+	policyEnv := env.PasswordPolicyEnv{
+		MinLength:          8,
+		MaxLength:          72,
+		RequireUpper:       true,
+		RequireLower:       true,
+		RequireDigit:       true,
+		ForbidPersonalInfo: true,
+		HistorySize:        3,
+	}
+
+	policy, err := LoadPolicy(policyEnv, newTestHasher(t, "bcrypt"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, policy.HistorySize())
+	assert.Equal(t, []string{"min_length", "character_classes"}, rules(policy.Validate(Candidate{Password: ""})))
+
+	policyEnv.BreachedListPath = filepath.Join(t.TempDir(), "missing.txt")
+	_, err = LoadPolicy(policyEnv, newTestHasher(t, "bcrypt"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+// Package revocation records access tokens that must stop working before
+// they expire. A single token is revoked by its jti claim and the tokens of
+// one refresh session by their sid claim; every token of a user, or of
+// everyone, is revoked by storing a cutoff, after which only tokens issued
+// later are accepted. All of them live in Redis so every replica sees them, behind a
+// short in-process cache so the middleware does not reach Redis on every
+// request. A revocation therefore takes up to the cache TTL to reach other
+// replicas, and is seen at once by the replica that made it.
+package revocation
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+)
+
+const (
+	tokenPrefix   = "revoked:jti:"
+	sessionPrefix = "revoked:sid:"
+	userPrefix    = "revoked:user:"
+	everyoneKey   = "revoked:all"
+)
+
+// maxCacheEntries bounds the cache. Expired entries are swept once it is
+// reached, and the whole cache is dropped if that frees nothing.
+const maxCacheEntries = 10000
+
+type IStore interface {
+	// RevokeToken rejects the token with the given jti until it expires.
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	// RevokeSession rejects every token issued for the refresh session.
+	RevokeSession(ctx context.Context, sessionId string) error
+	// RevokeUser rejects every token of the user issued at or before at.
+	RevokeUser(ctx context.Context, userId string, at time.Time) error
+	// RevokeEveryone rejects every token issued at or before at.
+	RevokeEveryone(ctx context.Context, at time.Time) error
+	// IsRevoked reports whether token is revoked.
+	IsRevoked(ctx context.Context, token Token) (bool, error)
+}
+
+// Token holds the claims a revocation can match. ID and SessionID may be
+// empty, and a token without an issue time is revoked by any cutoff that
+// applies to it.
+type Token struct {
+	ID        string
+	SessionID string
+	UserID    string
+	IssuedAt  *time.Time
+}
+
+type cacheEntry struct {
+	value   string
+	expires time.Time
+}
+
+type store struct {
+	redisClient interfaces.IRedisClient
+	cacheTTL    time.Duration
+	retention   time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// NewStore keeps user cutoffs for env.Retention, which must outlast the
+// longest-lived access token, and caches lookups for env.CacheTTL; a zero
+// CacheTTL disables the cache.
+func NewStore(redisClient interfaces.IRedisClient, env env.RevocationEnv) IStore {
+	return &store{
+		redisClient: redisClient,
+		cacheTTL:    env.CacheTTL,
+		retention:   env.Retention,
+		cache:       make(map[string]cacheEntry),
+	}
+}
+
+func (s *store) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := s.redisClient.Set(ctx, tokenPrefix+jti, "1", ttl); err != nil {
+		return err
+	}
+	s.remember(tokenPrefix+jti, "1")
+	return nil
+}
+
+// RevokeSession keeps the session for env.Retention, like a user cutoff, as
+// the tokens it issued cannot outlive that.
+func (s *store) RevokeSession(ctx context.Context, sessionId string) error {
+	if err := s.redisClient.Set(ctx, sessionPrefix+sessionId, "1", s.retention); err != nil {
+		return err
+	}
+	s.remember(sessionPrefix+sessionId, "1")
+	return nil
+}
+
+func (s *store) RevokeUser(ctx context.Context, userId string, at time.Time) error {
+	return s.setCutoff(ctx, userPrefix+userId, at)
+}
+
+func (s *store) RevokeEveryone(ctx context.Context, at time.Time) error {
+	return s.setCutoff(ctx, everyoneKey, at)
+}
+
+func (s *store) IsRevoked(ctx context.Context, token Token) (bool, error) {
+	if token.ID != "" {
+		revoked, err := s.lookup(ctx, tokenPrefix+token.ID)
+		if err != nil || revoked != "" {
+			return revoked != "", err
+		}
+	}
+	if token.SessionID != "" {
+		revoked, err := s.lookup(ctx, sessionPrefix+token.SessionID)
+		if err != nil || revoked != "" {
+			return revoked != "", err
+		}
+	}
+
+	revoked, err := s.issuedBefore(ctx, userPrefix+token.UserID, token.IssuedAt)
+	if err != nil || revoked {
+		return revoked, err
+	}
+	return s.issuedBefore(ctx, everyoneKey, token.IssuedAt)
+}
+
+func (s *store) setCutoff(ctx context.Context, key string, at time.Time) error {
+	cutoff := strconv.FormatInt(at.UnixMilli(), 10)
+	if err := s.redisClient.Set(ctx, key, cutoff, s.retention); err != nil {
+		return err
+	}
+	s.remember(key, cutoff)
+	return nil
+}
+
+// issuedBefore reports whether issuedAt is at or before the cutoff stored
+// under key, which is kept in milliseconds. An iat in whole seconds is
+// compared as it is, so a token issued in the same second as a revocation is
+// revoked too; an issuer that reissues tokens right after revoking them puts
+// milliseconds in iat.
+func (s *store) issuedBefore(ctx context.Context, key string, issuedAt *time.Time) (bool, error) {
+	value, err := s.lookup(ctx, key)
+	if err != nil || value == "" {
+		return false, err
+	}
+	cutoff, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	return issuedAt == nil || !issuedAt.After(time.UnixMilli(cutoff)), nil
+}
+
+// lookup reads key through the cache. Missing keys are cached as well, since
+// they are what almost every request finds.
+func (s *store) lookup(ctx context.Context, key string) (string, error) {
+	now := time.Now()
+	s.mu.Lock()
+	entry, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.value, nil
+	}
+
+	value, err := s.redisClient.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	s.remember(key, value)
+	return value, nil
+}
+
+func (s *store) remember(key, value string) {
+	if s.cacheTTL <= 0 {
+		return
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.cache) >= maxCacheEntries {
+		for k, entry := range s.cache {
+			if !now.Before(entry.expires) {
+				delete(s.cache, k)
+			}
+		}
+		if len(s.cache) >= maxCacheEntries {
+			s.cache = make(map[string]cacheEntry)
+		}
+	}
+	s.cache[key] = cacheEntry{value: value, expires: now.Add(s.cacheTTL)}
+}
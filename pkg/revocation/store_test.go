@@ -0,0 +1,174 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+)
+
+type StoreSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	mockRedis *interfaces.MockIRedisClient
+	store     IStore
+	ctx       context.Context
+}
+
+func (s *StoreSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRedis = interfaces.NewMockIRedisClient(s.ctrl)
+	s.store = NewStore(s.mockRedis, env.RevocationEnv{CacheTTL: time.Minute, Retention: 24 * time.Hour})
+	s.ctx = context.Background()
+}
+
+func (s *StoreSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestStoreSuite(t *testing.T) {
+	suite.Run(t, new(StoreSuite))
+}
+
+func (s *StoreSuite) TestNotRevoked() {
+	issuedAt := time.Now()
+	s.mockRedis.EXPECT().Get(s.ctx, "revoked:jti:token1").Return("", nil).Times(1)
+	s.mockRedis.EXPECT().Get(s.ctx, "revoked:user:user1").Return("", nil).Times(1)
+	s.mockRedis.EXPECT().Get(s.ctx, "revoked:all").Return("", nil).Times(1)
+
+	// The second check is answered from the cache.
+	for i := 0; i < 2; i++ {
+		revoked, err := s.store.IsRevoked(s.ctx, Token{ID: "token1", UserID: "user1", IssuedAt: &issuedAt})
+		s.NoError(err)
+		s.False(revoked)
+	}
+}
+
+func (s *StoreSuite) TestRevokeToken() {
+	expiresAt := time.Now().Add(time.Hour)
+	s.mockRedis.EXPECT().Set(s.ctx, "revoked:jti:token1", "1", gomock.Any()).DoAndReturn(func(_ context.Context, _, _ string, ttl time.Duration) error {
+		s.InDelta(time.Hour, ttl, float64(time.Second))
+		return nil
+	})
+	s.NoError(s.store.RevokeToken(s.ctx, "token1", expiresAt))
+
+	issuedAt := time.Now()
+	revoked, err := s.store.IsRevoked(s.ctx, Token{ID: "token1", UserID: "user1", IssuedAt: &issuedAt})
+	s.NoError(err)
+	s.True(revoked)
+}
+
+func (s *StoreSuite) TestRevokeExpiredToken() {
+	s.NoError(s.store.RevokeToken(s.ctx, "token1", time.Now().Add(-time.Minute)))
+}
+
+func (s *StoreSuite) TestRevokeUser() {
+	cutoff := time.Now()
+	s.mockRedis.EXPECT().Set(s.ctx, "revoked:user:user1", strconv.FormatInt(cutoff.UnixMilli(), 10), 24*time.Hour).Return(nil)
+	s.NoError(s.store.RevokeUser(s.ctx, "user1", cutoff))
+
+	before := cutoff.Add(-time.Minute)
+	revoked, err := s.store.IsRevoked(s.ctx, Token{UserID: "user1", IssuedAt: &before})
+	s.NoError(err)
+	s.True(revoked)
+
+	revoked, err = s.store.IsRevoked(s.ctx, Token{UserID: "user1"})
+	s.NoError(err)
+	s.True(revoked)
+
+	after := cutoff.Add(time.Second)
+	s.mockRedis.EXPECT().Get(s.ctx, "revoked:all").Return("", nil)
+	revoked, err = s.store.IsRevoked(s.ctx, Token{UserID: "user1", IssuedAt: &after})
+	s.NoError(err)
+	s.False(revoked)
+}
+
+func (s *StoreSuite) TestRevokeUserSameSecond() {
+	second := time.Unix(1700000000, 0)
+	cutoff := second.Add(500 * time.Millisecond)
+	s.mockRedis.EXPECT().Set(s.ctx, "revoked:user:user1", "1700000000500", 24*time.Hour).Return(nil)
+	s.NoError(s.store.RevokeUser(s.ctx, "user1", cutoff))
+	s.mockRedis.EXPECT().Get(s.ctx, "revoked:all").Return("", nil)
+
+	for _, tc := range []struct {
+		name     string
+		issuedAt time.Time
+		revoked  bool
+	}{
+		{"milliseconds before the cutoff", cutoff.Add(-time.Millisecond), true},
+		{"milliseconds at the cutoff", cutoff, true},
+		{"milliseconds after the cutoff", cutoff.Add(time.Millisecond), false},
+		{"whole seconds in the cutoff's second", second, true},
+		{"whole seconds after the cutoff's second", second.Add(time.Second), false},
+	} {
+		issuedAt := tc.issuedAt
+		revoked, err := s.store.IsRevoked(s.ctx, Token{UserID: "user1", IssuedAt: &issuedAt})
+		s.NoError(err, tc.name)
+		s.Equal(tc.revoked, revoked, tc.name)
+	}
+}
+
+func (s *StoreSuite) TestRevokeSession() {
+	s.mockRedis.EXPECT().Set(s.ctx, "revoked:sid:session1", "1", 24*time.Hour).Return(nil)
+	s.NoError(s.store.RevokeSession(s.ctx, "session1"))
+
+	issuedAt := time.Now()
+	revoked, err := s.store.IsRevoked(s.ctx, Token{SessionID: "session1", UserID: "user1", IssuedAt: &issuedAt})
+	s.NoError(err)
+	s.True(revoked)
+}
+
+func (s *StoreSuite) TestRevokeEveryone() {
+	cutoff := time.Now()
+	s.mockRedis.EXPECT().Set(s.ctx, "revoked:all", strconv.FormatInt(cutoff.UnixMilli(), 10), 24*time.Hour).Return(nil)
+	s.NoError(s.store.RevokeEveryone(s.ctx, cutoff))
+
+	s.mockRedis.EXPECT().Get(s.ctx, "revoked:user:user1").Return("", nil)
+	s.mockRedis.EXPECT().Get(s.ctx, "revoked:user:user2").Return("", nil)
+
+	before := cutoff.Add(-time.Minute)
+	revoked, err := s.store.IsRevoked(s.ctx, Token{UserID: "user1", IssuedAt: &before})
+	s.NoError(err)
+	s.True(revoked)
+
+	after := cutoff.Add(time.Second)
+	revoked, err = s.store.IsRevoked(s.ctx, Token{UserID: "user2", IssuedAt: &after})
+	s.NoError(err)
+	s.False(revoked)
+}
+
+func (s *StoreSuite) TestRevokedElsewhere() {
+	cutoff := time.Now()
+	s.mockRedis.EXPECT().Get(s.ctx, "revoked:user:user1").Return(strconv.FormatInt(cutoff.UnixMilli(), 10), nil)
+
+	issuedAt := cutoff.Add(-time.Hour)
+	revoked, err := s.store.IsRevoked(s.ctx, Token{UserID: "user1", IssuedAt: &issuedAt})
+	s.NoError(err)
+	s.True(revoked)
+}
+
+func (s *StoreSuite) TestRedisError() {
+	s.mockRedis.EXPECT().Get(s.ctx, "revoked:jti:token1").Return("", errors.New("redis down"))
+
+	_, err := s.store.IsRevoked(s.ctx, Token{ID: "token1", UserID: "user1"})
+	s.ErrorContains(err, "redis down")
+}
+
+func (s *StoreSuite) TestCacheDisabled() {
+	s.store = NewStore(s.mockRedis, env.RevocationEnv{Retention: time.Hour})
+	s.mockRedis.EXPECT().Get(s.ctx, "revoked:user:user1").Return("", nil).Times(2)
+	s.mockRedis.EXPECT().Get(s.ctx, "revoked:all").Return("", nil).Times(2)
+
+	for i := 0; i < 2; i++ {
+		revoked, err := s.store.IsRevoked(s.ctx, Token{UserID: "user1"})
+		s.NoError(err)
+		s.False(revoked)
+	}
+}
@@ -0,0 +1,97 @@
+// Package scopes implements matching for resource:action scopes. A segment of
+// "*" matches any single segment, and a trailing "*" also matches any deeper
+// segments, so "container:*" grants both "container:view" and
+// "container:logs:view", while "*:view" grants "container:view" only.
+package scopes
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	Separator = ":"
+	Wildcard  = "*"
+)
+
+// maxLength mirrors the user_scopes.name column.
+const maxLength = 50
+
+var (
+	ErrInvalidScope = errors.New("invalid scope")
+	segmentPattern  = regexp.MustCompile(`^[a-z0-9_.-]+$`)
+)
+
+// Validate accepts concrete scopes and patterns. Each segment is either "*"
+// or a lowercase name; wildcards inside a segment such as "contain*" are not
+// supported.
+func Validate(scope string) error {
+	if scope == "" || len(scope) > maxLength {
+		return fmt.Errorf("%w %q: must be 1 to %d characters", ErrInvalidScope, scope, maxLength)
+	}
+	for _, segment := range strings.Split(scope, Separator) {
+		if segment == Wildcard {
+			continue
+		}
+		if !segmentPattern.MatchString(segment) {
+			return fmt.Errorf("%w %q: segment %q must be %q or match %s", ErrInvalidScope, scope, segment, Wildcard, segmentPattern)
+		}
+	}
+	return nil
+}
+
+func IsPattern(scope string) bool {
+	return strings.Contains(scope, Wildcard)
+}
+
+// Match reports whether pattern grants scope. A concrete pattern only grants
+// itself.
+func Match(pattern, scope string) bool {
+	if pattern == scope {
+		return true
+	}
+	if !IsPattern(pattern) {
+		return false
+	}
+
+	patternSegments := strings.Split(pattern, Separator)
+	scopeSegments := strings.Split(scope, Separator)
+	for i, segment := range patternSegments {
+		last := i == len(patternSegments)-1
+		if i >= len(scopeSegments) {
+			return false
+		}
+		if segment == Wildcard && last {
+			return true
+		}
+		if segment != Wildcard && segment != scopeSegments[i] {
+			return false
+		}
+	}
+	return len(patternSegments) == len(scopeSegments)
+}
+
+// Grants reports whether any of the granted scopes or patterns grants
+// required.
+func Grants(granted []string, required string) bool {
+	for _, pattern := range granted {
+		if Match(pattern, required) {
+			return true
+		}
+	}
+	return false
+}
+
+// Expand returns the concrete scopes in known that pattern grants, in the
+// order of known. Patterns in known are skipped.
+func Expand(pattern string, known []string) []string {
+	expanded := []string{}
+	for _, scope := range known {
+		if !IsPattern(scope) && Match(pattern, scope) {
+			expanded = append(expanded, scope)
+		}
+	}
+	return expanded
+}
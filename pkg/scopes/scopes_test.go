@@ -0,0 +1,60 @@
+package scopes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	for _, scope := range []string{"read", "container:view", "container:*", "*:view", "*:*", "container:logs:view", "report.mail:send"} {
+		assert.NoError(t, Validate(scope), scope)
+	}
+	for _, scope := range []string{"", "Container:view", "container:", ":view", "contain*:view", "container view", "a:b:" + string(make([]byte, 50))} {
+		assert.ErrorIs(t, Validate(scope), ErrInvalidScope, scope)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		scope   string
+		match   bool
+	}{
+		{"container:view", "container:view", true},
+		{"container:view", "container:create", false},
+		{"container:*", "container:view", true},
+		{"container:*", "container:logs:view", true},
+		{"container:*", "container", false},
+		{"container:*", "user:view", false},
+		{"*:view", "container:view", true},
+		{"*:view", "user:view", true},
+		{"*:view", "container:create", false},
+		{"*:view", "container:logs:view", false},
+		{"container:*:view", "container:logs:view", true},
+		{"container:*:view", "container:logs:delete", false},
+		{"*", "anything:at:all", true},
+		{"*:*", "user:manage", true},
+		{"*:*", "read", false},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.match, Match(tc.pattern, tc.scope), "%s ~ %s", tc.pattern, tc.scope)
+	}
+}
+
+func TestGrants(t *testing.T) {
+	granted := []string{"container:*", "user:view"}
+	assert.True(t, Grants(granted, "container:delete"))
+	assert.True(t, Grants(granted, "user:view"))
+	assert.False(t, Grants(granted, "user:manage"))
+	assert.False(t, Grants(nil, "user:view"))
+}
+
+func TestExpand(t *testing.T) {
+	known := []string{"container:create", "container:view", "container:*", "user:view", "user:manage", "*:view"}
+
+	assert.Equal(t, []string{"container:create", "container:view"}, Expand("container:*", known))
+	assert.Equal(t, []string{"container:view", "user:view"}, Expand("*:view", known))
+	assert.Equal(t, []string{"user:manage"}, Expand("user:manage", known))
+	assert.Empty(t, Expand("report:*", known))
+}
@@ -0,0 +1,112 @@
+package tenancy
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// field is the struct field that marks a model as owned by an organization.
+const field = "OrganizationID"
+
+// restrictedClause marks a statement that already carries the tenant
+// condition, so a statement executed twice is not restricted twice.
+const restrictedClause = "tenancy_restricted"
+
+// Plugin registers the tenant callbacks. Install it with db.Use(Plugin{})
+// and run queries with db.WithContext(ctx).
+type Plugin struct{}
+
+func (Plugin) Name() string {
+	return "tenancy"
+}
+
+func (Plugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("tenancy:create", stamp); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tenancy:query", restrict); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("tenancy:row", restrict); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenancy:update", restrict); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("tenancy:delete", restrict)
+}
+
+func tenantField(db *gorm.DB) (Tenant, *schema.Field, bool) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return Tenant{}, nil, false
+	}
+	tenant, ok := FromContext(db.Statement.Context)
+	if !ok {
+		return Tenant{}, nil, false
+	}
+	f := db.Statement.Schema.LookUpField(field)
+	return tenant, f, f != nil
+}
+
+// restrict adds organization_id = tenant to the WHERE clause.
+func restrict(db *gorm.DB) {
+	tenant, f, ok := tenantField(db)
+	if !ok || tenant.CrossTenant {
+		return
+	}
+	stmt := db.Statement
+	if _, ok := stmt.Clauses[restrictedClause]; ok {
+		return
+	}
+
+	// A lone Or condition would otherwise be joined to the tenant condition
+	// with OR; group the existing conditions first, as soft delete does.
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			for _, expr := range where.Exprs {
+				if orCond, ok := expr.(clause.OrConditions); ok && len(orCond.Exprs) == 1 {
+					where.Exprs = []clause.Expression{clause.And(where.Exprs...)}
+					c.Expression = where
+					stmt.Clauses["WHERE"] = c
+					break
+				}
+			}
+		}
+	}
+
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: f.DBName}, Value: tenant.OrganizationID},
+	}})
+	stmt.Clauses[restrictedClause] = clause.Clause{}
+}
+
+// stamp assigns new rows to the tenant. Only a cross-tenant super-admin may
+// create rows in another organization by naming it.
+func stamp(db *gorm.DB) {
+	tenant, f, ok := tenantField(db)
+	if !ok || tenant.OrganizationID == "" {
+		return
+	}
+	ctx := db.Statement.Context
+	set := func(rv reflect.Value) {
+		if _, zero := f.ValueOf(ctx, rv); zero || !tenant.CrossTenant {
+			if err := f.Set(ctx, rv, tenant.OrganizationID); err != nil {
+				db.AddError(err)
+			}
+		}
+	}
+
+	rv := db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			set(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		set(rv)
+	}
+}
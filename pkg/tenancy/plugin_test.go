@@ -0,0 +1,139 @@
+package tenancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type record struct {
+	ID             uint `gorm:"primaryKey"`
+	OrganizationID string
+	Name           string
+}
+
+type sharedRecord struct {
+	ID   uint `gorm:"primaryKey"`
+	Name string
+}
+
+type PluginSuite struct {
+	suite.Suite
+	db    *gorm.DB
+	acme  context.Context
+	super context.Context
+}
+
+func (s *PluginSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(db.AutoMigrate(&record{}, &sharedRecord{}))
+	s.Require().NoError(db.Use(Plugin{}))
+	s.db = db
+
+	s.acme = WithTenant(context.Background(), Tenant{OrganizationID: "acme"})
+	s.super = WithTenant(context.Background(), Tenant{OrganizationID: "default", Super: true, CrossTenant: true})
+
+	s.Require().NoError(db.Create([]*record{
+		{OrganizationID: "acme", Name: "alpha"},
+		{OrganizationID: "acme", Name: "beta"},
+		{OrganizationID: "globex", Name: "alpha"},
+	}).Error)
+}
+
+func (s *PluginSuite) TearDownTest() {
+	sqlDB, err := s.db.DB()
+	s.Require().NoError(err)
+	sqlDB.Close()
+}
+
+func TestPluginSuite(t *testing.T) {
+	suite.Run(t, new(PluginSuite))
+}
+
+func (s *PluginSuite) names(ctx context.Context) []string {
+	var names []string
+	s.Require().NoError(s.db.WithContext(ctx).Model(&record{}).Order("id").Pluck("name", &names).Error)
+	return names
+}
+
+func (s *PluginSuite) TestQueryIsRestricted() {
+	s.Equal([]string{"alpha", "beta"}, s.names(s.acme))
+
+	var found record
+	err := s.db.WithContext(s.acme).Where("organization_id = ?", "globex").First(&found).Error
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	var count int64
+	s.NoError(s.db.WithContext(s.acme).Model(&record{}).Count(&count).Error)
+	s.Equal(int64(2), count)
+}
+
+func (s *PluginSuite) TestOrConditionsStayInsideTenant() {
+	var records []record
+	err := s.db.WithContext(s.acme).Where("name = ?", "beta").Or("name = ?", "alpha").Find(&records).Error
+	s.NoError(err)
+	s.Len(records, 2)
+	for _, r := range records {
+		s.Equal("acme", r.OrganizationID)
+	}
+}
+
+func (s *PluginSuite) TestReusedStatementIsRestrictedOnce() {
+	db := s.db.WithContext(s.acme).Model(&record{})
+	var count int64
+	s.NoError(db.Count(&count).Error)
+	var records []record
+	s.NoError(db.Find(&records).Error)
+	s.Len(records, 2)
+}
+
+func (s *PluginSuite) TestUpdateAndDeleteAreRestricted() {
+	res := s.db.WithContext(s.acme).Model(&record{}).Where("name = ?", "alpha").Update("name", "gamma")
+	s.NoError(res.Error)
+	s.Equal(int64(1), res.RowsAffected)
+
+	res = s.db.WithContext(s.acme).Where("name = ?", "alpha").Delete(&record{})
+	s.NoError(res.Error)
+	s.Zero(res.RowsAffected)
+
+	s.Equal([]string{"gamma", "beta", "alpha"}, s.names(context.Background()))
+}
+
+func (s *PluginSuite) TestCreateStampsTenant() {
+	created := &record{Name: "delta", OrganizationID: "globex"}
+	s.NoError(s.db.WithContext(s.acme).Create(created).Error)
+	s.Equal("acme", created.OrganizationID)
+
+	batch := []*record{{Name: "epsilon"}, {Name: "zeta"}}
+	s.NoError(s.db.WithContext(s.acme).Create(batch).Error)
+	s.Equal("acme", batch[0].OrganizationID)
+	s.Equal("acme", batch[1].OrganizationID)
+}
+
+func (s *PluginSuite) TestCrossTenantSuperAdmin() {
+	s.Len(s.names(s.super), 3)
+
+	named := &record{Name: "delta", OrganizationID: "globex"}
+	s.NoError(s.db.WithContext(s.super).Create(named).Error)
+	s.Equal("globex", named.OrganizationID)
+
+	unnamed := &record{Name: "epsilon"}
+	s.NoError(s.db.WithContext(s.super).Create(unnamed).Error)
+	s.Equal("default", unnamed.OrganizationID)
+}
+
+func (s *PluginSuite) TestUnscopedContextsAndModels() {
+	s.Len(s.names(context.Background()), 3)
+
+	s.NoError(s.db.WithContext(s.acme).Create(&sharedRecord{Name: "shared"}).Error)
+	var count int64
+	s.NoError(s.db.WithContext(s.acme).Model(&sharedRecord{}).Count(&count).Error)
+	s.Equal(int64(1), count)
+}
@@ -0,0 +1,77 @@
+// Package tenancy confines requests to the organization they act for. The JWT
+// middleware stores a Tenant in the request context, and Plugin restricts every
+// query on a model with an OrganizationID field to that organization and
+// stamps the field on new rows. Contexts without a Tenant, such as migrations
+// and background jobs, are not restricted.
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+const (
+	// Claim is the token claim naming the caller's organization.
+	Claim = "tenant"
+	// Header lets a super-admin pick the organization it acts in.
+	Header = "X-Organization-ID"
+	// SuperAdminScope makes a caller from the platform organization a
+	// super-admin.
+	SuperAdminScope = "organization:manage"
+)
+
+// maxLength mirrors the organizations.id column.
+const maxLength = 50
+
+var (
+	ErrInvalidOrganization = errors.New("invalid organization id")
+	// ErrOrganizationRequired refuses a lookup that is only unambiguous
+	// within one organization, made by a super-admin acting across all of
+	// them.
+	ErrOrganizationRequired = errors.New("an organization must be selected with " + Header)
+	organizationPattern     = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+)
+
+// Tenant is the organization a request acts for. A super-admin that did not
+// pick an organization acts across all of them; its new rows still belong to
+// OrganizationID.
+type Tenant struct {
+	OrganizationID string
+	Super          bool
+	CrossTenant    bool
+}
+
+type contextKey struct{}
+
+func WithTenant(ctx context.Context, tenant Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenant)
+}
+
+func FromContext(ctx context.Context) (Tenant, bool) {
+	if ctx == nil {
+		return Tenant{}, false
+	}
+	tenant, ok := ctx.Value(contextKey{}).(Tenant)
+	return tenant, ok
+}
+
+// ValidateOrganizationID accepts lowercase slugs such as "acme" or
+// "acme-eu".
+func ValidateOrganizationID(id string) error {
+	if len(id) > maxLength || !organizationPattern.MatchString(id) {
+		return fmt.Errorf("%w %q: must be at most %d characters and match %s", ErrInvalidOrganization, id, maxLength, organizationPattern)
+	}
+	return nil
+}
+
+// RequireOrganization fails for a cross-tenant super-admin. Scope, role and
+// group names are unique per organization only, so looking one up by name
+// across tenants would pick an arbitrary organization's row.
+func RequireOrganization(ctx context.Context) error {
+	if tenant, ok := FromContext(ctx); ok && tenant.CrossTenant {
+		return ErrOrganizationRequired
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package tenancy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOrganizationID(t *testing.T) {
+	for _, id := range []string{"acme", "acme-eu", "a", "42"} {
+		assert.NoError(t, ValidateOrganizationID(id), id)
+	}
+	for _, id := range []string{"", "Acme", "-acme", "acme_eu", "acme eu", strings.Repeat("a", 51)} {
+		assert.ErrorIs(t, ValidateOrganizationID(id), ErrInvalidOrganization, id)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	tenant := Tenant{OrganizationID: "acme"}
+	found, ok := FromContext(WithTenant(context.Background(), tenant))
+	assert.True(t, ok)
+	assert.Equal(t, tenant, found)
+}
+
+func TestRequireOrganization(t *testing.T) {
+	assert.NoError(t, RequireOrganization(context.Background()))
+	assert.NoError(t, RequireOrganization(WithTenant(context.Background(), Tenant{OrganizationID: "acme"})))
+	assert.NoError(t, RequireOrganization(WithTenant(context.Background(), Tenant{OrganizationID: "platform", Super: true})))
+
+	ctx := WithTenant(context.Background(), Tenant{OrganizationID: "platform", Super: true, CrossTenant: true})
+	assert.ErrorIs(t, RequireOrganization(ctx), ErrOrganizationRequired)
+}
@@ -0,0 +1,128 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is returned for endpoints on loopback, private,
+// link-local and other non-public addresses, such as cloud metadata
+// services, which a webhook must not be able to reach.
+var ErrPrivateAddress = errors.New("webhook address is not public")
+
+// Ranges whose addresses carry an IPv4 address: IPv4-compatible addresses,
+// NAT64, whose gateway forwards to the last four bytes, and 6to4, whose
+// relay forwards to the four bytes after the prefix.
+var (
+	ipv4Compatible = netip.MustParsePrefix("::/96")
+	nat64          = netip.MustParsePrefix("64:ff9b::/96")
+	sixToFour      = netip.MustParsePrefix("2002::/16")
+)
+
+// deniedPrefixes are ranges net/netip counts as global unicast that are not
+// public: "this network", carrier-grade NAT, and the translation ranges,
+// which are refused whatever they embed.
+var deniedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	ipv4Compatible,
+	nat64,
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	sixToFour,
+}
+
+// Public reports whether deliveries may be sent to addr. An IPv4 address
+// embedded in an IPv6 one must be public as well.
+func Public(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if embedded, ok := embeddedIPv4(addr); ok && !Public(embedded) {
+		return false
+	}
+	if !addr.IsValid() || !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range deniedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// embeddedIPv4 extracts the IPv4 address an IPv4-compatible, NAT64 or 6to4
+// address carries.
+func embeddedIPv4(addr netip.Addr) (netip.Addr, bool) {
+	if !addr.Is6() {
+		return netip.Addr{}, false
+	}
+	b := addr.As16()
+	switch {
+	case nat64.Contains(addr), ipv4Compatible.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[12:16])), true
+	case sixToFour.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[2:6])), true
+	}
+	return netip.Addr{}, false
+}
+
+// CheckHost rejects a host that is a non-public address or resolves to one.
+// A host that does not resolve is let through: the client checks every
+// address it dials anyway, this only reports the mistake early.
+func CheckHost(ctx context.Context, host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return checkAddr(addr)
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if err := checkAddr(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewClient returns the HTTP client deliveries are sent with. It refuses to
+// connect to non-public addresses, checking the address actually dialed so
+// that a host cannot pass validation and then resolve to a private address,
+// and it does not follow redirects, which the receiver could otherwise use
+// to point the request anywhere. Proxies are not used, since the client
+// could not check where they connect.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			return checkAddr(addrPort.Addr())
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func checkAddr(addr netip.Addr) error {
+	if !Public(addr) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, addr)
+	}
+	return nil
+}
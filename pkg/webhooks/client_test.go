@@ -0,0 +1,73 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublic(t *testing.T) {
+	for _, tc := range []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1::1", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.0.0.1", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"0.1.2.3", false},
+		{"::", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"::127.0.0.1", false},
+		{"::93.184.216.34", false},
+		{"64:ff9b::a9fe:a9fe", false},
+		{"64:ff9b::7f00:1", false},
+		{"64:ff9b::5db8:d822", false},
+		{"64:ff9b:1::a00:1", false},
+		{"2002:a9fe:a9fe::1", false},
+		{"2002:c0a8:101::1", false},
+		{"2002:5db8:d822::1", false},
+	} {
+		assert.Equal(t, tc.public, Public(netip.MustParseAddr(tc.addr)), tc.addr)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, CheckHost(ctx, "93.184.216.34"))
+	assert.ErrorIs(t, CheckHost(ctx, "169.254.169.254"), ErrPrivateAddress)
+	assert.ErrorIs(t, CheckHost(ctx, "::1"), ErrPrivateAddress)
+	assert.ErrorIs(t, CheckHost(ctx, "localhost"), ErrPrivateAddress)
+}
+
+func TestClientRefusesPrivateAddresses(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	_, err := NewClient(time.Second).Post(server.URL, "application/json", nil)
+	assert.ErrorIs(t, err, ErrPrivateAddress)
+	assert.False(t, called)
+}
+
+func TestClientDoesNotFollowRedirects(t *testing.T) {
+	client := NewClient(time.Second)
+	assert.ErrorIs(t, client.CheckRedirect(nil, nil), http.ErrUseLastResponse)
+}
@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSecret(t *testing.T) {
+	first, err := NewSecret()
+	assert.NoError(t, err)
+	second, err := NewSecret()
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(first, "whsec_"))
+	assert.Len(t, first, len("whsec_")+64)
+	assert.NotEqual(t, first, second)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"event1"}`)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := Sign("secret", now.Unix(), body)
+
+	assert.True(t, strings.HasPrefix(signature, "v1="))
+	assert.NoError(t, Verify("secret", timestamp, signature, body, now.Add(time.Minute), 5*time.Minute))
+}
+
+func TestVerifyRejects(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"event1"}`)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := Sign("secret", now.Unix(), body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+		err       error
+	}{
+		{"wrong secret", "other", timestamp, signature, body, now, ErrInvalidSignature},
+		{"tampered body", "secret", timestamp, signature, []byte(`{"id":"event2"}`), now, ErrInvalidSignature},
+		{"replayed with new timestamp", "secret", strconv.FormatInt(now.Unix()+60, 10), signature, body, now, ErrInvalidSignature},
+		{"missing scheme", "secret", timestamp, strings.TrimPrefix(signature, "v1="), body, now, ErrInvalidSignature},
+		{"not hex", "secret", timestamp, "v1=zz", body, now, ErrInvalidSignature},
+		{"stale", "secret", timestamp, signature, body, now.Add(10 * time.Minute), ErrInvalidTimestamp},
+		{"future", "secret", timestamp, signature, body, now.Add(-10 * time.Minute), ErrInvalidTimestamp},
+		{"malformed timestamp", "secret", "yesterday", signature, body, now, ErrInvalidTimestamp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.timestamp, tt.signature, tt.body, tt.now, 5*time.Minute)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+
+	"gorm.io/gorm"
+)
+
+var ErrAccessRequestNotPending = errors.New("access request is not pending")
+
+type IAccessRequestRepository interface {
+	FindById(ctx context.Context, requestId uint) (*entities.AccessRequest, error)
+	FindAll(ctx context.Context, query dto.ListAccessRequestsRequest) ([]*entities.AccessRequest, *dto.Paging, error)
+	Create(ctx context.Context, requesterId, scope, justification string, grantExpiresAt *time.Time, expiresAt time.Time) (*entities.AccessRequest, error)
+	Decide(ctx context.Context, requestId uint, status, actorId, comment string) error
+	AddComment(ctx context.Context, requestId uint, authorId, body string) (*entities.AccessRequestComment, error)
+	ExpireStale(ctx context.Context, now time.Time) (int64, error)
+}
+
+type accessRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewAccessRequestRepository(db *gorm.DB) IAccessRequestRepository {
+	return &accessRequestRepository{db: db}
+}
+
+func preloadComments(db *gorm.DB) *gorm.DB {
+	return db.Order("access_request_comments.id")
+}
+
+func (r *accessRequestRepository) FindById(ctx context.Context, requestId uint) (*entities.AccessRequest, error) {
+	var request entities.AccessRequest
+	res := r.db.WithContext(ctx).Preload("Comments", preloadComments).First(&request, requestId)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &request, nil
+}
+
+var accessRequestSortColumns = map[string]string{
+	"id": "id",
+}
+
+func (r *accessRequestRepository) FindAll(ctx context.Context, query dto.ListAccessRequestsRequest) ([]*entities.AccessRequest, *dto.Paging, error) {
+	page, err := newPageQuery(query.Limit, query.Cursor, query.SortBy, query.Order, accessRequestSortColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db := r.db.WithContext(ctx).Model(&entities.AccessRequest{})
+	if query.Status != "" {
+		db = db.Where("access_requests.status = ?", query.Status)
+	}
+	if query.Scope != "" {
+		db = db.Where("access_requests.scope = ?", query.Scope)
+	}
+	if query.RequesterID != "" {
+		db = db.Where("access_requests.requester_id = ?", query.RequesterID)
+	}
+
+	var cursorID interface{}
+	if page.cursor != nil {
+		id, err := strconv.ParseUint(page.cursor.ID, 10, 64)
+		if err != nil {
+			return nil, nil, ErrInvalidCursor
+		}
+		cursorID = id
+	}
+
+	var requests []*entities.AccessRequest
+	res := page.apply(db, "access_requests", cursorID).Preload("Comments", preloadComments).Find(&requests)
+	if res.Error != nil {
+		return nil, nil, res.Error
+	}
+
+	count, paging := page.paging(len(requests), func(i int) (string, string) {
+		id := strconv.FormatUint(uint64(requests[i].ID), 10)
+		return id, id
+	})
+	return requests[:count], paging, nil
+}
+
+func (r *accessRequestRepository) Create(ctx context.Context, requesterId, scope, justification string, grantExpiresAt *time.Time, expiresAt time.Time) (*entities.AccessRequest, error) {
+	newRequest := &entities.AccessRequest{
+		RequesterID:    requesterId,
+		Scope:          scope,
+		Justification:  justification,
+		Status:         entities.AccessRequestPending,
+		GrantExpiresAt: grantExpiresAt,
+		ExpiresAt:      expiresAt,
+	}
+	res := r.db.WithContext(ctx).Create(newRequest)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return newRequest, nil
+}
+
+// Decide moves a pending request to status on behalf of actorId and records
+// comment, if any. It fails with ErrAccessRequestNotPending when the request
+// left the pending state first, so two reviewers cannot both decide it.
+func (r *accessRequestRepository) Decide(ctx context.Context, requestId uint, status, actorId, comment string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&entities.AccessRequest{}).
+			Where("id = ? AND status = ?", requestId, entities.AccessRequestPending).
+			Updates(map[string]interface{}{
+				"status":     status,
+				"decided_by": actorId,
+				"decided_at": time.Now(),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrAccessRequestNotPending
+		}
+		if comment == "" {
+			return nil
+		}
+		return tx.Create(&entities.AccessRequestComment{AccessRequestID: requestId, AuthorID: actorId, Body: comment}).Error
+	})
+}
+
+func (r *accessRequestRepository) AddComment(ctx context.Context, requestId uint, authorId, body string) (*entities.AccessRequestComment, error) {
+	comment := &entities.AccessRequestComment{AccessRequestID: requestId, AuthorID: authorId, Body: body}
+	res := r.db.WithContext(ctx).Create(comment)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return comment, nil
+}
+
+// ExpireStale expires every pending request whose deadline passed at or
+// before now and returns how many were expired. It spans every organization
+// unless ctx carries a tenant.
+func (r *accessRequestRepository) ExpireStale(ctx context.Context, now time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Model(&entities.AccessRequest{}).
+		Where("status = ? AND expires_at <= ?", entities.AccessRequestPending, now).
+		Update("status", entities.AccessRequestExpired)
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+)
+
+type AccessRequestRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	ctx  context.Context
+	repo IAccessRequestRepository
+}
+
+func (suite *AccessRequestRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.AccessRequest{}, &entities.AccessRequestComment{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), gormDB.Use(tenancy.Plugin{}))
+	suite.db = gormDB
+	suite.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
+	suite.repo = NewAccessRequestRepository(gormDB)
+}
+
+func (suite *AccessRequestRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestAccessRequestRepoSuite(t *testing.T) {
+	suite.Run(t, new(AccessRequestRepoSuite))
+}
+
+func (suite *AccessRequestRepoSuite) create(requesterId, scope string) *entities.AccessRequest {
+	request, err := suite.repo.Create(suite.ctx, requesterId, scope, "need it", nil, time.Now().Add(time.Hour))
+	assert.NoError(suite.T(), err)
+	return request
+}
+
+func (suite *AccessRequestRepoSuite) TestCreateAndFindById() {
+	grantExpiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	request, err := suite.repo.Create(suite.ctx, "user1", "user:view", "on-call rotation", &grantExpiresAt, time.Now().Add(time.Hour))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "acme", request.OrganizationID)
+	assert.Equal(suite.T(), entities.AccessRequestPending, request.Status)
+
+	found, err := suite.repo.FindById(suite.ctx, request.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "user1", found.RequesterID)
+	assert.Equal(suite.T(), "on-call rotation", found.Justification)
+	assert.True(suite.T(), grantExpiresAt.Equal(*found.GrantExpiresAt))
+	assert.Empty(suite.T(), found.Comments)
+}
+
+func (suite *AccessRequestRepoSuite) TestCreateDuplicatePending() {
+	first := suite.create("user1", "user:view")
+
+	_, err := suite.repo.Create(suite.ctx, "user1", "user:view", "again", nil, time.Now().Add(time.Hour))
+	assert.ErrorIs(suite.T(), err, gorm.ErrDuplicatedKey)
+
+	// Once the first request is decided the scope can be requested again.
+	assert.NoError(suite.T(), suite.repo.Decide(suite.ctx, first.ID, entities.AccessRequestRejected, "admin", ""))
+	_, err = suite.repo.Create(suite.ctx, "user1", "user:view", "again", nil, time.Now().Add(time.Hour))
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *AccessRequestRepoSuite) TestFindByIdNotFound() {
+	_, err := suite.repo.FindById(suite.ctx, 42)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *AccessRequestRepoSuite) TestFindByIdOtherTenant() {
+	request := suite.create("user1", "user:view")
+
+	globex := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "globex"})
+	_, err := suite.repo.FindById(globex, request.ID)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *AccessRequestRepoSuite) TestDecide() {
+	request := suite.create("user1", "user:view")
+
+	err := suite.repo.Decide(suite.ctx, request.ID, entities.AccessRequestApproved, "admin", "approved for the incident")
+	assert.NoError(suite.T(), err)
+
+	found, err := suite.repo.FindById(suite.ctx, request.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entities.AccessRequestApproved, found.Status)
+	assert.Equal(suite.T(), "admin", *found.DecidedBy)
+	assert.NotNil(suite.T(), found.DecidedAt)
+	assert.Len(suite.T(), found.Comments, 1)
+	assert.Equal(suite.T(), "approved for the incident", found.Comments[0].Body)
+
+	err = suite.repo.Decide(suite.ctx, request.ID, entities.AccessRequestRejected, "other-admin", "")
+	assert.ErrorIs(suite.T(), err, ErrAccessRequestNotPending)
+}
+
+func (suite *AccessRequestRepoSuite) TestDecideNotFound() {
+	err := suite.repo.Decide(suite.ctx, 42, entities.AccessRequestApproved, "admin", "")
+	assert.ErrorIs(suite.T(), err, ErrAccessRequestNotPending)
+}
+
+func (suite *AccessRequestRepoSuite) TestAddComment() {
+	request := suite.create("user1", "user:view")
+
+	_, err := suite.repo.AddComment(suite.ctx, request.ID, "admin", "why?")
+	assert.NoError(suite.T(), err)
+	comment, err := suite.repo.AddComment(suite.ctx, request.ID, "user1", "for the migration")
+	assert.NoError(suite.T(), err)
+	assert.NotZero(suite.T(), comment.ID)
+
+	found, err := suite.repo.FindById(suite.ctx, request.ID)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), found.Comments, 2)
+	assert.Equal(suite.T(), "why?", found.Comments[0].Body)
+	assert.Equal(suite.T(), "user1", found.Comments[1].AuthorID)
+}
+
+func (suite *AccessRequestRepoSuite) TestExpireStale() {
+	stale, err := suite.repo.Create(suite.ctx, "user1", "user:view", "need it", nil, time.Now().Add(-time.Minute))
+	assert.NoError(suite.T(), err)
+	fresh := suite.create("user2", "user:view")
+	decided, err := suite.repo.Create(suite.ctx, "user3", "user:view", "need it", nil, time.Now().Add(-time.Minute))
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.repo.Decide(suite.ctx, decided.ID, entities.AccessRequestRejected, "admin", ""))
+
+	globex := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "globex"})
+	_, err = suite.repo.Create(globex, "user4", "user:view", "need it", nil, time.Now().Add(-time.Minute))
+	assert.NoError(suite.T(), err)
+
+	count, err := suite.repo.ExpireStale(context.Background(), time.Now())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), count)
+
+	found, err := suite.repo.FindById(suite.ctx, stale.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entities.AccessRequestExpired, found.Status)
+
+	found, err = suite.repo.FindById(suite.ctx, fresh.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entities.AccessRequestPending, found.Status)
+
+	found, err = suite.repo.FindById(suite.ctx, decided.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entities.AccessRequestRejected, found.Status)
+}
+
+func (suite *AccessRequestRepoSuite) TestFindAll() {
+	suite.create("user1", "user:view")
+	suite.create("user1", "scope:view")
+	rejected := suite.create("user2", "user:view")
+	assert.NoError(suite.T(), suite.repo.Decide(suite.ctx, rejected.ID, entities.AccessRequestRejected, "admin", ""))
+
+	requests, paging, err := suite.repo.FindAll(suite.ctx, dto.ListAccessRequestsRequest{Limit: 2})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), requests, 2)
+	assert.True(suite.T(), paging.HasMore)
+
+	requests, paging, err = suite.repo.FindAll(suite.ctx, dto.ListAccessRequestsRequest{Limit: 2, Cursor: paging.NextCursor})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), requests, 1)
+	assert.False(suite.T(), paging.HasMore)
+
+	requests, _, err = suite.repo.FindAll(suite.ctx, dto.ListAccessRequestsRequest{Status: entities.AccessRequestPending})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), requests, 2)
+
+	requests, _, err = suite.repo.FindAll(suite.ctx, dto.ListAccessRequestsRequest{Scope: "user:view"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), requests, 2)
+
+	requests, _, err = suite.repo.FindAll(suite.ctx, dto.ListAccessRequestsRequest{RequesterID: "user2"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), requests, 1)
+	assert.Equal(suite.T(), rejected.ID, requests[0].ID)
+}
+
+func (suite *AccessRequestRepoSuite) TestFindAllInvalidCursor() {
+	_, _, err := suite.repo.FindAll(suite.ctx, dto.ListAccessRequestsRequest{Cursor: "invalid"})
+	assert.ErrorIs(suite.T(), err, ErrInvalidCursor)
+}
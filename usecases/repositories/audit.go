@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+
+	"gorm.io/gorm"
+)
+
+// IAuditRepository appends to and reads the audit log. It has no way to
+// change or remove an entry. Services record entries through
+// WithTransaction so that an entry commits or rolls back with its change.
+type IAuditRepository interface {
+	Create(ctx context.Context, entry *entities.AuditEntry) error
+	FindAll(ctx context.Context, query dto.ListAuditEntriesRequest) ([]*entities.AuditEntry, *dto.Paging, error)
+	WithTransaction(tx *gorm.DB) IAuditRepository
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) IAuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Create(ctx context.Context, entry *entities.AuditEntry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+var auditSortColumns = map[string]string{
+	"id": "id",
+}
+
+func (r *auditRepository) FindAll(ctx context.Context, query dto.ListAuditEntriesRequest) ([]*entities.AuditEntry, *dto.Paging, error) {
+	page, err := newPageQuery(query.Limit, query.Cursor, query.SortBy, query.Order, auditSortColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db := r.db.WithContext(ctx).Model(&entities.AuditEntry{})
+	if query.ActorID != "" {
+		db = db.Where("audit_log.actor_id = ?", query.ActorID)
+	}
+	if query.Action != "" {
+		db = db.Where("audit_log.action = ?", query.Action)
+	}
+	if query.TargetType != "" {
+		db = db.Where("audit_log.target_type = ?", query.TargetType)
+	}
+	if query.TargetID != "" {
+		db = db.Where("audit_log.target_id = ?", query.TargetID)
+	}
+	if query.RequestID != "" {
+		db = db.Where("audit_log.request_id = ?", query.RequestID)
+	}
+	if query.Since != nil {
+		db = db.Where("audit_log.created_at >= ?", *query.Since)
+	}
+	if query.Until != nil {
+		db = db.Where("audit_log.created_at < ?", *query.Until)
+	}
+
+	var cursorID interface{}
+	if page.cursor != nil {
+		id, err := strconv.ParseUint(page.cursor.ID, 10, 64)
+		if err != nil {
+			return nil, nil, ErrInvalidCursor
+		}
+		cursorID = id
+	}
+
+	var entries []*entities.AuditEntry
+	res := page.apply(db, "audit_log", cursorID).Find(&entries)
+	if res.Error != nil {
+		return nil, nil, res.Error
+	}
+
+	count, paging := page.paging(len(entries), func(i int) (string, string) {
+		id := strconv.FormatUint(uint64(entries[i].ID), 10)
+		return id, id
+	})
+	return entries[:count], paging, nil
+}
+
+func (r *auditRepository) WithTransaction(tx *gorm.DB) IAuditRepository {
+	return &auditRepository{db: tx}
+}
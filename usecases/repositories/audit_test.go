@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+)
+
+type AuditRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	ctx  context.Context
+	repo IAuditRepository
+}
+
+func (suite *AuditRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.AuditEntry{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), gormDB.Use(tenancy.Plugin{}))
+	suite.db = gormDB
+	suite.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
+	suite.repo = NewAuditRepository(gormDB)
+}
+
+func (suite *AuditRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestAuditRepoSuite(t *testing.T) {
+	suite.Run(t, new(AuditRepoSuite))
+}
+
+func (suite *AuditRepoSuite) create(ctx context.Context, actorId, action, targetId string) *entities.AuditEntry {
+	after := `{"id":"` + targetId + `"}`
+	entry := &entities.AuditEntry{
+		ActorID:    actorId,
+		Action:     action,
+		TargetType: entities.AuditTargetUser,
+		TargetID:   targetId,
+		After:      &after,
+		RequestID:  "req-" + targetId,
+		SourceIP:   "10.0.0.1",
+	}
+	assert.NoError(suite.T(), suite.repo.Create(ctx, entry))
+	return entry
+}
+
+func (suite *AuditRepoSuite) TestCreate() {
+	entry := suite.create(suite.ctx, "admin", entities.AuditUserCreate, "user1")
+	assert.NotZero(suite.T(), entry.ID)
+	assert.Equal(suite.T(), "acme", entry.OrganizationID)
+
+	entries, _, err := suite.repo.FindAll(suite.ctx, dto.ListAuditEntriesRequest{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), entries, 1)
+	assert.Equal(suite.T(), `{"id":"user1"}`, *entries[0].After)
+	assert.Nil(suite.T(), entries[0].Before)
+}
+
+func (suite *AuditRepoSuite) TestCreateRollsBackWithTransaction() {
+	tx := suite.db.WithContext(suite.ctx).Begin()
+	assert.NoError(suite.T(), tx.Error)
+	assert.NoError(suite.T(), suite.repo.WithTransaction(tx).Create(suite.ctx, &entities.AuditEntry{
+		Action:     entities.AuditUserDelete,
+		TargetType: entities.AuditTargetUser,
+		TargetID:   "user1",
+	}))
+	assert.NoError(suite.T(), tx.Rollback().Error)
+
+	entries, _, err := suite.repo.FindAll(suite.ctx, dto.ListAuditEntriesRequest{})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), entries)
+}
+
+func (suite *AuditRepoSuite) TestFindAll() {
+	suite.create(suite.ctx, "admin", entities.AuditUserCreate, "user1")
+	suite.create(suite.ctx, "admin", entities.AuditUserScopeUpdate, "user1")
+	suite.create(suite.ctx, "operator", entities.AuditUserCreate, "user2")
+	globex := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "globex"})
+	suite.create(globex, "admin", entities.AuditUserCreate, "user3")
+
+	entries, paging, err := suite.repo.FindAll(suite.ctx, dto.ListAuditEntriesRequest{Limit: 2})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), entries, 2)
+	assert.True(suite.T(), paging.HasMore)
+
+	entries, paging, err = suite.repo.FindAll(suite.ctx, dto.ListAuditEntriesRequest{Limit: 2, Cursor: paging.NextCursor})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), entries, 1)
+	assert.False(suite.T(), paging.HasMore)
+
+	entries, _, err = suite.repo.FindAll(suite.ctx, dto.ListAuditEntriesRequest{ActorID: "admin"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), entries, 2)
+
+	entries, _, err = suite.repo.FindAll(suite.ctx, dto.ListAuditEntriesRequest{Action: entities.AuditUserCreate, TargetType: entities.AuditTargetUser})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), entries, 2)
+
+	entries, _, err = suite.repo.FindAll(suite.ctx, dto.ListAuditEntriesRequest{TargetID: "user1", Order: "desc"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), entries, 2)
+	assert.Equal(suite.T(), entities.AuditUserScopeUpdate, entries[0].Action)
+
+	entries, _, err = suite.repo.FindAll(suite.ctx, dto.ListAuditEntriesRequest{RequestID: "req-user2"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), entries, 1)
+	assert.Equal(suite.T(), "operator", entries[0].ActorID)
+}
+
+func (suite *AuditRepoSuite) TestFindAllTimeRange() {
+	suite.create(suite.ctx, "admin", entities.AuditUserCreate, "user1")
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	entries, _, err := suite.repo.FindAll(suite.ctx, dto.ListAuditEntriesRequest{Since: &past, Until: &future})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), entries, 1)
+
+	entries, _, err = suite.repo.FindAll(suite.ctx, dto.ListAuditEntriesRequest{Since: &future})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), entries)
+
+	entries, _, err = suite.repo.FindAll(suite.ctx, dto.ListAuditEntriesRequest{Until: &past})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), entries)
+}
+
+func (suite *AuditRepoSuite) TestFindAllInvalidCursor() {
+	_, _, err := suite.repo.FindAll(suite.ctx, dto.ListAuditEntriesRequest{Cursor: "invalid"})
+	assert.ErrorIs(suite.T(), err, ErrInvalidCursor)
+}
@@ -0,0 +1,231 @@
+package repositories
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+
+	"gorm.io/gorm"
+)
+
+type IGroupRepository interface {
+	FindByName(ctx context.Context, name string) (*entities.Group, error)
+	FindAll(ctx context.Context, query dto.ListGroupsRequest) ([]*entities.Group, *dto.Paging, error)
+	FindMemberIds(ctx context.Context, groupId uint) ([]string, error)
+	Create(ctx context.Context, name string, parentId *uint, scopes []*entities.UserScope) (*entities.Group, error)
+	UpdateScope(ctx context.Context, group *entities.Group, scopes []*entities.UserScope) error
+	UpdateParent(ctx context.Context, groupId uint, parentId *uint) error
+	UpdateMember(ctx context.Context, group *entities.Group, user *entities.User, isAdded bool) error
+	Delete(ctx context.Context, groupId uint) error
+	BeginTransaction(ctx context.Context) (*gorm.DB, error)
+	WithTransaction(tx *gorm.DB) IGroupRepository
+}
+
+type groupRepository struct {
+	db *gorm.DB
+}
+
+func NewGroupRepository(db *gorm.DB) IGroupRepository {
+	return &groupRepository{db: db}
+}
+
+// FindByName returns the group with its scopes and its full ancestry.
+func (r *groupRepository) FindByName(ctx context.Context, name string) (*entities.Group, error) {
+	if err := tenancy.RequireOrganization(ctx); err != nil {
+		return nil, err
+	}
+	var group entities.Group
+	res := r.db.WithContext(ctx).Preload("Scopes").First(&group, entities.Group{Name: name})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if err := loadAncestors(r.db.WithContext(ctx), []*entities.Group{&group}); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+var groupSortColumns = map[string]string{
+	"id":   "id",
+	"name": "name",
+}
+
+func (r *groupRepository) FindAll(ctx context.Context, query dto.ListGroupsRequest) ([]*entities.Group, *dto.Paging, error) {
+	page, err := newPageQuery(query.Limit, query.Cursor, query.SortBy, query.Order, groupSortColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db := r.db.WithContext(ctx).Model(&entities.Group{})
+	if query.NamePrefix != "" {
+		db = db.Where(`user_groups.name LIKE ? ESCAPE '\'`, escapeLike(query.NamePrefix)+"%")
+	}
+
+	var cursorID interface{}
+	if page.cursor != nil {
+		id, err := strconv.ParseUint(page.cursor.ID, 10, 64)
+		if err != nil {
+			return nil, nil, ErrInvalidCursor
+		}
+		cursorID = id
+	}
+
+	var groups []*entities.Group
+	res := page.apply(db, "user_groups", cursorID).Preload("Parent").Preload("Scopes").Find(&groups)
+	if res.Error != nil {
+		return nil, nil, res.Error
+	}
+
+	count, paging := page.paging(len(groups), func(i int) (string, string) {
+		id := strconv.FormatUint(uint64(groups[i].ID), 10)
+		if page.column == "name" {
+			return groups[i].Name, id
+		}
+		return id, id
+	})
+	return groups[:count], paging, nil
+}
+
+// groupSubtree selects the ids of the group and all of its descendants.
+// UNION rather than UNION ALL makes the recursion stop even on a cycle.
+const groupSubtree = `WITH RECURSIVE subtree(id) AS (
+	SELECT id FROM user_groups WHERE id = ?
+	UNION
+	SELECT user_groups.id FROM user_groups JOIN subtree ON user_groups.parent_id = subtree.id
+) SELECT id FROM subtree`
+
+// FindMemberIds returns the users who inherit from the group: its own
+// members and the members of every group nested below it.
+func (r *groupRepository) FindMemberIds(ctx context.Context, groupId uint) ([]string, error) {
+	var userIds []string
+	res := r.db.WithContext(ctx).Table("user_group_mapping").
+		Distinct("user_id").
+		Where("group_id IN (?)", r.db.Raw(groupSubtree, groupId)).
+		Order("user_id").
+		Pluck("user_id", &userIds)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return userIds, nil
+}
+
+func (r *groupRepository) Create(ctx context.Context, name string, parentId *uint, scopes []*entities.UserScope) (*entities.Group, error) {
+	newGroup := &entities.Group{
+		Name:     name,
+		ParentID: parentId,
+		Scopes:   scopes,
+	}
+	res := r.db.WithContext(ctx).Omit("Parent").Create(newGroup)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return newGroup, nil
+}
+
+func (r *groupRepository) UpdateScope(ctx context.Context, group *entities.Group, scopes []*entities.UserScope) error {
+	err := r.db.WithContext(ctx).Model(group).Omit("Parent").Association("Scopes").Replace(scopes)
+	return err
+}
+
+func (r *groupRepository) UpdateParent(ctx context.Context, groupId uint, parentId *uint) error {
+	res := r.db.WithContext(ctx).Model(&entities.Group{}).Where("id = ?", groupId).Update("parent_id", parentId)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *groupRepository) UpdateMember(ctx context.Context, group *entities.Group, user *entities.User, isAdded bool) error {
+	association := r.db.WithContext(ctx).Model(user).Omit("Groups.*").Association("Groups")
+	if isAdded {
+		return association.Append(group)
+	}
+	return association.Delete(group)
+}
+
+// Delete removes the group and its mappings. Child groups move to the top
+// level rather than being deleted with it.
+func (r *groupRepository) Delete(ctx context.Context, groupId uint) error {
+	res := r.db.WithContext(ctx).Model(&entities.Group{}).Where("parent_id = ?", groupId).Update("parent_id", nil)
+	if res.Error != nil {
+		return res.Error
+	}
+	res = r.db.WithContext(ctx).Exec("DELETE FROM user_group_mapping WHERE group_id = ?", groupId)
+	if res.Error != nil {
+		return res.Error
+	}
+	res = r.db.WithContext(ctx).Select("Scopes").Delete(&entities.Group{ID: groupId})
+	return res.Error
+}
+
+func (r *groupRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
+	tx := r.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return tx, nil
+}
+
+func (r *groupRepository) WithTransaction(tx *gorm.DB) IGroupRepository {
+	return &groupRepository{db: tx}
+}
+
+// loadAncestors links Parent along the ancestry of each group, loading each
+// ancestor's scopes. It issues one query per level of nesting, and a group
+// already loaded is never fetched again, so a cycle cannot loop.
+func loadAncestors(db *gorm.DB, groups []*entities.Group) error {
+	loaded := make(map[uint]*entities.Group, len(groups))
+	all := make([]*entities.Group, 0, len(groups))
+	for _, group := range groups {
+		if _, ok := loaded[group.ID]; !ok {
+			loaded[group.ID] = group
+		}
+		all = append(all, group)
+	}
+
+	pending := groups
+	for len(pending) > 0 {
+		var parentIds []uint
+		requested := make(map[uint]struct{})
+		for _, group := range pending {
+			if group.ParentID == nil {
+				continue
+			}
+			id := *group.ParentID
+			if _, ok := loaded[id]; ok {
+				continue
+			}
+			if _, ok := requested[id]; ok {
+				continue
+			}
+			requested[id] = struct{}{}
+			parentIds = append(parentIds, id)
+		}
+		if len(parentIds) == 0 {
+			break
+		}
+
+		var parents []*entities.Group
+		if err := db.Preload("Scopes").Where("id IN ?", parentIds).Find(&parents).Error; err != nil {
+			return err
+		}
+		for _, parent := range parents {
+			loaded[parent.ID] = parent
+			all = append(all, parent)
+		}
+		pending = parents
+	}
+
+	for _, group := range all {
+		if group.ParentID != nil {
+			group.Parent = loaded[*group.ParentID]
+		}
+	}
+	return nil
+}
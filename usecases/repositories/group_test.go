@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+)
+
+type GroupRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	ctx  context.Context
+	repo IGroupRepository
+}
+
+func (suite *GroupRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.User{}, &entities.Role{}, &entities.Group{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), gormDB.Use(tenancy.Plugin{}))
+	suite.db = gormDB
+	suite.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
+	suite.repo = NewGroupRepository(gormDB)
+}
+
+func (suite *GroupRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestGroupRepoSuite(t *testing.T) {
+	suite.Run(t, new(GroupRepoSuite))
+}
+
+// createTree builds engineering > backend > payments.
+func (suite *GroupRepoSuite) createTree() (*entities.Group, *entities.Group, *entities.Group) {
+	engineering, err := suite.repo.Create(suite.ctx, "engineering", nil, []*entities.UserScope{{Name: "container:view"}})
+	assert.NoError(suite.T(), err)
+	backend, err := suite.repo.Create(suite.ctx, "backend", &engineering.ID, []*entities.UserScope{{Name: "container:update"}})
+	assert.NoError(suite.T(), err)
+	payments, err := suite.repo.Create(suite.ctx, "payments", &backend.ID, nil)
+	assert.NoError(suite.T(), err)
+	return engineering, backend, payments
+}
+
+func (suite *GroupRepoSuite) createUser(id string, groups ...*entities.Group) *entities.User {
+	user := &entities.User{ID: id, Username: id, Hash: "hash", Email: id + "@example.com", Groups: groups}
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create(user).Error)
+	return user
+}
+
+func (suite *GroupRepoSuite) TestFindByNameLoadsAncestry() {
+	suite.createTree()
+
+	found, err := suite.repo.FindByName(suite.ctx, "payments")
+	assert.NoError(suite.T(), err)
+
+	names := []string{}
+	for _, group := range found.Lineage() {
+		names = append(names, group.Name)
+	}
+	assert.Equal(suite.T(), []string{"payments", "backend", "engineering"}, names)
+	assert.Len(suite.T(), found.Parent.Parent.Scopes, 1)
+}
+
+func (suite *GroupRepoSuite) TestFindByNameNotFound() {
+	_, err := suite.repo.FindByName(suite.ctx, "missing")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *GroupRepoSuite) TestCreateDuplicateName() {
+	_, err := suite.repo.Create(suite.ctx, "engineering", nil, nil)
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.repo.Create(suite.ctx, "engineering", nil, nil)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *GroupRepoSuite) TestFindAll() {
+	suite.createTree()
+
+	groups, paging, err := suite.repo.FindAll(suite.ctx, dto.ListGroupsRequest{SortBy: "name"})
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), paging.HasMore)
+	assert.Len(suite.T(), groups, 3)
+	assert.Equal(suite.T(), "backend", groups[0].Name)
+	assert.Equal(suite.T(), "engineering", groups[0].Parent.Name)
+	assert.Nil(suite.T(), groups[1].Parent)
+
+	groups, paging, err = suite.repo.FindAll(suite.ctx, dto.ListGroupsRequest{Limit: 1, NamePrefix: "pay"})
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), paging.HasMore)
+	assert.Equal(suite.T(), "payments", groups[0].Name)
+}
+
+func (suite *GroupRepoSuite) TestFindMemberIdsIncludesNestedGroups() {
+	engineering, backend, payments := suite.createTree()
+	suite.createUser("user-1", engineering)
+	suite.createUser("user-2", payments)
+	suite.createUser("user-3", backend, payments)
+	suite.createUser("user-4")
+
+	userIds, err := suite.repo.FindMemberIds(suite.ctx, engineering.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"user-1", "user-2", "user-3"}, userIds)
+
+	userIds, err = suite.repo.FindMemberIds(suite.ctx, backend.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"user-2", "user-3"}, userIds)
+}
+
+func (suite *GroupRepoSuite) TestUpdateScope() {
+	engineering, _, _ := suite.createTree()
+
+	err := suite.repo.UpdateScope(suite.ctx, engineering, []*entities.UserScope{{Name: "report:mail"}})
+	assert.NoError(suite.T(), err)
+
+	found, err := suite.repo.FindByName(suite.ctx, "engineering")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), found.Scopes, 1)
+	assert.Equal(suite.T(), "report:mail", found.Scopes[0].Name)
+}
+
+func (suite *GroupRepoSuite) TestUpdateParent() {
+	engineering, backend, _ := suite.createTree()
+
+	err := suite.repo.UpdateParent(suite.ctx, backend.ID, nil)
+	assert.NoError(suite.T(), err)
+	found, err := suite.repo.FindByName(suite.ctx, "payments")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), found.Lineage(), 2)
+
+	err = suite.repo.UpdateParent(suite.ctx, engineering.ID, &backend.ID)
+	assert.NoError(suite.T(), err)
+	found, err = suite.repo.FindByName(suite.ctx, "engineering")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "backend", found.Parent.Name)
+
+	err = suite.repo.UpdateParent(suite.ctx, 999, nil)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *GroupRepoSuite) TestLineageStopsOnCycle() {
+	engineering, backend, _ := suite.createTree()
+	assert.NoError(suite.T(), suite.repo.UpdateParent(suite.ctx, engineering.ID, &backend.ID))
+
+	found, err := suite.repo.FindByName(suite.ctx, "payments")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), found.Lineage(), 3)
+
+	userIds, err := suite.repo.FindMemberIds(suite.ctx, engineering.ID)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), userIds)
+}
+
+func (suite *GroupRepoSuite) TestUpdateMember() {
+	engineering, _, _ := suite.createTree()
+	user := suite.createUser("user-1")
+
+	err := suite.repo.UpdateMember(suite.ctx, engineering, user, true)
+	assert.NoError(suite.T(), err)
+	userIds, err := suite.repo.FindMemberIds(suite.ctx, engineering.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"user-1"}, userIds)
+
+	err = suite.repo.UpdateMember(suite.ctx, engineering, user, false)
+	assert.NoError(suite.T(), err)
+	userIds, err = suite.repo.FindMemberIds(suite.ctx, engineering.ID)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), userIds)
+}
+
+func (suite *GroupRepoSuite) TestDeleteDetachesChildren() {
+	_, backend, _ := suite.createTree()
+	suite.createUser("user-1", backend)
+
+	err := suite.repo.Delete(suite.ctx, backend.ID)
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.repo.FindByName(suite.ctx, "backend")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+
+	payments, err := suite.repo.FindByName(suite.ctx, "payments")
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), payments.ParentID)
+
+	userIds, err := suite.repo.FindMemberIds(suite.ctx, backend.ID)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), userIds)
+}
+
+func (suite *GroupRepoSuite) TestBeginTransactionError() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+
+	_, err := suite.repo.BeginTransaction(suite.ctx)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *GroupRepoSuite) TestWithTransaction() {
+	tx, err := suite.repo.BeginTransaction(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.repo.WithTransaction(tx).Create(suite.ctx, "engineering", nil, nil)
+	assert.NoError(suite.T(), err)
+	tx.Rollback()
+
+	_, err = suite.repo.FindByName(suite.ctx, "engineering")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
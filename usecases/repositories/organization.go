@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+
+	"gorm.io/gorm"
+)
+
+type IOrganizationRepository interface {
+	FindById(ctx context.Context, organizationId string) (*entities.Organization, error)
+	FindAll(ctx context.Context, query dto.ListOrganizationsRequest) ([]*entities.Organization, *dto.Paging, error)
+	Create(ctx context.Context, organizationId, name string) (*entities.Organization, error)
+}
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) IOrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+func (r *organizationRepository) FindById(ctx context.Context, organizationId string) (*entities.Organization, error) {
+	var organization entities.Organization
+	res := r.db.WithContext(ctx).First(&organization, entities.Organization{ID: organizationId})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &organization, nil
+}
+
+var organizationSortColumns = map[string]string{
+	"id":   "id",
+	"name": "name",
+}
+
+func (r *organizationRepository) FindAll(ctx context.Context, query dto.ListOrganizationsRequest) ([]*entities.Organization, *dto.Paging, error) {
+	page, err := newPageQuery(query.Limit, query.Cursor, query.SortBy, query.Order, organizationSortColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db := r.db.WithContext(ctx).Model(&entities.Organization{})
+	if query.NamePrefix != "" {
+		db = db.Where(`organizations.name LIKE ? ESCAPE '\'`, escapeLike(query.NamePrefix)+"%")
+	}
+
+	var cursorID interface{}
+	if page.cursor != nil {
+		cursorID = page.cursor.ID
+	}
+
+	var organizations []*entities.Organization
+	res := page.apply(db, "organizations", cursorID).Find(&organizations)
+	if res.Error != nil {
+		return nil, nil, res.Error
+	}
+
+	count, paging := page.paging(len(organizations), func(i int) (string, string) {
+		if page.column == "name" {
+			return organizations[i].Name, organizations[i].ID
+		}
+		return organizations[i].ID, organizations[i].ID
+	})
+	return organizations[:count], paging, nil
+}
+
+func (r *organizationRepository) Create(ctx context.Context, organizationId, name string) (*entities.Organization, error) {
+	newOrganization := &entities.Organization{
+		ID:   organizationId,
+		Name: name,
+	}
+	res := r.db.WithContext(ctx).Create(newOrganization)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return newOrganization, nil
+}
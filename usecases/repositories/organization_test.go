@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+)
+
+type OrganizationRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	ctx  context.Context
+	repo IOrganizationRepository
+}
+
+func (suite *OrganizationRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.Organization{})
+	assert.NoError(suite.T(), err)
+	suite.db = gormDB
+	suite.ctx = context.Background()
+	suite.repo = NewOrganizationRepository(gormDB)
+}
+
+func (suite *OrganizationRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestOrganizationRepoSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationRepoSuite))
+}
+
+func (suite *OrganizationRepoSuite) TestCreateAndFindById() {
+	organization, err := suite.repo.Create(suite.ctx, "acme", "Acme Corp")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "acme", organization.ID)
+
+	found, err := suite.repo.FindById(suite.ctx, "acme")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Acme Corp", found.Name)
+}
+
+func (suite *OrganizationRepoSuite) TestCreateDuplicate() {
+	_, err := suite.repo.Create(suite.ctx, "acme", "Acme Corp")
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.repo.Create(suite.ctx, "acme", "Another Acme")
+	assert.ErrorIs(suite.T(), err, gorm.ErrDuplicatedKey)
+
+	_, err = suite.repo.Create(suite.ctx, "acme-eu", "Acme Corp")
+	assert.ErrorIs(suite.T(), err, gorm.ErrDuplicatedKey)
+}
+
+func (suite *OrganizationRepoSuite) TestFindByIdNotFound() {
+	_, err := suite.repo.FindById(suite.ctx, "acme")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *OrganizationRepoSuite) TestFindAll() {
+	for id, name := range map[string]string{"acme": "Acme Corp", "globex": "Globex", "initech": "Initech"} {
+		_, err := suite.repo.Create(suite.ctx, id, name)
+		assert.NoError(suite.T(), err)
+	}
+
+	organizations, paging, err := suite.repo.FindAll(suite.ctx, dto.ListOrganizationsRequest{Limit: 2})
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), paging.HasMore)
+	assert.Equal(suite.T(), []string{"acme", "globex"}, []string{organizations[0].ID, organizations[1].ID})
+
+	organizations, paging, err = suite.repo.FindAll(suite.ctx, dto.ListOrganizationsRequest{Limit: 2, Cursor: paging.NextCursor})
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), paging.HasMore)
+	assert.Len(suite.T(), organizations, 1)
+	assert.Equal(suite.T(), "initech", organizations[0].ID)
+
+	organizations, _, err = suite.repo.FindAll(suite.ctx, dto.ListOrganizationsRequest{NamePrefix: "G", SortBy: "name"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), organizations, 1)
+	assert.Equal(suite.T(), "globex", organizations[0].ID)
+}
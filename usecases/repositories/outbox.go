@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IOutboxRepository stores domain events until they are published. Services
+// add events through WithTransaction so that an event commits or rolls back
+// with its change. The relay takes the relay lock, then claims events oldest
+// first and marks them published in the same transaction, so a single
+// replica relays at a time; an event may still be published again if the
+// commit is lost, but it is never lost itself.
+type IOutboxRepository interface {
+	Create(ctx context.Context, event *entities.OutboxEvent) error
+	LockRelay(ctx context.Context) (bool, error)
+	ClaimUnpublished(ctx context.Context, limit int) ([]*entities.OutboxEvent, error)
+	MarkPublished(ctx context.Context, ids []uint, publishedAt time.Time) error
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+	BeginTransaction(ctx context.Context) (*gorm.DB, error)
+	WithTransaction(tx *gorm.DB) IOutboxRepository
+}
+
+// outboxRelayLockKey is the pg_advisory_xact_lock key shared by every
+// replica, so only one of them relays at a time.
+const outboxRelayLockKey int64 = 7460132
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) IOutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, event *entities.OutboxEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+// LockRelay takes the relay lock until the transaction it runs in ends and
+// reports whether it got it; it does not wait for another replica to finish.
+// Databases other than Postgres have no such lock and always get it.
+func (r *outboxRepository) LockRelay(ctx context.Context) (bool, error) {
+	db := r.db.WithContext(ctx)
+	if db.Dialector.Name() != "postgres" {
+		return true, nil
+	}
+	var locked bool
+	if err := db.Raw("SELECT pg_try_advisory_xact_lock(?)", outboxRelayLockKey).Scan(&locked).Error; err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+// ClaimUnpublished locks up to limit unpublished events, oldest first, until
+// the transaction it runs in ends.
+func (r *outboxRepository) ClaimUnpublished(ctx context.Context, limit int) ([]*entities.OutboxEvent, error) {
+	var events []*entities.OutboxEvent
+	res := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("published_at IS NULL").
+		Order("id ASC").
+		Limit(limit).
+		Find(&events)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return events, nil
+}
+
+func (r *outboxRepository) MarkPublished(ctx context.Context, ids []uint, publishedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&entities.OutboxEvent{}).Where("id IN ?", ids).Update("published_at", publishedAt).Error
+}
+
+// DeletePublished removes the events published before the given time and
+// returns how many it removed. Unpublished events are kept however old.
+func (r *outboxRepository) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Where("published_at < ?", before).Delete(&entities.OutboxEvent{})
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+func (r *outboxRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
+	tx := r.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return tx, nil
+}
+
+func (r *outboxRepository) WithTransaction(tx *gorm.DB) IOutboxRepository {
+	return &outboxRepository{db: tx}
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+)
+
+type OutboxRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	ctx  context.Context
+	repo IOutboxRepository
+}
+
+func (suite *OutboxRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.OutboxEvent{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), gormDB.Use(tenancy.Plugin{}))
+	suite.db = gormDB
+	suite.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
+	suite.repo = NewOutboxRepository(gormDB)
+}
+
+func (suite *OutboxRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestOutboxRepoSuite(t *testing.T) {
+	suite.Run(t, new(OutboxRepoSuite))
+}
+
+// postgresQuery returns the SQL the last query of run renders for
+// PostgreSQL, whose row locking SQLite does not support.
+func postgresQuery(t *testing.T, run func(db *gorm.DB)) string {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	assert.NoError(t, err)
+
+	var query string
+	err = db.Callback().Query().After("gorm:query").Register("test:capture", func(db *gorm.DB) {
+		query = db.Statement.SQL.String()
+	})
+	assert.NoError(t, err)
+	run(db)
+	return query
+}
+
+func (suite *OutboxRepoSuite) create(ctx context.Context, eventId string) *entities.OutboxEvent {
+	event := &entities.OutboxEvent{
+		EventID: eventId,
+		Type:    "user.created",
+		Payload: `{"id":"` + eventId + `"}`,
+	}
+	assert.NoError(suite.T(), suite.repo.Create(ctx, event))
+	return event
+}
+
+func (suite *OutboxRepoSuite) TestCreate() {
+	event := suite.create(suite.ctx, "event1")
+	assert.NotZero(suite.T(), event.ID)
+	assert.Equal(suite.T(), "acme", event.OrganizationID)
+	assert.Nil(suite.T(), event.PublishedAt)
+}
+
+func (suite *OutboxRepoSuite) TestCreateDuplicateEventId() {
+	suite.create(suite.ctx, "event1")
+
+	err := suite.repo.Create(suite.ctx, &entities.OutboxEvent{EventID: "event1", Type: "user.created", Payload: "{}"})
+	assert.ErrorIs(suite.T(), err, gorm.ErrDuplicatedKey)
+}
+
+func (suite *OutboxRepoSuite) TestClaimUnpublishedAcrossTenants() {
+	first := suite.create(suite.ctx, "event1")
+	globex := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "globex"})
+	second := suite.create(globex, "event2")
+	third := suite.create(suite.ctx, "event3")
+
+	events, err := suite.repo.ClaimUnpublished(context.Background(), 2)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), events, 2)
+	assert.Equal(suite.T(), first.ID, events[0].ID)
+	assert.Equal(suite.T(), second.ID, events[1].ID)
+
+	assert.NoError(suite.T(), suite.repo.MarkPublished(context.Background(), []uint{first.ID, second.ID}, time.Now()))
+
+	events, err = suite.repo.ClaimUnpublished(context.Background(), 2)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), events, 1)
+	assert.Equal(suite.T(), third.ID, events[0].ID)
+}
+
+func (suite *OutboxRepoSuite) TestClaimUnpublishedLocksEvents() {
+	query := postgresQuery(suite.T(), func(db *gorm.DB) {
+		NewOutboxRepository(db).ClaimUnpublished(context.Background(), 10)
+	})
+	assert.Contains(suite.T(), query, "FOR UPDATE")
+}
+
+func (suite *OutboxRepoSuite) TestLockRelay() {
+	locked, err := suite.repo.LockRelay(context.Background())
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), locked)
+}
+
+func (suite *OutboxRepoSuite) TestMarkPublishedNothing() {
+	assert.NoError(suite.T(), suite.repo.MarkPublished(context.Background(), nil, time.Now()))
+}
+
+func (suite *OutboxRepoSuite) TestDeletePublished() {
+	old := suite.create(suite.ctx, "event1")
+	recent := suite.create(suite.ctx, "event2")
+	suite.create(suite.ctx, "event3")
+
+	now := time.Now()
+	assert.NoError(suite.T(), suite.repo.MarkPublished(context.Background(), []uint{old.ID}, now.Add(-2*time.Hour)))
+	assert.NoError(suite.T(), suite.repo.MarkPublished(context.Background(), []uint{recent.ID}, now))
+
+	count, err := suite.repo.DeletePublished(context.Background(), now.Add(-time.Hour))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), count)
+
+	var remaining []*entities.OutboxEvent
+	assert.NoError(suite.T(), suite.db.Order("id ASC").Find(&remaining).Error)
+	assert.Len(suite.T(), remaining, 2)
+	assert.Equal(suite.T(), recent.ID, remaining[0].ID)
+}
+
+func (suite *OutboxRepoSuite) TestWithTransactionRollback() {
+	tx := suite.db.Begin()
+	assert.NoError(suite.T(), suite.repo.WithTransaction(tx).Create(suite.ctx, &entities.OutboxEvent{EventID: "event1", Type: "user.created", Payload: "{}"}))
+	tx.Rollback()
+
+	events, err := suite.repo.ClaimUnpublished(context.Background(), 10)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), events)
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+	ErrInvalidSort   = errors.New("unsupported sort field or order")
+)
+
+// pageCursor is the decoded form of dto.Paging.NextCursor. Sort pins the
+// cursor to the ordering it was issued for, so it cannot be replayed against
+// a different sort_by/order combination.
+type pageCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+type pageQuery struct {
+	limit   int
+	column  string
+	order   string
+	cursor  *pageCursor
+	sortKey string
+}
+
+func newPageQuery(limit int, rawCursor, sortBy, order string, columns map[string]string) (*pageQuery, error) {
+	if limit <= 0 {
+		limit = dto.DefaultPageSize
+	}
+	if limit > dto.MaxPageSize {
+		limit = dto.MaxPageSize
+	}
+	if sortBy == "" {
+		sortBy = "id"
+	}
+	if order == "" {
+		order = "asc"
+	}
+
+	column, ok := columns[sortBy]
+	if !ok || (order != "asc" && order != "desc") {
+		return nil, ErrInvalidSort
+	}
+
+	q := &pageQuery{
+		limit:   limit,
+		column:  column,
+		order:   order,
+		sortKey: sortBy + ":" + order,
+	}
+	if rawCursor == "" {
+		return q, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(rawCursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Sort != q.sortKey {
+		return nil, ErrInvalidCursor
+	}
+	q.cursor = &cursor
+	return q, nil
+}
+
+// apply adds the keyset condition, the ordering and a limit of one extra row,
+// which is used to detect whether another page exists.
+func (q *pageQuery) apply(db *gorm.DB, table string, cursorID interface{}) *gorm.DB {
+	column := table + "." + q.column
+	id := table + ".id"
+	op := ">"
+	if q.order == "desc" {
+		op = "<"
+	}
+
+	if q.cursor != nil {
+		if q.column == "id" {
+			db = db.Where(id+" "+op+" ?", cursorID)
+		} else {
+			db = db.Where("("+column+" "+op+" ?) OR ("+column+" = ? AND "+id+" "+op+" ?)", q.cursor.Value, q.cursor.Value, cursorID)
+		}
+	}
+	if q.column == "id" {
+		return db.Order(id + " " + q.order).Limit(q.limit + 1)
+	}
+	return db.Order(column + " " + q.order).Order(id + " " + q.order).Limit(q.limit + 1)
+}
+
+// paging trims the extra row fetched by apply and builds the envelope. last
+// returns the sort value and id of the final row on the page.
+func (q *pageQuery) paging(count int, last func(i int) (string, string)) (int, *dto.Paging) {
+	paging := &dto.Paging{Limit: q.limit}
+	if count <= q.limit {
+		return count, paging
+	}
+
+	value, id := last(q.limit - 1)
+	raw, _ := json.Marshal(pageCursor{Sort: q.sortKey, Value: value, ID: id})
+	paging.HasMore = true
+	paging.NextCursor = base64.RawURLEncoding.EncodeToString(raw)
+	return q.limit, paging
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+
+	"gorm.io/gorm"
+)
+
+type IRoleRepository interface {
+	FindByName(ctx context.Context, name string) (*entities.Role, error)
+	FindAll(ctx context.Context, query dto.ListRolesRequest) ([]*entities.Role, *dto.Paging, error)
+	FindUserIds(ctx context.Context, roleId uint) ([]string, error)
+	Create(ctx context.Context, name string, scopes []*entities.UserScope) (*entities.Role, error)
+	UpdateScope(ctx context.Context, role *entities.Role, scopes []*entities.UserScope) error
+	Delete(ctx context.Context, roleId uint) error
+	BeginTransaction(ctx context.Context) (*gorm.DB, error)
+	WithTransaction(tx *gorm.DB) IRoleRepository
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) IRoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*entities.Role, error) {
+	if err := tenancy.RequireOrganization(ctx); err != nil {
+		return nil, err
+	}
+	var role entities.Role
+	res := r.db.WithContext(ctx).Preload("Scopes").First(&role, entities.Role{Name: name})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &role, nil
+}
+
+var roleSortColumns = map[string]string{
+	"id":   "id",
+	"name": "name",
+}
+
+func (r *roleRepository) FindAll(ctx context.Context, query dto.ListRolesRequest) ([]*entities.Role, *dto.Paging, error) {
+	page, err := newPageQuery(query.Limit, query.Cursor, query.SortBy, query.Order, roleSortColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db := r.db.WithContext(ctx).Model(&entities.Role{})
+	if query.NamePrefix != "" {
+		db = db.Where(`roles.name LIKE ? ESCAPE '\'`, escapeLike(query.NamePrefix)+"%")
+	}
+
+	var cursorID interface{}
+	if page.cursor != nil {
+		id, err := strconv.ParseUint(page.cursor.ID, 10, 64)
+		if err != nil {
+			return nil, nil, ErrInvalidCursor
+		}
+		cursorID = id
+	}
+
+	var roles []*entities.Role
+	res := page.apply(db, "roles", cursorID).Preload("Scopes").Find(&roles)
+	if res.Error != nil {
+		return nil, nil, res.Error
+	}
+
+	count, paging := page.paging(len(roles), func(i int) (string, string) {
+		id := strconv.FormatUint(uint64(roles[i].ID), 10)
+		if page.column == "name" {
+			return roles[i].Name, id
+		}
+		return id, id
+	})
+	return roles[:count], paging, nil
+}
+
+// FindUserIds returns the ids of every user holding the role.
+func (r *roleRepository) FindUserIds(ctx context.Context, roleId uint) ([]string, error) {
+	var userIds []string
+	res := r.db.WithContext(ctx).Table("user_role_mapping").
+		Where("role_id = ?", roleId).
+		Order("user_id").
+		Pluck("user_id", &userIds)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return userIds, nil
+}
+
+func (r *roleRepository) Create(ctx context.Context, name string, scopes []*entities.UserScope) (*entities.Role, error) {
+	newRole := &entities.Role{
+		Name:   name,
+		Scopes: scopes,
+	}
+	res := r.db.WithContext(ctx).Create(newRole)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return newRole, nil
+}
+
+func (r *roleRepository) UpdateScope(ctx context.Context, role *entities.Role, scopes []*entities.UserScope) error {
+	err := r.db.WithContext(ctx).Model(role).Association("Scopes").Replace(scopes)
+	return err
+}
+
+// Delete removes the role together with its scope and user mappings.
+func (r *roleRepository) Delete(ctx context.Context, roleId uint) error {
+	role := &entities.Role{ID: roleId}
+	res := r.db.WithContext(ctx).Select("Scopes").Delete(role)
+	if res.Error != nil {
+		return res.Error
+	}
+	res = r.db.WithContext(ctx).Exec("DELETE FROM user_role_mapping WHERE role_id = ?", roleId)
+	return res.Error
+}
+
+func (r *roleRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
+	tx := r.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return tx, nil
+}
+
+func (r *roleRepository) WithTransaction(tx *gorm.DB) IRoleRepository {
+	return &roleRepository{db: tx}
+}
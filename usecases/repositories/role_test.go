@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+)
+
+type RoleRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	ctx  context.Context
+	repo IRoleRepository
+}
+
+func (suite *RoleRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.User{}, &entities.Role{}, &entities.Group{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), gormDB.Use(tenancy.Plugin{}))
+	suite.db = gormDB
+	suite.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
+	suite.repo = NewRoleRepository(gormDB)
+}
+
+func (suite *RoleRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestRoleRepoSuite(t *testing.T) {
+	suite.Run(t, new(RoleRepoSuite))
+}
+
+func (suite *RoleRepoSuite) TestCreateAndFindByName() {
+	role, err := suite.repo.Create(suite.ctx, "operator", []*entities.UserScope{
+		{Name: "container:view"},
+		{Name: "container:update"},
+	})
+	assert.NoError(suite.T(), err)
+	assert.NotZero(suite.T(), role.ID)
+
+	found, err := suite.repo.FindByName(suite.ctx, "operator")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), role.ID, found.ID)
+	assert.Len(suite.T(), found.Scopes, 2)
+}
+
+func (suite *RoleRepoSuite) TestCreateDuplicateName() {
+	_, err := suite.repo.Create(suite.ctx, "operator", nil)
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.repo.Create(suite.ctx, "operator", nil)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RoleRepoSuite) TestFindByNameNotFound() {
+	_, err := suite.repo.FindByName(suite.ctx, "operator")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *RoleRepoSuite) TestFindAll() {
+	for _, name := range []string{"operator", "auditor", "on-call"} {
+		_, err := suite.repo.Create(suite.ctx, name, []*entities.UserScope{{Name: name + ":scope"}})
+		assert.NoError(suite.T(), err)
+	}
+
+	roles, paging, err := suite.repo.FindAll(suite.ctx, dto.ListRolesRequest{SortBy: "name"})
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), paging.HasMore)
+	assert.Len(suite.T(), roles, 3)
+	assert.Equal(suite.T(), "auditor", roles[0].Name)
+	assert.Len(suite.T(), roles[0].Scopes, 1)
+
+	roles, paging, err = suite.repo.FindAll(suite.ctx, dto.ListRolesRequest{Limit: 1, NamePrefix: "o", SortBy: "name"})
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), paging.HasMore)
+	assert.Equal(suite.T(), "on-call", roles[0].Name)
+
+	roles, _, err = suite.repo.FindAll(suite.ctx, dto.ListRolesRequest{Limit: 1, NamePrefix: "o", SortBy: "name", Cursor: paging.NextCursor})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "operator", roles[0].Name)
+}
+
+func (suite *RoleRepoSuite) TestUpdateScope() {
+	role, err := suite.repo.Create(suite.ctx, "operator", []*entities.UserScope{{Name: "container:view"}})
+	assert.NoError(suite.T(), err)
+
+	err = suite.repo.UpdateScope(suite.ctx, role, []*entities.UserScope{{Name: "container:update"}})
+	assert.NoError(suite.T(), err)
+
+	found, err := suite.repo.FindByName(suite.ctx, "operator")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), found.Scopes, 1)
+	assert.Equal(suite.T(), "container:update", found.Scopes[0].Name)
+}
+
+func (suite *RoleRepoSuite) TestFindUserIdsAndDelete() {
+	role, err := suite.repo.Create(suite.ctx, "operator", []*entities.UserScope{{Name: "container:view"}})
+	assert.NoError(suite.T(), err)
+	for _, id := range []string{"user-2", "user-1"} {
+		user := &entities.User{ID: id, Username: id, Hash: "hash", Email: id + "@example.com", Roles: []*entities.Role{role}}
+		assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create(user).Error)
+	}
+
+	userIds, err := suite.repo.FindUserIds(suite.ctx, role.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"user-1", "user-2"}, userIds)
+
+	err = suite.repo.Delete(suite.ctx, role.ID)
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.repo.FindByName(suite.ctx, "operator")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	userIds, err = suite.repo.FindUserIds(suite.ctx, role.ID)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), userIds)
+}
+
+func (suite *RoleRepoSuite) TestBeginTransactionError() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+
+	_, err := suite.repo.BeginTransaction(suite.ctx)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RoleRepoSuite) TestWithTransaction() {
+	tx, err := suite.repo.BeginTransaction(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.repo.WithTransaction(tx).Create(suite.ctx, "operator", nil)
+	assert.NoError(suite.T(), err)
+	tx.Rollback()
+
+	_, err = suite.repo.FindByName(suite.ctx, "operator")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
@@ -2,18 +2,24 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"strconv"
 
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
 	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
 
 	"gorm.io/gorm"
 )
 
 type IScopeRepository interface {
-	FindById(scopeId uint) (*entities.UserScope, error)
-	FindByName(name string) (*entities.UserScope, error)
-	FindAll() ([]*entities.UserScope, error)
-	Create(name string) (*entities.UserScope, error)
-	Delete(name string) error
+	FindById(ctx context.Context, scopeId uint) (*entities.UserScope, error)
+	FindByName(ctx context.Context, name string) (*entities.UserScope, error)
+	FindAll(ctx context.Context, query dto.ListScopesRequest) ([]*entities.UserScope, *dto.Paging, error)
+	ListNames(ctx context.Context) ([]string, error)
+	FindHolderIds(ctx context.Context, scopeId uint) ([]string, error)
+	Create(ctx context.Context, name string) (*entities.UserScope, error)
+	Delete(ctx context.Context, name string) error
 	BeginTransaction(ctx context.Context) (*gorm.DB, error)
 	WithTransaction(tx *gorm.DB) IScopeRepository
 }
@@ -26,51 +32,122 @@ func NewScopeRepository(db *gorm.DB) IScopeRepository {
 	return &scopeRepository{db: db}
 }
 
-func (r *scopeRepository) FindById(scopeId uint) (*entities.UserScope, error) {
+func (r *scopeRepository) FindById(ctx context.Context, scopeId uint) (*entities.UserScope, error) {
 	var scope entities.UserScope
-	res := r.db.First(&scope, entities.UserScope{ID: scopeId})
+	res := r.db.WithContext(ctx).First(&scope, entities.UserScope{ID: scopeId})
 	if res.Error != nil {
 		return nil, res.Error
 	}
 	return &scope, nil
 }
 
-func (r *scopeRepository) FindByName(name string) (*entities.UserScope, error) {
+func (r *scopeRepository) FindByName(ctx context.Context, name string) (*entities.UserScope, error) {
+	if err := tenancy.RequireOrganization(ctx); err != nil {
+		return nil, err
+	}
 	var scope entities.UserScope
-	res := r.db.First(&scope, entities.UserScope{Name: name})
+	res := r.db.WithContext(ctx).First(&scope, entities.UserScope{Name: name})
 	if res.Error != nil {
 		return nil, res.Error
 	}
 	return &scope, nil
 }
 
-func (r *scopeRepository) FindAll() ([]*entities.UserScope, error) {
+var scopeSortColumns = map[string]string{
+	"id":   "id",
+	"name": "name",
+}
+
+func (r *scopeRepository) FindAll(ctx context.Context, query dto.ListScopesRequest) ([]*entities.UserScope, *dto.Paging, error) {
+	page, err := newPageQuery(query.Limit, query.Cursor, query.SortBy, query.Order, scopeSortColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db := r.db.WithContext(ctx).Model(&entities.UserScope{})
+	if query.NamePrefix != "" {
+		db = db.Where(`user_scopes.name LIKE ? ESCAPE '\'`, escapeLike(query.NamePrefix)+"%")
+	}
+
+	var cursorID interface{}
+	if page.cursor != nil {
+		id, err := strconv.ParseUint(page.cursor.ID, 10, 64)
+		if err != nil {
+			return nil, nil, ErrInvalidCursor
+		}
+		cursorID = id
+	}
+
 	var scopes []*entities.UserScope
-	res := r.db.Find(&scopes)
+	res := page.apply(db, "user_scopes", cursorID).Find(&scopes)
+	if res.Error != nil {
+		return nil, nil, res.Error
+	}
+
+	count, paging := page.paging(len(scopes), func(i int) (string, string) {
+		id := strconv.FormatUint(uint64(scopes[i].ID), 10)
+		if page.column == "name" {
+			return scopes[i].Name, id
+		}
+		return id, id
+	})
+	return scopes[:count], paging, nil
+}
+
+func (r *scopeRepository) ListNames(ctx context.Context) ([]string, error) {
+	var names []string
+	res := r.db.WithContext(ctx).Model(&entities.UserScope{}).Order("name ASC").Pluck("name", &names)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return names, nil
+}
+
+// scopeHolders selects the users granted the scope directly, through a role,
+// or through a group holding it or nested below one that does.
+const scopeHolders = `WITH RECURSIVE granting(id) AS (
+	SELECT group_id FROM group_scope_mapping WHERE user_scope_id = @scope
+	UNION
+	SELECT user_groups.id FROM user_groups JOIN granting ON user_groups.parent_id = granting.id
+)
+SELECT user_id FROM user_scope_mapping WHERE user_scope_id = @scope
+UNION
+SELECT user_role_mapping.user_id FROM user_role_mapping
+	JOIN role_scope_mapping ON role_scope_mapping.role_id = user_role_mapping.role_id
+	WHERE role_scope_mapping.user_scope_id = @scope
+UNION
+SELECT user_id FROM user_group_mapping WHERE group_id IN (SELECT id FROM granting)
+ORDER BY user_id`
+
+// FindHolderIds returns the distinct ids of the users granted the scope in
+// any way.
+func (r *scopeRepository) FindHolderIds(ctx context.Context, scopeId uint) ([]string, error) {
+	var userIds []string
+	res := r.db.WithContext(ctx).Raw(scopeHolders, sql.Named("scope", scopeId)).Scan(&userIds)
 	if res.Error != nil {
 		return nil, res.Error
 	}
-	return scopes, nil
+	return userIds, nil
 }
 
-func (r *scopeRepository) Create(name string) (*entities.UserScope, error) {
+func (r *scopeRepository) Create(ctx context.Context, name string) (*entities.UserScope, error) {
 	newScope := &entities.UserScope{
 		Name: name,
 	}
-	res := r.db.Create(newScope)
+	res := r.db.WithContext(ctx).Create(newScope)
 	if res.Error != nil {
 		return nil, res.Error
 	}
 	return newScope, nil
 }
 
-func (r *scopeRepository) Delete(name string) error {
-	res := r.db.Where("name = ?", name).Delete(&entities.UserScope{})
+func (r *scopeRepository) Delete(ctx context.Context, name string) error {
+	res := r.db.WithContext(ctx).Where("name = ?", name).Delete(&entities.UserScope{})
 	return res.Error
 }
 
 func (r *scopeRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
-	tx := r.db.Begin()
+	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
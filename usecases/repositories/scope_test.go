@@ -10,12 +10,15 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
 	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
 )
 
 type ScopeRepoSuite struct {
 	suite.Suite
 	db   *gorm.DB
+	ctx  context.Context
 	repo IScopeRepository
 }
 
@@ -24,9 +27,11 @@ func (suite *ScopeRepoSuite) SetupTest() {
 		Logger: logger.Default.LogMode(logger.Silent),
 	})
 	assert.NoError(suite.T(), err)
-	err = gormDB.AutoMigrate(&entities.User{})
+	err = gormDB.AutoMigrate(&entities.User{}, &entities.Role{}, &entities.Group{})
 	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), gormDB.Use(tenancy.Plugin{}))
 	suite.db = gormDB
+	suite.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
 	suite.repo = NewScopeRepository(gormDB)
 }
 
@@ -41,46 +46,96 @@ func TestScopeRepoSuite(t *testing.T) {
 }
 
 func (suite *ScopeRepoSuite) TestCreateAndFindById() {
-	scope, err := suite.repo.Create("test")
+	scope, err := suite.repo.Create(suite.ctx, "test")
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), scope)
 
-	found, err := suite.repo.FindById(scope.ID)
+	found, err := suite.repo.FindById(suite.ctx, scope.ID)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "test", found.Name)
 
-	found, err = suite.repo.FindByName(scope.Name)
+	found, err = suite.repo.FindByName(suite.ctx, scope.Name)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), uint(1), found.ID)
 }
 
 func (suite *ScopeRepoSuite) TestCreateDuplicateName() {
-	_, err := suite.repo.Create("test")
+	_, err := suite.repo.Create(suite.ctx, "test")
 	assert.NoError(suite.T(), err)
 
-	_, err = suite.repo.Create("test")
+	_, err = suite.repo.Create(suite.ctx, "test")
 	assert.Error(suite.T(), err)
 }
 
 func (suite *ScopeRepoSuite) TestFindNotFound() {
-	_, err := suite.repo.FindById(1)
+	_, err := suite.repo.FindById(suite.ctx, 1)
 	assert.Error(suite.T(), err)
 
-	_, err = suite.repo.FindByName("test")
+	_, err = suite.repo.FindByName(suite.ctx, "test")
 	assert.Error(suite.T(), err)
 }
 
+func (suite *ScopeRepoSuite) TestFindByNameRequiresOrganization() {
+	_, err := suite.repo.Create(suite.ctx, "user:manage")
+	assert.NoError(suite.T(), err)
+	other := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "globex"})
+	_, err = suite.repo.Create(other, "user:manage")
+	assert.NoError(suite.T(), err)
+
+	// The name alone does not tell the organizations' scopes apart.
+	crossTenant := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "platform", Super: true, CrossTenant: true})
+	_, err = suite.repo.FindByName(crossTenant, "user:manage")
+	assert.ErrorIs(suite.T(), err, tenancy.ErrOrganizationRequired)
+
+	selected := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "globex", Super: true})
+	found, err := suite.repo.FindByName(selected, "user:manage")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "globex", found.OrganizationID)
+}
+
 func (suite *ScopeRepoSuite) TestDelete() {
-	scope, _ := suite.repo.Create("test")
-	err := suite.repo.Delete(scope.Name)
+	scope, _ := suite.repo.Create(suite.ctx, "test")
+	err := suite.repo.Delete(suite.ctx, scope.Name)
 	assert.NoError(suite.T(), err)
 
-	_, err = suite.repo.FindById(scope.ID)
+	_, err = suite.repo.FindById(suite.ctx, scope.ID)
 	assert.Error(suite.T(), err)
 }
 
+func (suite *ScopeRepoSuite) TestFindHolderIds() {
+	scope, err := suite.repo.Create(suite.ctx, "container:update")
+	assert.NoError(suite.T(), err)
+	other, err := suite.repo.Create(suite.ctx, "container:view")
+	assert.NoError(suite.T(), err)
+
+	operator := &entities.Role{Name: "operator", Scopes: []*entities.UserScope{scope}}
+	viewer := &entities.Role{Name: "viewer", Scopes: []*entities.UserScope{other}}
+	engineering := &entities.Group{Name: "engineering", Scopes: []*entities.UserScope{scope}}
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create(operator).Error)
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create(viewer).Error)
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create(engineering).Error)
+	backend := &entities.Group{Name: "backend", ParentID: &engineering.ID}
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Omit("Parent").Create(backend).Error)
+
+	users := []*entities.User{
+		{ID: "direct", Scopes: []*entities.UserScope{scope}},
+		{ID: "role", Roles: []*entities.Role{operator}},
+		{ID: "nested", Groups: []*entities.Group{backend}},
+		{ID: "twice", Scopes: []*entities.UserScope{scope}, Groups: []*entities.Group{engineering}},
+		{ID: "none", Scopes: []*entities.UserScope{other}, Roles: []*entities.Role{viewer}},
+	}
+	for _, user := range users {
+		user.Username, user.Hash, user.Email = user.ID, "hash", user.ID+"@example.com"
+		assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Omit("Scopes.*", "Roles.*", "Groups.*").Create(user).Error)
+	}
+
+	userIds, err := suite.repo.FindHolderIds(suite.ctx, scope.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"direct", "nested", "role", "twice"}, userIds)
+}
+
 func (suite *ScopeRepoSuite) TestDeleteNonExistent() {
-	err := suite.repo.Delete("not-exist")
+	err := suite.repo.Delete(suite.ctx, "not-exist")
 	assert.NoError(suite.T(), err)
 }
 
@@ -88,7 +143,7 @@ func (suite *ScopeRepoSuite) TestBeginTransactionError() {
 	sqlDB, _ := suite.db.DB()
 	sqlDB.Close()
 
-	_, err := suite.repo.BeginTransaction(context.Background())
+	_, err := suite.repo.BeginTransaction(suite.ctx)
 	assert.Error(suite.T(), err)
 }
 
@@ -97,23 +152,24 @@ func (suite *ScopeRepoSuite) TestBeginAndWithTransaction_Rollback() {
 	assert.NoError(suite.T(), err)
 
 	txRepo := suite.repo.WithTransaction(tx)
-	_, err = txRepo.Create("test")
+	_, err = txRepo.Create(suite.ctx, "test")
 	assert.NoError(suite.T(), err)
 
 	tx.Rollback()
 }
 
 func (suite *ScopeRepoSuite) TestFindAll() {
-	scope1, err := suite.repo.Create("read")
+	scope1, err := suite.repo.Create(suite.ctx, "read")
 	assert.NoError(suite.T(), err)
-	scope2, err := suite.repo.Create("write")
+	scope2, err := suite.repo.Create(suite.ctx, "write")
 	assert.NoError(suite.T(), err)
-	scope3, err := suite.repo.Create("admin")
+	scope3, err := suite.repo.Create(suite.ctx, "admin")
 	assert.NoError(suite.T(), err)
 
-	scopes, err := suite.repo.FindAll()
+	scopes, paging, err := suite.repo.FindAll(suite.ctx, dto.ListScopesRequest{})
 	assert.NoError(suite.T(), err)
 	assert.Len(suite.T(), scopes, 3)
+	assert.False(suite.T(), paging.HasMore)
 
 	scopeNames := make([]string, len(scopes))
 	for i, scope := range scopes {
@@ -128,7 +184,63 @@ func (suite *ScopeRepoSuite) TestFindAllDatabaseError() {
 	sqlDB, _ := suite.db.DB()
 	sqlDB.Close()
 
-	users, err := suite.repo.FindAll()
+	users, _, err := suite.repo.FindAll(suite.ctx, dto.ListScopesRequest{})
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), users)
 }
+
+func (suite *ScopeRepoSuite) TestFindAllPaginates() {
+	for _, name := range []string{"container:view", "container:create", "user:manage"} {
+		_, err := suite.repo.Create(suite.ctx, name)
+		assert.NoError(suite.T(), err)
+	}
+
+	scopes, paging, err := suite.repo.FindAll(suite.ctx, dto.ListScopesRequest{Limit: 2})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), scopes, 2)
+	assert.True(suite.T(), paging.HasMore)
+	assert.Equal(suite.T(), "container:view", scopes[0].Name)
+
+	scopes, paging, err = suite.repo.FindAll(suite.ctx, dto.ListScopesRequest{Limit: 2, Cursor: paging.NextCursor})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), scopes, 1)
+	assert.False(suite.T(), paging.HasMore)
+	assert.Equal(suite.T(), "user:manage", scopes[0].Name)
+}
+
+func (suite *ScopeRepoSuite) TestFindAllNamePrefix() {
+	for _, name := range []string{"container:view", "container:create", "user:manage"} {
+		_, err := suite.repo.Create(suite.ctx, name)
+		assert.NoError(suite.T(), err)
+	}
+
+	scopes, _, err := suite.repo.FindAll(suite.ctx, dto.ListScopesRequest{NamePrefix: "container:", SortBy: "name"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), scopes, 2)
+	assert.Equal(suite.T(), "container:create", scopes[0].Name)
+	assert.Equal(suite.T(), "container:view", scopes[1].Name)
+}
+
+func (suite *ScopeRepoSuite) TestFindAllInvalidCursor() {
+	_, _, err := suite.repo.FindAll(suite.ctx, dto.ListScopesRequest{Cursor: "%%%"})
+	assert.ErrorIs(suite.T(), err, ErrInvalidCursor)
+}
+
+func (suite *ScopeRepoSuite) TestListNames() {
+	for _, name := range []string{"container:view", "container:*", "user:view"} {
+		_, err := suite.repo.Create(suite.ctx, name)
+		assert.NoError(suite.T(), err)
+	}
+
+	names, err := suite.repo.ListNames(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"container:*", "container:view", "user:view"}, names)
+}
+
+func (suite *ScopeRepoSuite) TestListNamesError() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+
+	_, err := suite.repo.ListNames(suite.ctx)
+	assert.Error(suite.T(), err)
+}
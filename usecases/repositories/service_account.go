@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+
+	"gorm.io/gorm"
+)
+
+type IServiceAccountRepository interface {
+	Create(ctx context.Context, account *entities.ServiceAccount) error
+	FindById(ctx context.Context, accountId string) (*entities.ServiceAccount, error)
+	FindAll(ctx context.Context, query dto.ListServiceAccountsRequest) ([]*entities.ServiceAccount, *dto.Paging, error)
+	Update(ctx context.Context, account *entities.ServiceAccount) error
+	UpdateScope(ctx context.Context, account *entities.ServiceAccount, scopes []*entities.UserScope) error
+	AddSecret(ctx context.Context, secret *entities.ServiceAccountSecret) error
+	DeleteSecret(ctx context.Context, accountId string, secretId uint) error
+	Delete(ctx context.Context, accountId string) error
+}
+
+type serviceAccountRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceAccountRepository(db *gorm.DB) IServiceAccountRepository {
+	return &serviceAccountRepository{db: db}
+}
+
+// Create stores the account with its scopes and initial secrets, assigning
+// it a new id.
+func (r *serviceAccountRepository) Create(ctx context.Context, account *entities.ServiceAccount) error {
+	account.ID = uuid.New().String()
+	return r.db.WithContext(ctx).Create(account).Error
+}
+
+func (r *serviceAccountRepository) FindById(ctx context.Context, accountId string) (*entities.ServiceAccount, error) {
+	var account entities.ServiceAccount
+	res := r.db.WithContext(ctx).Preload("Scopes").Preload("Secrets", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).First(&account, entities.ServiceAccount{ID: accountId})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &account, nil
+}
+
+var serviceAccountSortColumns = map[string]string{
+	"id":   "id",
+	"name": "name",
+}
+
+func (r *serviceAccountRepository) FindAll(ctx context.Context, query dto.ListServiceAccountsRequest) ([]*entities.ServiceAccount, *dto.Paging, error) {
+	page, err := newPageQuery(query.Limit, query.Cursor, query.SortBy, query.Order, serviceAccountSortColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db := r.db.WithContext(ctx).Model(&entities.ServiceAccount{})
+	if query.OwnerID != "" {
+		db = db.Where("service_accounts.owner_id = ?", query.OwnerID)
+	}
+
+	var cursorID interface{}
+	if page.cursor != nil {
+		cursorID = page.cursor.ID
+	}
+
+	var accounts []*entities.ServiceAccount
+	res := page.apply(db, "service_accounts", cursorID).Preload("Scopes").Preload("Secrets", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Find(&accounts)
+	if res.Error != nil {
+		return nil, nil, res.Error
+	}
+
+	count, paging := page.paging(len(accounts), func(i int) (string, string) {
+		if page.column == "name" {
+			return accounts[i].Name, accounts[i].ID
+		}
+		return accounts[i].ID, accounts[i].ID
+	})
+	return accounts[:count], paging, nil
+}
+
+func (r *serviceAccountRepository) Update(ctx context.Context, account *entities.ServiceAccount) error {
+	res := r.db.WithContext(ctx).Model(account).Select("Name", "OwnerID", "ExpiresAt").Updates(account)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *serviceAccountRepository) UpdateScope(ctx context.Context, account *entities.ServiceAccount, scopes []*entities.UserScope) error {
+	return r.db.WithContext(ctx).Model(account).Association("Scopes").Replace(scopes)
+}
+
+// AddSecret stores a secret for an account the caller has already found, as
+// secrets carry no organization of their own.
+func (r *serviceAccountRepository) AddSecret(ctx context.Context, secret *entities.ServiceAccountSecret) error {
+	return r.db.WithContext(ctx).Create(secret).Error
+}
+
+func (r *serviceAccountRepository) DeleteSecret(ctx context.Context, accountId string, secretId uint) error {
+	res := r.db.WithContext(ctx).Where("service_account_id = ?", accountId).Delete(&entities.ServiceAccountSecret{}, secretId)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Delete removes the account together with its scope mappings and secrets.
+// The account goes first, so nothing is touched when it is outside the
+// caller's organization.
+func (r *serviceAccountRepository) Delete(ctx context.Context, accountId string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&entities.ServiceAccount{ID: accountId})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Exec("DELETE FROM service_account_scope_mapping WHERE service_account_id = ?", accountId).Error; err != nil {
+			return err
+		}
+		return tx.Where("service_account_id = ?", accountId).Delete(&entities.ServiceAccountSecret{}).Error
+	})
+}
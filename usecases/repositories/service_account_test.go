@@ -0,0 +1,214 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+)
+
+type ServiceAccountRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	ctx  context.Context
+	repo IServiceAccountRepository
+}
+
+func (suite *ServiceAccountRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.User{}, &entities.ServiceAccount{}, &entities.ServiceAccountSecret{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), gormDB.Use(tenancy.Plugin{}))
+	suite.db = gormDB
+	suite.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
+	suite.repo = NewServiceAccountRepository(gormDB)
+}
+
+func (suite *ServiceAccountRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestServiceAccountRepoSuite(t *testing.T) {
+	suite.Run(t, new(ServiceAccountRepoSuite))
+}
+
+func (suite *ServiceAccountRepoSuite) create(ctx context.Context, name string) *entities.ServiceAccount {
+	account := &entities.ServiceAccount{
+		Name:      name,
+		OwnerID:   "user1",
+		Scopes:    []*entities.UserScope{{Name: "container:view"}},
+		Secrets:   []*entities.ServiceAccountSecret{{Hash: "hash1", Hint: "aaaa", CreatedBy: "admin"}},
+		CreatedBy: "admin",
+	}
+	assert.NoError(suite.T(), suite.repo.Create(ctx, account))
+	return account
+}
+
+func (suite *ServiceAccountRepoSuite) TestCreateAndFindById() {
+	account := suite.create(suite.ctx, "ci")
+	assert.NotEmpty(suite.T(), account.ID)
+	assert.Equal(suite.T(), "acme", account.OrganizationID)
+
+	found, err := suite.repo.FindById(suite.ctx, account.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "ci", found.Name)
+	assert.Equal(suite.T(), []string{"container:view"}, found.ScopeNames())
+	assert.Len(suite.T(), found.Secrets, 1)
+	assert.Equal(suite.T(), "hash1", found.Secrets[0].Hash)
+}
+
+func (suite *ServiceAccountRepoSuite) TestCreateDuplicateName() {
+	suite.create(suite.ctx, "ci")
+
+	err := suite.repo.Create(suite.ctx, &entities.ServiceAccount{Name: "ci", OwnerID: "user1", CreatedBy: "admin"})
+	assert.ErrorIs(suite.T(), err, gorm.ErrDuplicatedKey)
+
+	other := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "globex"})
+	err = suite.repo.Create(other, &entities.ServiceAccount{Name: "ci", OwnerID: "user2", CreatedBy: "admin"})
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *ServiceAccountRepoSuite) TestFindByIdOtherTenant() {
+	account := suite.create(suite.ctx, "ci")
+
+	other := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "globex"})
+	_, err := suite.repo.FindById(other, account.ID)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *ServiceAccountRepoSuite) TestFindAll() {
+	suite.create(suite.ctx, "deploy")
+	suite.create(suite.ctx, "ci")
+	other := &entities.ServiceAccount{Name: "backup", OwnerID: "user2", CreatedBy: "admin"}
+	assert.NoError(suite.T(), suite.repo.Create(suite.ctx, other))
+
+	accounts, paging, err := suite.repo.FindAll(suite.ctx, dto.ListServiceAccountsRequest{
+		Limit:   1,
+		OwnerID: "user1",
+		SortBy:  "name",
+		Order:   "asc",
+	})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), accounts, 1)
+	assert.Equal(suite.T(), "ci", accounts[0].Name)
+	assert.Len(suite.T(), accounts[0].Secrets, 1)
+	assert.True(suite.T(), paging.HasMore)
+
+	accounts, paging, err = suite.repo.FindAll(suite.ctx, dto.ListServiceAccountsRequest{
+		Cursor:  paging.NextCursor,
+		Limit:   1,
+		OwnerID: "user1",
+		SortBy:  "name",
+		Order:   "asc",
+	})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), accounts, 1)
+	assert.Equal(suite.T(), "deploy", accounts[0].Name)
+	assert.False(suite.T(), paging.HasMore)
+}
+
+func (suite *ServiceAccountRepoSuite) TestUpdate() {
+	account := suite.create(suite.ctx, "ci")
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	account.Name = "pipeline"
+	account.ExpiresAt = &expiresAt
+
+	assert.NoError(suite.T(), suite.repo.Update(suite.ctx, account))
+
+	found, err := suite.repo.FindById(suite.ctx, account.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "pipeline", found.Name)
+	assert.True(suite.T(), expiresAt.Equal(*found.ExpiresAt))
+
+	account.ExpiresAt = nil
+	assert.NoError(suite.T(), suite.repo.Update(suite.ctx, account))
+	found, err = suite.repo.FindById(suite.ctx, account.ID)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), found.ExpiresAt)
+}
+
+func (suite *ServiceAccountRepoSuite) TestUpdateOtherTenant() {
+	account := suite.create(suite.ctx, "ci")
+	account.Name = "pipeline"
+
+	other := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "globex"})
+	err := suite.repo.Update(other, account)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *ServiceAccountRepoSuite) TestUpdateScope() {
+	account := suite.create(suite.ctx, "ci")
+
+	err := suite.repo.UpdateScope(suite.ctx, account, []*entities.UserScope{{Name: "container:update"}})
+	assert.NoError(suite.T(), err)
+
+	found, err := suite.repo.FindById(suite.ctx, account.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"container:update"}, found.ScopeNames())
+}
+
+func (suite *ServiceAccountRepoSuite) TestAddAndDeleteSecret() {
+	account := suite.create(suite.ctx, "ci")
+
+	secret := &entities.ServiceAccountSecret{ServiceAccountID: account.ID, Hash: "hash2", Hint: "bbbb", CreatedBy: "admin"}
+	assert.NoError(suite.T(), suite.repo.AddSecret(suite.ctx, secret))
+
+	found, err := suite.repo.FindById(suite.ctx, account.ID)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), found.Secrets, 2)
+	assert.Equal(suite.T(), "hash2", found.Secrets[1].Hash)
+
+	assert.NoError(suite.T(), suite.repo.DeleteSecret(suite.ctx, account.ID, found.Secrets[0].ID))
+	err = suite.repo.DeleteSecret(suite.ctx, account.ID, found.Secrets[0].ID)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+
+	err = suite.repo.DeleteSecret(suite.ctx, "other", secret.ID)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+
+	found, err = suite.repo.FindById(suite.ctx, account.ID)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), found.Secrets, 1)
+	assert.Equal(suite.T(), "hash2", found.Secrets[0].Hash)
+}
+
+func (suite *ServiceAccountRepoSuite) TestDelete() {
+	account := suite.create(suite.ctx, "ci")
+
+	assert.NoError(suite.T(), suite.repo.Delete(suite.ctx, account.ID))
+
+	_, err := suite.repo.FindById(suite.ctx, account.ID)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+
+	var secrets, mappings int64
+	suite.db.Model(&entities.ServiceAccountSecret{}).Where("service_account_id = ?", account.ID).Count(&secrets)
+	suite.db.Table("service_account_scope_mapping").Where("service_account_id = ?", account.ID).Count(&mappings)
+	assert.Zero(suite.T(), secrets)
+	assert.Zero(suite.T(), mappings)
+}
+
+func (suite *ServiceAccountRepoSuite) TestDeleteOtherTenant() {
+	account := suite.create(suite.ctx, "ci")
+
+	other := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "globex"})
+	err := suite.repo.Delete(other, account.ID)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+
+	found, err := suite.repo.FindById(suite.ctx, account.ID)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), found.Secrets, 1)
+}
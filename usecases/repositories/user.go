@@ -2,19 +2,31 @@ package repositories
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
 	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/scopes"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IUserRepository interface {
-	FindById(userId string) (*entities.User, error)
-	FindAll() ([]*entities.User, error)
-	Create(username, hash, email string, scopes []*entities.UserScope) (*entities.User, error)
-	UpdateScope(user *entities.User, scopes []*entities.UserScope) error
-	Delete(userId string) error
+	FindById(ctx context.Context, userId string) (*entities.User, error)
+	FindByIds(ctx context.Context, userIds []string) ([]*entities.User, error)
+	FindAll(ctx context.Context, query dto.ListUsersRequest) ([]*entities.User, *dto.Paging, error)
+	Create(ctx context.Context, username, hash, email string, scopes []*entities.UserScope, expiries map[string]time.Time, roles []*entities.Role) (*entities.User, error)
+	UpdateScope(ctx context.Context, user *entities.User, scopes []*entities.UserScope, expiries map[string]time.Time) error
+	FindExpiredScopeHolders(ctx context.Context, now time.Time) ([]string, error)
+	DeleteExpiredScopes(ctx context.Context, now time.Time) ([]string, error)
+	UpdateRole(ctx context.Context, user *entities.User, roles []*entities.Role) error
+	UpdateHash(ctx context.Context, userId, hash string) error
+	FindPasswordHistory(ctx context.Context, userId string, limit int) ([]string, error)
+	AddPasswordHistory(ctx context.Context, userId, hash string, keep int) error
+	Delete(ctx context.Context, userId string) error
 	BeginTransaction(ctx context.Context) (*gorm.DB, error)
 	WithTransaction(tx *gorm.DB) IUserRepository
 }
@@ -27,51 +39,315 @@ func NewUserRepository(db *gorm.DB) IUserRepository {
 	return &userRepository{db: db}
 }
 
-func (r *userRepository) FindById(userId string) (*entities.User, error) {
+func (r *userRepository) FindById(ctx context.Context, userId string) (*entities.User, error) {
 	var user entities.User
-	res := r.db.Preload("Scopes").First(&user, entities.User{ID: userId})
+	res := r.db.WithContext(ctx).Preload("Scopes").Preload("ScopeMappings").Preload("Roles.Scopes").Preload("Groups.Scopes").First(&user, entities.User{ID: userId})
 	if res.Error != nil {
 		return nil, res.Error
 	}
+	if err := loadAncestors(r.db.WithContext(ctx), user.Groups); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
-func (r *userRepository) FindAll() ([]*entities.User, error) {
-	var users []*entities.User
-	res := r.db.Preload("Scopes").Find(&users)
+// FindByIds loads the users with the same associations as FindById, with one
+// query per association however many users are asked for. Ids that match no
+// user are left out.
+func (r *userRepository) FindByIds(ctx context.Context, userIds []string) ([]*entities.User, error) {
+	users := []*entities.User{}
+	if len(userIds) == 0 {
+		return users, nil
+	}
+	res := r.db.WithContext(ctx).Preload("Scopes").Preload("ScopeMappings").Preload("Roles.Scopes").Preload("Groups.Scopes").Where("id IN ?", userIds).Find(&users)
 	if res.Error != nil {
 		return nil, res.Error
 	}
+
+	var groups []*entities.Group
+	for _, user := range users {
+		groups = append(groups, user.Groups...)
+	}
+	if err := loadAncestors(r.db.WithContext(ctx), groups); err != nil {
+		return nil, err
+	}
 	return users, nil
 }
 
-func (r *userRepository) Create(username, hash, email string, scopes []*entities.UserScope) (*entities.User, error) {
+var userSortColumns = map[string]string{
+	"id":       "id",
+	"username": "username",
+	"email":    "email",
+}
+
+func (r *userRepository) FindAll(ctx context.Context, query dto.ListUsersRequest) ([]*entities.User, *dto.Paging, error) {
+	page, err := newPageQuery(query.Limit, query.Cursor, query.SortBy, query.Order, userSortColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db := r.db.WithContext(ctx).Model(&entities.User{})
+	if query.UsernamePrefix != "" {
+		db = db.Where(`users.username LIKE ? ESCAPE '\'`, escapeLike(query.UsernamePrefix)+"%")
+	}
+	if query.EmailDomain != "" {
+		db = db.Where(`LOWER(users.email) LIKE ? ESCAPE '\'`, "%@"+escapeLike(strings.ToLower(query.EmailDomain)))
+	}
+	if query.HasScope != "" {
+		names, err := r.grantingScopes(ctx, query.HasScope)
+		if err != nil {
+			return nil, nil, err
+		}
+		db = db.Where("users.id IN (?) OR users.id IN (?) OR users.id IN (?)",
+			r.usersWithScope(names), r.usersWithRoleScope(names), r.usersWithGroupScope(names))
+	}
+	if query.LacksScope != "" {
+		names, err := r.grantingScopes(ctx, query.LacksScope)
+		if err != nil {
+			return nil, nil, err
+		}
+		db = db.Where("users.id NOT IN (?) AND users.id NOT IN (?) AND users.id NOT IN (?)",
+			r.usersWithScope(names), r.usersWithRoleScope(names), r.usersWithGroupScope(names))
+	}
+
+	var cursorID interface{}
+	if page.cursor != nil {
+		cursorID = page.cursor.ID
+	}
+
+	var users []*entities.User
+	res := page.apply(db, "users", cursorID).Preload("Scopes").Preload("ScopeMappings").Preload("Roles.Scopes").Preload("Groups.Scopes").Find(&users)
+	if res.Error != nil {
+		return nil, nil, res.Error
+	}
+
+	var groups []*entities.Group
+	for _, user := range users {
+		groups = append(groups, user.Groups...)
+	}
+	if err := loadAncestors(r.db.WithContext(ctx), groups); err != nil {
+		return nil, nil, err
+	}
+
+	count, paging := page.paging(len(users), func(i int) (string, string) {
+		switch page.column {
+		case "username":
+			return users[i].Username, users[i].ID
+		case "email":
+			return users[i].Email, users[i].ID
+		default:
+			return users[i].ID, users[i].ID
+		}
+	})
+	return users[:count], paging, nil
+}
+
+// grantingScopes lists the scopes that grant scopeName the way authorization
+// checks match them: the scope itself and every wildcard pattern in the
+// catalogue that matches it, such as "user:*" for "user:view".
+func (r *userRepository) grantingScopes(ctx context.Context, scopeName string) ([]string, error) {
+	var patterns []string
+	err := r.db.WithContext(ctx).Model(&entities.UserScope{}).
+		Distinct("name").
+		Where("name LIKE ?", "%"+scopes.Wildcard+"%").
+		Pluck("name", &patterns).Error
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{scopeName}
+	for _, pattern := range patterns {
+		if pattern != scopeName && scopes.Match(pattern, scopeName) {
+			names = append(names, pattern)
+		}
+	}
+	return names, nil
+}
+
+// usersWithScope selects users granted one of the scopes directly.
+func (r *userRepository) usersWithScope(scopeNames []string) *gorm.DB {
+	return r.db.Table("user_scope_mapping").
+		Select("user_scope_mapping.user_id").
+		Joins("JOIN user_scopes ON user_scopes.id = user_scope_mapping.user_scope_id").
+		Where("user_scopes.name IN ?", scopeNames)
+}
+
+// usersWithRoleScope selects users granted one of the scopes through one of
+// their roles.
+func (r *userRepository) usersWithRoleScope(scopeNames []string) *gorm.DB {
+	return r.db.Table("user_role_mapping").
+		Select("user_role_mapping.user_id").
+		Joins("JOIN role_scope_mapping ON role_scope_mapping.role_id = user_role_mapping.role_id").
+		Joins("JOIN user_scopes ON user_scopes.id = role_scope_mapping.user_scope_id").
+		Where("user_scopes.name IN ?", scopeNames)
+}
+
+// groupScopeMembers pairs every group with itself and each of its ancestors,
+// then selects the members of groups whose lineage holds the scope.
+const groupScopeMembers = `WITH RECURSIVE lineage(group_id, ancestor_id) AS (
+	SELECT id, id FROM user_groups
+	UNION
+	SELECT lineage.group_id, user_groups.parent_id FROM lineage
+	JOIN user_groups ON user_groups.id = lineage.ancestor_id
+	WHERE user_groups.parent_id IS NOT NULL
+)
+SELECT user_group_mapping.user_id FROM user_group_mapping
+JOIN lineage ON lineage.group_id = user_group_mapping.group_id
+JOIN group_scope_mapping ON group_scope_mapping.group_id = lineage.ancestor_id
+JOIN user_scopes ON user_scopes.id = group_scope_mapping.user_scope_id
+WHERE user_scopes.name IN ?`
+
+// usersWithGroupScope selects users granted one of the scopes by one of their
+// groups or any ancestor of those groups.
+func (r *userRepository) usersWithGroupScope(scopeNames []string) *gorm.DB {
+	return r.db.Raw(groupScopeMembers, scopeNames)
+}
+
+// Create grants scopes to the new user. expiries maps a scope name to when
+// its grant expires; scopes missing from it are granted permanently.
+func (r *userRepository) Create(ctx context.Context, username, hash, email string, scopes []*entities.UserScope, expiries map[string]time.Time, roles []*entities.Role) (*entities.User, error) {
 	newUser := &entities.User{
 		ID:       uuid.New().String(),
 		Username: username,
 		Hash:     hash,
 		Email:    email,
 		Scopes:   scopes,
+		Roles:    roles,
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(newUser).Error; err != nil {
+			return err
+		}
+		return setScopeExpiries(tx, newUser.ID, scopes, expiries)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newUser, nil
+}
+
+// UpdateScope replaces the user's direct scopes. expiries has the same
+// meaning as in Create and overwrites the expiry of every scope kept.
+func (r *userRepository) UpdateScope(ctx context.Context, user *entities.User, scopes []*entities.UserScope, expiries map[string]time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(user).Association("Scopes").Replace(scopes); err != nil {
+			return err
+		}
+		return setScopeExpiries(tx, user.ID, scopes, expiries)
+	})
+}
+
+func setScopeExpiries(tx *gorm.DB, userId string, scopes []*entities.UserScope, expiries map[string]time.Time) error {
+	for _, scope := range scopes {
+		var expiresAt *time.Time
+		if t, ok := expiries[scope.Name]; ok {
+			expiresAt = &t
+		}
+		res := tx.Model(&entities.UserScopeMapping{}).
+			Where("user_id = ? AND user_scope_id = ?", userId, scope.ID).
+			Update("expires_at", expiresAt)
+		if res.Error != nil {
+			return res.Error
+		}
 	}
-	res := r.db.Create(newUser)
+	return nil
+}
+
+// FindExpiredScopeHolders returns the distinct ids of the users holding a
+// direct grant that expired at or before now, across every tenant like
+// DeleteExpiredScopes.
+func (r *userRepository) FindExpiredScopeHolders(ctx context.Context, now time.Time) ([]string, error) {
+	var userIds []string
+	res := r.db.WithContext(ctx).Model(&entities.UserScopeMapping{}).
+		Where("expires_at <= ?", now).
+		Distinct().
+		Order("user_id").
+		Pluck("user_id", &userIds)
 	if res.Error != nil {
 		return nil, res.Error
 	}
-	return newUser, nil
+	return userIds, nil
+}
+
+// DeleteExpiredScopes removes every direct grant that expired at or before
+// now and returns the distinct ids of the users that lost one. Grants carry
+// no organization, so this spans every tenant.
+func (r *userRepository) DeleteExpiredScopes(ctx context.Context, now time.Time) ([]string, error) {
+	var expired []*entities.UserScopeMapping
+	res := r.db.WithContext(ctx).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "user_id"}}}).
+		Where("expires_at <= ?", now).
+		Delete(&expired)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	seen := make(map[string]struct{}, len(expired))
+	userIds := make([]string, 0, len(expired))
+	for _, mapping := range expired {
+		if _, ok := seen[mapping.UserID]; ok {
+			continue
+		}
+		seen[mapping.UserID] = struct{}{}
+		userIds = append(userIds, mapping.UserID)
+	}
+	return userIds, nil
 }
 
-func (r *userRepository) UpdateScope(user *entities.User, scopes []*entities.UserScope) error {
-	err := r.db.Model(user).Association("Scopes").Replace(scopes)
+func (r *userRepository) UpdateRole(ctx context.Context, user *entities.User, roles []*entities.Role) error {
+	err := r.db.WithContext(ctx).Model(user).Association("Roles").Replace(roles)
 	return err
 }
 
-func (r *userRepository) Delete(userId string) error {
-	res := r.db.Where("id = ?", userId).Delete(&entities.User{})
+func (r *userRepository) UpdateHash(ctx context.Context, userId, hash string) error {
+	res := r.db.WithContext(ctx).Model(&entities.User{}).Where("id = ?", userId).Update("hash", hash)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindPasswordHistory returns up to limit previous hashes, newest first.
+func (r *userRepository) FindPasswordHistory(ctx context.Context, userId string, limit int) ([]string, error) {
+	var hashes []string
+	res := r.db.WithContext(ctx).Model(&entities.PasswordHistory{}).
+		Where("user_id = ?", userId).
+		Order("id DESC").
+		Limit(limit).
+		Pluck("hash", &hashes)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return hashes, nil
+}
+
+// AddPasswordHistory records hash and drops all but the keep newest entries
+// for the user.
+func (r *userRepository) AddPasswordHistory(ctx context.Context, userId, hash string, keep int) error {
+	res := r.db.WithContext(ctx).Create(&entities.PasswordHistory{UserID: userId, Hash: hash})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	kept := r.db.WithContext(ctx).Model(&entities.PasswordHistory{}).
+		Select("id").
+		Where("user_id = ?", userId).
+		Order("id DESC").
+		Limit(keep)
+	res = r.db.WithContext(ctx).Where("user_id = ? AND id NOT IN (?)", userId, kept).Delete(&entities.PasswordHistory{})
+	return res.Error
+}
+
+func (r *userRepository) Delete(ctx context.Context, userId string) error {
+	res := r.db.WithContext(ctx).Where("id = ?", userId).Delete(&entities.User{})
 	return res.Error
 }
 
 func (r *userRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
-	tx := r.db.Begin()
+	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
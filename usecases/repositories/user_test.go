@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -10,12 +11,15 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
 	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
 )
 
 type UserRepoSuite struct {
 	suite.Suite
 	db   *gorm.DB
+	ctx  context.Context
 	repo IUserRepository
 }
 
@@ -24,9 +28,11 @@ func (suite *UserRepoSuite) SetupTest() {
 		Logger: logger.Default.LogMode(logger.Silent),
 	})
 	assert.NoError(suite.T(), err)
-	err = gormDB.AutoMigrate(&entities.User{})
+	err = gormDB.AutoMigrate(&entities.User{}, &entities.Role{}, &entities.Group{}, &entities.PasswordHistory{}, &entities.UserScopeMapping{})
 	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), gormDB.Use(tenancy.Plugin{}))
 	suite.db = gormDB
+	suite.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
 	suite.repo = NewUserRepository(gormDB)
 }
 
@@ -41,54 +47,54 @@ func TestUserRepoSuite(t *testing.T) {
 }
 
 func (suite *UserRepoSuite) TestCreateAndFindById() {
-	user, err := suite.repo.Create("test", "pass", "test@example.com", []*entities.UserScope{
+	user, err := suite.repo.Create(suite.ctx, "test", "pass", "test@example.com", []*entities.UserScope{
 		{Name: "read"},
 		{Name: "write"},
-	})
+	}, nil, nil)
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), user)
 
-	found, err := suite.repo.FindById(user.ID)
+	found, err := suite.repo.FindById(suite.ctx, user.ID)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "test", found.Username)
 }
 
 func (suite *UserRepoSuite) TestCreateDuplicateEmail() {
-	_, err := suite.repo.Create("test", "pass", "test@example.com", []*entities.UserScope{})
+	_, err := suite.repo.Create(suite.ctx, "test", "pass", "test@example.com", []*entities.UserScope{}, nil, nil)
 	assert.NoError(suite.T(), err)
 
-	_, err = suite.repo.Create("testnil", "pass", "test@example.com", []*entities.UserScope{})
+	_, err = suite.repo.Create(suite.ctx, "testnil", "pass", "test@example.com", []*entities.UserScope{}, nil, nil)
 	assert.Error(suite.T(), err)
 }
 
 func (suite *UserRepoSuite) TestFindByIdNotFound() {
-	_, err := suite.repo.FindById("non-existent-id")
+	_, err := suite.repo.FindById(suite.ctx, "non-existent-id")
 	assert.Error(suite.T(), err)
 }
 
 func (suite *UserRepoSuite) TestUpdateScope() {
-	user, _ := suite.repo.Create("test", "pass", "test@example.com", []*entities.UserScope{
+	user, _ := suite.repo.Create(suite.ctx, "test", "pass", "test@example.com", []*entities.UserScope{
 		{Name: "read"},
-	})
-	err := suite.repo.UpdateScope(user, []*entities.UserScope{
+	}, nil, nil)
+	err := suite.repo.UpdateScope(suite.ctx, user, []*entities.UserScope{
 		{Name: "admin"},
-	})
+	}, nil)
 	assert.NoError(suite.T(), err)
 }
 
 func (suite *UserRepoSuite) TestDelete() {
-	user, _ := suite.repo.Create("test", "pass", "test@example.com", []*entities.UserScope{
+	user, _ := suite.repo.Create(suite.ctx, "test", "pass", "test@example.com", []*entities.UserScope{
 		{Name: "read"},
-	})
-	err := suite.repo.Delete(user.ID)
+	}, nil, nil)
+	err := suite.repo.Delete(suite.ctx, user.ID)
 	assert.NoError(suite.T(), err)
 
-	_, err = suite.repo.FindById("test")
+	_, err = suite.repo.FindById(suite.ctx, "test")
 	assert.Error(suite.T(), err)
 }
 
 func (suite *UserRepoSuite) TestDeleteNonExistent() {
-	err := suite.repo.Delete("not-exist")
+	err := suite.repo.Delete(suite.ctx, "not-exist")
 	assert.NoError(suite.T(), err)
 }
 
@@ -96,7 +102,7 @@ func (suite *UserRepoSuite) TestBeginTransactionError() {
 	sqlDB, _ := suite.db.DB()
 	sqlDB.Close()
 
-	_, err := suite.repo.BeginTransaction(context.Background())
+	_, err := suite.repo.BeginTransaction(suite.ctx)
 	assert.Error(suite.T(), err)
 }
 
@@ -105,33 +111,34 @@ func (suite *UserRepoSuite) TestBeginAndWithTransaction_Rollback() {
 	assert.NoError(suite.T(), err)
 
 	txRepo := suite.repo.WithTransaction(tx)
-	_, err = txRepo.Create("test", "pass", "test@example.com", []*entities.UserScope{
+	_, err = txRepo.Create(suite.ctx, "test", "pass", "test@example.com", []*entities.UserScope{
 		{Name: "read"},
-	})
+	}, nil, nil)
 	assert.NoError(suite.T(), err)
 
 	tx.Rollback()
 }
 
 func (suite *UserRepoSuite) TestFindAll() {
-	user1, err := suite.repo.Create("user1", "pass1", "user1@example.com", []*entities.UserScope{
+	user1, err := suite.repo.Create(suite.ctx, "user1", "pass1", "user1@example.com", []*entities.UserScope{
 		{Name: "read"},
-	})
+	}, nil, nil)
 	assert.NoError(suite.T(), err)
 
-	user2, err := suite.repo.Create("user2", "pass2", "user2@example.com", []*entities.UserScope{
+	user2, err := suite.repo.Create(suite.ctx, "user2", "pass2", "user2@example.com", []*entities.UserScope{
 		{Name: "write"},
-	})
+	}, nil, nil)
 	assert.NoError(suite.T(), err)
 
-	user3, err := suite.repo.Create("user3", "pass3", "user3@example.com", []*entities.UserScope{
+	user3, err := suite.repo.Create(suite.ctx, "user3", "pass3", "user3@example.com", []*entities.UserScope{
 		{Name: "admin"},
-	})
+	}, nil, nil)
 	assert.NoError(suite.T(), err)
 
-	users, err := suite.repo.FindAll()
+	users, paging, err := suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{})
 	assert.NoError(suite.T(), err)
 	assert.Len(suite.T(), users, 3)
+	assert.False(suite.T(), paging.HasMore)
 
 	usernames := make([]string, len(users))
 	for i, user := range users {
@@ -147,7 +154,366 @@ func (suite *UserRepoSuite) TestFindAllDatabaseError() {
 	sqlDB, _ := suite.db.DB()
 	sqlDB.Close()
 
-	users, err := suite.repo.FindAll()
+	users, _, err := suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{})
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), users)
 }
+
+func (suite *UserRepoSuite) TestFindAllPaginates() {
+	for _, name := range []string{"carol", "alice", "bob", "dave", "erin"} {
+		_, err := suite.repo.Create(suite.ctx, name, "pass", name+"@example.com", []*entities.UserScope{}, nil, nil)
+		assert.NoError(suite.T(), err)
+	}
+
+	query := dto.ListUsersRequest{Limit: 2, SortBy: "username"}
+	seen := []string{}
+	for {
+		users, paging, err := suite.repo.FindAll(suite.ctx, query)
+		assert.NoError(suite.T(), err)
+		assert.LessOrEqual(suite.T(), len(users), 2)
+		for _, user := range users {
+			seen = append(seen, user.Username)
+		}
+		if !paging.HasMore {
+			assert.Empty(suite.T(), paging.NextCursor)
+			break
+		}
+		query.Cursor = paging.NextCursor
+	}
+	assert.Equal(suite.T(), []string{"alice", "bob", "carol", "dave", "erin"}, seen)
+}
+
+func (suite *UserRepoSuite) TestFindAllDescending() {
+	for _, name := range []string{"alice", "bob", "carol"} {
+		_, err := suite.repo.Create(suite.ctx, name, "pass", name+"@example.com", []*entities.UserScope{}, nil, nil)
+		assert.NoError(suite.T(), err)
+	}
+
+	users, paging, err := suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{Limit: 2, SortBy: "email", Order: "desc"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "carol", users[0].Username)
+	assert.Equal(suite.T(), "bob", users[1].Username)
+
+	users, _, err = suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{Limit: 2, SortBy: "email", Order: "desc", Cursor: paging.NextCursor})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 1)
+	assert.Equal(suite.T(), "alice", users[0].Username)
+}
+
+func (suite *UserRepoSuite) TestFindAllFilters() {
+	_, err := suite.repo.Create(suite.ctx, "ops-alice", "pass", "alice@corp.com", []*entities.UserScope{{Name: "container:view"}}, nil, nil)
+	assert.NoError(suite.T(), err)
+	_, err = suite.repo.Create(suite.ctx, "ops-bob", "pass", "bob@other.com", []*entities.UserScope{}, nil, nil)
+	assert.NoError(suite.T(), err)
+	_, err = suite.repo.Create(suite.ctx, "dev_carol", "pass", "carol@CORP.com", []*entities.UserScope{}, nil, nil)
+	assert.NoError(suite.T(), err)
+
+	users, _, err := suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{UsernamePrefix: "ops-"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 2)
+
+	users, _, err = suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{UsernamePrefix: "dev_"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 1)
+
+	users, _, err = suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{EmailDomain: "corp.com"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 2)
+
+	users, _, err = suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{HasScope: "container:view"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 1)
+	assert.Equal(suite.T(), "ops-alice", users[0].Username)
+
+	users, _, err = suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{LacksScope: "container:view"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 2)
+}
+
+func (suite *UserRepoSuite) TestFindAllFiltersRoleScopes() {
+	operator := &entities.Role{Name: "operator", Scopes: []*entities.UserScope{{Name: "container:view"}}}
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create(operator).Error)
+
+	_, err := suite.repo.Create(suite.ctx, "alice", "pass", "alice@corp.com", []*entities.UserScope{}, nil, []*entities.Role{operator})
+	assert.NoError(suite.T(), err)
+	_, err = suite.repo.Create(suite.ctx, "bob", "pass", "bob@corp.com", []*entities.UserScope{}, nil, nil)
+	assert.NoError(suite.T(), err)
+
+	users, _, err := suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{HasScope: "container:view"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 1)
+	assert.Equal(suite.T(), "alice", users[0].Username)
+	assert.Equal(suite.T(), []string{"container:view"}, users[0].EffectiveScopes())
+
+	users, _, err = suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{LacksScope: "container:view"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 1)
+	assert.Equal(suite.T(), "bob", users[0].Username)
+}
+
+func (suite *UserRepoSuite) TestFindAllFiltersWildcardScopes() {
+	viewer := &entities.Role{Name: "viewer", Scopes: []*entities.UserScope{{Name: "*:view"}}}
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create(viewer).Error)
+	ops := &entities.Group{Name: "ops", Scopes: []*entities.UserScope{{Name: "container:*"}}}
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create(ops).Error)
+
+	_, err := suite.repo.Create(suite.ctx, "alice", "pass", "alice@corp.com", ops.Scopes, nil, nil)
+	assert.NoError(suite.T(), err)
+	_, err = suite.repo.Create(suite.ctx, "bob", "pass", "bob@corp.com", []*entities.UserScope{}, nil, []*entities.Role{viewer})
+	assert.NoError(suite.T(), err)
+	carol, err := suite.repo.Create(suite.ctx, "carol", "pass", "carol@corp.com", []*entities.UserScope{}, nil, nil)
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Model(carol).Association("Groups").Append(ops))
+	_, err = suite.repo.Create(suite.ctx, "dave", "pass", "dave@corp.com", []*entities.UserScope{{Name: "user:*"}}, nil, nil)
+	assert.NoError(suite.T(), err)
+
+	usernames := func(users []*entities.User) []string {
+		names := make([]string, len(users))
+		for i, user := range users {
+			names[i] = user.Username
+		}
+		return names
+	}
+
+	users, _, err := suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{HasScope: "container:view", SortBy: "username"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"alice", "bob", "carol"}, usernames(users))
+
+	users, _, err = suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{LacksScope: "container:view", SortBy: "username"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"dave"}, usernames(users))
+
+	users, _, err = suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{HasScope: "container:delete", SortBy: "username"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"alice", "carol"}, usernames(users))
+}
+
+func (suite *UserRepoSuite) TestFindAllInvalidCursor() {
+	_, _, err := suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{Cursor: "not-a-cursor"})
+	assert.ErrorIs(suite.T(), err, ErrInvalidCursor)
+}
+
+func (suite *UserRepoSuite) TestFindAllCursorSortMismatch() {
+	for _, name := range []string{"alice", "bob"} {
+		_, err := suite.repo.Create(suite.ctx, name, "pass", name+"@example.com", []*entities.UserScope{}, nil, nil)
+		assert.NoError(suite.T(), err)
+	}
+
+	_, paging, err := suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{Limit: 1, SortBy: "username"})
+	assert.NoError(suite.T(), err)
+
+	_, _, err = suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{Limit: 1, SortBy: "email", Cursor: paging.NextCursor})
+	assert.ErrorIs(suite.T(), err, ErrInvalidCursor)
+}
+
+func (suite *UserRepoSuite) TestUpdateHash() {
+	user, err := suite.repo.Create(suite.ctx, "test", "old-hash", "test@example.com", []*entities.UserScope{}, nil, nil)
+	assert.NoError(suite.T(), err)
+
+	err = suite.repo.UpdateHash(suite.ctx, user.ID, "new-hash")
+	assert.NoError(suite.T(), err)
+
+	found, err := suite.repo.FindById(suite.ctx, user.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "new-hash", found.Hash)
+}
+
+func (suite *UserRepoSuite) TestUpdateHashNotFound() {
+	err := suite.repo.UpdateHash(suite.ctx, "not-exist", "new-hash")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *UserRepoSuite) TestPasswordHistory() {
+	user, err := suite.repo.Create(suite.ctx, "test", "hash-0", "test@example.com", []*entities.UserScope{}, nil, nil)
+	assert.NoError(suite.T(), err)
+
+	for _, hash := range []string{"hash-1", "hash-2", "hash-3"} {
+		err = suite.repo.AddPasswordHistory(suite.ctx, user.ID, hash, 2)
+		assert.NoError(suite.T(), err)
+	}
+
+	hashes, err := suite.repo.FindPasswordHistory(suite.ctx, user.ID, 5)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"hash-3", "hash-2"}, hashes)
+
+	hashes, err = suite.repo.FindPasswordHistory(suite.ctx, user.ID, 1)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"hash-3"}, hashes)
+}
+
+func (suite *UserRepoSuite) TestFindPasswordHistoryEmpty() {
+	hashes, err := suite.repo.FindPasswordHistory(suite.ctx, "not-exist", 5)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), hashes)
+}
+
+func (suite *UserRepoSuite) TestCreateWithRolesMergesScopes() {
+	read := &entities.UserScope{Name: "container:view"}
+	operator := &entities.Role{Name: "operator", Scopes: []*entities.UserScope{read, {Name: "container:update"}}}
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create(operator).Error)
+
+	user, err := suite.repo.Create(suite.ctx, "test", "pass", "test@example.com", []*entities.UserScope{read, {Name: "report:mail"}}, nil, []*entities.Role{operator})
+	assert.NoError(suite.T(), err)
+
+	found, err := suite.repo.FindById(suite.ctx, user.ID)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), found.Roles, 1)
+	assert.Equal(suite.T(), "operator", found.Roles[0].Name)
+	assert.Equal(suite.T(), []string{"container:view", "report:mail", "container:update"}, found.EffectiveScopes())
+}
+
+func (suite *UserRepoSuite) TestUpdateRole() {
+	operator := &entities.Role{Name: "operator"}
+	auditor := &entities.Role{Name: "auditor"}
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create([]*entities.Role{operator, auditor}).Error)
+
+	user, err := suite.repo.Create(suite.ctx, "test", "pass", "test@example.com", []*entities.UserScope{}, nil, []*entities.Role{operator})
+	assert.NoError(suite.T(), err)
+
+	err = suite.repo.UpdateRole(suite.ctx, user, []*entities.Role{auditor})
+	assert.NoError(suite.T(), err)
+
+	found, err := suite.repo.FindById(suite.ctx, user.ID)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), found.Roles, 1)
+	assert.Equal(suite.T(), "auditor", found.Roles[0].Name)
+}
+
+func (suite *UserRepoSuite) TestNestedGroupScopes() {
+	engineering := &entities.Group{Name: "engineering", Scopes: []*entities.UserScope{{Name: "container:view"}}}
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create(engineering).Error)
+	backend := &entities.Group{Name: "backend", ParentID: &engineering.ID, Scopes: []*entities.UserScope{{Name: "container:update"}}}
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create(backend).Error)
+
+	user, err := suite.repo.Create(suite.ctx, "alice", "pass", "alice@example.com", []*entities.UserScope{}, nil, nil)
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Model(user).Association("Groups").Append(backend))
+	_, err = suite.repo.Create(suite.ctx, "bob", "pass", "bob@example.com", []*entities.UserScope{}, nil, nil)
+	assert.NoError(suite.T(), err)
+
+	found, err := suite.repo.FindById(suite.ctx, user.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"container:update", "container:view"}, found.EffectiveScopes())
+	assert.Equal(suite.T(), []entities.ScopeGrant{
+		{Scope: "container:view", Source: entities.GrantGroup, Path: []string{"backend", "engineering"}},
+	}, found.ExplainScope("container:view"))
+
+	users, _, err := suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{HasScope: "container:view"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 1)
+	assert.Equal(suite.T(), "alice", users[0].Username)
+	assert.Equal(suite.T(), "engineering", users[0].Groups[0].Parent.Name)
+
+	users, _, err = suite.repo.FindAll(suite.ctx, dto.ListUsersRequest{LacksScope: "container:view"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 1)
+	assert.Equal(suite.T(), "bob", users[0].Username)
+}
+
+func (suite *UserRepoSuite) TestFindByIds() {
+	engineering := &entities.Group{Name: "engineering", Scopes: []*entities.UserScope{{Name: "container:view"}}}
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create(engineering).Error)
+	backend := &entities.Group{Name: "backend", ParentID: &engineering.ID}
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Create(backend).Error)
+
+	alice, err := suite.repo.Create(suite.ctx, "alice", "pass", "alice@example.com", []*entities.UserScope{{Name: "read"}}, nil, nil)
+	assert.NoError(suite.T(), err)
+	bob, err := suite.repo.Create(suite.ctx, "bob", "pass", "bob@example.com", []*entities.UserScope{}, nil, nil)
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Model(alice).Association("Groups").Append(backend))
+	assert.NoError(suite.T(), suite.db.WithContext(suite.ctx).Model(bob).Association("Groups").Append(backend))
+
+	users, err := suite.repo.FindByIds(suite.ctx, []string{alice.ID, bob.ID, "non-existent-id"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 2)
+	scopes := map[string][]string{}
+	for _, user := range users {
+		scopes[user.Username] = user.EffectiveScopes()
+	}
+	assert.Equal(suite.T(), []string{"read", "container:view"}, scopes["alice"])
+	assert.Equal(suite.T(), []string{"container:view"}, scopes["bob"])
+
+	users, err = suite.repo.FindByIds(suite.ctx, nil)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), users)
+}
+
+func (suite *UserRepoSuite) TestTenantIsolation() {
+	globex := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "globex"})
+
+	acmeUser, err := suite.repo.Create(suite.ctx, "alice", "pass", "alice@example.com", []*entities.UserScope{}, nil, nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "acme", acmeUser.OrganizationID)
+	globexUser, err := suite.repo.Create(globex, "alice", "pass", "alice@example.com", []*entities.UserScope{}, nil, nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "globex", globexUser.OrganizationID)
+
+	_, err = suite.repo.FindById(globex, acmeUser.ID)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	assert.NoError(suite.T(), suite.repo.Delete(globex, acmeUser.ID))
+	_, err = suite.repo.FindById(suite.ctx, acmeUser.ID)
+	assert.NoError(suite.T(), err)
+
+	users, _, err := suite.repo.FindAll(globex, dto.ListUsersRequest{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 1)
+	assert.Equal(suite.T(), globexUser.ID, users[0].ID)
+
+	crossTenant := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "default", Super: true, CrossTenant: true})
+	users, _, err = suite.repo.FindAll(crossTenant, dto.ListUsersRequest{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 2)
+}
+
+func (suite *UserRepoSuite) TestScopeExpiries() {
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	user, err := suite.repo.Create(suite.ctx, "contractor", "pass", "contractor@example.com", []*entities.UserScope{
+		{Name: "read"},
+		{Name: "deploy"},
+	}, map[string]time.Time{"deploy": expiresAt}, nil)
+	assert.NoError(suite.T(), err)
+
+	found, err := suite.repo.FindById(suite.ctx, user.ID)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), found.ScopeExpiry(found.Scopes[0].ID))
+	assert.True(suite.T(), expiresAt.Equal(*found.ScopeExpiry(found.Scopes[1].ID)))
+	assert.Equal(suite.T(), []string{"deploy"}, []string{found.ExpiringScopes()[0].Name})
+
+	err = suite.repo.UpdateScope(suite.ctx, found, found.Scopes, map[string]time.Time{"read": expiresAt})
+	assert.NoError(suite.T(), err)
+	found, err = suite.repo.FindById(suite.ctx, user.ID)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), expiresAt.Equal(*found.ScopeExpiry(found.Scopes[0].ID)))
+	assert.Nil(suite.T(), found.ScopeExpiry(found.Scopes[1].ID))
+}
+
+func (suite *UserRepoSuite) TestDeleteExpiredScopes() {
+	now := time.Now()
+	alice, err := suite.repo.Create(suite.ctx, "alice", "pass", "alice@example.com", []*entities.UserScope{
+		{Name: "read"},
+		{Name: "deploy"},
+		{Name: "oncall"},
+	}, map[string]time.Time{"deploy": now.Add(-time.Minute), "oncall": now.Add(-time.Second)}, nil)
+	assert.NoError(suite.T(), err)
+	_, err = suite.repo.Create(suite.ctx, "bob", "pass", "bob@example.com", []*entities.UserScope{
+		{Name: "audit"},
+	}, map[string]time.Time{"audit": now.Add(time.Hour)}, nil)
+	assert.NoError(suite.T(), err)
+
+	userIds, err := suite.repo.FindExpiredScopeHolders(context.Background(), now)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{alice.ID}, userIds)
+
+	userIds, err = suite.repo.DeleteExpiredScopes(context.Background(), now)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{alice.ID}, userIds)
+
+	found, err := suite.repo.FindById(suite.ctx, alice.ID)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), found.Scopes, 1)
+	assert.Equal(suite.T(), "read", found.Scopes[0].Name)
+
+	userIds, err = suite.repo.DeleteExpiredScopes(context.Background(), now)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), userIds)
+}
@@ -0,0 +1,240 @@
+package repositories
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IWebhookRepository interface {
+	Create(ctx context.Context, webhook *entities.Webhook) error
+	FindById(ctx context.Context, webhookId uint) (*entities.Webhook, error)
+	FindAll(ctx context.Context, query dto.ListWebhooksRequest) ([]*entities.Webhook, *dto.Paging, error)
+	FindActive(ctx context.Context, organizationId string) ([]*entities.Webhook, error)
+	Update(ctx context.Context, webhook *entities.Webhook) error
+	Delete(ctx context.Context, webhookId uint) error
+	CreateDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.WebhookDelivery, error)
+	FindDeliveries(ctx context.Context, webhookId uint, query dto.ListWebhookDeliveriesRequest) ([]*entities.WebhookDelivery, *dto.Paging, error)
+	RecordAttempt(ctx context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt, disableAfter int) (bool, error)
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) IWebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *entities.Webhook) error {
+	return r.db.WithContext(ctx).Create(webhook).Error
+}
+
+func (r *webhookRepository) FindById(ctx context.Context, webhookId uint) (*entities.Webhook, error) {
+	var webhook entities.Webhook
+	res := r.db.WithContext(ctx).First(&webhook, webhookId)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &webhook, nil
+}
+
+var webhookSortColumns = map[string]string{
+	"id": "id",
+}
+
+func (r *webhookRepository) FindAll(ctx context.Context, query dto.ListWebhooksRequest) ([]*entities.Webhook, *dto.Paging, error) {
+	page, err := newPageQuery(query.Limit, query.Cursor, query.SortBy, query.Order, webhookSortColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db := r.db.WithContext(ctx).Model(&entities.Webhook{})
+	if query.Active != nil {
+		db = db.Where("webhooks.active = ?", *query.Active)
+	}
+
+	var cursorID interface{}
+	if page.cursor != nil {
+		id, err := strconv.ParseUint(page.cursor.ID, 10, 64)
+		if err != nil {
+			return nil, nil, ErrInvalidCursor
+		}
+		cursorID = id
+	}
+
+	var webhooks []*entities.Webhook
+	res := page.apply(db, "webhooks", cursorID).Find(&webhooks)
+	if res.Error != nil {
+		return nil, nil, res.Error
+	}
+
+	count, paging := page.paging(len(webhooks), func(i int) (string, string) {
+		id := strconv.FormatUint(uint64(webhooks[i].ID), 10)
+		return id, id
+	})
+	return webhooks[:count], paging, nil
+}
+
+// FindActive lists the active webhooks of an organization. It names the
+// organization explicitly because events are dispatched outside any
+// request.
+func (r *webhookRepository) FindActive(ctx context.Context, organizationId string) ([]*entities.Webhook, error) {
+	var webhooks []*entities.Webhook
+	res := r.db.WithContext(ctx).Where("organization_id = ? AND active = ?", organizationId, true).Order("id").Find(&webhooks)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *entities.Webhook) error {
+	res := r.db.WithContext(ctx).Model(webhook).Select("URL", "EventTypes", "Active", "ConsecutiveFailures", "DisabledAt").Updates(webhook)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, webhookId uint) error {
+	res := r.db.WithContext(ctx).Delete(&entities.Webhook{}, webhookId)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateDeliveries skips deliveries of an event a webhook already has, so an
+// event consumed twice is still delivered once.
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Omit("Webhook", "Attempts").Create(&deliveries).Error
+}
+
+// ClaimDueDeliveries leases up to limit pending deliveries whose next attempt
+// is due at now, oldest first, with their webhooks. A leased delivery's next
+// attempt moves to leaseUntil, so no other sender picks it up before the
+// attempt is recorded or the lease runs out. Deliveries another sender is
+// claiming are skipped, and deliveries of inactive webhooks wait until the
+// webhook is enabled again.
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	var deliveries []*entities.WebhookDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		res := tx.Model(&entities.WebhookDelivery{}).
+			Joins("JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id AND webhooks.active = ?", true).
+			Where("webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ?", entities.WebhookDeliveryPending, now).
+			Clauses(clause.Locking{
+				Strength: clause.LockingStrengthUpdate,
+				Table:    clause.Table{Name: "webhook_deliveries"},
+				Options:  clause.LockingOptionsSkipLocked,
+			}).
+			Order("webhook_deliveries.id").
+			Limit(limit).
+			Pluck("webhook_deliveries.id", &ids)
+		if res.Error != nil || len(ids) == 0 {
+			return res.Error
+		}
+
+		res = tx.Model(&entities.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", leaseUntil)
+		if res.Error != nil {
+			return res.Error
+		}
+		return tx.Preload("Webhook").Order("id").Find(&deliveries, ids).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+var webhookDeliverySortColumns = map[string]string{
+	"id": "id",
+}
+
+func (r *webhookRepository) FindDeliveries(ctx context.Context, webhookId uint, query dto.ListWebhookDeliveriesRequest) ([]*entities.WebhookDelivery, *dto.Paging, error) {
+	page, err := newPageQuery(query.Limit, query.Cursor, query.SortBy, query.Order, webhookDeliverySortColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db := r.db.WithContext(ctx).Model(&entities.WebhookDelivery{}).Where("webhook_deliveries.webhook_id = ?", webhookId)
+	if query.Status != "" {
+		db = db.Where("webhook_deliveries.status = ?", query.Status)
+	}
+
+	var cursorID interface{}
+	if page.cursor != nil {
+		id, err := strconv.ParseUint(page.cursor.ID, 10, 64)
+		if err != nil {
+			return nil, nil, ErrInvalidCursor
+		}
+		cursorID = id
+	}
+
+	var deliveries []*entities.WebhookDelivery
+	res := page.apply(db, "webhook_deliveries", cursorID).Preload("Attempts", func(db *gorm.DB) *gorm.DB {
+		return db.Order("webhook_attempts.id")
+	}).Find(&deliveries)
+	if res.Error != nil {
+		return nil, nil, res.Error
+	}
+
+	count, paging := page.paging(len(deliveries), func(i int) (string, string) {
+		id := strconv.FormatUint(uint64(deliveries[i].ID), 10)
+		return id, id
+	})
+	return deliveries[:count], paging, nil
+}
+
+// RecordAttempt stores attempt and the delivery's new state together. A
+// failed attempt adds to the webhook's consecutive failures and deactivates
+// it once they reach disableAfter, which RecordAttempt reports; a successful
+// one resets the count.
+func (r *webhookRepository) RecordAttempt(ctx context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt, disableAfter int) (bool, error) {
+	disabled := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		attempt.WebhookDeliveryID = delivery.ID
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+
+		res := tx.Model(delivery).Select("Status", "AttemptCount", "NextAttemptAt", "LastStatusCode", "CompletedAt").Updates(delivery)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		webhooks := tx.Model(&entities.Webhook{}).Where("id = ?", delivery.WebhookID)
+		if delivery.Status == entities.WebhookDeliverySucceeded {
+			return webhooks.Update("consecutive_failures", 0).Error
+		}
+		if err := webhooks.Update("consecutive_failures", gorm.Expr("consecutive_failures + 1")).Error; err != nil {
+			return err
+		}
+
+		res = tx.Model(&entities.Webhook{}).
+			Where("id = ? AND active = ? AND consecutive_failures >= ?", delivery.WebhookID, true, disableAfter).
+			Updates(map[string]interface{}{"active": false, "disabled_at": time.Now()})
+		if res.Error != nil {
+			return res.Error
+		}
+		disabled = res.RowsAffected > 0
+		return nil
+	})
+	return disabled, err
+}
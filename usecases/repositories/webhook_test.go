@@ -0,0 +1,238 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+)
+
+type WebhookRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	ctx  context.Context
+	repo IWebhookRepository
+}
+
+func (suite *WebhookRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.Webhook{}, &entities.WebhookDelivery{}, &entities.WebhookAttempt{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), gormDB.Use(tenancy.Plugin{}))
+	suite.db = gormDB
+	suite.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
+	suite.repo = NewWebhookRepository(gormDB)
+}
+
+func (suite *WebhookRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestWebhookRepoSuite(t *testing.T) {
+	suite.Run(t, new(WebhookRepoSuite))
+}
+
+func (suite *WebhookRepoSuite) create(ctx context.Context, active bool) *entities.Webhook {
+	webhook := &entities.Webhook{
+		URL:        "https://example.com/hook",
+		Secret:     "whsec_test",
+		EventTypes: "user.created,user.deleted",
+		Active:     active,
+		CreatedBy:  "admin",
+	}
+	assert.NoError(suite.T(), suite.repo.Create(ctx, webhook))
+	return webhook
+}
+
+func (suite *WebhookRepoSuite) deliver(webhook *entities.Webhook, eventId string, nextAttemptAt time.Time) *entities.WebhookDelivery {
+	delivery := &entities.WebhookDelivery{
+		OrganizationID: webhook.OrganizationID,
+		WebhookID:      webhook.ID,
+		EventID:        eventId,
+		EventType:      "user.created",
+		Payload:        `{"id":"` + eventId + `"}`,
+		Status:         entities.WebhookDeliveryPending,
+		NextAttemptAt:  &nextAttemptAt,
+	}
+	assert.NoError(suite.T(), suite.repo.CreateDeliveries(context.Background(), []*entities.WebhookDelivery{delivery}))
+	return delivery
+}
+
+func (suite *WebhookRepoSuite) TestCreateAndFindById() {
+	webhook := suite.create(suite.ctx, true)
+	assert.Equal(suite.T(), "acme", webhook.OrganizationID)
+
+	found, err := suite.repo.FindById(suite.ctx, webhook.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"user.created", "user.deleted"}, found.Events())
+	assert.True(suite.T(), found.Subscribes("user.deleted"))
+	assert.False(suite.T(), found.Subscribes("scope.created"))
+
+	globex := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "globex"})
+	_, err = suite.repo.FindById(globex, webhook.ID)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *WebhookRepoSuite) TestFindAll() {
+	suite.create(suite.ctx, true)
+	suite.create(suite.ctx, true)
+	disabled := suite.create(suite.ctx, false)
+
+	webhooks, paging, err := suite.repo.FindAll(suite.ctx, dto.ListWebhooksRequest{Limit: 2})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), webhooks, 2)
+	assert.True(suite.T(), paging.HasMore)
+
+	active := false
+	webhooks, _, err = suite.repo.FindAll(suite.ctx, dto.ListWebhooksRequest{Active: &active})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), webhooks, 1)
+	assert.Equal(suite.T(), disabled.ID, webhooks[0].ID)
+
+	_, _, err = suite.repo.FindAll(suite.ctx, dto.ListWebhooksRequest{Cursor: "invalid"})
+	assert.ErrorIs(suite.T(), err, ErrInvalidCursor)
+}
+
+func (suite *WebhookRepoSuite) TestFindActive() {
+	active := suite.create(suite.ctx, true)
+	suite.create(suite.ctx, false)
+	globex := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "globex"})
+	suite.create(globex, true)
+
+	webhooks, err := suite.repo.FindActive(context.Background(), "acme")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), webhooks, 1)
+	assert.Equal(suite.T(), active.ID, webhooks[0].ID)
+}
+
+func (suite *WebhookRepoSuite) TestUpdate() {
+	webhook := suite.create(suite.ctx, true)
+
+	webhook.URL = "https://example.com/other"
+	webhook.Active = false
+	assert.NoError(suite.T(), suite.repo.Update(suite.ctx, webhook))
+
+	found, err := suite.repo.FindById(suite.ctx, webhook.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "https://example.com/other", found.URL)
+	assert.False(suite.T(), found.Active)
+
+	err = suite.repo.Update(suite.ctx, &entities.Webhook{ID: 42})
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *WebhookRepoSuite) TestDelete() {
+	webhook := suite.create(suite.ctx, true)
+
+	assert.NoError(suite.T(), suite.repo.Delete(suite.ctx, webhook.ID))
+	assert.ErrorIs(suite.T(), suite.repo.Delete(suite.ctx, webhook.ID), gorm.ErrRecordNotFound)
+}
+
+func (suite *WebhookRepoSuite) TestCreateDeliveriesSkipsDuplicates() {
+	webhook := suite.create(suite.ctx, true)
+	first := suite.deliver(webhook, "event1", time.Now())
+	suite.deliver(webhook, "event1", time.Now())
+
+	deliveries, _, err := suite.repo.FindDeliveries(suite.ctx, webhook.ID, dto.ListWebhookDeliveriesRequest{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), deliveries, 1)
+	assert.Equal(suite.T(), first.ID, deliveries[0].ID)
+}
+
+func (suite *WebhookRepoSuite) TestClaimDueDeliveries() {
+	webhook := suite.create(suite.ctx, true)
+	disabled := suite.create(suite.ctx, false)
+	now := time.Now()
+	due := suite.deliver(webhook, "event1", now.Add(-time.Minute))
+	suite.deliver(webhook, "event2", now.Add(time.Minute))
+	suite.deliver(disabled, "event3", now.Add(-time.Minute))
+
+	leaseUntil := now.Add(30 * time.Second)
+	deliveries, err := suite.repo.ClaimDueDeliveries(context.Background(), now, leaseUntil, 10)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), deliveries, 1)
+	assert.Equal(suite.T(), due.ID, deliveries[0].ID)
+	assert.Equal(suite.T(), "whsec_test", deliveries[0].Webhook.Secret)
+	assert.True(suite.T(), leaseUntil.Equal(*deliveries[0].NextAttemptAt))
+
+	// A leased delivery is not claimed again until its lease runs out.
+	deliveries, err = suite.repo.ClaimDueDeliveries(context.Background(), now, leaseUntil, 10)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), deliveries)
+
+	deliveries, err = suite.repo.ClaimDueDeliveries(context.Background(), leaseUntil, leaseUntil.Add(30*time.Second), 10)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), deliveries, 1)
+	assert.Equal(suite.T(), due.ID, deliveries[0].ID)
+}
+
+func (suite *WebhookRepoSuite) TestRecordAttempt() {
+	webhook := suite.create(suite.ctx, true)
+	delivery := suite.deliver(webhook, "event1", time.Now())
+
+	for i := 1; i <= 2; i++ {
+		next := time.Now().Add(time.Minute)
+		delivery.AttemptCount = i
+		delivery.NextAttemptAt = &next
+		disabled, err := suite.repo.RecordAttempt(context.Background(), delivery, &entities.WebhookAttempt{StatusCode: 500, LatencyMs: 12}, 3)
+		assert.NoError(suite.T(), err)
+		assert.False(suite.T(), disabled)
+	}
+
+	delivery.AttemptCount = 3
+	delivery.Status = entities.WebhookDeliveryFailed
+	delivery.NextAttemptAt = nil
+	disabled, err := suite.repo.RecordAttempt(context.Background(), delivery, &entities.WebhookAttempt{Error: "connection refused", LatencyMs: 3}, 3)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), disabled)
+
+	found, err := suite.repo.FindById(suite.ctx, webhook.ID)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), found.Active)
+	assert.NotNil(suite.T(), found.DisabledAt)
+	assert.Equal(suite.T(), 3, found.ConsecutiveFailures)
+
+	deliveries, _, err := suite.repo.FindDeliveries(suite.ctx, webhook.ID, dto.ListWebhookDeliveriesRequest{Status: entities.WebhookDeliveryFailed})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), deliveries, 1)
+	assert.Equal(suite.T(), 3, deliveries[0].AttemptCount)
+	assert.Nil(suite.T(), deliveries[0].NextAttemptAt)
+	assert.Len(suite.T(), deliveries[0].Attempts, 3)
+	assert.Equal(suite.T(), 500, deliveries[0].Attempts[0].StatusCode)
+	assert.Equal(suite.T(), "connection refused", deliveries[0].Attempts[2].Error)
+}
+
+func (suite *WebhookRepoSuite) TestRecordAttemptSuccessResetsFailures() {
+	webhook := suite.create(suite.ctx, true)
+	delivery := suite.deliver(webhook, "event1", time.Now())
+	assert.NoError(suite.T(), suite.db.Model(webhook).Update("consecutive_failures", 2).Error)
+
+	status := 204
+	delivery.AttemptCount = 1
+	delivery.Status = entities.WebhookDeliverySucceeded
+	delivery.LastStatusCode = &status
+	delivery.NextAttemptAt = nil
+	disabled, err := suite.repo.RecordAttempt(context.Background(), delivery, &entities.WebhookAttempt{StatusCode: status, LatencyMs: 5}, 3)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), disabled)
+
+	found, err := suite.repo.FindById(suite.ctx, webhook.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, found.ConsecutiveFailures)
+	assert.True(suite.T(), found.Active)
+}
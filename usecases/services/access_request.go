@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/scopes"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+)
+
+// IAccessRequestService runs the access request workflow. Callers pass
+// reviewer when the caller may decide requests; requesters may only see,
+// comment on and cancel their own requests.
+type IAccessRequestService interface {
+	Create(ctx context.Context, requesterId, scopeName, justification string, grantExpiresAt *time.Time) (*entities.AccessRequest, error)
+	FindOne(ctx context.Context, requestId uint, callerId string, reviewer bool) (*entities.AccessRequest, error)
+	FindAll(ctx context.Context, query dto.ListAccessRequestsRequest) ([]*entities.AccessRequest, *dto.Paging, error)
+	Approve(ctx context.Context, requestId uint, reviewerId string, reviewerScopes []string, comment string) (*entities.AccessRequest, error)
+	Reject(ctx context.Context, requestId uint, reviewerId, comment string) (*entities.AccessRequest, error)
+	Cancel(ctx context.Context, requestId uint, requesterId string) (*entities.AccessRequest, error)
+	Comment(ctx context.Context, requestId uint, authorId string, reviewer bool, body string) (*entities.AccessRequestComment, error)
+}
+
+type accessRequestService struct {
+	accessRequestRepo repositories.IAccessRequestRepository
+	scopeService      IScopeService
+	userService       IUserService
+	ttl               time.Duration
+	logger            logger.ILogger
+}
+
+// NewAccessRequestService builds the workflow. Pending requests expire ttl
+// after they are submitted.
+func NewAccessRequestService(accessRequestRepo repositories.IAccessRequestRepository, scopeService IScopeService, userService IUserService, ttl time.Duration, logger logger.ILogger) IAccessRequestService {
+	return &accessRequestService{
+		accessRequestRepo: accessRequestRepo,
+		scopeService:      scopeService,
+		userService:       userService,
+		ttl:               ttl,
+		logger:            logger,
+	}
+}
+
+func (s *accessRequestService) Create(ctx context.Context, requesterId, scopeName, justification string, grantExpiresAt *time.Time) (*entities.AccessRequest, error) {
+	now := time.Now()
+	if grantExpiresAt != nil && !grantExpiresAt.After(now) {
+		s.logger.Warn("grant expiry rejected", zap.String("scope", scopeName), zap.Time("expires_at", *grantExpiresAt))
+		return nil, apperrors.Validation(dto.CodeInvalidGrantExpiry, "grant expiry must be in the future", nil)
+	}
+
+	if _, err := s.scopeService.FindOne(ctx, scopeName); err != nil {
+		return nil, err
+	}
+
+	request, err := s.accessRequestRepo.Create(ctx, requesterId, scopeName, justification, grantExpiresAt, now.Add(s.ttl))
+	if err != nil {
+		s.logger.Error("failed to create access request", zap.Error(err))
+		return nil, repositoryError(err, dto.CodeAccessRequestNotFound, dto.CodeAccessRequestExists)
+	}
+
+	s.logger.Info("new access request submitted successfully", zap.Uint("id", request.ID))
+	return request, nil
+}
+
+func (s *accessRequestService) FindOne(ctx context.Context, requestId uint, callerId string, reviewer bool) (*entities.AccessRequest, error) {
+	request, err := s.findRequest(ctx, requestId)
+	if err != nil {
+		return nil, err
+	}
+	if !reviewer && request.RequesterID != callerId {
+		return nil, s.forbidden(requestId, callerId, "only the requester and reviewers may view an access request")
+	}
+
+	s.logger.Info("access request found successfully")
+	return request, nil
+}
+
+func (s *accessRequestService) FindAll(ctx context.Context, query dto.ListAccessRequestsRequest) ([]*entities.AccessRequest, *dto.Paging, error) {
+	requests, paging, err := s.accessRequestRepo.FindAll(ctx, query)
+	if err != nil {
+		s.logger.Error("failed to find all access requests", zap.Error(err))
+		return nil, nil, repositoryError(err, dto.CodeAccessRequestNotFound, dto.CodeAccessRequestExists)
+	}
+
+	s.logger.Info("all access requests retrieved successfully")
+	return requests, paging, nil
+}
+
+// Approve grants the requested scope through IUserService.UpdateScope, with
+// the requested grant expiry, and then marks the request approved. Should
+// another decision land in between, the grant stays in place and the caller
+// gets ACCESS_REQUEST_NOT_PENDING. Reviewers may only approve scopes their
+// own scopes grant, directly or through a wildcard, so that approving cannot
+// hand out more than the reviewer holds.
+func (s *accessRequestService) Approve(ctx context.Context, requestId uint, reviewerId string, reviewerScopes []string, comment string) (*entities.AccessRequest, error) {
+	request, err := s.reviewable(ctx, requestId, reviewerId)
+	if err != nil {
+		return nil, err
+	}
+	if !scopes.Grants(reviewerScopes, request.Scope) {
+		return nil, s.forbidden(requestId, reviewerId, "reviewers can only approve scopes they hold")
+	}
+
+	scope, err := s.scopeService.FindOne(ctx, request.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userService.UpdateScope(ctx, request.RequesterID, scope, true, request.GrantExpiresAt); err != nil {
+		return nil, err
+	}
+
+	return s.decide(ctx, request, entities.AccessRequestApproved, reviewerId, comment)
+}
+
+func (s *accessRequestService) Reject(ctx context.Context, requestId uint, reviewerId, comment string) (*entities.AccessRequest, error) {
+	request, err := s.reviewable(ctx, requestId, reviewerId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decide(ctx, request, entities.AccessRequestRejected, reviewerId, comment)
+}
+
+func (s *accessRequestService) Cancel(ctx context.Context, requestId uint, requesterId string) (*entities.AccessRequest, error) {
+	request, err := s.findPending(ctx, requestId)
+	if err != nil {
+		return nil, err
+	}
+	if request.RequesterID != requesterId {
+		return nil, s.forbidden(requestId, requesterId, "only the requester may cancel an access request")
+	}
+
+	return s.decide(ctx, request, entities.AccessRequestCancelled, requesterId, "")
+}
+
+func (s *accessRequestService) Comment(ctx context.Context, requestId uint, authorId string, reviewer bool, body string) (*entities.AccessRequestComment, error) {
+	request, err := s.findRequest(ctx, requestId)
+	if err != nil {
+		return nil, err
+	}
+	if !reviewer && request.RequesterID != authorId {
+		return nil, s.forbidden(requestId, authorId, "only the requester and reviewers may comment on an access request")
+	}
+
+	comment, err := s.accessRequestRepo.AddComment(ctx, requestId, authorId, body)
+	if err != nil {
+		s.logger.Error("failed to add access request comment", zap.Error(err))
+		return nil, repositoryError(err, dto.CodeAccessRequestNotFound, dto.CodeAccessRequestExists)
+	}
+
+	s.logger.Info("access request comment added successfully", zap.Uint("id", requestId))
+	return comment, nil
+}
+
+// reviewable loads a pending request and refuses to let requesters decide
+// their own.
+func (s *accessRequestService) reviewable(ctx context.Context, requestId uint, reviewerId string) (*entities.AccessRequest, error) {
+	request, err := s.findPending(ctx, requestId)
+	if err != nil {
+		return nil, err
+	}
+	if request.RequesterID == reviewerId {
+		return nil, s.forbidden(requestId, reviewerId, "requesters cannot review their own access request")
+	}
+	return request, nil
+}
+
+func (s *accessRequestService) decide(ctx context.Context, request *entities.AccessRequest, status, actorId, comment string) (*entities.AccessRequest, error) {
+	if err := s.accessRequestRepo.Decide(ctx, request.ID, status, actorId, comment); err != nil {
+		if errors.Is(err, repositories.ErrAccessRequestNotPending) {
+			s.logger.Warn("access request decided concurrently", zap.Uint("id", request.ID))
+			return nil, apperrors.Conflict(dto.CodeAccessRequestNotPending, "access request is no longer pending", err)
+		}
+		s.logger.Error("failed to decide access request", zap.Error(err))
+		return nil, repositoryError(err, dto.CodeAccessRequestNotFound, dto.CodeAccessRequestExists)
+	}
+
+	decided, err := s.findRequest(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("access request decided successfully", zap.Uint("id", request.ID), zap.String("status", status))
+	return decided, nil
+}
+
+func (s *accessRequestService) findPending(ctx context.Context, requestId uint) (*entities.AccessRequest, error) {
+	request, err := s.findRequest(ctx, requestId)
+	if err != nil {
+		return nil, err
+	}
+	if !request.Pending(time.Now()) {
+		s.logger.Warn("access request is not pending", zap.Uint("id", requestId), zap.String("status", request.Status))
+		return nil, apperrors.Conflict(dto.CodeAccessRequestNotPending, "access request is no longer pending", nil)
+	}
+	return request, nil
+}
+
+func (s *accessRequestService) findRequest(ctx context.Context, requestId uint) (*entities.AccessRequest, error) {
+	request, err := s.accessRequestRepo.FindById(ctx, requestId)
+	if err != nil {
+		s.logger.Error("failed to find access request", zap.Uint("id", requestId), zap.Error(err))
+		return nil, repositoryError(err, dto.CodeAccessRequestNotFound, dto.CodeAccessRequestExists)
+	}
+	return request, nil
+}
+
+func (s *accessRequestService) forbidden(requestId uint, callerId, message string) error {
+	s.logger.Warn("access request action rejected", zap.Uint("id", requestId), zap.String("caller", callerId))
+	return apperrors.Forbidden(dto.CodeForbidden, message, nil)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+)
+
+// IAccessRequestReaper expires access requests left pending past their
+// deadline. Run reaps on every tick of interval until ctx is cancelled; Reap
+// does a single pass.
+type IAccessRequestReaper interface {
+	Run(ctx context.Context, interval time.Duration)
+	Reap(ctx context.Context) error
+}
+
+type accessRequestReaper struct {
+	accessRequestRepo repositories.IAccessRequestRepository
+	logger            logger.ILogger
+}
+
+func NewAccessRequestReaper(accessRequestRepo repositories.IAccessRequestRepository, logger logger.ILogger) IAccessRequestReaper {
+	return &accessRequestReaper{
+		accessRequestRepo: accessRequestRepo,
+		logger:            logger,
+	}
+}
+
+func (r *accessRequestReaper) Run(ctx context.Context, interval time.Duration) {
+	runEvery(ctx, interval, func(ctx context.Context) {
+		r.Reap(ctx)
+	})
+}
+
+func (r *accessRequestReaper) Reap(ctx context.Context) error {
+	expired, err := r.accessRequestRepo.ExpireStale(ctx, time.Now())
+	if err != nil {
+		r.logger.Error("failed to expire stale access requests", zap.Error(err))
+		return err
+	}
+	if expired == 0 {
+		return nil
+	}
+
+	r.logger.Info("stale access requests expired successfully", zap.Int64("count", expired))
+	return nil
+}
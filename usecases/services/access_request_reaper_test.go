@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+)
+
+type AccessRequestReaperSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	accessRequestReaper IAccessRequestReaper
+	mockRepo            *repositories.MockIAccessRequestRepository
+	logger              *logger.MockILogger
+	ctx                 context.Context
+}
+
+func (s *AccessRequestReaperSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIAccessRequestRepository(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.accessRequestReaper = NewAccessRequestReaper(s.mockRepo, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *AccessRequestReaperSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestAccessRequestReaperSuite(t *testing.T) {
+	suite.Run(t, new(AccessRequestReaperSuite))
+}
+
+func (s *AccessRequestReaperSuite) TestReap() {
+	s.mockRepo.EXPECT().ExpireStale(s.ctx, gomock.Any()).Return(int64(3), nil)
+	s.logger.EXPECT().Info("stale access requests expired successfully", gomock.Any()).Times(1)
+
+	s.NoError(s.accessRequestReaper.Reap(s.ctx))
+}
+
+func (s *AccessRequestReaperSuite) TestReapNothingStale() {
+	s.mockRepo.EXPECT().ExpireStale(s.ctx, gomock.Any()).Return(int64(0), nil)
+
+	s.NoError(s.accessRequestReaper.Reap(s.ctx))
+}
+
+func (s *AccessRequestReaperSuite) TestReapRepoError() {
+	s.mockRepo.EXPECT().ExpireStale(s.ctx, gomock.Any()).Return(int64(0), errors.New("db error"))
+	s.logger.EXPECT().Error("failed to expire stale access requests", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.accessRequestReaper.Reap(s.ctx), "db error")
+}
+
+func (s *AccessRequestReaperSuite) TestRunStopsWithContext() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.mockRepo.EXPECT().ExpireStale(ctx, gomock.Any()).DoAndReturn(func(context.Context, time.Time) (int64, error) {
+		cancel()
+		return 0, nil
+	}).MinTimes(1)
+
+	done := make(chan struct{})
+	go func() {
+		s.accessRequestReaper.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("reaper did not stop after the context was cancelled")
+	}
+}
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/services"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	repos "github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+)
+
+type AccessRequestServiceSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	accessRequestService IAccessRequestService
+	mockRepo             *repositories.MockIAccessRequestRepository
+	mockScopeService     *services.MockIScopeService
+	mockUserService      *services.MockIUserService
+	logger               *logger.MockILogger
+	ctx                  context.Context
+}
+
+func (s *AccessRequestServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIAccessRequestRepository(s.ctrl)
+	s.mockScopeService = services.NewMockIScopeService(s.ctrl)
+	s.mockUserService = services.NewMockIUserService(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.accessRequestService = NewAccessRequestService(s.mockRepo, s.mockScopeService, s.mockUserService, time.Hour, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *AccessRequestServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestAccessRequestServiceSuite(t *testing.T) {
+	suite.Run(t, new(AccessRequestServiceSuite))
+}
+
+func (s *AccessRequestServiceSuite) pending() *entities.AccessRequest {
+	return &entities.AccessRequest{
+		ID:          1,
+		RequesterID: "alice",
+		Scope:       "user:view",
+		Status:      entities.AccessRequestPending,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+}
+
+func (s *AccessRequestServiceSuite) assertCode(err error, code string) {
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *AccessRequestServiceSuite) TestCreate() {
+	grantExpiresAt := time.Now().Add(24 * time.Hour)
+	expected := s.pending()
+
+	s.mockScopeService.EXPECT().FindOne(s.ctx, "user:view").Return(&entities.UserScope{Name: "user:view"}, nil)
+	s.mockRepo.EXPECT().Create(s.ctx, "alice", "user:view", "on-call", &grantExpiresAt, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _ string, _ *time.Time, expiresAt time.Time) (*entities.AccessRequest, error) {
+			s.WithinDuration(time.Now().Add(time.Hour), expiresAt, time.Minute)
+			return expected, nil
+		})
+	s.logger.EXPECT().Info("new access request submitted successfully", gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Create(s.ctx, "alice", "user:view", "on-call", &grantExpiresAt)
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *AccessRequestServiceSuite) TestCreatePastGrantExpiry() {
+	past := time.Now().Add(-time.Hour)
+	s.logger.EXPECT().Warn("grant expiry rejected", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Create(s.ctx, "alice", "user:view", "on-call", &past)
+	s.Nil(result)
+	s.assertCode(err, dto.CodeInvalidGrantExpiry)
+}
+
+func (s *AccessRequestServiceSuite) TestCreateScopeNotFound() {
+	s.mockScopeService.EXPECT().FindOne(s.ctx, "user:view").Return(nil, apperrors.NotFound(dto.CodeScopeNotFound, "record not found", gorm.ErrRecordNotFound))
+
+	result, err := s.accessRequestService.Create(s.ctx, "alice", "user:view", "on-call", nil)
+	s.Nil(result)
+	s.assertCode(err, dto.CodeScopeNotFound)
+}
+
+func (s *AccessRequestServiceSuite) TestCreateDuplicate() {
+	s.mockScopeService.EXPECT().FindOne(s.ctx, "user:view").Return(&entities.UserScope{Name: "user:view"}, nil)
+	s.mockRepo.EXPECT().Create(s.ctx, "alice", "user:view", "on-call", nil, gomock.Any()).Return(nil, gorm.ErrDuplicatedKey)
+	s.logger.EXPECT().Error("failed to create access request", gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Create(s.ctx, "alice", "user:view", "on-call", nil)
+	s.Nil(result)
+	s.assertCode(err, dto.CodeAccessRequestExists)
+}
+
+func (s *AccessRequestServiceSuite) TestFindOne() {
+	request := s.pending()
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(request, nil).Times(2)
+	s.logger.EXPECT().Info("access request found successfully").Times(2)
+
+	result, err := s.accessRequestService.FindOne(s.ctx, 1, "alice", false)
+	s.NoError(err)
+	s.Equal(request, result)
+
+	result, err = s.accessRequestService.FindOne(s.ctx, 1, "admin", true)
+	s.NoError(err)
+	s.Equal(request, result)
+}
+
+func (s *AccessRequestServiceSuite) TestFindOneForbidden() {
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(s.pending(), nil)
+	s.logger.EXPECT().Warn("access request action rejected", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.FindOne(s.ctx, 1, "bob", false)
+	s.Nil(result)
+	s.assertCode(err, dto.CodeForbidden)
+}
+
+func (s *AccessRequestServiceSuite) TestFindOneNotFound() {
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find access request", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.FindOne(s.ctx, 1, "alice", false)
+	s.Nil(result)
+	s.assertCode(err, dto.CodeAccessRequestNotFound)
+}
+
+func (s *AccessRequestServiceSuite) TestFindAll() {
+	query := dto.ListAccessRequestsRequest{Status: entities.AccessRequestPending}
+	expected := []*entities.AccessRequest{s.pending()}
+	paging := &dto.Paging{}
+
+	s.mockRepo.EXPECT().FindAll(s.ctx, query).Return(expected, paging, nil)
+	s.logger.EXPECT().Info("all access requests retrieved successfully").Times(1)
+
+	result, resultPaging, err := s.accessRequestService.FindAll(s.ctx, query)
+	s.NoError(err)
+	s.Equal(expected, result)
+	s.Equal(paging, resultPaging)
+}
+
+func (s *AccessRequestServiceSuite) TestFindAllError() {
+	s.mockRepo.EXPECT().FindAll(s.ctx, gomock.Any()).Return(nil, nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find all access requests", gomock.Any()).Times(1)
+
+	result, paging, err := s.accessRequestService.FindAll(s.ctx, dto.ListAccessRequestsRequest{})
+	s.Nil(result)
+	s.Nil(paging)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *AccessRequestServiceSuite) TestApprove() {
+	grantExpiresAt := time.Now().Add(24 * time.Hour)
+	request := s.pending()
+	request.GrantExpiresAt = &grantExpiresAt
+	scope := &entities.UserScope{ID: 7, Name: "user:view"}
+	approved := s.pending()
+	approved.Status = entities.AccessRequestApproved
+
+	gomock.InOrder(
+		s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(request, nil),
+		s.mockScopeService.EXPECT().FindOne(s.ctx, "user:view").Return(scope, nil),
+		s.mockUserService.EXPECT().UpdateScope(s.ctx, "alice", scope, true, &grantExpiresAt).Return(nil),
+		s.mockRepo.EXPECT().Decide(s.ctx, uint(1), entities.AccessRequestApproved, "admin", "ok").Return(nil),
+		s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(approved, nil),
+	)
+	s.logger.EXPECT().Info("access request decided successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Approve(s.ctx, 1, "admin", []string{"scope:manage", "user:*"}, "ok")
+	s.NoError(err)
+	s.Equal(approved, result)
+}
+
+func (s *AccessRequestServiceSuite) TestApproveOwnRequest() {
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(s.pending(), nil)
+	s.logger.EXPECT().Warn("access request action rejected", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Approve(s.ctx, 1, "alice", []string{"scope:manage", "user:view"}, "")
+	s.Nil(result)
+	s.assertCode(err, dto.CodeForbidden)
+}
+
+func (s *AccessRequestServiceSuite) TestApproveScopeNotHeld() {
+	request := s.pending()
+	request.Scope = "organization:manage"
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(request, nil)
+	s.logger.EXPECT().Warn("access request action rejected", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Approve(s.ctx, 1, "admin", []string{"scope:manage", "user:*"}, "")
+	s.Nil(result)
+	s.assertCode(err, dto.CodeForbidden)
+}
+
+func (s *AccessRequestServiceSuite) TestApproveNotPending() {
+	for _, request := range []*entities.AccessRequest{
+		{ID: 1, RequesterID: "alice", Status: entities.AccessRequestRejected, ExpiresAt: time.Now().Add(time.Hour)},
+		{ID: 1, RequesterID: "alice", Status: entities.AccessRequestPending, ExpiresAt: time.Now().Add(-time.Minute)},
+	} {
+		s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(request, nil)
+		s.logger.EXPECT().Warn("access request is not pending", gomock.Any(), gomock.Any()).Times(1)
+
+		result, err := s.accessRequestService.Approve(s.ctx, 1, "admin", []string{"scope:manage", "user:view"}, "")
+		s.Nil(result)
+		s.assertCode(err, dto.CodeAccessRequestNotPending)
+	}
+}
+
+func (s *AccessRequestServiceSuite) TestApproveGrantFails() {
+	scope := &entities.UserScope{ID: 7, Name: "user:view"}
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(s.pending(), nil)
+	s.mockScopeService.EXPECT().FindOne(s.ctx, "user:view").Return(scope, nil)
+	s.mockUserService.EXPECT().UpdateScope(s.ctx, "alice", scope, true, nil).Return(errors.New("db error"))
+
+	result, err := s.accessRequestService.Approve(s.ctx, 1, "admin", []string{"scope:manage", "user:view"}, "")
+	s.Nil(result)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *AccessRequestServiceSuite) TestApproveDecidedConcurrently() {
+	scope := &entities.UserScope{ID: 7, Name: "user:view"}
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(s.pending(), nil)
+	s.mockScopeService.EXPECT().FindOne(s.ctx, "user:view").Return(scope, nil)
+	s.mockUserService.EXPECT().UpdateScope(s.ctx, "alice", scope, true, nil).Return(nil)
+	s.mockRepo.EXPECT().Decide(s.ctx, uint(1), entities.AccessRequestApproved, "admin", "").Return(repos.ErrAccessRequestNotPending)
+	s.logger.EXPECT().Warn("access request decided concurrently", gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Approve(s.ctx, 1, "admin", []string{"scope:manage", "user:view"}, "")
+	s.Nil(result)
+	s.assertCode(err, dto.CodeAccessRequestNotPending)
+}
+
+func (s *AccessRequestServiceSuite) TestReject() {
+	rejected := s.pending()
+	rejected.Status = entities.AccessRequestRejected
+
+	gomock.InOrder(
+		s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(s.pending(), nil),
+		s.mockRepo.EXPECT().Decide(s.ctx, uint(1), entities.AccessRequestRejected, "admin", "not needed").Return(nil),
+		s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(rejected, nil),
+	)
+	s.logger.EXPECT().Info("access request decided successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Reject(s.ctx, 1, "admin", "not needed")
+	s.NoError(err)
+	s.Equal(rejected, result)
+}
+
+func (s *AccessRequestServiceSuite) TestRejectDecideError() {
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(s.pending(), nil)
+	s.mockRepo.EXPECT().Decide(s.ctx, uint(1), entities.AccessRequestRejected, "admin", "").Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to decide access request", gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Reject(s.ctx, 1, "admin", "")
+	s.Nil(result)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *AccessRequestServiceSuite) TestCancel() {
+	cancelled := s.pending()
+	cancelled.Status = entities.AccessRequestCancelled
+
+	gomock.InOrder(
+		s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(s.pending(), nil),
+		s.mockRepo.EXPECT().Decide(s.ctx, uint(1), entities.AccessRequestCancelled, "alice", "").Return(nil),
+		s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(cancelled, nil),
+	)
+	s.logger.EXPECT().Info("access request decided successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Cancel(s.ctx, 1, "alice")
+	s.NoError(err)
+	s.Equal(cancelled, result)
+}
+
+func (s *AccessRequestServiceSuite) TestCancelNotRequester() {
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(s.pending(), nil)
+	s.logger.EXPECT().Warn("access request action rejected", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Cancel(s.ctx, 1, "bob")
+	s.Nil(result)
+	s.assertCode(err, dto.CodeForbidden)
+}
+
+func (s *AccessRequestServiceSuite) TestComment() {
+	comment := &entities.AccessRequestComment{ID: 3, AccessRequestID: 1, AuthorID: "admin", Body: "why?"}
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(s.pending(), nil)
+	s.mockRepo.EXPECT().AddComment(s.ctx, uint(1), "admin", "why?").Return(comment, nil)
+	s.logger.EXPECT().Info("access request comment added successfully", gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Comment(s.ctx, 1, "admin", true, "why?")
+	s.NoError(err)
+	s.Equal(comment, result)
+}
+
+func (s *AccessRequestServiceSuite) TestCommentForbidden() {
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(s.pending(), nil)
+	s.logger.EXPECT().Warn("access request action rejected", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Comment(s.ctx, 1, "bob", false, "me too")
+	s.Nil(result)
+	s.assertCode(err, dto.CodeForbidden)
+}
+
+func (s *AccessRequestServiceSuite) TestCommentError() {
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(s.pending(), nil)
+	s.mockRepo.EXPECT().AddComment(s.ctx, uint(1), "alice", "please").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to add access request comment", gomock.Any()).Times(1)
+
+	result, err := s.accessRequestService.Comment(s.ctx, 1, "alice", false, "please")
+	s.Nil(result)
+	s.ErrorContains(err, "db error")
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/audit"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IAuditService interface {
+	FindAll(ctx context.Context, query dto.ListAuditEntriesRequest) ([]*entities.AuditEntry, *dto.Paging, error)
+}
+
+type auditService struct {
+	auditRepo repositories.IAuditRepository
+	logger    logger.ILogger
+}
+
+func NewAuditService(auditRepo repositories.IAuditRepository, logger logger.ILogger) IAuditService {
+	return &auditService{
+		auditRepo: auditRepo,
+		logger:    logger,
+	}
+}
+
+func (s *auditService) FindAll(ctx context.Context, query dto.ListAuditEntriesRequest) ([]*entities.AuditEntry, *dto.Paging, error) {
+	entries, paging, err := s.auditRepo.FindAll(ctx, query)
+	if err != nil {
+		s.logger.Error("failed to find all audit entries", zap.Error(err))
+		return nil, nil, repositoryError(err, dto.CodeInternalServerError, dto.CodeInternalServerError)
+	}
+
+	s.logger.Info("all audit entries retrieved successfully")
+	return entries, paging, nil
+}
+
+// recordAudit appends entry, which names the action and its target, with the
+// origin of the request in ctx and the target's state before and after the
+// change. A nil state is left empty. Pass a repository bound to the
+// change's transaction.
+func recordAudit(ctx context.Context, auditRepo repositories.IAuditRepository, entry *entities.AuditEntry, before, after interface{}) error {
+	origin, _ := audit.FromContext(ctx)
+	entry.ActorID = origin.ActorID
+	entry.RequestID = origin.RequestID
+	entry.SourceIP = origin.SourceIP
+
+	var err error
+	if entry.Before, err = auditState(before); err != nil {
+		return err
+	}
+	if entry.After, err = auditState(after); err != nil {
+		return err
+	}
+	return auditRepo.Create(ctx, entry)
+}
+
+func auditState(state interface{}) (*string, error) {
+	if state == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	value := string(raw)
+	return &value, nil
+}
+
+// userSnapshot is the audited state of a user: its direct grants and roles,
+// never its password hash.
+type userSnapshot struct {
+	ID            string               `json:"id"`
+	Username      string               `json:"username"`
+	Email         string               `json:"email"`
+	Scopes        []string             `json:"scopes"`
+	ScopeExpiries map[string]time.Time `json:"scope_expiries,omitempty"`
+	Roles         []string             `json:"roles"`
+}
+
+func newUserSnapshot(user *entities.User, scopes []*entities.UserScope, expiries map[string]time.Time, roles []*entities.Role) *userSnapshot {
+	snapshot := &userSnapshot{
+		ID:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Scopes:        make([]string, 0, len(scopes)),
+		ScopeExpiries: expiries,
+		Roles:         make([]string, 0, len(roles)),
+	}
+	for _, scope := range scopes {
+		snapshot.Scopes = append(snapshot.Scopes, scope.Name)
+	}
+	for _, role := range roles {
+		snapshot.Roles = append(snapshot.Roles, role.Name)
+	}
+	return snapshot
+}
+
+// scopeExpiries maps each of the user's expiring direct scopes to its
+// expiry.
+func scopeExpiries(user *entities.User) map[string]time.Time {
+	expiries := make(map[string]time.Time)
+	for _, scope := range user.Scopes {
+		if expiry := user.ScopeExpiry(scope.ID); expiry != nil {
+			expiries[scope.Name] = *expiry
+		}
+	}
+	return expiries
+}
+
+type scopeSnapshot struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+func newScopeSnapshot(scope *entities.UserScope) *scopeSnapshot {
+	return &scopeSnapshot{ID: scope.ID, Name: scope.Name}
+}
+
+// commitAudited records entry in tx and commits it, so the change made in tx
+// and its audit entry are stored together or not at all. tx is rolled back
+// when the entry cannot be recorded.
+func commitAudited(ctx context.Context, tx *gorm.DB, auditRepo repositories.IAuditRepository, logger logger.ILogger, entry *entities.AuditEntry, before, after interface{}) error {
+	if err := recordAudit(ctx, auditRepo.WithTransaction(tx), entry, before, after); err != nil {
+		logger.Error("failed to record audit entry", zap.Error(err))
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit().Error; err != nil {
+		logger.Error("failed to commit transaction", zap.Error(err))
+		return err
+	}
+	return nil
+}
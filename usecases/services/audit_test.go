@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/audit"
+	repos "github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+)
+
+type AuditServiceSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	auditService IAuditService
+	mockRepo     *repositories.MockIAuditRepository
+	logger       *logger.MockILogger
+	ctx          context.Context
+}
+
+func (s *AuditServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIAuditRepository(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.auditService = NewAuditService(s.mockRepo, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *AuditServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestAuditServiceSuite(t *testing.T) {
+	suite.Run(t, new(AuditServiceSuite))
+}
+
+func (s *AuditServiceSuite) TestFindAll() {
+	query := dto.ListAuditEntriesRequest{ActorID: "admin"}
+	expected := []*entities.AuditEntry{{ID: 1, ActorID: "admin", Action: entities.AuditUserCreate}}
+	paging := &dto.Paging{Limit: 20}
+
+	s.mockRepo.EXPECT().FindAll(s.ctx, query).Return(expected, paging, nil)
+	s.logger.EXPECT().Info("all audit entries retrieved successfully").Times(1)
+
+	result, resultPaging, err := s.auditService.FindAll(s.ctx, query)
+	s.NoError(err)
+	s.Equal(expected, result)
+	s.Equal(paging, resultPaging)
+}
+
+func (s *AuditServiceSuite) TestFindAllInvalidCursor() {
+	s.mockRepo.EXPECT().FindAll(s.ctx, gomock.Any()).Return(nil, nil, repos.ErrInvalidCursor)
+	s.logger.EXPECT().Error("failed to find all audit entries", gomock.Any()).Times(1)
+
+	result, paging, err := s.auditService.FindAll(s.ctx, dto.ListAuditEntriesRequest{Cursor: "invalid"})
+	s.Nil(result)
+	s.Nil(paging)
+	s.True(apperrors.IsKind(err, apperrors.KindBadRequest))
+}
+
+func (s *AuditServiceSuite) TestRecordAudit() {
+	ctx := audit.WithOrigin(s.ctx, audit.Origin{ActorID: "admin", RequestID: "req-1", SourceIP: "10.0.0.1"})
+	entry := &entities.AuditEntry{Action: entities.AuditScopeCreate, TargetType: entities.AuditTargetScope, TargetID: "1"}
+
+	s.mockRepo.EXPECT().Create(ctx, entry).Return(nil)
+
+	s.NoError(recordAudit(ctx, s.mockRepo, entry, nil, map[string]string{"name": "report:mail"}))
+	s.Equal("admin", entry.ActorID)
+	s.Equal("req-1", entry.RequestID)
+	s.Equal("10.0.0.1", entry.SourceIP)
+	s.Nil(entry.Before)
+	s.JSONEq(`{"name":"report:mail"}`, *entry.After)
+}
+
+func (s *AuditServiceSuite) TestRecordAuditWithoutOrigin() {
+	entry := &entities.AuditEntry{Action: entities.AuditUserScopeUpdate, TargetType: entities.AuditTargetUser, TargetID: "user1"}
+
+	s.mockRepo.EXPECT().Create(s.ctx, entry).Return(errors.New("db error"))
+
+	s.ErrorContains(recordAudit(s.ctx, s.mockRepo, entry, nil, nil), "db error")
+	s.Empty(entry.ActorID)
+	s.Empty(entry.RequestID)
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/scopes"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// effectiveScopesKeyPrefix keys the cached effective scopes of a user. Every
+// change to a user's scopes, roles or groups must drop the entry through
+// invalidateEffectiveScopes.
+const effectiveScopesKeyPrefix = "authz:scopes:"
+
+// IAuthzService decides whether a user holds a set of scopes, from the
+// current grants rather than those baked into a token, so other services can
+// authorize against current state.
+type IAuthzService interface {
+	Check(ctx context.Context, check dto.AuthzCheckRequest) (*dto.AuthzDecision, error)
+	CheckBatch(ctx context.Context, checks []dto.AuthzCheckRequest) ([]*dto.AuthzDecision, error)
+}
+
+// cachedScopes keeps the organization next to the scopes, so a cached entry
+// is not served outside the user's tenant.
+type cachedScopes struct {
+	OrganizationID string   `json:"organization_id"`
+	Scopes         []string `json:"scopes"`
+}
+
+type authzService struct {
+	userRepo    repositories.IUserRepository
+	redisClient interfaces.IRedisClient
+	cacheTTL    time.Duration
+	logger      logger.ILogger
+}
+
+// NewAuthzService caches effective scopes for cacheTTL; zero disables the
+// cache.
+func NewAuthzService(userRepo repositories.IUserRepository, redisClient interfaces.IRedisClient, cacheTTL time.Duration, logger logger.ILogger) IAuthzService {
+	return &authzService{
+		userRepo:    userRepo,
+		redisClient: redisClient,
+		cacheTTL:    cacheTTL,
+		logger:      logger,
+	}
+}
+
+func (s *authzService) Check(ctx context.Context, check dto.AuthzCheckRequest) (*dto.AuthzDecision, error) {
+	if err := s.validate(check.Scopes); err != nil {
+		return nil, err
+	}
+
+	granted, err := s.effectiveScopes(ctx, check.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := decide(check, granted)
+	s.logger.Info("authorization checked successfully", zap.String("subject", check.Subject), zap.Bool("allowed", decision.Allowed))
+	return decision, nil
+}
+
+// CheckBatch answers every check in order, reading each subject's scopes
+// once. An invalid scope in any check fails the whole batch.
+func (s *authzService) CheckBatch(ctx context.Context, checks []dto.AuthzCheckRequest) ([]*dto.AuthzDecision, error) {
+	for _, check := range checks {
+		if err := s.validate(check.Scopes); err != nil {
+			return nil, err
+		}
+	}
+
+	subjects := make(map[string][]string)
+	decisions := make([]*dto.AuthzDecision, 0, len(checks))
+	for _, check := range checks {
+		granted, ok := subjects[check.Subject]
+		if !ok {
+			var err error
+			if granted, err = s.effectiveScopes(ctx, check.Subject); err != nil {
+				return nil, err
+			}
+			subjects[check.Subject] = granted
+		}
+		decisions = append(decisions, decide(check, granted))
+	}
+
+	s.logger.Info("authorizations checked successfully", zap.Int("count", len(decisions)))
+	return decisions, nil
+}
+
+// validate only accepts concrete scopes: a pattern would ask whether the
+// subject holds every scope it matches, which grants cannot answer.
+func (s *authzService) validate(required []string) error {
+	for _, scope := range required {
+		if err := scopes.Validate(scope); err != nil {
+			return apperrors.Validation(dto.CodeInvalidScope, "invalid scope name", err)
+		}
+		if scopes.IsPattern(scope) {
+			return apperrors.Validation(dto.CodeInvalidScope, "invalid scope name", fmt.Errorf("%q is a pattern", scope))
+		}
+	}
+	return nil
+}
+
+// effectiveScopes returns nil when the subject does not exist in the
+// caller's organization. The cache is an optimization, so failing to read or
+// write it falls back to the database.
+func (s *authzService) effectiveScopes(ctx context.Context, userId string) ([]string, error) {
+	key := effectiveScopesKeyPrefix + userId
+	if s.cacheTTL > 0 {
+		value, err := s.redisClient.Get(ctx, key)
+		if err != nil {
+			s.logger.Warn("failed to read effective scopes from redis", zap.String("id", userId), zap.Error(err))
+		}
+		var cached cachedScopes
+		if err == nil && value != "" && json.Unmarshal([]byte(value), &cached) == nil {
+			if !visible(ctx, cached.OrganizationID) {
+				return nil, nil
+			}
+			return cached.Scopes, nil
+		}
+	}
+
+	user, err := s.userRepo.FindById(ctx, userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		s.logger.Error("failed to find user by id", zap.String("id", userId), zap.Error(err))
+		return nil, err
+	}
+
+	granted := user.EffectiveScopes()
+	if ttl := s.cacheLifetime(user); ttl > 0 {
+		value, _ := json.Marshal(cachedScopes{OrganizationID: user.OrganizationID, Scopes: granted})
+		if err := s.redisClient.Set(ctx, key, string(value), ttl); err != nil {
+			s.logger.Warn("failed to cache effective scopes in redis", zap.String("id", userId), zap.Error(err))
+		}
+	}
+	return granted, nil
+}
+
+// cacheLifetime stops the entry from outliving the user's next direct grant
+// to expire, which changes the effective scopes without any write.
+func (s *authzService) cacheLifetime(user *entities.User) time.Duration {
+	ttl := s.cacheTTL
+	now := time.Now()
+	for _, mapping := range user.ScopeMappings {
+		if mapping.ExpiresAt != nil && mapping.ExpiresAt.After(now) && mapping.ExpiresAt.Sub(now) < ttl {
+			ttl = mapping.ExpiresAt.Sub(now)
+		}
+	}
+	return ttl
+}
+
+// visible applies the tenant restriction the database would to a cached
+// entry.
+func visible(ctx context.Context, organizationId string) bool {
+	tenant, ok := tenancy.FromContext(ctx)
+	return !ok || tenant.CrossTenant || tenant.OrganizationID == organizationId
+}
+
+// decide treats a nil granted as an unknown subject.
+func decide(check dto.AuthzCheckRequest, granted []string) *dto.AuthzDecision {
+	decision := &dto.AuthzDecision{Subject: check.Subject, Scopes: check.Scopes}
+	if granted == nil {
+		decision.Reason = dto.AuthzReasonUnknownSubject
+		return decision
+	}
+
+	for _, scope := range check.Scopes {
+		if !scopes.Grants(granted, scope) {
+			decision.Missing = append(decision.Missing, scope)
+		}
+	}
+	decision.Allowed = len(decision.Missing) == 0
+	decision.Reason = dto.AuthzReasonGranted
+	if !decision.Allowed {
+		decision.Reason = dto.AuthzReasonMissingScope
+	}
+	return decision
+}
+
+// invalidateEffectiveScopes drops the cached effective scopes of the users.
+func invalidateEffectiveScopes(ctx context.Context, redisClient interfaces.IRedisClient, userIds ...string) error {
+	keys := make([]string, 0, len(userIds))
+	for _, userId := range userIds {
+		keys = append(keys, effectiveScopesKeyPrefix+userId)
+	}
+	return redisClient.Del(ctx, keys...)
+}
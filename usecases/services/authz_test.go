@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+)
+
+type AuthzServiceSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	authzService IAuthzService
+	mockUserRepo *repositories.MockIUserRepository
+	mockRedis    *interfaces.MockIRedisClient
+	logger       *logger.MockILogger
+	ctx          context.Context
+}
+
+func (s *AuthzServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockUserRepo = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockRedis = interfaces.NewMockIRedisClient(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.authzService = NewAuthzService(s.mockUserRepo, s.mockRedis, 5*time.Minute, s.logger)
+	s.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
+}
+
+func (s *AuthzServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestAuthzServiceSuite(t *testing.T) {
+	suite.Run(t, new(AuthzServiceSuite))
+}
+
+func (s *AuthzServiceSuite) user() *entities.User {
+	return &entities.User{
+		ID:             "user1",
+		OrganizationID: "acme",
+		Scopes:         []*entities.UserScope{{ID: 1, Name: "container:view"}},
+		Roles:          []*entities.Role{{Name: "operator", Scopes: []*entities.UserScope{{ID: 2, Name: "container:*"}}}},
+	}
+}
+
+func (s *AuthzServiceSuite) TestCheckAllowedFromDatabase() {
+	s.mockRedis.EXPECT().Get(s.ctx, "authz:scopes:user1").Return("", nil)
+	s.mockUserRepo.EXPECT().FindById(s.ctx, "user1").Return(s.user(), nil)
+	s.mockRedis.EXPECT().Set(s.ctx, "authz:scopes:user1", `{"organization_id":"acme","scopes":["container:view","container:*"]}`, 5*time.Minute).Return(nil)
+	s.logger.EXPECT().Info("authorization checked successfully", gomock.Any()).Times(1)
+
+	decision, err := s.authzService.Check(s.ctx, dto.AuthzCheckRequest{Subject: "user1", Scopes: []string{"container:view", "container:delete"}})
+	s.NoError(err)
+	s.True(decision.Allowed)
+	s.Equal(dto.AuthzReasonGranted, decision.Reason)
+	s.Empty(decision.Missing)
+}
+
+func (s *AuthzServiceSuite) TestCheckDeniedFromCache() {
+	s.mockRedis.EXPECT().Get(s.ctx, "authz:scopes:user1").Return(`{"organization_id":"acme","scopes":["container:view"]}`, nil)
+	s.logger.EXPECT().Info("authorization checked successfully", gomock.Any()).Times(1)
+
+	decision, err := s.authzService.Check(s.ctx, dto.AuthzCheckRequest{Subject: "user1", Scopes: []string{"container:view", "user:manage"}})
+	s.NoError(err)
+	s.False(decision.Allowed)
+	s.Equal(dto.AuthzReasonMissingScope, decision.Reason)
+	s.Equal([]string{"user:manage"}, decision.Missing)
+}
+
+func (s *AuthzServiceSuite) TestCheckCachedInOtherTenant() {
+	s.mockRedis.EXPECT().Get(s.ctx, "authz:scopes:user1").Return(`{"organization_id":"globex","scopes":["container:view"]}`, nil)
+	s.logger.EXPECT().Info("authorization checked successfully", gomock.Any()).Times(1)
+
+	decision, err := s.authzService.Check(s.ctx, dto.AuthzCheckRequest{Subject: "user1", Scopes: []string{"container:view"}})
+	s.NoError(err)
+	s.False(decision.Allowed)
+	s.Equal(dto.AuthzReasonUnknownSubject, decision.Reason)
+}
+
+func (s *AuthzServiceSuite) TestCheckUnknownSubject() {
+	s.mockRedis.EXPECT().Get(s.ctx, "authz:scopes:ghost").Return("", nil)
+	s.mockUserRepo.EXPECT().FindById(s.ctx, "ghost").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Info("authorization checked successfully", gomock.Any()).Times(1)
+
+	decision, err := s.authzService.Check(s.ctx, dto.AuthzCheckRequest{Subject: "ghost", Scopes: []string{"container:view"}})
+	s.NoError(err)
+	s.False(decision.Allowed)
+	s.Equal(dto.AuthzReasonUnknownSubject, decision.Reason)
+}
+
+func (s *AuthzServiceSuite) TestCheckCacheUnavailable() {
+	s.mockRedis.EXPECT().Get(s.ctx, "authz:scopes:user1").Return("", errors.New("redis error"))
+	s.logger.EXPECT().Warn("failed to read effective scopes from redis", gomock.Any()).Times(1)
+	s.mockUserRepo.EXPECT().FindById(s.ctx, "user1").Return(s.user(), nil)
+	s.mockRedis.EXPECT().Set(s.ctx, "authz:scopes:user1", gomock.Any(), 5*time.Minute).Return(errors.New("redis error"))
+	s.logger.EXPECT().Warn("failed to cache effective scopes in redis", gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("authorization checked successfully", gomock.Any()).Times(1)
+
+	decision, err := s.authzService.Check(s.ctx, dto.AuthzCheckRequest{Subject: "user1", Scopes: []string{"container:view"}})
+	s.NoError(err)
+	s.True(decision.Allowed)
+}
+
+func (s *AuthzServiceSuite) TestCheckCacheExpiresWithGrant() {
+	user := s.user()
+	expiresAt := time.Now().Add(time.Minute)
+	user.ScopeMappings = []*entities.UserScopeMapping{{UserID: "user1", UserScopeID: 1, ExpiresAt: &expiresAt}}
+
+	s.mockRedis.EXPECT().Get(s.ctx, "authz:scopes:user1").Return("", nil)
+	s.mockUserRepo.EXPECT().FindById(s.ctx, "user1").Return(user, nil)
+	s.mockRedis.EXPECT().Set(s.ctx, "authz:scopes:user1", gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _, _ string, ttl time.Duration) error {
+		s.LessOrEqual(ttl, time.Minute)
+		return nil
+	})
+	s.logger.EXPECT().Info("authorization checked successfully", gomock.Any()).Times(1)
+
+	_, err := s.authzService.Check(s.ctx, dto.AuthzCheckRequest{Subject: "user1", Scopes: []string{"container:view"}})
+	s.NoError(err)
+}
+
+func (s *AuthzServiceSuite) TestCheckCacheDisabled() {
+	s.authzService = NewAuthzService(s.mockUserRepo, s.mockRedis, 0, s.logger)
+	s.mockUserRepo.EXPECT().FindById(s.ctx, "user1").Return(s.user(), nil)
+	s.logger.EXPECT().Info("authorization checked successfully", gomock.Any()).Times(1)
+
+	decision, err := s.authzService.Check(s.ctx, dto.AuthzCheckRequest{Subject: "user1", Scopes: []string{"container:view"}})
+	s.NoError(err)
+	s.True(decision.Allowed)
+}
+
+func (s *AuthzServiceSuite) TestCheckRepoError() {
+	s.mockRedis.EXPECT().Get(s.ctx, "authz:scopes:user1").Return("", nil)
+	s.mockUserRepo.EXPECT().FindById(s.ctx, "user1").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find user by id", gomock.Any()).Times(1)
+
+	_, err := s.authzService.Check(s.ctx, dto.AuthzCheckRequest{Subject: "user1", Scopes: []string{"container:view"}})
+	s.ErrorContains(err, "db error")
+}
+
+func (s *AuthzServiceSuite) TestCheckRejectsPattern() {
+	_, err := s.authzService.Check(s.ctx, dto.AuthzCheckRequest{Subject: "user1", Scopes: []string{"container:*"}})
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeInvalidScope, appErr.Code)
+}
+
+func (s *AuthzServiceSuite) TestCheckBatch() {
+	s.mockRedis.EXPECT().Get(s.ctx, "authz:scopes:user1").Return(`{"organization_id":"acme","scopes":["container:view"]}`, nil).Times(1)
+	s.mockRedis.EXPECT().Get(s.ctx, "authz:scopes:ghost").Return("", nil)
+	s.mockUserRepo.EXPECT().FindById(s.ctx, "ghost").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Info("authorizations checked successfully", gomock.Any()).Times(1)
+
+	decisions, err := s.authzService.CheckBatch(s.ctx, []dto.AuthzCheckRequest{
+		{Subject: "user1", Scopes: []string{"container:view"}},
+		{Subject: "user1", Scopes: []string{"container:delete"}},
+		{Subject: "ghost", Scopes: []string{"container:view"}},
+	})
+	s.NoError(err)
+	s.Len(decisions, 3)
+	s.True(decisions[0].Allowed)
+	s.Equal(dto.AuthzReasonMissingScope, decisions[1].Reason)
+	s.Equal(dto.AuthzReasonUnknownSubject, decisions[2].Reason)
+}
+
+func (s *AuthzServiceSuite) TestCheckBatchInvalidScope() {
+	_, err := s.authzService.CheckBatch(s.ctx, []dto.AuthzCheckRequest{
+		{Subject: "user1", Scopes: []string{"container:view"}},
+		{Subject: "user1", Scopes: []string{"Not A Scope"}},
+	})
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeInvalidScope, appErr.Code)
+}
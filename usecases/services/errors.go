@@ -0,0 +1,27 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"gorm.io/gorm"
+)
+
+// repositoryError maps persistence errors onto domain errors so handlers can
+// render them with the right status. Unknown errors are returned unchanged.
+func repositoryError(err error, notFoundCode, conflictCode string) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return apperrors.NotFound(notFoundCode, "record not found", err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return apperrors.Conflict(conflictCode, "record already exists", err)
+	case errors.Is(err, repositories.ErrInvalidCursor), errors.Is(err, repositories.ErrInvalidSort):
+		return apperrors.BadRequest(dto.CodeInvalidPagination, "invalid pagination parameters", err)
+	case errors.Is(err, tenancy.ErrOrganizationRequired):
+		return apperrors.BadRequest(dto.CodeOrganizationRequired, "select an organization with the "+tenancy.Header+" header", err)
+	}
+	return err
+}
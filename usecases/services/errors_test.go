@@ -0,0 +1,40 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+)
+
+func TestRepositoryError(t *testing.T) {
+	cases := []struct {
+		err  error
+		kind apperrors.Kind
+		code string
+	}{
+		{gorm.ErrRecordNotFound, apperrors.KindNotFound, dto.CodeUserNotFound},
+		{gorm.ErrDuplicatedKey, apperrors.KindConflict, dto.CodeUserAlreadyExists},
+		{repositories.ErrInvalidCursor, apperrors.KindBadRequest, dto.CodeInvalidPagination},
+		{repositories.ErrInvalidSort, apperrors.KindBadRequest, dto.CodeInvalidPagination},
+		{tenancy.ErrOrganizationRequired, apperrors.KindBadRequest, dto.CodeOrganizationRequired},
+	}
+
+	for _, tc := range cases {
+		err := repositoryError(tc.err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
+		appErr, ok := apperrors.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, tc.kind, appErr.Kind)
+		assert.Equal(t, tc.code, appErr.Code)
+		assert.ErrorIs(t, err, tc.err)
+	}
+
+	unknown := errors.New("connection refused")
+	assert.Equal(t, unknown, repositoryError(unknown, dto.CodeUserNotFound, dto.CodeUserAlreadyExists))
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+)
+
+// IGrantReaper removes expired scope grants. Run reaps on every tick of
+// interval until ctx is cancelled; Reap does a single pass.
+type IGrantReaper interface {
+	Run(ctx context.Context, interval time.Duration)
+	Reap(ctx context.Context) error
+}
+
+type grantReaper struct {
+	userRepo    repositories.IUserRepository
+	auditRepo   repositories.IAuditRepository
+	outboxRepo  repositories.IOutboxRepository
+	redisClient interfaces.IRedisClient
+	revocations revocation.IStore
+	logger      logger.ILogger
+}
+
+func NewGrantReaper(userRepo repositories.IUserRepository, auditRepo repositories.IAuditRepository, outboxRepo repositories.IOutboxRepository, redisClient interfaces.IRedisClient, revocations revocation.IStore, logger logger.ILogger) IGrantReaper {
+	return &grantReaper{
+		userRepo:    userRepo,
+		auditRepo:   auditRepo,
+		outboxRepo:  outboxRepo,
+		redisClient: redisClient,
+		revocations: revocations,
+		logger:      logger,
+	}
+}
+
+func (r *grantReaper) Run(ctx context.Context, interval time.Duration) {
+	runEvery(ctx, interval, func(ctx context.Context) {
+		r.Reap(ctx)
+	})
+}
+
+// Reap deletes the grants that have expired in every organization. Like any
+// other scope removal, each holder is audited, gets user.scopes_changed when
+// its effective scopes shrink, and has its refresh sessions and, if it lost
+// a scope, its access tokens revoked.
+func (r *grantReaper) Reap(ctx context.Context) error {
+	now := time.Now()
+	userIds, err := r.userRepo.FindExpiredScopeHolders(ctx, now)
+	if err != nil {
+		r.logger.Error("failed to find expired scope grants", zap.Error(err))
+		return err
+	}
+	if len(userIds) == 0 {
+		return nil
+	}
+
+	changes, err := captureScopes(ctx, r.userRepo, r.logger, userIds)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.userRepo.BeginTransaction(ctx)
+	if err != nil {
+		r.logger.Error("failed to create transaction", zap.Error(err))
+		return err
+	}
+
+	// Another replica may have reaped some of the grants since they were
+	// found; only the users that lost one here are reported.
+	reaped, err := r.userRepo.WithTransaction(tx).DeleteExpiredScopes(ctx, now)
+	if err != nil {
+		r.logger.Error("failed to delete expired scope grants", zap.Error(err))
+		tx.Rollback()
+		return err
+	}
+	changes.keep(reaped)
+	if err := changes.reload(ctx, tx, r.userRepo, r.logger); err != nil {
+		return err
+	}
+	if err := changes.enqueue(ctx, tx, r.outboxRepo, r.logger); err != nil {
+		return err
+	}
+	if err := changes.audit(ctx, tx, r.auditRepo, r.logger, entities.AuditUserScopeExpire); err != nil {
+		return err
+	}
+	if err := tx.Commit().Error; err != nil {
+		r.logger.Error("failed to commit transaction", zap.Error(err))
+		return err
+	}
+
+	if err := changes.revoke(ctx, r.redisClient, r.revocations, r.logger); err != nil {
+		return err
+	}
+
+	r.logger.Info("expired scope grants reaped successfully", zap.Int("users", len(changes.userIds)))
+	return nil
+}
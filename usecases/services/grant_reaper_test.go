@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	Logger "gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
+)
+
+type GrantReaperSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	grantReaper  IGrantReaper
+	mockRepo     *repositories.MockIUserRepository
+	mockTxRepo   *repositories.MockIUserRepository
+	mockAudit    *repositories.MockIAuditRepository
+	mockTxAudit  *repositories.MockIAuditRepository
+	mockOutbox   *repositories.MockIOutboxRepository
+	mockTxOutbox *repositories.MockIOutboxRepository
+	mockRedis    *interfaces.MockIRedisClient
+	mockRevoke   *revocation.MockIStore
+	logger       *logger.MockILogger
+	ctx          context.Context
+}
+
+func (s *GrantReaperSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockTxRepo = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockAudit = repositories.NewMockIAuditRepository(s.ctrl)
+	s.mockTxAudit = repositories.NewMockIAuditRepository(s.ctrl)
+	s.mockOutbox = repositories.NewMockIOutboxRepository(s.ctrl)
+	s.mockTxOutbox = repositories.NewMockIOutboxRepository(s.ctrl)
+	s.mockRedis = interfaces.NewMockIRedisClient(s.ctrl)
+	s.mockRevoke = revocation.NewMockIStore(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.grantReaper = NewGrantReaper(s.mockRepo, s.mockAudit, s.mockOutbox, s.mockRedis, s.mockRevoke, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *GrantReaperSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestGrantReaperSuite(t *testing.T) {
+	suite.Run(t, new(GrantReaperSuite))
+}
+
+func (s *GrantReaperSuite) expectTransaction() *gorm.DB {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: Logger.Default.LogMode(Logger.Silent),
+	})
+	s.Require().NoError(err)
+	tx := gormDB.Begin()
+	s.Require().NoError(tx.Error)
+
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(s.mockTxRepo).AnyTimes()
+	s.mockAudit.EXPECT().WithTransaction(tx).Return(s.mockTxAudit).AnyTimes()
+	s.mockOutbox.EXPECT().WithTransaction(tx).Return(s.mockTxOutbox).AnyTimes()
+	return tx
+}
+
+// expiringUser holds container:view for good and container:update under a
+// grant that has expired.
+func expiringUser(id string) *entities.User {
+	expiresAt := time.Now().Add(-time.Minute)
+	return &entities.User{
+		ID:             id,
+		OrganizationID: "acme",
+		Username:       id,
+		Scopes:         []*entities.UserScope{{ID: 1, Name: "container:view"}, {ID: 2, Name: "container:update"}},
+		ScopeMappings:  []*entities.UserScopeMapping{{UserID: id, UserScopeID: 2, ExpiresAt: &expiresAt}},
+	}
+}
+
+func reapedUser(id string) *entities.User {
+	return &entities.User{
+		ID:             id,
+		OrganizationID: "acme",
+		Username:       id,
+		Scopes:         []*entities.UserScope{{ID: 1, Name: "container:view"}},
+	}
+}
+
+func (s *GrantReaperSuite) TestReap() {
+	s.mockRepo.EXPECT().FindExpiredScopeHolders(s.ctx, gomock.Any()).Return([]string{"alice"}, nil)
+	s.mockRepo.EXPECT().FindByIds(s.ctx, []string{"alice"}).Return([]*entities.User{expiringUser("alice")}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().DeleteExpiredScopes(s.ctx, gomock.Any()).Return([]string{"alice"}, nil)
+	s.mockTxRepo.EXPECT().FindByIds(s.ctx, []string{"alice"}).Return([]*entities.User{reapedUser("alice")}, nil)
+	s.mockTxOutbox.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *entities.OutboxEvent) error {
+		s.Equal(events.UserScopesChanged, event.Type)
+		s.Equal("acme", event.OrganizationID)
+		parsed, err := events.Parse(events.Encoded(event.Payload))
+		s.Require().NoError(err)
+		var data events.UserScopesChangedData
+		s.Require().NoError(parsed.Decode(&data))
+		s.Equal("alice", data.UserID)
+		s.Equal([]string{"container:update"}, data.Removed)
+		return nil
+	})
+	s.mockTxAudit.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry *entities.AuditEntry) error {
+		s.Equal(entities.AuditUserScopeExpire, entry.Action)
+		s.Equal("alice", entry.TargetID)
+		return nil
+	})
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:alice").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:alice").Return([]string{"s1"}, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:alice", "sessions:alice", "session:s1").Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "alice", gomock.Any()).Return(nil)
+	s.logger.EXPECT().Info("expired scope grants reaped successfully", gomock.Any()).Times(1)
+
+	s.NoError(s.grantReaper.Reap(s.ctx))
+}
+
+func (s *GrantReaperSuite) TestReapAlreadyReaped() {
+	s.mockRepo.EXPECT().FindExpiredScopeHolders(s.ctx, gomock.Any()).Return([]string{"alice"}, nil)
+	s.mockRepo.EXPECT().FindByIds(s.ctx, []string{"alice"}).Return([]*entities.User{expiringUser("alice")}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().DeleteExpiredScopes(s.ctx, gomock.Any()).Return([]string{}, nil)
+	s.logger.EXPECT().Info("expired scope grants reaped successfully", gomock.Any()).Times(1)
+
+	s.NoError(s.grantReaper.Reap(s.ctx))
+}
+
+func (s *GrantReaperSuite) TestReapNothingExpired() {
+	s.mockRepo.EXPECT().FindExpiredScopeHolders(s.ctx, gomock.Any()).Return([]string{}, nil)
+
+	s.NoError(s.grantReaper.Reap(s.ctx))
+}
+
+func (s *GrantReaperSuite) TestReapFindError() {
+	s.mockRepo.EXPECT().FindExpiredScopeHolders(s.ctx, gomock.Any()).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find expired scope grants", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.grantReaper.Reap(s.ctx), "db error")
+}
+
+func (s *GrantReaperSuite) TestReapDeleteError() {
+	s.mockRepo.EXPECT().FindExpiredScopeHolders(s.ctx, gomock.Any()).Return([]string{"alice"}, nil)
+	s.mockRepo.EXPECT().FindByIds(s.ctx, []string{"alice"}).Return([]*entities.User{expiringUser("alice")}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().DeleteExpiredScopes(s.ctx, gomock.Any()).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to delete expired scope grants", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.grantReaper.Reap(s.ctx), "db error")
+}
+
+func (s *GrantReaperSuite) TestReapAuditError() {
+	s.mockRepo.EXPECT().FindExpiredScopeHolders(s.ctx, gomock.Any()).Return([]string{"alice"}, nil)
+	s.mockRepo.EXPECT().FindByIds(s.ctx, []string{"alice"}).Return([]*entities.User{expiringUser("alice")}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().DeleteExpiredScopes(s.ctx, gomock.Any()).Return([]string{"alice"}, nil)
+	s.mockTxRepo.EXPECT().FindByIds(s.ctx, []string{"alice"}).Return([]*entities.User{reapedUser("alice")}, nil)
+	s.mockTxOutbox.EXPECT().Create(s.ctx, gomock.Any()).Return(nil)
+	s.mockTxAudit.EXPECT().Create(s.ctx, gomock.Any()).Return(errors.New("audit error"))
+	s.logger.EXPECT().Error("failed to record audit entry", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.grantReaper.Reap(s.ctx), "audit error")
+}
+
+func (s *GrantReaperSuite) TestReapRevokeError() {
+	s.mockRepo.EXPECT().FindExpiredScopeHolders(s.ctx, gomock.Any()).Return([]string{"alice", "bob"}, nil)
+	s.mockRepo.EXPECT().FindByIds(s.ctx, []string{"alice", "bob"}).Return([]*entities.User{expiringUser("alice"), expiringUser("bob")}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().DeleteExpiredScopes(s.ctx, gomock.Any()).Return([]string{"alice", "bob"}, nil)
+	s.mockTxRepo.EXPECT().FindByIds(s.ctx, []string{"alice", "bob"}).Return([]*entities.User{reapedUser("alice"), reapedUser("bob")}, nil)
+	s.mockTxOutbox.EXPECT().Create(s.ctx, gomock.Any()).Return(nil).Times(2)
+	s.mockTxAudit.EXPECT().Create(s.ctx, gomock.Any()).Return(nil).Times(2)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:alice", "authz:scopes:bob").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:alice").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:alice", "sessions:alice").Return(errors.New("redis error"))
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:bob").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:bob", "sessions:bob").Return(nil)
+	s.logger.EXPECT().Error("failed to delete refresh token in redis", gomock.Any(), gomock.Any()).Times(1)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "alice", gomock.Any()).Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "bob", gomock.Any()).Return(nil)
+
+	s.ErrorContains(s.grantReaper.Reap(s.ctx), "redis error")
+}
+
+func (s *GrantReaperSuite) TestRunStopsWithContext() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.mockRepo.EXPECT().FindExpiredScopeHolders(ctx, gomock.Any()).DoAndReturn(func(context.Context, time.Time) ([]string, error) {
+		cancel()
+		return nil, nil
+	}).MinTimes(1)
+
+	done := make(chan struct{})
+	go func() {
+		s.grantReaper.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("reaper did not stop after the context was cancelled")
+	}
+}
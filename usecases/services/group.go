@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+)
+
+type IGroupService interface {
+	Create(ctx context.Context, groupName, parentName string, scopes []*entities.UserScope) (*entities.Group, error)
+	FindOne(ctx context.Context, groupName string) (*entities.Group, error)
+	FindAll(ctx context.Context, query dto.ListGroupsRequest) ([]*entities.Group, *dto.Paging, error)
+	UpdateScope(ctx context.Context, groupName string, scope *entities.UserScope, isAdded bool) error
+	UpdateParent(ctx context.Context, groupName, parentName string) error
+	UpdateMember(ctx context.Context, groupName string, user *entities.User, isAdded bool) error
+	Delete(ctx context.Context, groupName string) error
+}
+
+type groupService struct {
+	groupRepo   repositories.IGroupRepository
+	userRepo    repositories.IUserRepository
+	outboxRepo  repositories.IOutboxRepository
+	redisClient interfaces.IRedisClient
+	revocations revocation.IStore
+	logger      logger.ILogger
+}
+
+func NewGroupService(groupRepo repositories.IGroupRepository, userRepo repositories.IUserRepository, outboxRepo repositories.IOutboxRepository, redisClient interfaces.IRedisClient, revocations revocation.IStore, logger logger.ILogger) IGroupService {
+	return &groupService{
+		groupRepo:   groupRepo,
+		userRepo:    userRepo,
+		outboxRepo:  outboxRepo,
+		redisClient: redisClient,
+		revocations: revocations,
+		logger:      logger,
+	}
+}
+
+func (s *groupService) Create(ctx context.Context, groupName, parentName string, scopes []*entities.UserScope) (*entities.Group, error) {
+	var parent *entities.Group
+	var parentId *uint
+	if parentName != "" {
+		var err error
+		parent, err = s.findGroup(ctx, parentName)
+		if err != nil {
+			return nil, err
+		}
+		parentId = &parent.ID
+	}
+
+	group, err := s.groupRepo.Create(ctx, groupName, parentId, scopes)
+	if err != nil {
+		s.logger.Error("failed to create group", zap.Error(err))
+		return nil, repositoryError(err, dto.CodeGroupNotFound, dto.CodeGroupAlreadyExists)
+	}
+	group.Parent = parent
+
+	s.logger.Info("new group created successfully")
+	return group, nil
+}
+
+func (s *groupService) FindOne(ctx context.Context, groupName string) (*entities.Group, error) {
+	group, err := s.findGroup(ctx, groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("group found successfully")
+	return group, nil
+}
+
+func (s *groupService) FindAll(ctx context.Context, query dto.ListGroupsRequest) ([]*entities.Group, *dto.Paging, error) {
+	groups, paging, err := s.groupRepo.FindAll(ctx, query)
+	if err != nil {
+		s.logger.Error("failed to find all groups", zap.Error(err))
+		return nil, nil, repositoryError(err, dto.CodeGroupNotFound, dto.CodeGroupAlreadyExists)
+	}
+
+	s.logger.Info("all groups retrieved successfully")
+	return groups, paging, nil
+}
+
+// UpdateScope adds or removes a scope from the group. Members of the group
+// and of every group nested below it get user.scopes_changed when their
+// effective scopes change, and have their refresh tokens revoked.
+func (s *groupService) UpdateScope(ctx context.Context, groupName string, scope *entities.UserScope, isAdded bool) error {
+	group, err := s.findGroup(ctx, groupName)
+	if err != nil {
+		return err
+	}
+
+	scopeList := make([]*entities.UserScope, 0, len(group.Scopes))
+	for _, s := range group.Scopes {
+		if s.ID == scope.ID {
+			continue
+		}
+		scopeList = append(scopeList, s)
+	}
+	if isAdded {
+		scopeList = append(scopeList, scope)
+	}
+
+	changes, err := s.captureMembers(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.groupRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to create transaction", zap.Error(err))
+		return err
+	}
+
+	if err := s.groupRepo.WithTransaction(tx).UpdateScope(ctx, group, scopeList); err != nil {
+		s.logger.Error("failed to update group's scopes", zap.Error(err))
+		tx.Rollback()
+		return repositoryError(err, dto.CodeScopeNotFound, dto.CodeScopeAlreadyExists)
+	}
+	if err := changes.commit(ctx, tx, s.userRepo, s.outboxRepo, s.logger); err != nil {
+		return err
+	}
+
+	if err := changes.revoke(ctx, s.redisClient, s.revocations, s.logger); err != nil {
+		return err
+	}
+
+	s.logger.Info("group's scopes updated successfully", zap.String("name", groupName))
+	return nil
+}
+
+// UpdateParent nests the group under parentName, or moves it to the top level
+// when parentName is empty. A group cannot be nested under itself or one of
+// its descendants.
+func (s *groupService) UpdateParent(ctx context.Context, groupName, parentName string) error {
+	group, err := s.findGroup(ctx, groupName)
+	if err != nil {
+		return err
+	}
+
+	var parentId *uint
+	if parentName != "" {
+		parent, err := s.findGroup(ctx, parentName)
+		if err != nil {
+			return err
+		}
+		for _, ancestor := range parent.Lineage() {
+			if ancestor.ID == group.ID {
+				s.logger.Warn("group nesting rejected", zap.String("name", groupName), zap.String("parent", parentName))
+				return apperrors.Validation(dto.CodeGroupCycle, "a group cannot be nested under itself or its descendants", nil)
+			}
+		}
+		parentId = &parent.ID
+	}
+
+	changes, err := s.captureMembers(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.groupRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to create transaction", zap.Error(err))
+		return err
+	}
+
+	if err := s.groupRepo.WithTransaction(tx).UpdateParent(ctx, group.ID, parentId); err != nil {
+		s.logger.Error("failed to update group's parent", zap.Error(err))
+		tx.Rollback()
+		return repositoryError(err, dto.CodeGroupNotFound, dto.CodeGroupAlreadyExists)
+	}
+	if err := changes.commit(ctx, tx, s.userRepo, s.outboxRepo, s.logger); err != nil {
+		return err
+	}
+
+	if err := changes.revoke(ctx, s.redisClient, s.revocations, s.logger); err != nil {
+		return err
+	}
+
+	s.logger.Info("group's parent updated successfully", zap.String("name", groupName))
+	return nil
+}
+
+func (s *groupService) UpdateMember(ctx context.Context, groupName string, user *entities.User, isAdded bool) error {
+	group, err := s.findGroup(ctx, groupName)
+	if err != nil {
+		return err
+	}
+
+	changes, err := captureScopes(ctx, s.userRepo, s.logger, []string{user.ID})
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.groupRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to create transaction", zap.Error(err))
+		return err
+	}
+
+	if err := s.groupRepo.WithTransaction(tx).UpdateMember(ctx, group, user, isAdded); err != nil {
+		s.logger.Error("failed to update group's members", zap.Error(err))
+		tx.Rollback()
+		return repositoryError(err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
+	}
+	if err := changes.commit(ctx, tx, s.userRepo, s.outboxRepo, s.logger); err != nil {
+		return err
+	}
+
+	if err := changes.revoke(ctx, s.redisClient, s.revocations, s.logger); err != nil {
+		return err
+	}
+
+	s.logger.Info("group's members updated successfully", zap.String("name", groupName))
+	return nil
+}
+
+func (s *groupService) Delete(ctx context.Context, groupName string) error {
+	group, err := s.findGroup(ctx, groupName)
+	if err != nil {
+		return err
+	}
+
+	// Members are looked up before the mappings are removed with the group.
+	changes, err := s.captureMembers(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.groupRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to create transaction", zap.Error(err))
+		return err
+	}
+
+	if err := s.groupRepo.WithTransaction(tx).Delete(ctx, group.ID); err != nil {
+		s.logger.Error("failed to delete group", zap.Error(err))
+		tx.Rollback()
+		return repositoryError(err, dto.CodeGroupNotFound, dto.CodeGroupAlreadyExists)
+	}
+	if err := changes.commit(ctx, tx, s.userRepo, s.outboxRepo, s.logger); err != nil {
+		return err
+	}
+
+	if err := changes.revoke(ctx, s.redisClient, s.revocations, s.logger); err != nil {
+		return err
+	}
+
+	s.logger.Info("group deleted successfully", zap.String("name", groupName))
+	return nil
+}
+
+func (s *groupService) findGroup(ctx context.Context, groupName string) (*entities.Group, error) {
+	group, err := s.groupRepo.FindByName(ctx, groupName)
+	if err != nil {
+		s.logger.Error("failed to find group", zap.String("name", groupName), zap.Error(err))
+		return nil, repositoryError(err, dto.CodeGroupNotFound, dto.CodeGroupAlreadyExists)
+	}
+	return group, nil
+}
+
+// captureMembers captures the scopes of every user who inherits from the
+// group.
+func (s *groupService) captureMembers(ctx context.Context, group *entities.Group) (*scopeChanges, error) {
+	userIds, err := s.groupRepo.FindMemberIds(ctx, group.ID)
+	if err != nil {
+		s.logger.Error("failed to find group members", zap.Error(err))
+		return nil, err
+	}
+	return captureScopes(ctx, s.userRepo, s.logger, userIds)
+}
@@ -0,0 +1,461 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	Logger "gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
+)
+
+type GroupServiceSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	groupService IGroupService
+	mockRepo     *repositories.MockIGroupRepository
+	mockTxRepo   *repositories.MockIGroupRepository
+	mockUser     *repositories.MockIUserRepository
+	mockTxUser   *repositories.MockIUserRepository
+	mockOutbox   *repositories.MockIOutboxRepository
+	mockTxOutbox *repositories.MockIOutboxRepository
+	mockRedis    *interfaces.MockIRedisClient
+	mockRevoke   *revocation.MockIStore
+	logger       *logger.MockILogger
+	ctx          context.Context
+}
+
+func (s *GroupServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIGroupRepository(s.ctrl)
+	s.mockTxRepo = repositories.NewMockIGroupRepository(s.ctrl)
+	s.mockUser = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockTxUser = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockOutbox = repositories.NewMockIOutboxRepository(s.ctrl)
+	s.mockTxOutbox = repositories.NewMockIOutboxRepository(s.ctrl)
+	s.mockRedis = interfaces.NewMockIRedisClient(s.ctrl)
+	s.mockRevoke = revocation.NewMockIStore(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.groupService = NewGroupService(s.mockRepo, s.mockUser, s.mockOutbox, s.mockRedis, s.mockRevoke, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *GroupServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestGroupServiceSuite(t *testing.T) {
+	suite.Run(t, new(GroupServiceSuite))
+}
+
+func (s *GroupServiceSuite) assertCode(err error, code string) {
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(code, appErr.Code)
+}
+
+// expectTransaction lets the service open a transaction and bind the group,
+// user and outbox repositories to it.
+func (s *GroupServiceSuite) expectTransaction() *gorm.DB {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: Logger.Default.LogMode(Logger.Silent),
+	})
+	s.Require().NoError(err)
+
+	tx := gormDB.Begin()
+	s.Require().NoError(tx.Error)
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(s.mockTxRepo)
+	s.mockUser.EXPECT().WithTransaction(tx).Return(s.mockTxUser).AnyTimes()
+	s.mockOutbox.EXPECT().WithTransaction(tx).Return(s.mockTxOutbox).AnyTimes()
+	return tx
+}
+
+// expectUsers returns before from the user repository ahead of the change and
+// after from within its transaction.
+func (s *GroupServiceSuite) expectUsers(before, after []*entities.User) {
+	userIds := make([]string, len(before))
+	for i, user := range before {
+		userIds[i] = user.ID
+	}
+	s.mockUser.EXPECT().FindByIds(s.ctx, userIds).Return(before, nil)
+	s.mockTxUser.EXPECT().FindByIds(s.ctx, userIds).Return(after, nil)
+}
+
+// expectScopesChanged checks that the change enqueues user.scopes_changed
+// for userId with the given difference.
+func (s *GroupServiceSuite) expectScopesChanged(userId string, added, removed []string) {
+	s.mockTxOutbox.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *entities.OutboxEvent) error {
+		s.Equal(events.UserScopesChanged, event.Type)
+		parsed, err := events.Parse(events.Encoded(event.Payload))
+		s.Require().NoError(err)
+		var data events.UserScopesChangedData
+		s.Require().NoError(parsed.Decode(&data))
+		s.Equal(userId, data.UserID)
+		s.Equal(added, data.Added)
+		s.Equal(removed, data.Removed)
+		return nil
+	})
+}
+
+func groupMember(id string, groups ...*entities.Group) *entities.User {
+	return &entities.User{ID: id, OrganizationID: "acme", Groups: groups}
+}
+
+func (s *GroupServiceSuite) TestCreate() {
+	scopes := []*entities.UserScope{{ID: 1, Name: "container:view"}}
+	expected := &entities.Group{ID: 1, Name: "engineering", Scopes: scopes}
+
+	s.mockRepo.EXPECT().Create(s.ctx, "engineering", nil, scopes).Return(expected, nil)
+	s.logger.EXPECT().Info("new group created successfully").Times(1)
+
+	result, err := s.groupService.Create(s.ctx, "engineering", "", scopes)
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *GroupServiceSuite) TestCreateNested() {
+	parent := &entities.Group{ID: 1, Name: "engineering"}
+	parentId := uint(1)
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(parent, nil)
+	s.mockRepo.EXPECT().Create(s.ctx, "backend", &parentId, nil).Return(&entities.Group{ID: 2, Name: "backend", ParentID: &parentId}, nil)
+	s.logger.EXPECT().Info("new group created successfully").Times(1)
+
+	result, err := s.groupService.Create(s.ctx, "backend", "engineering", nil)
+	s.NoError(err)
+	s.Equal(parent, result.Parent)
+}
+
+func (s *GroupServiceSuite) TestCreateParentNotFound() {
+	s.mockRepo.EXPECT().FindByName(s.ctx, "missing").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find group", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.groupService.Create(s.ctx, "backend", "missing", nil)
+	s.Nil(result)
+	s.assertCode(err, dto.CodeGroupNotFound)
+}
+
+func (s *GroupServiceSuite) TestCreateDuplicate() {
+	s.mockRepo.EXPECT().Create(s.ctx, "engineering", nil, nil).Return(nil, gorm.ErrDuplicatedKey)
+	s.logger.EXPECT().Error("failed to create group", gomock.Any()).Times(1)
+
+	result, err := s.groupService.Create(s.ctx, "engineering", "", nil)
+	s.Nil(result)
+	s.assertCode(err, dto.CodeGroupAlreadyExists)
+}
+
+func (s *GroupServiceSuite) TestFindOne() {
+	expected := &entities.Group{ID: 1, Name: "engineering"}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(expected, nil)
+	s.logger.EXPECT().Info("group found successfully").Times(1)
+
+	result, err := s.groupService.FindOne(s.ctx, "engineering")
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *GroupServiceSuite) TestFindAll() {
+	expected := []*entities.Group{{ID: 1, Name: "engineering"}}
+	paging := &dto.Paging{Limit: 20}
+
+	s.mockRepo.EXPECT().FindAll(s.ctx, dto.ListGroupsRequest{}).Return(expected, paging, nil)
+	s.logger.EXPECT().Info("all groups retrieved successfully").Times(1)
+
+	result, resultPaging, err := s.groupService.FindAll(s.ctx, dto.ListGroupsRequest{})
+	s.NoError(err)
+	s.Equal(expected, result)
+	s.Equal(paging, resultPaging)
+}
+
+func (s *GroupServiceSuite) TestFindAllError() {
+	s.mockRepo.EXPECT().FindAll(s.ctx, gomock.Any()).Return(nil, nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find all groups", gomock.Any()).Times(1)
+
+	_, _, err := s.groupService.FindAll(s.ctx, dto.ListGroupsRequest{})
+	s.ErrorContains(err, "db error")
+}
+
+func (s *GroupServiceSuite) TestUpdateScopeRevokesInheritors() {
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	group := &entities.Group{ID: 3, Name: "engineering"}
+	granted := &entities.Group{ID: 3, Name: "engineering", Scopes: []*entities.UserScope{view}}
+	nested := &entities.Group{ID: 4, Name: "backend", Parent: group}
+	nestedGranted := &entities.Group{ID: 4, Name: "backend", Parent: granted}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(group, nil)
+	s.mockRepo.EXPECT().FindMemberIds(s.ctx, uint(3)).Return([]string{"user-1", "user-2"}, nil)
+	s.expectUsers(
+		[]*entities.User{groupMember("user-1", group), groupMember("user-2", nested)},
+		[]*entities.User{groupMember("user-1", granted), groupMember("user-2", nestedGranted)},
+	)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, group, []*entities.UserScope{view}).Return(nil)
+	s.expectScopesChanged("user-1", []string{"container:view"}, []string{})
+	s.expectScopesChanged("user-2", []string{"container:view"}, []string{})
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:user-1", "authz:scopes:user-2").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-1").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-1", "sessions:user-1").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-2").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-2", "sessions:user-2").Return(nil)
+	s.logger.EXPECT().Info("group's scopes updated successfully", gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateScope(s.ctx, "engineering", view, true)
+	s.NoError(err)
+}
+
+func (s *GroupServiceSuite) TestUpdateScopeRemove() {
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	group := &entities.Group{ID: 3, Name: "engineering", Scopes: []*entities.UserScope{view}}
+	revoked := &entities.Group{ID: 3, Name: "engineering"}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(group, nil)
+	s.mockRepo.EXPECT().FindMemberIds(s.ctx, uint(3)).Return([]string{"user-1"}, nil)
+	s.expectUsers([]*entities.User{groupMember("user-1", group)}, []*entities.User{groupMember("user-1", revoked)})
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, group, []*entities.UserScope{}).Return(nil)
+	s.expectScopesChanged("user-1", []string{}, []string{"container:view"})
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:user-1").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-1").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-1", "sessions:user-1").Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "user-1", gomock.Any()).Return(nil)
+	s.logger.EXPECT().Info("group's scopes updated successfully", gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateScope(s.ctx, "engineering", view, false)
+	s.NoError(err)
+}
+
+func (s *GroupServiceSuite) TestUpdateScopeRepoError() {
+	group := &entities.Group{ID: 3, Name: "engineering", Scopes: []*entities.UserScope{{ID: 1}}}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(group, nil)
+	s.mockRepo.EXPECT().FindMemberIds(s.ctx, uint(3)).Return([]string{}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, group, []*entities.UserScope{}).Return(errors.New("update failed"))
+	s.logger.EXPECT().Error("failed to update group's scopes", gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateScope(s.ctx, "engineering", &entities.UserScope{ID: 1}, false)
+	s.ErrorContains(err, "update failed")
+}
+
+func (s *GroupServiceSuite) TestUpdateScopeMembersError() {
+	group := &entities.Group{ID: 3, Name: "engineering"}
+	scope := &entities.UserScope{ID: 1}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(group, nil)
+	s.mockRepo.EXPECT().FindMemberIds(s.ctx, uint(3)).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find group members", gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateScope(s.ctx, "engineering", scope, true)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *GroupServiceSuite) TestUpdateScopeOutboxError() {
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	group := &entities.Group{ID: 3, Name: "engineering"}
+	granted := &entities.Group{ID: 3, Name: "engineering", Scopes: []*entities.UserScope{view}}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(group, nil)
+	s.mockRepo.EXPECT().FindMemberIds(s.ctx, uint(3)).Return([]string{"user-1"}, nil)
+	s.expectUsers([]*entities.User{groupMember("user-1", group)}, []*entities.User{groupMember("user-1", granted)})
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, group, []*entities.UserScope{view}).Return(nil)
+	s.mockTxOutbox.EXPECT().Create(s.ctx, gomock.Any()).Return(errors.New("outbox error"))
+	s.logger.EXPECT().Error("failed to enqueue domain event", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateScope(s.ctx, "engineering", view, true)
+	s.ErrorContains(err, "outbox error")
+}
+
+func (s *GroupServiceSuite) TestUpdateParent() {
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	engineering := &entities.Group{ID: 1, Name: "engineering", Scopes: []*entities.UserScope{view}}
+	backend := &entities.Group{ID: 2, Name: "backend"}
+	nested := &entities.Group{ID: 2, Name: "backend", ParentID: &engineering.ID, Parent: engineering}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "backend").Return(backend, nil)
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(engineering, nil)
+	s.mockRepo.EXPECT().FindMemberIds(s.ctx, uint(2)).Return([]string{"user-1"}, nil)
+	s.expectUsers([]*entities.User{groupMember("user-1", backend)}, []*entities.User{groupMember("user-1", nested)})
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateParent(s.ctx, uint(2), &engineering.ID).Return(nil)
+	s.expectScopesChanged("user-1", []string{"container:view"}, []string{})
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:user-1").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-1").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-1", "sessions:user-1").Return(nil)
+	s.logger.EXPECT().Info("group's parent updated successfully", gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateParent(s.ctx, "backend", "engineering")
+	s.NoError(err)
+}
+
+func (s *GroupServiceSuite) TestUpdateParentDetach() {
+	backend := &entities.Group{ID: 2, Name: "backend"}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "backend").Return(backend, nil)
+	s.mockRepo.EXPECT().FindMemberIds(s.ctx, uint(2)).Return(nil, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateParent(s.ctx, uint(2), nil).Return(nil)
+	s.logger.EXPECT().Info("group's parent updated successfully", gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateParent(s.ctx, "backend", "")
+	s.NoError(err)
+}
+
+func (s *GroupServiceSuite) TestUpdateParentRejectsCycle() {
+	engineering := &entities.Group{ID: 1, Name: "engineering"}
+	backend := &entities.Group{ID: 2, Name: "backend", ParentID: &engineering.ID, Parent: engineering}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(engineering, nil)
+	s.mockRepo.EXPECT().FindByName(s.ctx, "backend").Return(backend, nil)
+	s.logger.EXPECT().Warn("group nesting rejected", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateParent(s.ctx, "engineering", "backend")
+	s.assertCode(err, dto.CodeGroupCycle)
+}
+
+func (s *GroupServiceSuite) TestUpdateParentRejectsSelf() {
+	engineering := &entities.Group{ID: 1, Name: "engineering"}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(engineering, nil).Times(2)
+	s.logger.EXPECT().Warn("group nesting rejected", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateParent(s.ctx, "engineering", "engineering")
+	s.assertCode(err, dto.CodeGroupCycle)
+}
+
+func (s *GroupServiceSuite) TestUpdateParentNotFound() {
+	s.mockRepo.EXPECT().FindByName(s.ctx, "backend").Return(&entities.Group{ID: 2, Name: "backend"}, nil)
+	s.mockRepo.EXPECT().FindByName(s.ctx, "missing").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find group", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateParent(s.ctx, "backend", "missing")
+	s.assertCode(err, dto.CodeGroupNotFound)
+}
+
+func (s *GroupServiceSuite) TestUpdateParentRepoError() {
+	s.mockRepo.EXPECT().FindByName(s.ctx, "backend").Return(&entities.Group{ID: 2, Name: "backend"}, nil)
+	s.mockRepo.EXPECT().FindMemberIds(s.ctx, uint(2)).Return(nil, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateParent(s.ctx, uint(2), nil).Return(errors.New("update failed"))
+	s.logger.EXPECT().Error("failed to update group's parent", gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateParent(s.ctx, "backend", "")
+	s.ErrorContains(err, "update failed")
+}
+
+func (s *GroupServiceSuite) TestUpdateMember() {
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	group := &entities.Group{ID: 1, Name: "engineering", Scopes: []*entities.UserScope{view}}
+	user := &entities.User{ID: "user-1"}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(group, nil)
+	s.expectUsers([]*entities.User{groupMember("user-1")}, []*entities.User{groupMember("user-1", group)})
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateMember(s.ctx, group, user, true).Return(nil)
+	s.expectScopesChanged("user-1", []string{"container:view"}, []string{})
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:user-1").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-1").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-1", "sessions:user-1").Return(nil)
+	s.logger.EXPECT().Info("group's members updated successfully", gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateMember(s.ctx, "engineering", user, true)
+	s.NoError(err)
+}
+
+func (s *GroupServiceSuite) TestUpdateMemberUserNotFound() {
+	group := &entities.Group{ID: 1, Name: "engineering"}
+	user := &entities.User{ID: "user-1"}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(group, nil)
+	s.mockUser.EXPECT().FindByIds(s.ctx, []string{"user-1"}).Return([]*entities.User{}, nil)
+	s.logger.EXPECT().Error("failed to find user by id", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateMember(s.ctx, "engineering", user, true)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *GroupServiceSuite) TestUpdateMemberRepoError() {
+	group := &entities.Group{ID: 1, Name: "engineering"}
+	user := &entities.User{ID: "user-1"}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(group, nil)
+	s.mockUser.EXPECT().FindByIds(s.ctx, []string{"user-1"}).Return([]*entities.User{groupMember("user-1", group)}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateMember(s.ctx, group, user, false).Return(errors.New("update failed"))
+	s.logger.EXPECT().Error("failed to update group's members", gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateMember(s.ctx, "engineering", user, false)
+	s.ErrorContains(err, "update failed")
+}
+
+func (s *GroupServiceSuite) TestUpdateMemberRedisError() {
+	group := &entities.Group{ID: 1, Name: "engineering"}
+	user := &entities.User{ID: "user-1"}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(group, nil)
+	s.expectUsers([]*entities.User{groupMember("user-1")}, []*entities.User{groupMember("user-1", group)})
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateMember(s.ctx, group, user, true).Return(nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:user-1").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-1").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-1", "sessions:user-1").Return(errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to delete refresh token in redis", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.groupService.UpdateMember(s.ctx, "engineering", user, true)
+	s.ErrorContains(err, "redis error")
+}
+
+func (s *GroupServiceSuite) TestDelete() {
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	group := &entities.Group{ID: 1, Name: "engineering", Scopes: []*entities.UserScope{view}}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(group, nil)
+	s.mockRepo.EXPECT().FindMemberIds(s.ctx, uint(1)).Return([]string{"user-1"}, nil)
+	s.expectUsers([]*entities.User{groupMember("user-1", group)}, []*entities.User{groupMember("user-1")})
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, uint(1)).Return(nil)
+	s.expectScopesChanged("user-1", []string{}, []string{"container:view"})
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:user-1").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-1").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-1", "sessions:user-1").Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "user-1", gomock.Any()).Return(nil)
+	s.logger.EXPECT().Info("group deleted successfully", gomock.Any()).Times(1)
+
+	err := s.groupService.Delete(s.ctx, "engineering")
+	s.NoError(err)
+}
+
+func (s *GroupServiceSuite) TestDeleteNotFound() {
+	s.mockRepo.EXPECT().FindByName(s.ctx, "missing").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find group", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.groupService.Delete(s.ctx, "missing")
+	s.assertCode(err, dto.CodeGroupNotFound)
+}
+
+func (s *GroupServiceSuite) TestDeleteRepoError() {
+	group := &entities.Group{ID: 1, Name: "engineering"}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "engineering").Return(group, nil)
+	s.mockRepo.EXPECT().FindMemberIds(s.ctx, uint(1)).Return([]string{}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, uint(1)).Return(errors.New("delete failed"))
+	s.logger.EXPECT().Error("failed to delete group", gomock.Any()).Times(1)
+
+	err := s.groupService.Delete(s.ctx, "engineering")
+	s.ErrorContains(err, "delete failed")
+}
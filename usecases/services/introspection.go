@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// IIntrospectionService reports whether an access token is currently active,
+// for resource servers that cannot verify tokens themselves.
+type IIntrospectionService interface {
+	Introspect(ctx context.Context, token string) (*dto.IntrospectionResponse, error)
+}
+
+type introspectionService struct {
+	verifier    middlewares.ITokenVerifier
+	userRepo    repositories.IUserRepository
+	accountRepo repositories.IServiceAccountRepository
+	logger      logger.ILogger
+}
+
+func NewIntrospectionService(verifier middlewares.ITokenVerifier, userRepo repositories.IUserRepository, accountRepo repositories.IServiceAccountRepository, logger logger.ILogger) IIntrospectionService {
+	return &introspectionService{
+		verifier:    verifier,
+		userRepo:    userRepo,
+		accountRepo: accountRepo,
+		logger:      logger,
+	}
+}
+
+// Introspect treats a token as active only when the middleware would accept
+// it and its subject still exists: the user, or for a client_credentials
+// token the service account, which must also not have expired. A caller
+// outside the token's organization sees it as inactive, unless it acts across
+// tenants. Only failing to tell is an error.
+func (s *introspectionService) Introspect(ctx context.Context, token string) (*dto.IntrospectionResponse, error) {
+	inactive := &dto.IntrospectionResponse{Active: false}
+
+	claims, err := s.verifier.Verify(ctx, token)
+	if errors.Is(err, middlewares.ErrRevocationUnavailable) {
+		s.logger.Error("failed to check token revocation", zap.Error(err))
+		return nil, err
+	}
+	if err != nil {
+		s.logger.Info("token introspected successfully", zap.Bool("active", false))
+		return inactive, nil
+	}
+
+	sub, _ := claims["sub"].(string)
+	organizationId, _ := claims[tenancy.Claim].(string)
+	rawScopes, ok := claims["scope"].([]interface{})
+	if sub == "" || organizationId == "" || !ok || !visible(ctx, organizationId) {
+		s.logger.Info("token introspected successfully", zap.Bool("active", false))
+		return inactive, nil
+	}
+
+	granted := make([]string, 0, len(rawScopes))
+	for _, scope := range rawScopes {
+		if name, ok := scope.(string); ok {
+			granted = append(granted, name)
+		}
+	}
+
+	clientId, _ := claims["client_id"].(string)
+	exists, err := s.subjectExists(tenancy.WithTenant(ctx, tenancy.Tenant{OrganizationID: organizationId}), sub, clientId == sub)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		s.logger.Info("token introspected successfully", zap.String("sub", sub), zap.Bool("active", false))
+		return inactive, nil
+	}
+
+	response := &dto.IntrospectionResponse{
+		Active: true,
+		Scope:  strings.Join(granted, " "),
+		Sub:    sub,
+	}
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		response.Exp = exp.Unix()
+	}
+	if response.ClientID = clientId; response.ClientID == "" {
+		response.ClientID, _ = claims["azp"].(string)
+	}
+	s.logger.Info("token introspected successfully", zap.String("sub", sub), zap.Bool("active", true))
+	return response, nil
+}
+
+// subjectExists looks the subject up in the token's own organization.
+func (s *introspectionService) subjectExists(ctx context.Context, sub string, serviceAccount bool) (bool, error) {
+	if serviceAccount {
+		account, err := s.accountRepo.FindById(ctx, sub)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			s.logger.Error("failed to find service account", zap.String("id", sub), zap.Error(err))
+			return false, err
+		}
+		return account.Active(time.Now()), nil
+	}
+
+	_, err := s.userRepo.FindById(ctx, sub)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		s.logger.Error("failed to find user by id", zap.String("id", sub), zap.Error(err))
+		return false, err
+	}
+	return true, nil
+}
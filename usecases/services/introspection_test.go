@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+)
+
+type IntrospectionServiceSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	introspectionService IIntrospectionService
+	mockVerifier         *middlewares.MockITokenVerifier
+	mockUserRepo         *repositories.MockIUserRepository
+	mockAccountRepo      *repositories.MockIServiceAccountRepository
+	logger               *logger.MockILogger
+	ctx                  context.Context
+	exp                  time.Time
+}
+
+func (s *IntrospectionServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockVerifier = middlewares.NewMockITokenVerifier(s.ctrl)
+	s.mockUserRepo = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockAccountRepo = repositories.NewMockIServiceAccountRepository(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.introspectionService = NewIntrospectionService(s.mockVerifier, s.mockUserRepo, s.mockAccountRepo, s.logger)
+	s.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
+	s.exp = time.Now().Add(time.Hour).Truncate(time.Second)
+}
+
+func (s *IntrospectionServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestIntrospectionServiceSuite(t *testing.T) {
+	suite.Run(t, new(IntrospectionServiceSuite))
+}
+
+func (s *IntrospectionServiceSuite) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":       "user1",
+		"tenant":    "acme",
+		"scope":     []interface{}{"container:view", "user:view"},
+		"exp":       float64(s.exp.Unix()),
+		"client_id": "dashboard",
+	}
+}
+
+func (s *IntrospectionServiceSuite) TestIntrospectActive() {
+	s.mockVerifier.EXPECT().Verify(s.ctx, "token").Return(s.claims(), nil)
+	s.mockUserRepo.EXPECT().FindById(gomock.Any(), "user1").DoAndReturn(func(ctx context.Context, id string) (*entities.User, error) {
+		tenant, ok := tenancy.FromContext(ctx)
+		s.True(ok)
+		s.Equal("acme", tenant.OrganizationID)
+		return &entities.User{ID: id}, nil
+	})
+	s.logger.EXPECT().Info("token introspected successfully", gomock.Any()).Times(1)
+
+	response, err := s.introspectionService.Introspect(s.ctx, "token")
+	s.NoError(err)
+	s.Equal(&dto.IntrospectionResponse{
+		Active:   true,
+		Scope:    "container:view user:view",
+		Sub:      "user1",
+		Exp:      s.exp.Unix(),
+		ClientID: "dashboard",
+	}, response)
+}
+
+func (s *IntrospectionServiceSuite) TestIntrospectInvalidToken() {
+	s.mockVerifier.EXPECT().Verify(s.ctx, "token").Return(nil, errors.New("Token has been revoked"))
+	s.logger.EXPECT().Info("token introspected successfully", gomock.Any()).Times(1)
+
+	response, err := s.introspectionService.Introspect(s.ctx, "token")
+	s.NoError(err)
+	s.Equal(&dto.IntrospectionResponse{Active: false}, response)
+}
+
+func (s *IntrospectionServiceSuite) TestIntrospectRevocationUnavailable() {
+	s.mockVerifier.EXPECT().Verify(s.ctx, "token").Return(nil, pkgmiddlewares.ErrRevocationUnavailable)
+	s.logger.EXPECT().Error("failed to check token revocation", gomock.Any()).Times(1)
+
+	_, err := s.introspectionService.Introspect(s.ctx, "token")
+	s.ErrorIs(err, pkgmiddlewares.ErrRevocationUnavailable)
+}
+
+func (s *IntrospectionServiceSuite) TestIntrospectOtherTenant() {
+	claims := s.claims()
+	claims["tenant"] = "globex"
+	s.mockVerifier.EXPECT().Verify(s.ctx, "token").Return(claims, nil)
+	s.logger.EXPECT().Info("token introspected successfully", gomock.Any()).Times(1)
+
+	response, err := s.introspectionService.Introspect(s.ctx, "token")
+	s.NoError(err)
+	s.False(response.Active)
+}
+
+func (s *IntrospectionServiceSuite) TestIntrospectCrossTenant() {
+	ctx := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "platform", Super: true, CrossTenant: true})
+	claims := s.claims()
+	claims["tenant"] = "globex"
+	s.mockVerifier.EXPECT().Verify(ctx, "token").Return(claims, nil)
+	s.mockUserRepo.EXPECT().FindById(gomock.Any(), "user1").Return(&entities.User{ID: "user1"}, nil)
+	s.logger.EXPECT().Info("token introspected successfully", gomock.Any()).Times(1)
+
+	response, err := s.introspectionService.Introspect(ctx, "token")
+	s.NoError(err)
+	s.True(response.Active)
+}
+
+func (s *IntrospectionServiceSuite) TestIntrospectDeletedUser() {
+	s.mockVerifier.EXPECT().Verify(s.ctx, "token").Return(s.claims(), nil)
+	s.mockUserRepo.EXPECT().FindById(gomock.Any(), "user1").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Info("token introspected successfully", gomock.Any()).Times(1)
+
+	response, err := s.introspectionService.Introspect(s.ctx, "token")
+	s.NoError(err)
+	s.Equal(&dto.IntrospectionResponse{Active: false}, response)
+}
+
+func (s *IntrospectionServiceSuite) TestIntrospectRepoError() {
+	s.mockVerifier.EXPECT().Verify(s.ctx, "token").Return(s.claims(), nil)
+	s.mockUserRepo.EXPECT().FindById(gomock.Any(), "user1").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find user by id", gomock.Any()).Times(1)
+
+	_, err := s.introspectionService.Introspect(s.ctx, "token")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *IntrospectionServiceSuite) TestIntrospectServiceAccount() {
+	claims := s.claims()
+	claims["sub"] = "sa1"
+	claims["client_id"] = "sa1"
+	s.mockVerifier.EXPECT().Verify(s.ctx, "token").Return(claims, nil)
+	s.mockAccountRepo.EXPECT().FindById(gomock.Any(), "sa1").Return(&entities.ServiceAccount{ID: "sa1"}, nil)
+	s.logger.EXPECT().Info("token introspected successfully", gomock.Any()).Times(1)
+
+	response, err := s.introspectionService.Introspect(s.ctx, "token")
+	s.NoError(err)
+	s.True(response.Active)
+	s.Equal("sa1", response.ClientID)
+}
+
+func (s *IntrospectionServiceSuite) TestIntrospectExpiredServiceAccount() {
+	claims := s.claims()
+	claims["sub"] = "sa1"
+	claims["client_id"] = "sa1"
+	expired := time.Now().Add(-time.Minute)
+	s.mockVerifier.EXPECT().Verify(s.ctx, "token").Return(claims, nil)
+	s.mockAccountRepo.EXPECT().FindById(gomock.Any(), "sa1").Return(&entities.ServiceAccount{ID: "sa1", ExpiresAt: &expired}, nil)
+	s.logger.EXPECT().Info("token introspected successfully", gomock.Any()).Times(1)
+
+	response, err := s.introspectionService.Introspect(s.ctx, "token")
+	s.NoError(err)
+	s.Equal(&dto.IntrospectionResponse{Active: false}, response)
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+)
+
+type IOrganizationService interface {
+	Create(ctx context.Context, organizationId, name string) (*entities.Organization, error)
+	FindOne(ctx context.Context, organizationId string) (*entities.Organization, error)
+	FindAll(ctx context.Context, query dto.ListOrganizationsRequest) ([]*entities.Organization, *dto.Paging, error)
+}
+
+type organizationService struct {
+	organizationRepo repositories.IOrganizationRepository
+	logger           logger.ILogger
+}
+
+func NewOrganizationService(organizationRepo repositories.IOrganizationRepository, logger logger.ILogger) IOrganizationService {
+	return &organizationService{
+		organizationRepo: organizationRepo,
+		logger:           logger,
+	}
+}
+
+func (s *organizationService) Create(ctx context.Context, organizationId, name string) (*entities.Organization, error) {
+	if err := s.requireSuperAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := tenancy.ValidateOrganizationID(organizationId); err != nil {
+		s.logger.Warn("organization id rejected", zap.String("id", organizationId), zap.Error(err))
+		return nil, apperrors.Validation(dto.CodeInvalidOrganization, "invalid organization id", err)
+	}
+
+	organization, err := s.organizationRepo.Create(ctx, organizationId, name)
+	if err != nil {
+		s.logger.Error("failed to create organization", zap.Error(err))
+		return nil, repositoryError(err, dto.CodeOrganizationNotFound, dto.CodeOrganizationAlreadyExists)
+	}
+
+	s.logger.Info("new organization created successfully", zap.String("id", organizationId))
+	return organization, nil
+}
+
+func (s *organizationService) FindOne(ctx context.Context, organizationId string) (*entities.Organization, error) {
+	if err := s.requireSuperAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	organization, err := s.organizationRepo.FindById(ctx, organizationId)
+	if err != nil {
+		s.logger.Error("failed to find organization", zap.String("id", organizationId), zap.Error(err))
+		return nil, repositoryError(err, dto.CodeOrganizationNotFound, dto.CodeOrganizationAlreadyExists)
+	}
+
+	s.logger.Info("organization found successfully")
+	return organization, nil
+}
+
+func (s *organizationService) FindAll(ctx context.Context, query dto.ListOrganizationsRequest) ([]*entities.Organization, *dto.Paging, error) {
+	if err := s.requireSuperAdmin(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	organizations, paging, err := s.organizationRepo.FindAll(ctx, query)
+	if err != nil {
+		s.logger.Error("failed to find all organizations", zap.Error(err))
+		return nil, nil, repositoryError(err, dto.CodeOrganizationNotFound, dto.CodeOrganizationAlreadyExists)
+	}
+
+	s.logger.Info("all organizations retrieved successfully")
+	return organizations, paging, nil
+}
+
+// requireSuperAdmin keeps organization management away from tenant admins,
+// who could otherwise grant themselves organization:manage in their own
+// scope catalogue. Contexts without a tenant are internal and allowed.
+func (s *organizationService) requireSuperAdmin(ctx context.Context) error {
+	tenant, ok := tenancy.FromContext(ctx)
+	if ok && !tenant.Super {
+		s.logger.Warn("organization management rejected", zap.String("organization", tenant.OrganizationID))
+		return apperrors.Forbidden(dto.CodeTenantForbidden, "only super-admins manage organizations", nil)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+)
+
+type OrganizationServiceSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	organizationService IOrganizationService
+	mockRepo            *repositories.MockIOrganizationRepository
+	logger              *logger.MockILogger
+	ctx                 context.Context
+}
+
+func (s *OrganizationServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIOrganizationRepository(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.organizationService = NewOrganizationService(s.mockRepo, s.logger)
+	s.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "default", Super: true, CrossTenant: true})
+}
+
+func (s *OrganizationServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestOrganizationServiceSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationServiceSuite))
+}
+
+func (s *OrganizationServiceSuite) TestCreate() {
+	expected := &entities.Organization{ID: "acme", Name: "Acme Corp"}
+
+	s.mockRepo.EXPECT().Create(s.ctx, "acme", "Acme Corp").Return(expected, nil)
+	s.logger.EXPECT().Info("new organization created successfully", gomock.Any()).Times(1)
+
+	result, err := s.organizationService.Create(s.ctx, "acme", "Acme Corp")
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *OrganizationServiceSuite) TestCreateInvalidID() {
+	s.logger.EXPECT().Warn("organization id rejected", gomock.Any()).Times(1)
+
+	result, err := s.organizationService.Create(s.ctx, "Acme Corp", "Acme Corp")
+	s.Nil(result)
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeInvalidOrganization, appErr.Code)
+}
+
+func (s *OrganizationServiceSuite) TestCreateDuplicate() {
+	s.mockRepo.EXPECT().Create(s.ctx, "acme", "Acme Corp").Return(nil, gorm.ErrDuplicatedKey)
+	s.logger.EXPECT().Error("failed to create organization", gomock.Any()).Times(1)
+
+	result, err := s.organizationService.Create(s.ctx, "acme", "Acme Corp")
+	s.Nil(result)
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeOrganizationAlreadyExists, appErr.Code)
+}
+
+func (s *OrganizationServiceSuite) TestCreateByTenantAdmin() {
+	ctx := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
+	s.logger.EXPECT().Warn("organization management rejected", gomock.Any()).Times(1)
+
+	result, err := s.organizationService.Create(ctx, "globex", "Globex")
+	s.Nil(result)
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeTenantForbidden, appErr.Code)
+}
+
+func (s *OrganizationServiceSuite) TestFindOne() {
+	expected := &entities.Organization{ID: "acme", Name: "Acme Corp"}
+
+	s.mockRepo.EXPECT().FindById(s.ctx, "acme").Return(expected, nil)
+	s.logger.EXPECT().Info("organization found successfully").Times(1)
+
+	result, err := s.organizationService.FindOne(s.ctx, "acme")
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *OrganizationServiceSuite) TestFindOneNotFound() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "acme").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find organization", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.organizationService.FindOne(s.ctx, "acme")
+	s.Nil(result)
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeOrganizationNotFound, appErr.Code)
+}
+
+func (s *OrganizationServiceSuite) TestFindAll() {
+	query := dto.ListOrganizationsRequest{Limit: 10}
+	expected := []*entities.Organization{{ID: "acme", Name: "Acme Corp"}}
+	paging := &dto.Paging{}
+
+	s.mockRepo.EXPECT().FindAll(s.ctx, query).Return(expected, paging, nil)
+	s.logger.EXPECT().Info("all organizations retrieved successfully").Times(1)
+
+	result, resultPaging, err := s.organizationService.FindAll(s.ctx, query)
+	s.NoError(err)
+	s.Equal(expected, result)
+	s.Equal(paging, resultPaging)
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// enqueueEvent adds a domain event to the outbox in tx, so that it is
+// published only if the change made in tx commits. tx is rolled back when
+// the event cannot be stored.
+func enqueueEvent(ctx context.Context, tx *gorm.DB, outboxRepo repositories.IOutboxRepository, logger logger.ILogger, eventType, organizationId string, data interface{}) error {
+	event, err := events.New(eventType, organizationId, data)
+	if err == nil {
+		err = storeEvent(ctx, outboxRepo.WithTransaction(tx), event)
+	}
+	if err != nil {
+		logger.Error("failed to enqueue domain event", zap.String("type", eventType), zap.Error(err))
+		tx.Rollback()
+		return err
+	}
+	return nil
+}
+
+func storeEvent(ctx context.Context, outboxRepo repositories.IOutboxRepository, event *events.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return outboxRepo.Create(ctx, &entities.OutboxEvent{
+		OrganizationID: event.OrganizationID,
+		EventID:        event.ID,
+		Type:           event.Type,
+		Payload:        string(payload),
+	})
+}
+
+func newUserData(user *entities.User) *events.UserData {
+	return &events.UserData{UserID: user.ID, Username: user.Username, Email: user.Email}
+}
+
+func newScopeData(scope *entities.UserScope) *events.ScopeData {
+	return &events.ScopeData{ScopeID: scope.ID, Name: scope.Name}
+}
+
+// scopesChanged compares the user's effective scopes before and after a
+// change. It reports false when they are the same, such as when only a
+// grant's expiry changed or a role adds nothing the user lacked.
+func scopesChanged(userId string, before, after []string) (*events.UserScopesChangedData, bool) {
+	data := &events.UserScopesChangedData{
+		UserID:  userId,
+		Scopes:  after,
+		Added:   difference(after, before),
+		Removed: difference(before, after),
+	}
+	return data, len(data.Added) > 0 || len(data.Removed) > 0
+}
+
+// difference lists the names in a that are missing from b, in a's order.
+func difference(a, b []string) []string {
+	exclude := make(map[string]struct{}, len(b))
+	for _, name := range b {
+		exclude[name] = struct{}{}
+	}
+	names := []string{}
+	for _, name := range a {
+		if _, ok := exclude[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
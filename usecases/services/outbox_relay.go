@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+)
+
+// IOutboxRelay publishes the domain events in the outbox to the events
+// stream. Run relays on every tick of interval until ctx is cancelled;
+// Relay does a single pass.
+type IOutboxRelay interface {
+	Run(ctx context.Context, interval time.Duration)
+	Relay(ctx context.Context) error
+}
+
+type outboxRelay struct {
+	outboxRepo  repositories.IOutboxRepository
+	redisClient interfaces.IRedisClient
+	env         env.EventsEnv
+	logger      logger.ILogger
+}
+
+func NewOutboxRelay(outboxRepo repositories.IOutboxRepository, redisClient interfaces.IRedisClient, env env.EventsEnv, logger logger.ILogger) IOutboxRelay {
+	return &outboxRelay{
+		outboxRepo:  outboxRepo,
+		redisClient: redisClient,
+		env:         env,
+		logger:      logger,
+	}
+}
+
+func (r *outboxRelay) Run(ctx context.Context, interval time.Duration) {
+	runEvery(ctx, interval, func(ctx context.Context) {
+		if r.Relay(ctx) == nil {
+			r.prune(ctx)
+		}
+	})
+}
+
+// Relay publishes pending events oldest first and marks them published. The
+// relay lock and the events are held in a transaction that lasts until they
+// are marked, so a single replica relays at a time and the others skip the
+// pass. It stops at the first event the stream refuses so that events stay
+// in order, and an event whose mark is lost is published again on a later
+// pass. Consumers drop such duplicates by event ID.
+func (r *outboxRelay) Relay(ctx context.Context) error {
+	tx, err := r.outboxRepo.BeginTransaction(ctx)
+	if err != nil {
+		r.logger.Error("failed to create transaction", zap.Error(err))
+		return err
+	}
+
+	txRepo := r.outboxRepo.WithTransaction(tx)
+	locked, err := txRepo.LockRelay(ctx)
+	if err != nil {
+		r.logger.Error("failed to lock outbox relay", zap.Error(err))
+		tx.Rollback()
+		return err
+	}
+	if !locked {
+		tx.Rollback()
+		return nil
+	}
+	pending, err := txRepo.ClaimUnpublished(ctx, r.env.RelayBatchSize)
+	if err != nil {
+		r.logger.Error("failed to find unpublished outbox events", zap.Error(err))
+		tx.Rollback()
+		return err
+	}
+	if len(pending) == 0 {
+		tx.Rollback()
+		return nil
+	}
+
+	published := make([]uint, 0, len(pending))
+	var publishErr error
+	for _, event := range pending {
+		if _, err := r.redisClient.XAdd(ctx, r.env.Stream, r.env.StreamMaxLen, events.Encoded(event.Payload)); err != nil {
+			r.logger.Error("failed to publish outbox event", zap.String("event_id", event.EventID), zap.Error(err))
+			publishErr = err
+			break
+		}
+		published = append(published, event.ID)
+	}
+
+	if err := txRepo.MarkPublished(ctx, published, time.Now()); err != nil {
+		r.logger.Error("failed to mark outbox events published", zap.Error(err))
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit().Error; err != nil {
+		r.logger.Error("failed to commit transaction", zap.Error(err))
+		return err
+	}
+	if publishErr != nil {
+		return publishErr
+	}
+
+	r.logger.Info("outbox events published successfully", zap.Int("count", len(published)))
+	return nil
+}
+
+// prune deletes the events published longer ago than the retention period.
+func (r *outboxRelay) prune(ctx context.Context) {
+	deleted, err := r.outboxRepo.DeletePublished(ctx, time.Now().Add(-r.env.OutboxRetention))
+	if err != nil {
+		r.logger.Error("failed to delete published outbox events", zap.Error(err))
+		return
+	}
+	if deleted > 0 {
+		r.logger.Info("published outbox events deleted successfully", zap.Int64("count", deleted))
+	}
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	Logger "gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
+)
+
+type OutboxRelaySuite struct {
+	suite.Suite
+	ctrl        *gomock.Controller
+	outboxRelay IOutboxRelay
+	mockRepo    *repositories.MockIOutboxRepository
+	mockTxRepo  *repositories.MockIOutboxRepository
+	mockRedis   *interfaces.MockIRedisClient
+	logger      *logger.MockILogger
+	ctx         context.Context
+}
+
+func (s *OutboxRelaySuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIOutboxRepository(s.ctrl)
+	s.mockTxRepo = repositories.NewMockIOutboxRepository(s.ctrl)
+	s.mockRedis = interfaces.NewMockIRedisClient(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.outboxRelay = NewOutboxRelay(s.mockRepo, s.mockRedis, env.EventsEnv{
+		Stream:          "events",
+		StreamMaxLen:    1000,
+		RelayBatchSize:  10,
+		OutboxRetention: time.Hour,
+	}, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *OutboxRelaySuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestOutboxRelaySuite(t *testing.T) {
+	suite.Run(t, new(OutboxRelaySuite))
+}
+
+func (s *OutboxRelaySuite) newTx() *gorm.DB {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: Logger.Default.LogMode(Logger.Silent),
+	})
+	s.Require().NoError(err)
+
+	tx := gormDB.Begin()
+	s.Require().NoError(tx.Error)
+	return tx
+}
+
+// expectTransaction lets every pass open a transaction in which the events
+// are claimed and marked.
+func (s *OutboxRelaySuite) expectTransaction(ctx context.Context) {
+	s.mockRepo.EXPECT().BeginTransaction(ctx).DoAndReturn(func(context.Context) (*gorm.DB, error) {
+		return s.newTx(), nil
+	}).MinTimes(1)
+	s.mockRepo.EXPECT().WithTransaction(gomock.Any()).Return(s.mockTxRepo).MinTimes(1)
+}
+
+func (s *OutboxRelaySuite) pending() []*entities.OutboxEvent {
+	return []*entities.OutboxEvent{
+		{ID: 1, EventID: "event1", Type: events.UserCreated, Payload: `{"id":"event1"}`},
+		{ID: 2, EventID: "event2", Type: events.UserDeleted, Payload: `{"id":"event2"}`},
+	}
+}
+
+func (s *OutboxRelaySuite) TestRelay() {
+	s.expectTransaction(s.ctx)
+	s.mockTxRepo.EXPECT().LockRelay(s.ctx).Return(true, nil)
+	s.mockTxRepo.EXPECT().ClaimUnpublished(s.ctx, 10).Return(s.pending(), nil)
+	gomock.InOrder(
+		s.mockRedis.EXPECT().XAdd(s.ctx, "events", int64(1000), events.Encoded(`{"id":"event1"}`)).Return("1-0", nil),
+		s.mockRedis.EXPECT().XAdd(s.ctx, "events", int64(1000), events.Encoded(`{"id":"event2"}`)).Return("2-0", nil),
+	)
+	s.mockTxRepo.EXPECT().MarkPublished(s.ctx, []uint{1, 2}, gomock.Any()).Return(nil)
+	s.logger.EXPECT().Info("outbox events published successfully", gomock.Any()).Times(1)
+
+	s.NoError(s.outboxRelay.Relay(s.ctx))
+}
+
+func (s *OutboxRelaySuite) TestRelayNothingPending() {
+	s.expectTransaction(s.ctx)
+	s.mockTxRepo.EXPECT().LockRelay(s.ctx).Return(true, nil)
+	s.mockTxRepo.EXPECT().ClaimUnpublished(s.ctx, 10).Return([]*entities.OutboxEvent{}, nil)
+
+	s.NoError(s.outboxRelay.Relay(s.ctx))
+}
+
+func (s *OutboxRelaySuite) TestRelayLockedElsewhere() {
+	s.expectTransaction(s.ctx)
+	s.mockTxRepo.EXPECT().LockRelay(s.ctx).Return(false, nil)
+
+	s.NoError(s.outboxRelay.Relay(s.ctx))
+}
+
+func (s *OutboxRelaySuite) TestRelayLockError() {
+	s.expectTransaction(s.ctx)
+	s.mockTxRepo.EXPECT().LockRelay(s.ctx).Return(false, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to lock outbox relay", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.outboxRelay.Relay(s.ctx), "db error")
+}
+
+func (s *OutboxRelaySuite) TestRelayRepoError() {
+	s.expectTransaction(s.ctx)
+	s.mockTxRepo.EXPECT().LockRelay(s.ctx).Return(true, nil)
+	s.mockTxRepo.EXPECT().ClaimUnpublished(s.ctx, 10).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find unpublished outbox events", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.outboxRelay.Relay(s.ctx), "db error")
+}
+
+func (s *OutboxRelaySuite) TestRelayBeginTransactionError() {
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(nil, errors.New("transaction error"))
+	s.logger.EXPECT().Error("failed to create transaction", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.outboxRelay.Relay(s.ctx), "transaction error")
+}
+
+func (s *OutboxRelaySuite) TestRelayStopsAtPublishError() {
+	s.expectTransaction(s.ctx)
+	s.mockTxRepo.EXPECT().LockRelay(s.ctx).Return(true, nil)
+	s.mockTxRepo.EXPECT().ClaimUnpublished(s.ctx, 10).Return(s.pending(), nil)
+	s.mockRedis.EXPECT().XAdd(s.ctx, "events", int64(1000), events.Encoded(`{"id":"event1"}`)).Return("1-0", nil)
+	s.mockRedis.EXPECT().XAdd(s.ctx, "events", int64(1000), events.Encoded(`{"id":"event2"}`)).Return("", errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to publish outbox event", gomock.Any(), gomock.Any()).Times(1)
+	s.mockTxRepo.EXPECT().MarkPublished(s.ctx, []uint{1}, gomock.Any()).Return(nil)
+
+	s.ErrorContains(s.outboxRelay.Relay(s.ctx), "redis error")
+}
+
+func (s *OutboxRelaySuite) TestRelayMarkError() {
+	s.expectTransaction(s.ctx)
+	s.mockTxRepo.EXPECT().LockRelay(s.ctx).Return(true, nil)
+	s.mockTxRepo.EXPECT().ClaimUnpublished(s.ctx, 10).Return(s.pending(), nil)
+	s.mockRedis.EXPECT().XAdd(s.ctx, "events", int64(1000), gomock.Any()).Return("1-0", nil).Times(2)
+	s.mockTxRepo.EXPECT().MarkPublished(s.ctx, []uint{1, 2}, gomock.Any()).Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to mark outbox events published", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.outboxRelay.Relay(s.ctx), "db error")
+}
+
+func (s *OutboxRelaySuite) TestRunPrunesAfterRelay() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.expectTransaction(ctx)
+	s.mockTxRepo.EXPECT().LockRelay(ctx).Return(true, nil).MinTimes(1)
+	s.mockTxRepo.EXPECT().ClaimUnpublished(ctx, 10).Return(nil, nil).MinTimes(1)
+	s.mockRepo.EXPECT().DeletePublished(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+		s.WithinDuration(time.Now().Add(-time.Hour), before, time.Minute)
+		cancel()
+		return 2, nil
+	}).MinTimes(1)
+	s.logger.EXPECT().Info("published outbox events deleted successfully", gomock.Any()).MinTimes(1)
+
+	done := make(chan struct{})
+	go func() {
+		s.outboxRelay.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("relay did not stop after the context was cancelled")
+	}
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+)
+
+type IRoleService interface {
+	Create(ctx context.Context, roleName string, scopes []*entities.UserScope) (*entities.Role, error)
+	FindOne(ctx context.Context, roleName string) (*entities.Role, error)
+	FindMany(ctx context.Context, roleNames []string) ([]*entities.Role, error)
+	FindAll(ctx context.Context, query dto.ListRolesRequest) ([]*entities.Role, *dto.Paging, error)
+	UpdateScope(ctx context.Context, roleName string, scope *entities.UserScope, isAdded bool) error
+	Delete(ctx context.Context, roleName string) error
+}
+
+type roleService struct {
+	roleRepo    repositories.IRoleRepository
+	userRepo    repositories.IUserRepository
+	outboxRepo  repositories.IOutboxRepository
+	redisClient interfaces.IRedisClient
+	revocations revocation.IStore
+	logger      logger.ILogger
+}
+
+func NewRoleService(roleRepo repositories.IRoleRepository, userRepo repositories.IUserRepository, outboxRepo repositories.IOutboxRepository, redisClient interfaces.IRedisClient, revocations revocation.IStore, logger logger.ILogger) IRoleService {
+	return &roleService{
+		roleRepo:    roleRepo,
+		userRepo:    userRepo,
+		outboxRepo:  outboxRepo,
+		redisClient: redisClient,
+		revocations: revocations,
+		logger:      logger,
+	}
+}
+
+func (s *roleService) Create(ctx context.Context, roleName string, scopes []*entities.UserScope) (*entities.Role, error) {
+	role, err := s.roleRepo.Create(ctx, roleName, scopes)
+	if err != nil {
+		s.logger.Error("failed to create role", zap.Error(err))
+		return nil, repositoryError(err, dto.CodeRoleNotFound, dto.CodeRoleAlreadyExists)
+	}
+
+	s.logger.Info("new role created successfully")
+	return role, nil
+}
+
+func (s *roleService) FindOne(ctx context.Context, roleName string) (*entities.Role, error) {
+	role, err := s.roleRepo.FindByName(ctx, roleName)
+	if err != nil {
+		s.logger.Error("failed to find role", zap.String("name", roleName), zap.Error(err))
+		return nil, repositoryError(err, dto.CodeRoleNotFound, dto.CodeRoleAlreadyExists)
+	}
+
+	s.logger.Info("role found successfully")
+	return role, nil
+}
+
+func (s *roleService) FindMany(ctx context.Context, roleNames []string) ([]*entities.Role, error) {
+	tx, err := s.roleRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to create transaction", zap.Error(err))
+		return nil, err
+	}
+
+	txRepo := s.roleRepo.WithTransaction(tx)
+	roles := make([]*entities.Role, 0, len(roleNames))
+	for _, roleName := range roleNames {
+		role, err := txRepo.FindByName(ctx, roleName)
+		if err != nil {
+			s.logger.Error("failed to find role", zap.String("name", roleName), zap.Error(err))
+			tx.Rollback()
+			return nil, repositoryError(err, dto.CodeRoleNotFound, dto.CodeRoleAlreadyExists)
+		}
+		roles = append(roles, role)
+	}
+	if err := tx.Commit().Error; err != nil {
+		s.logger.Error("failed to commit transaction", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("all roles found successfully")
+	return roles, nil
+}
+
+func (s *roleService) FindAll(ctx context.Context, query dto.ListRolesRequest) ([]*entities.Role, *dto.Paging, error) {
+	roles, paging, err := s.roleRepo.FindAll(ctx, query)
+	if err != nil {
+		s.logger.Error("failed to find all roles", zap.Error(err))
+		return nil, nil, repositoryError(err, dto.CodeRoleNotFound, dto.CodeRoleAlreadyExists)
+	}
+
+	s.logger.Info("all roles retrieved successfully")
+	return roles, paging, nil
+}
+
+// UpdateScope adds or removes a scope from the role. Every holder whose
+// effective scopes change gets user.scopes_changed, and every holder has its
+// refresh token revoked so the next token carries the new scopes.
+func (s *roleService) UpdateScope(ctx context.Context, roleName string, scope *entities.UserScope, isAdded bool) error {
+	role, err := s.roleRepo.FindByName(ctx, roleName)
+	if err != nil {
+		s.logger.Error("failed to find role", zap.String("name", roleName), zap.Error(err))
+		return repositoryError(err, dto.CodeRoleNotFound, dto.CodeRoleAlreadyExists)
+	}
+
+	scopeList := make([]*entities.UserScope, 0, len(role.Scopes))
+	for _, s := range role.Scopes {
+		if s.ID == scope.ID {
+			continue
+		}
+		scopeList = append(scopeList, s)
+	}
+	if isAdded {
+		scopeList = append(scopeList, scope)
+	}
+
+	changes, err := s.captureHolders(ctx, role)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.roleRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to create transaction", zap.Error(err))
+		return err
+	}
+
+	if err := s.roleRepo.WithTransaction(tx).UpdateScope(ctx, role, scopeList); err != nil {
+		s.logger.Error("failed to update role's scopes", zap.Error(err))
+		tx.Rollback()
+		return repositoryError(err, dto.CodeScopeNotFound, dto.CodeScopeAlreadyExists)
+	}
+	if err := changes.commit(ctx, tx, s.userRepo, s.outboxRepo, s.logger); err != nil {
+		return err
+	}
+
+	if err := changes.revoke(ctx, s.redisClient, s.revocations, s.logger); err != nil {
+		return err
+	}
+
+	s.logger.Info("role's scopes updated successfully", zap.String("name", roleName))
+	return nil
+}
+
+func (s *roleService) Delete(ctx context.Context, roleName string) error {
+	role, err := s.roleRepo.FindByName(ctx, roleName)
+	if err != nil {
+		s.logger.Error("failed to find role", zap.String("name", roleName), zap.Error(err))
+		return repositoryError(err, dto.CodeRoleNotFound, dto.CodeRoleAlreadyExists)
+	}
+
+	// Holders are looked up before the mappings are removed with the role.
+	changes, err := s.captureHolders(ctx, role)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.roleRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to create transaction", zap.Error(err))
+		return err
+	}
+
+	if err := s.roleRepo.WithTransaction(tx).Delete(ctx, role.ID); err != nil {
+		s.logger.Error("failed to delete role", zap.Error(err))
+		tx.Rollback()
+		return repositoryError(err, dto.CodeRoleNotFound, dto.CodeRoleAlreadyExists)
+	}
+	if err := changes.commit(ctx, tx, s.userRepo, s.outboxRepo, s.logger); err != nil {
+		return err
+	}
+
+	if err := changes.revoke(ctx, s.redisClient, s.revocations, s.logger); err != nil {
+		return err
+	}
+
+	s.logger.Info("role deleted successfully", zap.String("name", roleName))
+	return nil
+}
+
+// captureHolders captures the scopes of every holder of the role.
+func (s *roleService) captureHolders(ctx context.Context, role *entities.Role) (*scopeChanges, error) {
+	userIds, err := s.roleRepo.FindUserIds(ctx, role.ID)
+	if err != nil {
+		s.logger.Error("failed to find role holders", zap.Error(err))
+		return nil, err
+	}
+	return captureScopes(ctx, s.userRepo, s.logger, userIds)
+}
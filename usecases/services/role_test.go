@@ -0,0 +1,415 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	Logger "gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
+)
+
+type RoleServiceSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	roleService  IRoleService
+	mockRepo     *repositories.MockIRoleRepository
+	mockTxRepo   *repositories.MockIRoleRepository
+	mockUser     *repositories.MockIUserRepository
+	mockTxUser   *repositories.MockIUserRepository
+	mockOutbox   *repositories.MockIOutboxRepository
+	mockTxOutbox *repositories.MockIOutboxRepository
+	mockRedis    *interfaces.MockIRedisClient
+	mockRevoke   *revocation.MockIStore
+	logger       *logger.MockILogger
+	ctx          context.Context
+}
+
+func (s *RoleServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIRoleRepository(s.ctrl)
+	s.mockTxRepo = repositories.NewMockIRoleRepository(s.ctrl)
+	s.mockUser = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockTxUser = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockOutbox = repositories.NewMockIOutboxRepository(s.ctrl)
+	s.mockTxOutbox = repositories.NewMockIOutboxRepository(s.ctrl)
+	s.mockRedis = interfaces.NewMockIRedisClient(s.ctrl)
+	s.mockRevoke = revocation.NewMockIStore(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.roleService = NewRoleService(s.mockRepo, s.mockUser, s.mockOutbox, s.mockRedis, s.mockRevoke, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *RoleServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestRoleServiceSuite(t *testing.T) {
+	suite.Run(t, new(RoleServiceSuite))
+}
+
+func (s *RoleServiceSuite) TestCreate() {
+	scopes := []*entities.UserScope{{ID: 1, Name: "container:view"}}
+	expected := &entities.Role{ID: 1, Name: "operator", Scopes: scopes}
+
+	s.mockRepo.EXPECT().Create(s.ctx, "operator", scopes).Return(expected, nil)
+	s.logger.EXPECT().Info("new role created successfully").Times(1)
+
+	result, err := s.roleService.Create(s.ctx, "operator", scopes)
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *RoleServiceSuite) TestCreateDuplicate() {
+	s.mockRepo.EXPECT().Create(s.ctx, "operator", nil).Return(nil, gorm.ErrDuplicatedKey)
+	s.logger.EXPECT().Error("failed to create role", gomock.Any()).Times(1)
+
+	result, err := s.roleService.Create(s.ctx, "operator", nil)
+	s.Nil(result)
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeRoleAlreadyExists, appErr.Code)
+}
+
+func (s *RoleServiceSuite) TestFindOne() {
+	expected := &entities.Role{ID: 1, Name: "operator"}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(expected, nil)
+	s.logger.EXPECT().Info("role found successfully").Times(1)
+
+	result, err := s.roleService.FindOne(s.ctx, "operator")
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *RoleServiceSuite) TestFindOneNotFound() {
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find role", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.roleService.FindOne(s.ctx, "operator")
+	s.Nil(result)
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeRoleNotFound, appErr.Code)
+}
+
+func (s *RoleServiceSuite) newTx() *gorm.DB {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: Logger.Default.LogMode(Logger.Silent),
+	})
+	assert.NoError(s.T(), err)
+
+	tx := gormDB.Begin()
+	assert.NoError(s.T(), tx.Error)
+	return tx
+}
+
+func (s *RoleServiceSuite) TestFindMany() {
+	tx := s.newTx()
+	expected := []*entities.Role{{ID: 1, Name: "operator"}, {ID: 2, Name: "auditor"}}
+	mockTxRepo := repositories.NewMockIRoleRepository(s.ctrl)
+
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(mockTxRepo)
+	mockTxRepo.EXPECT().FindByName(s.ctx, "operator").Return(expected[0], nil)
+	mockTxRepo.EXPECT().FindByName(s.ctx, "auditor").Return(expected[1], nil)
+	s.logger.EXPECT().Info("all roles found successfully").Times(1)
+
+	result, err := s.roleService.FindMany(s.ctx, []string{"operator", "auditor"})
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *RoleServiceSuite) TestFindManyError() {
+	tx := s.newTx()
+	mockTxRepo := repositories.NewMockIRoleRepository(s.ctrl)
+
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(mockTxRepo)
+	mockTxRepo.EXPECT().FindByName(s.ctx, "operator").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find role", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.roleService.FindMany(s.ctx, []string{"operator"})
+	s.Nil(result)
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeRoleNotFound, appErr.Code)
+}
+
+func (s *RoleServiceSuite) TestFindManyBeginTransactionError() {
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(nil, errors.New("transaction error"))
+	s.logger.EXPECT().Error("failed to create transaction", gomock.Any()).Times(1)
+
+	result, err := s.roleService.FindMany(s.ctx, []string{"operator"})
+	s.ErrorContains(err, "transaction error")
+	s.Nil(result)
+}
+
+func (s *RoleServiceSuite) TestFindAll() {
+	expected := []*entities.Role{{ID: 1, Name: "operator"}}
+	paging := &dto.Paging{Limit: 20}
+
+	s.mockRepo.EXPECT().FindAll(s.ctx, dto.ListRolesRequest{}).Return(expected, paging, nil)
+	s.logger.EXPECT().Info("all roles retrieved successfully").Times(1)
+
+	result, resultPaging, err := s.roleService.FindAll(s.ctx, dto.ListRolesRequest{})
+	s.NoError(err)
+	s.Equal(expected, result)
+	s.Equal(paging, resultPaging)
+}
+
+func (s *RoleServiceSuite) TestFindAllError() {
+	s.mockRepo.EXPECT().FindAll(s.ctx, gomock.Any()).Return(nil, nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find all roles", gomock.Any()).Times(1)
+
+	result, _, err := s.roleService.FindAll(s.ctx, dto.ListRolesRequest{})
+	s.ErrorContains(err, "db error")
+	s.Nil(result)
+}
+
+// expectTransaction lets the service open a transaction and bind the role,
+// user and outbox repositories to it.
+func (s *RoleServiceSuite) expectTransaction() *gorm.DB {
+	tx := s.newTx()
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(s.mockTxRepo)
+	s.mockUser.EXPECT().WithTransaction(tx).Return(s.mockTxUser).AnyTimes()
+	s.mockOutbox.EXPECT().WithTransaction(tx).Return(s.mockTxOutbox).AnyTimes()
+	return tx
+}
+
+// expectHolders returns the holders of role before the change and of after
+// from within its transaction.
+func (s *RoleServiceSuite) expectHolders(userIds []string, before, after *entities.Role) {
+	holders := func(role *entities.Role) []*entities.User {
+		users := make([]*entities.User, len(userIds))
+		for i, userId := range userIds {
+			users[i] = &entities.User{ID: userId, OrganizationID: "acme", Roles: []*entities.Role{role}}
+		}
+		return users
+	}
+	s.mockUser.EXPECT().FindByIds(s.ctx, userIds).Return(holders(before), nil)
+	s.mockTxUser.EXPECT().FindByIds(s.ctx, userIds).Return(holders(after), nil)
+}
+
+// expectScopesChanged checks that the change enqueues user.scopes_changed
+// for userId with the given difference.
+func (s *RoleServiceSuite) expectScopesChanged(userId string, added, removed []string) {
+	s.mockTxOutbox.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *entities.OutboxEvent) error {
+		s.Equal(events.UserScopesChanged, event.Type)
+		s.Equal("acme", event.OrganizationID)
+		parsed, err := events.Parse(events.Encoded(event.Payload))
+		s.Require().NoError(err)
+		var data events.UserScopesChangedData
+		s.Require().NoError(parsed.Decode(&data))
+		s.Equal(userId, data.UserID)
+		s.Equal(added, data.Added)
+		s.Equal(removed, data.Removed)
+		return nil
+	})
+}
+
+func (s *RoleServiceSuite) TestUpdateScopeRevokesHolders() {
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	update := &entities.UserScope{ID: 2, Name: "container:update"}
+	role := &entities.Role{ID: 7, Name: "operator", Scopes: []*entities.UserScope{view}}
+	updated := &entities.Role{ID: 7, Name: "operator", Scopes: []*entities.UserScope{view, update}}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(role, nil)
+	s.mockRepo.EXPECT().FindUserIds(s.ctx, uint(7)).Return([]string{"user-1", "user-2"}, nil)
+	s.expectHolders([]string{"user-1", "user-2"}, role, updated)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, role, []*entities.UserScope{view, update}).Return(nil)
+	s.expectScopesChanged("user-1", []string{"container:update"}, []string{})
+	s.expectScopesChanged("user-2", []string{"container:update"}, []string{})
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:user-1", "authz:scopes:user-2").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-1").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-1", "sessions:user-1").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-2").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-2", "sessions:user-2").Return(nil)
+	s.logger.EXPECT().Info("role's scopes updated successfully", gomock.Any()).Times(1)
+
+	err := s.roleService.UpdateScope(s.ctx, "operator", update, true)
+	s.NoError(err)
+}
+
+func (s *RoleServiceSuite) TestUpdateScopeRemove() {
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	update := &entities.UserScope{ID: 2, Name: "container:update"}
+	role := &entities.Role{ID: 7, Name: "operator", Scopes: []*entities.UserScope{view, update}}
+	updated := &entities.Role{ID: 7, Name: "operator", Scopes: []*entities.UserScope{view}}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(role, nil)
+	s.mockRepo.EXPECT().FindUserIds(s.ctx, uint(7)).Return([]string{"user-1"}, nil)
+	s.expectHolders([]string{"user-1"}, role, updated)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, role, []*entities.UserScope{view}).Return(nil)
+	s.expectScopesChanged("user-1", []string{}, []string{"container:update"})
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:user-1").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-1").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-1", "sessions:user-1").Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "user-1", gomock.Any()).Return(nil)
+	s.logger.EXPECT().Info("role's scopes updated successfully", gomock.Any()).Times(1)
+
+	err := s.roleService.UpdateScope(s.ctx, "operator", &entities.UserScope{ID: 2}, false)
+	s.NoError(err)
+}
+
+func (s *RoleServiceSuite) TestUpdateScopeRoleNotFound() {
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find role", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.roleService.UpdateScope(s.ctx, "operator", &entities.UserScope{ID: 1}, true)
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeRoleNotFound, appErr.Code)
+}
+
+func (s *RoleServiceSuite) TestUpdateScopeRepoError() {
+	role := &entities.Role{ID: 7, Name: "operator"}
+	scope := &entities.UserScope{ID: 1}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(role, nil)
+	s.mockRepo.EXPECT().FindUserIds(s.ctx, uint(7)).Return([]string{}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, role, []*entities.UserScope{scope}).Return(errors.New("update failed"))
+	s.logger.EXPECT().Error("failed to update role's scopes", gomock.Any()).Times(1)
+
+	err := s.roleService.UpdateScope(s.ctx, "operator", scope, true)
+	s.ErrorContains(err, "update failed")
+}
+
+func (s *RoleServiceSuite) TestUpdateScopeHoldersError() {
+	role := &entities.Role{ID: 7, Name: "operator"}
+	scope := &entities.UserScope{ID: 1}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(role, nil)
+	s.mockRepo.EXPECT().FindUserIds(s.ctx, uint(7)).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find role holders", gomock.Any()).Times(1)
+
+	err := s.roleService.UpdateScope(s.ctx, "operator", scope, true)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *RoleServiceSuite) TestUpdateScopeHolderNotFound() {
+	role := &entities.Role{ID: 7, Name: "operator"}
+	scope := &entities.UserScope{ID: 1}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(role, nil)
+	s.mockRepo.EXPECT().FindUserIds(s.ctx, uint(7)).Return([]string{"user-1"}, nil)
+	s.mockUser.EXPECT().FindByIds(s.ctx, []string{"user-1"}).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find users by id", gomock.Any()).Times(1)
+
+	err := s.roleService.UpdateScope(s.ctx, "operator", scope, true)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *RoleServiceSuite) TestUpdateScopeRedisErrorRevokesRemaining() {
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	role := &entities.Role{ID: 7, Name: "operator"}
+	updated := &entities.Role{ID: 7, Name: "operator", Scopes: []*entities.UserScope{view}}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(role, nil)
+	s.mockRepo.EXPECT().FindUserIds(s.ctx, uint(7)).Return([]string{"user-1", "user-2"}, nil)
+	s.expectHolders([]string{"user-1", "user-2"}, role, updated)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, role, []*entities.UserScope{view}).Return(nil)
+	s.mockTxOutbox.EXPECT().Create(s.ctx, gomock.Any()).Return(nil).Times(2)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:user-1", "authz:scopes:user-2").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-1").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-1", "sessions:user-1").Return(errors.New("redis error"))
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-2").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-2", "sessions:user-2").Return(nil)
+	s.logger.EXPECT().Error("failed to delete refresh token in redis", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.roleService.UpdateScope(s.ctx, "operator", view, true)
+	s.ErrorContains(err, "redis error")
+}
+
+func (s *RoleServiceSuite) TestDelete() {
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	role := &entities.Role{ID: 7, Name: "operator", Scopes: []*entities.UserScope{view}}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(role, nil)
+	s.mockRepo.EXPECT().FindUserIds(s.ctx, uint(7)).Return([]string{"user-1"}, nil)
+	s.expectHolders([]string{"user-1"}, role, &entities.Role{})
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, uint(7)).Return(nil)
+	s.expectScopesChanged("user-1", []string{}, []string{"container:view"})
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:user-1").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-1").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-1", "sessions:user-1").Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "user-1", gomock.Any()).Return(nil)
+	s.logger.EXPECT().Info("role deleted successfully", gomock.Any()).Times(1)
+
+	err := s.roleService.Delete(s.ctx, "operator")
+	s.NoError(err)
+}
+
+func (s *RoleServiceSuite) TestDeleteRevokeError() {
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	role := &entities.Role{ID: 7, Name: "operator", Scopes: []*entities.UserScope{view}}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(role, nil)
+	s.mockRepo.EXPECT().FindUserIds(s.ctx, uint(7)).Return([]string{"user-1"}, nil)
+	s.expectHolders([]string{"user-1"}, role, &entities.Role{})
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, uint(7)).Return(nil)
+	s.expectScopesChanged("user-1", []string{}, []string{"container:view"})
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:user-1").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user-1").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user-1", "sessions:user-1").Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "user-1", gomock.Any()).Return(errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to revoke user's access tokens", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.roleService.Delete(s.ctx, "operator")
+	s.ErrorContains(err, "redis error")
+}
+
+func (s *RoleServiceSuite) TestDeleteNotFound() {
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find role", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.roleService.Delete(s.ctx, "operator")
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeRoleNotFound, appErr.Code)
+}
+
+func (s *RoleServiceSuite) TestDeleteHoldersError() {
+	role := &entities.Role{ID: 7, Name: "operator"}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(role, nil)
+	s.mockRepo.EXPECT().FindUserIds(s.ctx, uint(7)).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find role holders", gomock.Any()).Times(1)
+
+	err := s.roleService.Delete(s.ctx, "operator")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *RoleServiceSuite) TestDeleteRepoError() {
+	role := &entities.Role{ID: 7, Name: "operator"}
+
+	s.mockRepo.EXPECT().FindByName(s.ctx, "operator").Return(role, nil)
+	s.mockRepo.EXPECT().FindUserIds(s.ctx, uint(7)).Return([]string{"user-1"}, nil)
+	s.mockUser.EXPECT().FindByIds(s.ctx, []string{"user-1"}).Return([]*entities.User{&entities.User{ID: "user-1", Roles: []*entities.Role{role}}}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, uint(7)).Return(errors.New("delete failed"))
+	s.logger.EXPECT().Error("failed to delete role", gomock.Any()).Times(1)
+
+	err := s.roleService.Delete(s.ctx, "operator")
+	s.ErrorContains(err, "delete failed")
+}
@@ -2,9 +2,16 @@ package services
 
 import (
 	"context"
+	"strconv"
 
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
 	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
 	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/scopes"
 	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
 	"go.uber.org/zap"
 )
@@ -13,26 +20,55 @@ type IScopeService interface {
 	Create(ctx context.Context, scopeName string) (*entities.UserScope, error)
 	FindOne(ctx context.Context, scopeName string) (*entities.UserScope, error)
 	FindMany(ctx context.Context, scopeNames []string) ([]*entities.UserScope, error)
-	FindAll(ctx context.Context) ([]*entities.UserScope, error)
+	FindAll(ctx context.Context, query dto.ListScopesRequest) ([]*entities.UserScope, *dto.Paging, error)
+	Expand(ctx context.Context, pattern string) ([]*entities.UserScope, error)
 	Delete(ctx context.Context, scopeName string) error
 }
 
 type scopeService struct {
-	scopeRepo repositories.IScopeRepository
-	logger    logger.ILogger
+	scopeRepo   repositories.IScopeRepository
+	userRepo    repositories.IUserRepository
+	auditRepo   repositories.IAuditRepository
+	outboxRepo  repositories.IOutboxRepository
+	redisClient interfaces.IRedisClient
+	revocations revocation.IStore
+	logger      logger.ILogger
 }
 
-func NewScopeService(scopeRepo repositories.IScopeRepository, logger logger.ILogger) IScopeService {
+func NewScopeService(scopeRepo repositories.IScopeRepository, userRepo repositories.IUserRepository, auditRepo repositories.IAuditRepository, outboxRepo repositories.IOutboxRepository, redisClient interfaces.IRedisClient, revocations revocation.IStore, logger logger.ILogger) IScopeService {
 	return &scopeService{
-		scopeRepo: scopeRepo,
-		logger:    logger,
+		scopeRepo:   scopeRepo,
+		userRepo:    userRepo,
+		auditRepo:   auditRepo,
+		outboxRepo:  outboxRepo,
+		redisClient: redisClient,
+		revocations: revocations,
+		logger:      logger,
 	}
 }
 
 func (s *scopeService) Create(ctx context.Context, scopeName string) (*entities.UserScope, error) {
-	scope, err := s.scopeRepo.Create(scopeName)
+	if err := scopes.Validate(scopeName); err != nil {
+		s.logger.Warn("scope name rejected", zap.String("name", scopeName), zap.Error(err))
+		return nil, apperrors.Validation(dto.CodeInvalidScope, "invalid scope name", err)
+	}
+
+	tx, err := s.scopeRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to create transaction", zap.Error(err))
+		return nil, err
+	}
+
+	scope, err := s.scopeRepo.WithTransaction(tx).Create(ctx, scopeName)
 	if err != nil {
 		s.logger.Error("failed to create scope", zap.Error(err))
+		tx.Rollback()
+		return nil, repositoryError(err, dto.CodeScopeNotFound, dto.CodeScopeAlreadyExists)
+	}
+	if err := enqueueEvent(ctx, tx, s.outboxRepo, s.logger, events.ScopeCreated, scope.OrganizationID, newScopeData(scope)); err != nil {
+		return nil, err
+	}
+	if err := commitAudited(ctx, tx, s.auditRepo, s.logger, scopeAuditEntry(scope, entities.AuditScopeCreate), nil, newScopeSnapshot(scope)); err != nil {
 		return nil, err
 	}
 
@@ -41,10 +77,10 @@ func (s *scopeService) Create(ctx context.Context, scopeName string) (*entities.
 }
 
 func (s *scopeService) FindOne(ctx context.Context, scopeName string) (*entities.UserScope, error) {
-	scope, err := s.scopeRepo.FindByName(scopeName)
+	scope, err := s.scopeRepo.FindByName(ctx, scopeName)
 	if err != nil {
 		s.logger.Error("failed to find scope", zap.String("name", scopeName), zap.Error(err))
-		return nil, err
+		return nil, repositoryError(err, dto.CodeScopeNotFound, dto.CodeScopeAlreadyExists)
 	}
 
 	s.logger.Info("scope found successfully")
@@ -61,11 +97,11 @@ func (s *scopeService) FindMany(ctx context.Context, scopeNames []string) ([]*en
 	txRepo := s.scopeRepo.WithTransaction(tx)
 	scopes := make([]*entities.UserScope, 0, len(scopeNames))
 	for _, scopeName := range scopeNames {
-		scope, err := txRepo.FindByName(scopeName)
+		scope, err := txRepo.FindByName(ctx, scopeName)
 		if err != nil {
 			s.logger.Error("failed to find scope", zap.String("name", scopeName), zap.Error(err))
 			tx.Rollback()
-			return nil, err
+			return nil, repositoryError(err, dto.CodeScopeNotFound, dto.CodeScopeAlreadyExists)
 		}
 		scopes = append(scopes, scope)
 	}
@@ -78,24 +114,97 @@ func (s *scopeService) FindMany(ctx context.Context, scopeNames []string) ([]*en
 	return scopes, nil
 }
 
-func (s *scopeService) FindAll(ctx context.Context) ([]*entities.UserScope, error) {
-	scopes, err := s.scopeRepo.FindAll()
+func (s *scopeService) FindAll(ctx context.Context, query dto.ListScopesRequest) ([]*entities.UserScope, *dto.Paging, error) {
+	scopes, paging, err := s.scopeRepo.FindAll(ctx, query)
 	if err != nil {
 		s.logger.Error("failed to find all scopes", zap.Error(err))
-		return nil, err
+		return nil, nil, repositoryError(err, dto.CodeScopeNotFound, dto.CodeScopeAlreadyExists)
 	}
 
 	s.logger.Info("all scopes retrieved successfully")
-	return scopes, nil
+	return scopes, paging, nil
 }
 
+// Expand lists the concrete scopes a pattern grants. Pattern scopes stored
+// alongside them are left out.
+func (s *scopeService) Expand(ctx context.Context, pattern string) ([]*entities.UserScope, error) {
+	if err := scopes.Validate(pattern); err != nil {
+		s.logger.Warn("scope pattern rejected", zap.String("pattern", pattern), zap.Error(err))
+		return nil, apperrors.Validation(dto.CodeInvalidScope, "invalid scope pattern", err)
+	}
+
+	names, err := s.scopeRepo.ListNames(ctx)
+	if err != nil {
+		s.logger.Error("failed to list scope names", zap.Error(err))
+		return nil, repositoryError(err, dto.CodeScopeNotFound, dto.CodeScopeAlreadyExists)
+	}
+
+	expanded, err := s.FindMany(ctx, scopes.Expand(pattern, names))
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("scope pattern expanded successfully", zap.String("pattern", pattern), zap.Int("count", len(expanded)))
+	return expanded, nil
+}
+
+// Delete removes the scope and every grant of it. Each user who held it,
+// directly or through a role or group, gets user.scopes_changed.
 func (s *scopeService) Delete(ctx context.Context, scopeName string) error {
-	err := s.scopeRepo.Delete(scopeName)
+	scope, err := s.scopeRepo.FindByName(ctx, scopeName)
+	if err != nil {
+		s.logger.Error("failed to find scope", zap.String("name", scopeName), zap.Error(err))
+		return repositoryError(err, dto.CodeScopeNotFound, dto.CodeScopeAlreadyExists)
+	}
+
+	// Holders are looked up before the grants are removed with the scope.
+	userIds, err := s.scopeRepo.FindHolderIds(ctx, scope.ID)
+	if err != nil {
+		s.logger.Error("failed to find scope holders", zap.Error(err))
+		return err
+	}
+	changes, err := captureScopes(ctx, s.userRepo, s.logger, userIds)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.scopeRepo.BeginTransaction(ctx)
 	if err != nil {
+		s.logger.Error("failed to create transaction", zap.Error(err))
+		return err
+	}
+
+	if err := s.scopeRepo.WithTransaction(tx).Delete(ctx, scopeName); err != nil {
 		s.logger.Error("failed to delete scope", zap.Error(err))
+		tx.Rollback()
+		return repositoryError(err, dto.CodeScopeNotFound, dto.CodeScopeAlreadyExists)
+	}
+	if err := enqueueEvent(ctx, tx, s.outboxRepo, s.logger, events.ScopeDeleted, scope.OrganizationID, newScopeData(scope)); err != nil {
+		return err
+	}
+	if err := changes.reload(ctx, tx, s.userRepo, s.logger); err != nil {
+		return err
+	}
+	if err := changes.enqueue(ctx, tx, s.outboxRepo, s.logger); err != nil {
+		return err
+	}
+	if err := commitAudited(ctx, tx, s.auditRepo, s.logger, scopeAuditEntry(scope, entities.AuditScopeDelete), newScopeSnapshot(scope), nil); err != nil {
+		return err
+	}
+
+	if err := changes.revoke(ctx, s.redisClient, s.revocations, s.logger); err != nil {
 		return err
 	}
 
 	s.logger.Info("scope deleted successfully", zap.String("name", scopeName))
 	return nil
 }
+
+func scopeAuditEntry(scope *entities.UserScope, action string) *entities.AuditEntry {
+	return &entities.AuditEntry{
+		OrganizationID: scope.OrganizationID,
+		Action:         action,
+		TargetType:     entities.AuditTargetScope,
+		TargetID:       strconv.FormatUint(uint64(scope.ID), 10),
+	}
+}
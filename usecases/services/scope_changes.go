@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// scopeChanges follows the users whose effective scopes a change to shared
+// grants may alter, such as a role losing a scope or a grant expiring. The
+// users are captured before the change and reloaded inside its transaction,
+// so each of them can be published, audited and revoked like a change made
+// to the user directly.
+type scopeChanges struct {
+	userIds []string
+	before  map[string]*entities.User
+	after   map[string]*entities.User
+}
+
+// captureScopes loads the users as they are before the change.
+func captureScopes(ctx context.Context, userRepo repositories.IUserRepository, logger logger.ILogger, userIds []string) (*scopeChanges, error) {
+	before, err := findUsers(ctx, userRepo, logger, userIds)
+	if err != nil {
+		return nil, err
+	}
+	return &scopeChanges{
+		userIds: userIds,
+		before:  before,
+		after:   make(map[string]*entities.User, len(userIds)),
+	}, nil
+}
+
+// keep narrows the change to the captured users among userIds.
+func (c *scopeChanges) keep(userIds []string) {
+	kept := make([]string, 0, len(userIds))
+	for _, userId := range userIds {
+		if _, ok := c.before[userId]; ok {
+			kept = append(kept, userId)
+		}
+	}
+	c.userIds = kept
+}
+
+// reload loads the users again in tx, once the change has been made in it.
+// tx is rolled back when a user cannot be loaded.
+func (c *scopeChanges) reload(ctx context.Context, tx *gorm.DB, userRepo repositories.IUserRepository, logger logger.ILogger) error {
+	after, err := findUsers(ctx, userRepo.WithTransaction(tx), logger, c.userIds)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	c.after = after
+	return nil
+}
+
+// findUsers loads the users in a single round of queries, and fails with
+// gorm.ErrRecordNotFound when one of them does not exist.
+func findUsers(ctx context.Context, userRepo repositories.IUserRepository, logger logger.ILogger, userIds []string) (map[string]*entities.User, error) {
+	if len(userIds) == 0 {
+		return map[string]*entities.User{}, nil
+	}
+	users, err := userRepo.FindByIds(ctx, userIds)
+	if err != nil {
+		logger.Error("failed to find users by id", zap.Error(err))
+		return nil, err
+	}
+	found := make(map[string]*entities.User, len(users))
+	for _, user := range users {
+		found[user.ID] = user
+	}
+	for _, userId := range userIds {
+		if _, ok := found[userId]; !ok {
+			logger.Error("failed to find user by id", zap.String("id", userId), zap.Error(gorm.ErrRecordNotFound))
+			return nil, gorm.ErrRecordNotFound
+		}
+	}
+	return found, nil
+}
+
+// changed lists a user.scopes_changed payload for every user whose granted
+// scopes differ after the change. Expired grants count until they are
+// reaped, so that reaping them is reported as a removal.
+func (c *scopeChanges) changed() []*events.UserScopesChangedData {
+	var changed []*events.UserScopesChangedData
+	for _, userId := range c.userIds {
+		data, ok := scopesChanged(userId, c.before[userId].GrantedScopes(), c.after[userId].GrantedScopes())
+		if ok {
+			data.Scopes = c.after[userId].EffectiveScopes()
+			changed = append(changed, data)
+		}
+	}
+	return changed
+}
+
+// enqueue adds user.scopes_changed to the outbox in tx for every user whose
+// effective scopes changed.
+func (c *scopeChanges) enqueue(ctx context.Context, tx *gorm.DB, outboxRepo repositories.IOutboxRepository, logger logger.ILogger) error {
+	for _, data := range c.changed() {
+		if err := enqueueEvent(ctx, tx, outboxRepo, logger, events.UserScopesChanged, c.before[data.UserID].OrganizationID, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commit reloads the users in tx, enqueues their user.scopes_changed events
+// and commits tx.
+func (c *scopeChanges) commit(ctx context.Context, tx *gorm.DB, userRepo repositories.IUserRepository, outboxRepo repositories.IOutboxRepository, logger logger.ILogger) error {
+	if err := c.reload(ctx, tx, userRepo, logger); err != nil {
+		return err
+	}
+	if err := c.enqueue(ctx, tx, outboxRepo, logger); err != nil {
+		return err
+	}
+	if err := tx.Commit().Error; err != nil {
+		logger.Error("failed to commit transaction", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// audit records action in tx for every user, with its direct grants before
+// and after the change. tx is rolled back when an entry cannot be recorded.
+func (c *scopeChanges) audit(ctx context.Context, tx *gorm.DB, auditRepo repositories.IAuditRepository, logger logger.ILogger, action string) error {
+	txRepo := auditRepo.WithTransaction(tx)
+	for _, userId := range c.userIds {
+		before, after := c.before[userId], c.after[userId]
+		err := recordAudit(ctx, txRepo, userAuditEntry(before, action),
+			newUserSnapshot(before, before.Scopes, scopeExpiries(before), before.Roles),
+			newUserSnapshot(after, after.Scopes, scopeExpiries(after), after.Roles))
+		if err != nil {
+			logger.Error("failed to record audit entry", zap.Error(err))
+			tx.Rollback()
+			return err
+		}
+	}
+	return nil
+}
+
+// revoke runs once the change is committed. It drops the cached effective
+// scopes and refresh sessions of every user, and refuses the access tokens
+// of those that lost a scope, which would otherwise keep it until they
+// expire. It carries on past failures like revokeRefreshTokens.
+func (c *scopeChanges) revoke(ctx context.Context, redisClient interfaces.IRedisClient, revocations revocation.IStore, logger logger.ILogger) error {
+	var errs []error
+	if err := revokeRefreshTokens(ctx, redisClient, logger, c.userIds); err != nil {
+		errs = append(errs, err)
+	}
+	now := time.Now()
+	for _, data := range c.changed() {
+		if len(data.Removed) == 0 {
+			continue
+		}
+		if err := revocations.RevokeUser(ctx, data.UserID, now); err != nil {
+			logger.Error("failed to revoke user's access tokens", zap.String("id", data.UserID), zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
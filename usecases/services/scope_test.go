@@ -12,10 +12,15 @@ import (
 	"gorm.io/gorm"
 	Logger "gorm.io/gorm/logger"
 
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
 	"github.com/vnFuhung2903/vcs-user-management-service/entities"
 	"github.com/vnFuhung2903/vcs-user-management-service/mocks/interfaces"
 	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
 	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/scopes"
 )
 
 type ScopeServiceSuite struct {
@@ -23,7 +28,15 @@ type ScopeServiceSuite struct {
 	ctrl         *gomock.Controller
 	scopeService IScopeService
 	mockRepo     *repositories.MockIScopeRepository
+	mockTxRepo   *repositories.MockIScopeRepository
+	mockUser     *repositories.MockIUserRepository
+	mockTxUser   *repositories.MockIUserRepository
+	mockAudit    *repositories.MockIAuditRepository
+	mockTxAudit  *repositories.MockIAuditRepository
+	mockOutbox   *repositories.MockIOutboxRepository
+	mockTxOutbox *repositories.MockIOutboxRepository
 	mockRedis    *interfaces.MockIRedisClient
+	mockRevoke   *revocation.MockIStore
 	logger       *logger.MockILogger
 	ctx          context.Context
 }
@@ -31,9 +44,17 @@ type ScopeServiceSuite struct {
 func (s *ScopeServiceSuite) SetupTest() {
 	s.ctrl = gomock.NewController(s.T())
 	s.mockRepo = repositories.NewMockIScopeRepository(s.ctrl)
+	s.mockTxRepo = repositories.NewMockIScopeRepository(s.ctrl)
+	s.mockUser = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockTxUser = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockAudit = repositories.NewMockIAuditRepository(s.ctrl)
+	s.mockTxAudit = repositories.NewMockIAuditRepository(s.ctrl)
+	s.mockOutbox = repositories.NewMockIOutboxRepository(s.ctrl)
+	s.mockTxOutbox = repositories.NewMockIOutboxRepository(s.ctrl)
 	s.mockRedis = interfaces.NewMockIRedisClient(s.ctrl)
+	s.mockRevoke = revocation.NewMockIStore(s.ctrl)
 	s.logger = logger.NewMockILogger(s.ctrl)
-	s.scopeService = NewScopeService(s.mockRepo, s.logger)
+	s.scopeService = NewScopeService(s.mockRepo, s.mockUser, s.mockAudit, s.mockOutbox, s.mockRedis, s.mockRevoke, s.logger)
 	s.ctx = context.Background()
 }
 
@@ -45,25 +66,105 @@ func TestScopeServiceSuite(t *testing.T) {
 	suite.Run(t, new(ScopeServiceSuite))
 }
 
+// expectTransaction lets the service open a transaction and bind the scope,
+// user, audit and outbox repositories to it.
+func (s *ScopeServiceSuite) expectTransaction() *gorm.DB {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: Logger.Default.LogMode(Logger.Silent),
+	})
+	s.Require().NoError(err)
+
+	tx := gormDB.Begin()
+	s.Require().NoError(tx.Error)
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(s.mockTxRepo)
+	s.mockUser.EXPECT().WithTransaction(tx).Return(s.mockTxUser).AnyTimes()
+	s.mockAudit.EXPECT().WithTransaction(tx).Return(s.mockTxAudit).AnyTimes()
+	s.mockOutbox.EXPECT().WithTransaction(tx).Return(s.mockTxOutbox).AnyTimes()
+	return tx
+}
+
+// expectEvent checks that the change enqueues one domain event of eventType
+// and decodes its data into data when it is not nil.
+func (s *ScopeServiceSuite) expectEvent(eventType string, data interface{}) {
+	s.mockTxOutbox.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *entities.OutboxEvent) error {
+		s.Equal(eventType, event.Type)
+		parsed, err := events.Parse(events.Encoded(event.Payload))
+		s.Require().NoError(err)
+		s.Equal(event.EventID, parsed.ID)
+		if data != nil {
+			s.Require().NoError(parsed.Decode(data))
+		}
+		return nil
+	})
+}
+
 func (s *ScopeServiceSuite) TestCreate() {
 	name := "test"
 	expected := &entities.UserScope{
-		ID:   uint(1),
-		Name: name,
+		ID:             uint(1),
+		OrganizationID: "acme",
+		Name:           name,
 	}
 
-	s.mockRepo.EXPECT().Create(name).Return(expected, nil)
+	var data events.ScopeData
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Create(s.ctx, name).Return(expected, nil)
+	s.expectEvent(events.ScopeCreated, &data)
+	s.mockTxAudit.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry *entities.AuditEntry) error {
+		s.Equal("acme", entry.OrganizationID)
+		s.Equal(entities.AuditScopeCreate, entry.Action)
+		s.Equal(entities.AuditTargetScope, entry.TargetType)
+		s.Equal("1", entry.TargetID)
+		s.Nil(entry.Before)
+		s.JSONEq(`{"id":1,"name":"test"}`, *entry.After)
+		return nil
+	})
 	s.logger.EXPECT().Info("new scope created successfully").Times(1)
 
 	result, err := s.scopeService.Create(s.ctx, name)
 	s.NoError(err)
 	s.Equal(expected, result)
+	s.Equal(events.ScopeData{ScopeID: 1, Name: name}, data)
+}
+
+func (s *ScopeServiceSuite) TestCreateAuditError() {
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Create(s.ctx, "test").Return(&entities.UserScope{ID: uint(1), Name: "test"}, nil)
+	s.expectEvent(events.ScopeCreated, nil)
+	s.mockTxAudit.EXPECT().Create(s.ctx, gomock.Any()).Return(errors.New("audit error"))
+	s.logger.EXPECT().Error("failed to record audit entry", gomock.Any()).Times(1)
+
+	result, err := s.scopeService.Create(s.ctx, "test")
+	s.ErrorContains(err, "audit error")
+	s.Nil(result)
+}
+
+func (s *ScopeServiceSuite) TestCreateOutboxError() {
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Create(s.ctx, "test").Return(&entities.UserScope{ID: uint(1), Name: "test"}, nil)
+	s.mockTxOutbox.EXPECT().Create(s.ctx, gomock.Any()).Return(errors.New("outbox error"))
+	s.logger.EXPECT().Error("failed to enqueue domain event", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.scopeService.Create(s.ctx, "test")
+	s.ErrorContains(err, "outbox error")
+	s.Nil(result)
+}
+
+func (s *ScopeServiceSuite) TestCreateBeginTransactionError() {
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(nil, errors.New("transaction error"))
+	s.logger.EXPECT().Error("failed to create transaction", gomock.Any()).Times(1)
+
+	result, err := s.scopeService.Create(s.ctx, "test")
+	s.ErrorContains(err, "transaction error")
+	s.Nil(result)
 }
 
 func (s *ScopeServiceSuite) TestCreateError() {
 	name := "test"
 
-	s.mockRepo.EXPECT().Create(name).Return(nil, errors.New("db error"))
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Create(s.ctx, name).Return(nil, errors.New("db error"))
 	s.logger.EXPECT().Error("failed to create scope", gomock.Any()).Times(1)
 
 	result, err := s.scopeService.Create(s.ctx, name)
@@ -71,6 +172,33 @@ func (s *ScopeServiceSuite) TestCreateError() {
 	s.Nil(result)
 }
 
+func (s *ScopeServiceSuite) TestCreatePattern() {
+	name := "container:*"
+	expected := &entities.UserScope{ID: uint(1), Name: name}
+
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Create(s.ctx, name).Return(expected, nil)
+	s.expectEvent(events.ScopeCreated, nil)
+	s.mockTxAudit.EXPECT().Create(s.ctx, gomock.Any()).Return(nil)
+	s.logger.EXPECT().Info("new scope created successfully").Times(1)
+
+	result, err := s.scopeService.Create(s.ctx, name)
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *ScopeServiceSuite) TestCreateInvalidScope() {
+	s.logger.EXPECT().Warn("scope name rejected", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.scopeService.Create(s.ctx, "Container:cre*")
+	s.Nil(result)
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(apperrors.KindValidation, appErr.Kind)
+	s.Equal(dto.CodeInvalidScope, appErr.Code)
+	s.ErrorIs(err, scopes.ErrInvalidScope)
+}
+
 func (s *ScopeServiceSuite) TestFindOne() {
 	name := "test"
 	expected := &entities.UserScope{
@@ -78,7 +206,7 @@ func (s *ScopeServiceSuite) TestFindOne() {
 		Name: name,
 	}
 
-	s.mockRepo.EXPECT().FindByName(name).Return(expected, nil)
+	s.mockRepo.EXPECT().FindByName(s.ctx, name).Return(expected, nil)
 	s.logger.EXPECT().Info("scope found successfully").Times(1)
 
 	result, err := s.scopeService.FindOne(s.ctx, name)
@@ -89,7 +217,7 @@ func (s *ScopeServiceSuite) TestFindOne() {
 func (s *ScopeServiceSuite) TestFindOneError() {
 	name := "test"
 
-	s.mockRepo.EXPECT().FindByName(name).Return(nil, errors.New("db error"))
+	s.mockRepo.EXPECT().FindByName(s.ctx, name).Return(nil, errors.New("db error"))
 	s.logger.EXPECT().Error("failed to find scope", gomock.Any()).Times(1)
 
 	result, err := s.scopeService.FindOne(s.ctx, name)
@@ -117,8 +245,8 @@ func (s *ScopeServiceSuite) TestFindMany() {
 	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
 	s.mockRepo.EXPECT().WithTransaction(tx).Return(mockTxRepo)
 
-	mockTxRepo.EXPECT().FindByName("read").Return(expectedScopes[0], nil)
-	mockTxRepo.EXPECT().FindByName("write").Return(expectedScopes[1], nil)
+	mockTxRepo.EXPECT().FindByName(s.ctx, "read").Return(expectedScopes[0], nil)
+	mockTxRepo.EXPECT().FindByName(s.ctx, "write").Return(expectedScopes[1], nil)
 
 	s.logger.EXPECT().Info("all scopes found successfully").Times(1)
 
@@ -143,8 +271,8 @@ func (s *ScopeServiceSuite) TestFindManyError() {
 	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
 	s.mockRepo.EXPECT().WithTransaction(tx).Return(mockTxRepo)
 
-	mockTxRepo.EXPECT().FindByName("read").Return(&entities.UserScope{ID: 1, Name: "read"}, nil)
-	mockTxRepo.EXPECT().FindByName("write").Return(nil, errors.New("scope not found"))
+	mockTxRepo.EXPECT().FindByName(s.ctx, "read").Return(&entities.UserScope{ID: 1, Name: "read"}, nil)
+	mockTxRepo.EXPECT().FindByName(s.ctx, "write").Return(nil, errors.New("scope not found"))
 
 	s.logger.EXPECT().Error("failed to find scope", gomock.Any()).Times(1)
 
@@ -171,35 +299,149 @@ func (s *ScopeServiceSuite) TestFindAll() {
 		{ID: uint(3), Name: "admin"},
 	}
 
-	s.mockRepo.EXPECT().FindAll().Return(expectedScopes, nil)
+	s.mockRepo.EXPECT().FindAll(s.ctx, dto.ListScopesRequest{Limit: 2}).Return(expectedScopes, &dto.Paging{Limit: 2}, nil)
 	s.logger.EXPECT().Info("all scopes retrieved successfully").Times(1)
 
-	result, err := s.scopeService.FindAll(s.ctx)
+	result, paging, err := s.scopeService.FindAll(s.ctx, dto.ListScopesRequest{Limit: 2})
 	s.NoError(err)
+	s.Equal(&dto.Paging{Limit: 2}, paging)
 	s.Equal(expectedScopes, result)
 }
 
 func (s *ScopeServiceSuite) TestFindAllError() {
-	s.mockRepo.EXPECT().FindAll().Return(nil, errors.New("database error"))
+	s.mockRepo.EXPECT().FindAll(s.ctx, dto.ListScopesRequest{}).Return(nil, nil, errors.New("database error"))
 	s.logger.EXPECT().Error("failed to find all scopes", gomock.Any()).Times(1)
 
-	result, err := s.scopeService.FindAll(s.ctx)
+	result, _, err := s.scopeService.FindAll(s.ctx, dto.ListScopesRequest{})
 	s.ErrorContains(err, "database error")
 	s.Nil(result)
 }
 
+func (s *ScopeServiceSuite) TestExpand() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: Logger.Default.LogMode(Logger.Silent),
+	})
+	assert.NoError(s.T(), err)
+
+	tx := gormDB.Begin()
+	assert.NoError(s.T(), tx.Error)
+
+	expected := []*entities.UserScope{
+		{ID: uint(2), Name: "container:create"},
+		{ID: uint(3), Name: "container:view"},
+	}
+	mockTxRepo := repositories.NewMockIScopeRepository(s.ctrl)
+
+	s.mockRepo.EXPECT().ListNames(s.ctx).Return([]string{"container:*", "container:create", "container:view", "user:view"}, nil)
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(mockTxRepo)
+	mockTxRepo.EXPECT().FindByName(s.ctx, "container:create").Return(expected[0], nil)
+	mockTxRepo.EXPECT().FindByName(s.ctx, "container:view").Return(expected[1], nil)
+	s.logger.EXPECT().Info("all scopes found successfully").Times(1)
+	s.logger.EXPECT().Info("scope pattern expanded successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.scopeService.Expand(s.ctx, "container:*")
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *ScopeServiceSuite) TestExpandInvalidPattern() {
+	s.logger.EXPECT().Warn("scope pattern rejected", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.scopeService.Expand(s.ctx, "container:vi*")
+	s.Nil(result)
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeInvalidScope, appErr.Code)
+}
+
+func (s *ScopeServiceSuite) TestExpandListError() {
+	s.mockRepo.EXPECT().ListNames(s.ctx).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to list scope names", gomock.Any()).Times(1)
+
+	result, err := s.scopeService.Expand(s.ctx, "*:view")
+	s.ErrorContains(err, "db error")
+	s.Nil(result)
+}
+
 func (s *ScopeServiceSuite) TestDelete() {
 	scopeName := "test"
-	s.mockRepo.EXPECT().Delete(scopeName).Return(nil)
+	scope := &entities.UserScope{ID: uint(3), OrganizationID: "acme", Name: scopeName}
+	role := &entities.Role{Name: "operator", Scopes: []*entities.UserScope{{ID: 4, Name: "container:view"}, scope}}
+	s.mockRepo.EXPECT().FindByName(s.ctx, scopeName).Return(scope, nil)
+	s.mockRepo.EXPECT().FindHolderIds(s.ctx, uint(3)).Return([]string{"alice"}, nil)
+	s.mockUser.EXPECT().FindByIds(s.ctx, []string{"alice"}).Return([]*entities.User{&entities.User{ID: "alice", OrganizationID: "acme", Roles: []*entities.Role{role}}}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, scopeName).Return(nil)
+	s.mockTxUser.EXPECT().FindByIds(s.ctx, []string{"alice"}).Return([]*entities.User{&entities.User{ID: "alice", OrganizationID: "acme", Roles: []*entities.Role{{Name: "operator", Scopes: role.Scopes[:1]}}}}, nil)
+	var data events.UserScopesChangedData
+	s.expectEvent(events.ScopeDeleted, nil)
+	s.expectEvent(events.UserScopesChanged, &data)
+	s.mockTxAudit.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry *entities.AuditEntry) error {
+		s.Equal(entities.AuditScopeDelete, entry.Action)
+		s.Equal("3", entry.TargetID)
+		s.JSONEq(`{"id":3,"name":"test"}`, *entry.Before)
+		s.Nil(entry.After)
+		return nil
+	})
+	// /authz/check must not keep allowing the scope from the cache.
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:alice").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:alice").Return([]string{"s1"}, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:alice", "sessions:alice", "session:s1").Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "alice", gomock.Any()).Return(nil)
 	s.logger.EXPECT().Info("scope deleted successfully", gomock.Any()).Times(1)
 
 	err := s.scopeService.Delete(s.ctx, scopeName)
 	s.NoError(err)
+	s.Equal("alice", data.UserID)
+	s.Equal([]string{"container:view"}, data.Scopes)
+	s.Equal([]string{"test"}, data.Removed)
+}
+
+func (s *ScopeServiceSuite) TestDeleteInvalidateError() {
+	scope := &entities.UserScope{ID: uint(3), OrganizationID: "acme", Name: "test"}
+	s.mockRepo.EXPECT().FindByName(s.ctx, "test").Return(scope, nil)
+	s.mockRepo.EXPECT().FindHolderIds(s.ctx, uint(3)).Return([]string{"alice"}, nil)
+	s.mockUser.EXPECT().FindByIds(s.ctx, []string{"alice"}).Return([]*entities.User{&entities.User{ID: "alice", OrganizationID: "acme", Scopes: []*entities.UserScope{scope}}}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, "test").Return(nil)
+	s.mockTxUser.EXPECT().FindByIds(s.ctx, []string{"alice"}).Return([]*entities.User{&entities.User{ID: "alice", OrganizationID: "acme"}}, nil)
+	s.expectEvent(events.ScopeDeleted, nil)
+	s.expectEvent(events.UserScopesChanged, nil)
+	s.mockTxAudit.EXPECT().Create(s.ctx, gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:alice").Return(errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to invalidate effective scopes in redis", gomock.Any()).Times(1)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:alice").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:alice", "sessions:alice").Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "alice", gomock.Any()).Return(nil)
+
+	err := s.scopeService.Delete(s.ctx, "test")
+	s.ErrorContains(err, "redis error")
+}
+
+func (s *ScopeServiceSuite) TestDeleteHoldersError() {
+	s.mockRepo.EXPECT().FindByName(s.ctx, "test").Return(&entities.UserScope{ID: uint(3), Name: "test"}, nil)
+	s.mockRepo.EXPECT().FindHolderIds(s.ctx, uint(3)).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find scope holders", gomock.Any()).Times(1)
+
+	err := s.scopeService.Delete(s.ctx, "test")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ScopeServiceSuite) TestDeleteNotFound() {
+	s.mockRepo.EXPECT().FindByName(s.ctx, "test").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find scope", gomock.Any()).Times(1)
+
+	err := s.scopeService.Delete(s.ctx, "test")
+	s.True(apperrors.IsKind(err, apperrors.KindNotFound))
 }
 
 func (s *ScopeServiceSuite) TestDeleteError() {
 	scopeName := "test"
-	s.mockRepo.EXPECT().Delete(scopeName).Return(errors.New("database error"))
+	s.mockRepo.EXPECT().FindByName(s.ctx, scopeName).Return(&entities.UserScope{ID: uint(3), Name: scopeName}, nil)
+	s.mockRepo.EXPECT().FindHolderIds(s.ctx, uint(3)).Return([]string{}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, scopeName).Return(errors.New("database error"))
 	s.logger.EXPECT().Error("failed to delete scope", gomock.Any()).Times(1)
 
 	err := s.scopeService.Delete(s.ctx, scopeName)
@@ -0,0 +1,420 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/scopes"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	clientSecretPrefix = "sasec_"
+	// clientSecretHintLength is how many trailing characters of a secret are
+	// kept to tell secrets apart.
+	clientSecretHintLength = 4
+)
+
+// IServiceAccountService manages service accounts and issues their
+// client_credentials access tokens. Granting a scope to an account requires
+// the caller to hold it, passed as granted, so an account never exceeds the
+// user who configured it.
+type IServiceAccountService interface {
+	Create(ctx context.Context, name, ownerId string, scopes []*entities.UserScope, expiresAt *time.Time, granted []string, createdBy string) (*entities.ServiceAccount, string, error)
+	FindById(ctx context.Context, accountId string) (*entities.ServiceAccount, error)
+	FindAll(ctx context.Context, query dto.ListServiceAccountsRequest) ([]*entities.ServiceAccount, *dto.Paging, error)
+	Update(ctx context.Context, req dto.UpdateServiceAccountRequest) (*entities.ServiceAccount, error)
+	UpdateScope(ctx context.Context, accountId string, scope *entities.UserScope, isAdded bool, granted []string) error
+	AddSecret(ctx context.Context, accountId, createdBy string) (*entities.ServiceAccountSecret, string, error)
+	DeleteSecret(ctx context.Context, accountId string, secretId uint) error
+	Delete(ctx context.Context, accountId string) error
+	IssueToken(ctx context.Context, clientId, clientSecret string, requested []string) (*dto.TokenResponse, error)
+}
+
+type serviceAccountService struct {
+	accountRepo repositories.IServiceAccountRepository
+	userRepo    repositories.IUserRepository
+	revocations revocation.IStore
+	authEnv     env.AuthEnv
+	env         env.ServiceAccountEnv
+	logger      logger.ILogger
+}
+
+// NewServiceAccountService signs tokens with HS256 and authEnv.JWTSecret,
+// naming authEnv.JWTIssuer and JWTAudiences when set, so the JWT middleware
+// accepts them like any other token.
+func NewServiceAccountService(accountRepo repositories.IServiceAccountRepository, userRepo repositories.IUserRepository, revocations revocation.IStore, authEnv env.AuthEnv, env env.ServiceAccountEnv, logger logger.ILogger) IServiceAccountService {
+	return &serviceAccountService{
+		accountRepo: accountRepo,
+		userRepo:    userRepo,
+		revocations: revocations,
+		authEnv:     authEnv,
+		env:         env,
+		logger:      logger,
+	}
+}
+
+// Create registers an account with a first secret, which is returned in
+// plaintext and cannot be read again.
+func (s *serviceAccountService) Create(ctx context.Context, name, ownerId string, scopes []*entities.UserScope, expiresAt *time.Time, granted []string, createdBy string) (*entities.ServiceAccount, string, error) {
+	if err := s.checkExpiry(expiresAt); err != nil {
+		return nil, "", err
+	}
+	if err := s.checkGrantable(scopes, granted); err != nil {
+		return nil, "", err
+	}
+	if err := s.checkOwner(ctx, ownerId); err != nil {
+		return nil, "", err
+	}
+
+	secret, plaintext, err := s.newSecret(createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	account := &entities.ServiceAccount{
+		Name:      name,
+		OwnerID:   ownerId,
+		Scopes:    scopes,
+		Secrets:   []*entities.ServiceAccountSecret{secret},
+		ExpiresAt: expiresAt,
+		CreatedBy: createdBy,
+	}
+	if err := s.accountRepo.Create(ctx, account); err != nil {
+		s.logger.Error("failed to create service account", zap.Error(err))
+		return nil, "", repositoryError(err, dto.CodeServiceAccountNotFound, dto.CodeServiceAccountExists)
+	}
+
+	s.logger.Info("new service account created successfully", zap.String("id", account.ID))
+	return account, plaintext, nil
+}
+
+func (s *serviceAccountService) FindById(ctx context.Context, accountId string) (*entities.ServiceAccount, error) {
+	account, err := s.accountRepo.FindById(ctx, accountId)
+	if err != nil {
+		s.logger.Error("failed to find service account", zap.String("id", accountId), zap.Error(err))
+		return nil, repositoryError(err, dto.CodeServiceAccountNotFound, dto.CodeServiceAccountExists)
+	}
+
+	s.logger.Info("service account found successfully")
+	return account, nil
+}
+
+func (s *serviceAccountService) FindAll(ctx context.Context, query dto.ListServiceAccountsRequest) ([]*entities.ServiceAccount, *dto.Paging, error) {
+	accounts, paging, err := s.accountRepo.FindAll(ctx, query)
+	if err != nil {
+		s.logger.Error("failed to find all service accounts", zap.Error(err))
+		return nil, nil, repositoryError(err, dto.CodeServiceAccountNotFound, dto.CodeServiceAccountExists)
+	}
+
+	s.logger.Info("all service accounts retrieved successfully")
+	return accounts, paging, nil
+}
+
+// Update changes the fields set in req. Bringing the expiry forward revokes
+// the tokens already issued, which may outlive the new expiry.
+func (s *serviceAccountService) Update(ctx context.Context, req dto.UpdateServiceAccountRequest) (*entities.ServiceAccount, error) {
+	account, err := s.FindById(ctx, req.ServiceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		account.Name = *req.Name
+	}
+	if req.OwnerID != nil {
+		if err := s.checkOwner(ctx, *req.OwnerID); err != nil {
+			return nil, err
+		}
+		account.OwnerID = *req.OwnerID
+	}
+	shortened := false
+	if req.ExpiresAt != nil {
+		if err := s.checkExpiry(req.ExpiresAt); err != nil {
+			return nil, err
+		}
+		shortened = account.ExpiresAt == nil || req.ExpiresAt.Before(*account.ExpiresAt)
+		account.ExpiresAt = req.ExpiresAt
+	}
+	if req.ClearExpiry {
+		account.ExpiresAt = nil
+	}
+
+	if err := s.accountRepo.Update(ctx, account); err != nil {
+		s.logger.Error("failed to update service account", zap.String("id", account.ID), zap.Error(err))
+		return nil, repositoryError(err, dto.CodeServiceAccountNotFound, dto.CodeServiceAccountExists)
+	}
+	if shortened {
+		if err := s.revokeAccessTokens(ctx, account.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("service account updated successfully", zap.String("id", account.ID))
+	return account, nil
+}
+
+// UpdateScope grants or removes a scope. Removing one revokes the tokens
+// already issued, as they still carry it.
+func (s *serviceAccountService) UpdateScope(ctx context.Context, accountId string, scope *entities.UserScope, isAdded bool, granted []string) error {
+	account, err := s.FindById(ctx, accountId)
+	if err != nil {
+		return err
+	}
+	if isAdded {
+		if err := s.checkGrantable([]*entities.UserScope{scope}, granted); err != nil {
+			return err
+		}
+	}
+
+	scopeList := make([]*entities.UserScope, 0, len(account.Scopes)+1)
+	removed := false
+	for _, held := range account.Scopes {
+		if held.ID == scope.ID {
+			removed = !isAdded
+			continue
+		}
+		scopeList = append(scopeList, held)
+	}
+	if isAdded {
+		scopeList = append(scopeList, scope)
+	}
+
+	if err := s.accountRepo.UpdateScope(ctx, account, scopeList); err != nil {
+		s.logger.Error("failed to update service account scopes", zap.String("id", accountId), zap.Error(err))
+		return err
+	}
+	if removed {
+		if err := s.revokeAccessTokens(ctx, accountId); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("service account scopes updated successfully", zap.String("id", accountId))
+	return nil
+}
+
+// AddSecret adds a secret alongside the existing ones, so a client can move
+// to it before the old one is deleted. The plaintext is returned once.
+func (s *serviceAccountService) AddSecret(ctx context.Context, accountId, createdBy string) (*entities.ServiceAccountSecret, string, error) {
+	account, err := s.FindById(ctx, accountId)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(account.Secrets) >= s.env.MaxSecrets {
+		return nil, "", apperrors.Conflict(dto.CodeSecretLimitReached, "service account already holds the maximum number of secrets", nil)
+	}
+
+	secret, plaintext, err := s.newSecret(createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+	secret.ServiceAccountID = account.ID
+	if err := s.accountRepo.AddSecret(ctx, secret); err != nil {
+		s.logger.Error("failed to add service account secret", zap.String("id", accountId), zap.Error(err))
+		return nil, "", err
+	}
+
+	s.logger.Info("service account secret added successfully", zap.String("id", accountId), zap.Uint("secret_id", secret.ID))
+	return secret, plaintext, nil
+}
+
+// DeleteSecret removes a secret and revokes the account's tokens, since any
+// of them may have been obtained with it. Clients holding another secret
+// simply request a new token.
+func (s *serviceAccountService) DeleteSecret(ctx context.Context, accountId string, secretId uint) error {
+	if _, err := s.FindById(ctx, accountId); err != nil {
+		return err
+	}
+
+	if err := s.accountRepo.DeleteSecret(ctx, accountId, secretId); err != nil {
+		s.logger.Error("failed to delete service account secret", zap.String("id", accountId), zap.Uint("secret_id", secretId), zap.Error(err))
+		return repositoryError(err, dto.CodeSecretNotFound, dto.CodeInternalServerError)
+	}
+	if err := s.revokeAccessTokens(ctx, accountId); err != nil {
+		return err
+	}
+
+	s.logger.Info("service account secret deleted successfully", zap.String("id", accountId), zap.Uint("secret_id", secretId))
+	return nil
+}
+
+func (s *serviceAccountService) Delete(ctx context.Context, accountId string) error {
+	if err := s.accountRepo.Delete(ctx, accountId); err != nil {
+		s.logger.Error("failed to delete service account", zap.String("id", accountId), zap.Error(err))
+		return repositoryError(err, dto.CodeServiceAccountNotFound, dto.CodeServiceAccountExists)
+	}
+	if err := s.revokeAccessTokens(ctx, accountId); err != nil {
+		return err
+	}
+
+	s.logger.Info("service account deleted successfully", zap.String("id", accountId))
+	return nil
+}
+
+// IssueToken authenticates the client and issues an access token for the
+// requested scopes, or for every scope of the account when none is
+// requested. An unknown client, an expired account and a wrong secret are
+// refused alike.
+func (s *serviceAccountService) IssueToken(ctx context.Context, clientId, clientSecret string, requested []string) (*dto.TokenResponse, error) {
+	invalidClient := apperrors.Unauthorized(dto.CodeInvalidClient, "client authentication failed", nil)
+
+	account, err := s.accountRepo.FindById(ctx, clientId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.logger.Warn("client authentication failed", zap.String("client_id", clientId))
+		return nil, invalidClient
+	}
+	if err != nil {
+		s.logger.Error("failed to find service account", zap.String("id", clientId), zap.Error(err))
+		return nil, err
+	}
+	now := time.Now()
+	if !account.Active(now) || !matchSecret(account.Secrets, clientSecret) {
+		s.logger.Warn("client authentication failed", zap.String("client_id", clientId))
+		return nil, invalidClient
+	}
+
+	held := account.ScopeNames()
+	granted := held
+	if len(requested) > 0 {
+		for _, scope := range requested {
+			if err := scopes.Validate(scope); err != nil {
+				return nil, apperrors.Validation(dto.CodeInvalidScope, "invalid scope name", err)
+			}
+			if !scopes.Grants(held, scope) {
+				return nil, apperrors.Validation(dto.CodeInvalidScope, "requested scope is not granted to the client", nil)
+			}
+		}
+		granted = requested
+	}
+
+	expiresAt := now.Add(s.env.TokenTTL)
+	if account.ExpiresAt != nil && account.ExpiresAt.Before(expiresAt) {
+		expiresAt = *account.ExpiresAt
+	}
+	accessToken, err := s.sign(account, granted, now, expiresAt)
+	if err != nil {
+		s.logger.Error("failed to sign access token", zap.String("client_id", clientId), zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("access token issued successfully", zap.String("client_id", clientId))
+	return &dto.TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(expiresAt.Sub(now).Seconds()),
+		Scope:       strings.Join(granted, " "),
+	}, nil
+}
+
+// sign builds the claims the JWT middleware reads from a user's token, with
+// the account standing in as the subject. client_id marks the token as a
+// service account's. iat carries milliseconds, so that a token issued in
+// the same second as a revocation of the account, such as a secret rotation,
+// is told apart from the ones revoked.
+func (s *serviceAccountService) sign(account *entities.ServiceAccount, granted []string, now, expiresAt time.Time) (string, error) {
+	if s.authEnv.JWTSecret == "" {
+		return "", errors.New("no JWT secret is configured to sign service account tokens")
+	}
+
+	claims := jwt.MapClaims{
+		"sub":         account.ID,
+		"client_id":   account.ID,
+		tenancy.Claim: account.OrganizationID,
+		"scope":       granted,
+		"jti":         uuid.New().String(),
+		"iat":         float64(now.UnixMilli()) / 1e3,
+		"exp":         expiresAt.Unix(),
+	}
+	if s.authEnv.JWTIssuer != "" {
+		claims["iss"] = s.authEnv.JWTIssuer
+	}
+	if len(s.authEnv.JWTAudiences) > 0 {
+		claims["aud"] = s.authEnv.JWTAudiences
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.authEnv.JWTSecret))
+}
+
+func (s *serviceAccountService) checkExpiry(expiresAt *time.Time) error {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return apperrors.Validation(dto.CodeInvalidAccountExpiry, "service account expiry must be in the future", nil)
+	}
+	return nil
+}
+
+func (s *serviceAccountService) checkGrantable(scopeList []*entities.UserScope, granted []string) error {
+	for _, scope := range scopeList {
+		if !scopes.Grants(granted, scope.Name) {
+			return apperrors.Forbidden(dto.CodeInsufficientScope, "cannot grant a scope the caller does not hold", nil)
+		}
+	}
+	return nil
+}
+
+// checkOwner makes sure the owner is a user of the caller's organization.
+func (s *serviceAccountService) checkOwner(ctx context.Context, ownerId string) error {
+	if _, err := s.userRepo.FindById(ctx, ownerId); err != nil {
+		s.logger.Error("failed to find user by id", zap.String("id", ownerId), zap.Error(err))
+		return repositoryError(err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
+	}
+	return nil
+}
+
+func (s *serviceAccountService) newSecret(createdBy string) (*entities.ServiceAccountSecret, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		s.logger.Error("failed to generate service account secret", zap.Error(err))
+		return nil, "", err
+	}
+	plaintext := clientSecretPrefix + hex.EncodeToString(raw)
+	return &entities.ServiceAccountSecret{
+		Hash:      hashClientSecret(plaintext),
+		Hint:      plaintext[len(plaintext)-clientSecretHintLength:],
+		CreatedBy: createdBy,
+	}, plaintext, nil
+}
+
+// revokeAccessTokens refuses every token issued to the account so far.
+func (s *serviceAccountService) revokeAccessTokens(ctx context.Context, accountId string) error {
+	if err := s.revocations.RevokeUser(ctx, accountId, time.Now()); err != nil {
+		s.logger.Error("failed to revoke service account's access tokens", zap.String("id", accountId), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// hashClientSecret uses a plain digest rather than the password hasher:
+// client secrets are 256 random bits, so a slow hash adds nothing but
+// latency to every token request.
+func hashClientSecret(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+func matchSecret(secrets []*entities.ServiceAccountSecret, plaintext string) bool {
+	hash := []byte(hashClientSecret(plaintext))
+	matched := false
+	for _, secret := range secrets {
+		if subtle.ConstantTimeCompare([]byte(secret.Hash), hash) == 1 {
+			matched = true
+		}
+	}
+	return matched
+}
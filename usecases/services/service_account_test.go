@@ -0,0 +1,362 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+	pkgmiddlewares "github.com/vnFuhung2903/vcs-user-management-service/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+)
+
+type ServiceAccountServiceSuite struct {
+	suite.Suite
+	ctrl                  *gomock.Controller
+	serviceAccountService IServiceAccountService
+	mockAccountRepo       *repositories.MockIServiceAccountRepository
+	mockUserRepo          *repositories.MockIUserRepository
+	mockRevoke            *revocation.MockIStore
+	logger                *logger.MockILogger
+	authEnv               env.AuthEnv
+	ctx                   context.Context
+}
+
+func (s *ServiceAccountServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockAccountRepo = repositories.NewMockIServiceAccountRepository(s.ctrl)
+	s.mockUserRepo = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockRevoke = revocation.NewMockIStore(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.authEnv = env.AuthEnv{JWTSecret: "test-secret", JWTIssuer: "ums", JWTAudiences: []string{"vcs"}, JWTRequireExpiry: true}
+	s.serviceAccountService = NewServiceAccountService(s.mockAccountRepo, s.mockUserRepo, s.mockRevoke, s.authEnv, env.ServiceAccountEnv{
+		TokenTTL:   15 * time.Minute,
+		MaxSecrets: 2,
+	}, s.logger)
+	s.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
+}
+
+func (s *ServiceAccountServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestServiceAccountServiceSuite(t *testing.T) {
+	suite.Run(t, new(ServiceAccountServiceSuite))
+}
+
+func (s *ServiceAccountServiceSuite) assertCode(err error, code string) {
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *ServiceAccountServiceSuite) account(secret string) *entities.ServiceAccount {
+	return &entities.ServiceAccount{
+		ID:             "sa1",
+		OrganizationID: "acme",
+		Name:           "ci",
+		OwnerID:        "user1",
+		Scopes:         []*entities.UserScope{{ID: 1, Name: "container:view"}, {ID: 2, Name: "container:update"}},
+		Secrets:        []*entities.ServiceAccountSecret{{ID: 1, Hash: hashClientSecret(secret)}},
+	}
+}
+
+func (s *ServiceAccountServiceSuite) TestCreate() {
+	scopes := []*entities.UserScope{{ID: 1, Name: "container:view"}}
+	s.mockUserRepo.EXPECT().FindById(s.ctx, "user1").Return(&entities.User{ID: "user1"}, nil)
+	s.mockAccountRepo.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, account *entities.ServiceAccount) error {
+		account.ID = "sa1"
+		return nil
+	})
+	s.logger.EXPECT().Info("new service account created successfully", gomock.Any()).Times(1)
+
+	account, secret, err := s.serviceAccountService.Create(s.ctx, "ci", "user1", scopes, nil, []string{"container:*"}, "admin")
+	s.NoError(err)
+	s.Equal("sa1", account.ID)
+	s.Equal(scopes, account.Scopes)
+	s.True(strings.HasPrefix(secret, "sasec_"))
+	s.Len(account.Secrets, 1)
+	s.Equal(hashClientSecret(secret), account.Secrets[0].Hash)
+	s.Equal(secret[len(secret)-4:], account.Secrets[0].Hint)
+	s.NotContains(account.Secrets[0].Hash, secret)
+}
+
+func (s *ServiceAccountServiceSuite) TestCreateScopeNotHeld() {
+	scopes := []*entities.UserScope{{ID: 1, Name: "user:manage"}}
+
+	_, _, err := s.serviceAccountService.Create(s.ctx, "ci", "user1", scopes, nil, []string{"container:*"}, "admin")
+	s.assertCode(err, dto.CodeInsufficientScope)
+}
+
+func (s *ServiceAccountServiceSuite) TestCreatePastExpiry() {
+	expiresAt := time.Now().Add(-time.Minute)
+
+	_, _, err := s.serviceAccountService.Create(s.ctx, "ci", "user1", nil, &expiresAt, nil, "admin")
+	s.assertCode(err, dto.CodeInvalidAccountExpiry)
+}
+
+func (s *ServiceAccountServiceSuite) TestCreateOwnerNotFound() {
+	s.mockUserRepo.EXPECT().FindById(s.ctx, "ghost").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find user by id", gomock.Any()).Times(1)
+
+	_, _, err := s.serviceAccountService.Create(s.ctx, "ci", "ghost", nil, nil, nil, "admin")
+	s.assertCode(err, dto.CodeUserNotFound)
+}
+
+func (s *ServiceAccountServiceSuite) TestCreateDuplicate() {
+	s.mockUserRepo.EXPECT().FindById(s.ctx, "user1").Return(&entities.User{ID: "user1"}, nil)
+	s.mockAccountRepo.EXPECT().Create(s.ctx, gomock.Any()).Return(gorm.ErrDuplicatedKey)
+	s.logger.EXPECT().Error("failed to create service account", gomock.Any()).Times(1)
+
+	_, _, err := s.serviceAccountService.Create(s.ctx, "ci", "user1", nil, nil, nil, "admin")
+	s.assertCode(err, dto.CodeServiceAccountExists)
+}
+
+func (s *ServiceAccountServiceSuite) TestFindByIdNotFound() {
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find service account", gomock.Any()).Times(1)
+
+	_, err := s.serviceAccountService.FindById(s.ctx, "sa1")
+	s.assertCode(err, dto.CodeServiceAccountNotFound)
+}
+
+func (s *ServiceAccountServiceSuite) TestFindAll() {
+	accounts := []*entities.ServiceAccount{s.account("secret")}
+	paging := &dto.Paging{Limit: 20}
+	query := dto.ListServiceAccountsRequest{OwnerID: "user1"}
+	s.mockAccountRepo.EXPECT().FindAll(s.ctx, query).Return(accounts, paging, nil)
+	s.logger.EXPECT().Info("all service accounts retrieved successfully").Times(1)
+
+	result, resultPaging, err := s.serviceAccountService.FindAll(s.ctx, query)
+	s.NoError(err)
+	s.Equal(accounts, result)
+	s.Equal(paging, resultPaging)
+}
+
+func (s *ServiceAccountServiceSuite) TestUpdateShortensExpiry() {
+	account := s.account("secret")
+	expiresAt := time.Now().Add(time.Hour)
+	name := "pipeline"
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(account, nil)
+	s.logger.EXPECT().Info("service account found successfully").Times(1)
+	s.mockAccountRepo.EXPECT().Update(s.ctx, account).Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "sa1", gomock.Any()).Return(nil)
+	s.logger.EXPECT().Info("service account updated successfully", gomock.Any()).Times(1)
+
+	updated, err := s.serviceAccountService.Update(s.ctx, dto.UpdateServiceAccountRequest{ServiceAccountID: "sa1", Name: &name, ExpiresAt: &expiresAt})
+	s.NoError(err)
+	s.Equal("pipeline", updated.Name)
+	s.Equal(&expiresAt, updated.ExpiresAt)
+}
+
+func (s *ServiceAccountServiceSuite) TestUpdateClearExpiry() {
+	account := s.account("secret")
+	expiresAt := time.Now().Add(time.Hour)
+	account.ExpiresAt = &expiresAt
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(account, nil)
+	s.logger.EXPECT().Info("service account found successfully").Times(1)
+	s.mockAccountRepo.EXPECT().Update(s.ctx, account).Return(nil)
+	s.logger.EXPECT().Info("service account updated successfully", gomock.Any()).Times(1)
+
+	updated, err := s.serviceAccountService.Update(s.ctx, dto.UpdateServiceAccountRequest{ServiceAccountID: "sa1", ClearExpiry: true})
+	s.NoError(err)
+	s.Nil(updated.ExpiresAt)
+}
+
+func (s *ServiceAccountServiceSuite) TestUpdateScopeAdd() {
+	account := s.account("secret")
+	scope := &entities.UserScope{ID: 3, Name: "container:delete"}
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(account, nil)
+	s.logger.EXPECT().Info("service account found successfully").Times(1)
+	s.mockAccountRepo.EXPECT().UpdateScope(s.ctx, account, []*entities.UserScope{account.Scopes[0], account.Scopes[1], scope}).Return(nil)
+	s.logger.EXPECT().Info("service account scopes updated successfully", gomock.Any()).Times(1)
+
+	err := s.serviceAccountService.UpdateScope(s.ctx, "sa1", scope, true, []string{"container:*"})
+	s.NoError(err)
+}
+
+func (s *ServiceAccountServiceSuite) TestUpdateScopeAddNotHeld() {
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(s.account("secret"), nil)
+	s.logger.EXPECT().Info("service account found successfully").Times(1)
+
+	err := s.serviceAccountService.UpdateScope(s.ctx, "sa1", &entities.UserScope{ID: 3, Name: "user:manage"}, true, []string{"container:*"})
+	s.assertCode(err, dto.CodeInsufficientScope)
+}
+
+func (s *ServiceAccountServiceSuite) TestUpdateScopeRemoveRevokes() {
+	account := s.account("secret")
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(account, nil)
+	s.logger.EXPECT().Info("service account found successfully").Times(1)
+	s.mockAccountRepo.EXPECT().UpdateScope(s.ctx, account, []*entities.UserScope{account.Scopes[1]}).Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "sa1", gomock.Any()).Return(nil)
+	s.logger.EXPECT().Info("service account scopes updated successfully", gomock.Any()).Times(1)
+
+	err := s.serviceAccountService.UpdateScope(s.ctx, "sa1", account.Scopes[0], false, nil)
+	s.NoError(err)
+}
+
+func (s *ServiceAccountServiceSuite) TestAddSecret() {
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(s.account("secret"), nil)
+	s.logger.EXPECT().Info("service account found successfully").Times(1)
+	s.mockAccountRepo.EXPECT().AddSecret(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, secret *entities.ServiceAccountSecret) error {
+		secret.ID = 2
+		return nil
+	})
+	s.logger.EXPECT().Info("service account secret added successfully", gomock.Any()).Times(1)
+
+	secret, plaintext, err := s.serviceAccountService.AddSecret(s.ctx, "sa1", "admin")
+	s.NoError(err)
+	s.Equal("sa1", secret.ServiceAccountID)
+	s.Equal(hashClientSecret(plaintext), secret.Hash)
+	s.Equal("admin", secret.CreatedBy)
+}
+
+func (s *ServiceAccountServiceSuite) TestAddSecretLimitReached() {
+	account := s.account("secret")
+	account.Secrets = append(account.Secrets, &entities.ServiceAccountSecret{ID: 2})
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(account, nil)
+	s.logger.EXPECT().Info("service account found successfully").Times(1)
+
+	_, _, err := s.serviceAccountService.AddSecret(s.ctx, "sa1", "admin")
+	s.assertCode(err, dto.CodeSecretLimitReached)
+}
+
+func (s *ServiceAccountServiceSuite) TestDeleteSecretRevokes() {
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(s.account("secret"), nil)
+	s.logger.EXPECT().Info("service account found successfully").Times(1)
+	s.mockAccountRepo.EXPECT().DeleteSecret(s.ctx, "sa1", uint(1)).Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "sa1", gomock.Any()).Return(nil)
+	s.logger.EXPECT().Info("service account secret deleted successfully", gomock.Any()).Times(1)
+
+	err := s.serviceAccountService.DeleteSecret(s.ctx, "sa1", 1)
+	s.NoError(err)
+}
+
+func (s *ServiceAccountServiceSuite) TestDeleteSecretNotFound() {
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(s.account("secret"), nil)
+	s.logger.EXPECT().Info("service account found successfully").Times(1)
+	s.mockAccountRepo.EXPECT().DeleteSecret(s.ctx, "sa1", uint(9)).Return(gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to delete service account secret", gomock.Any()).Times(1)
+
+	err := s.serviceAccountService.DeleteSecret(s.ctx, "sa1", 9)
+	s.assertCode(err, dto.CodeSecretNotFound)
+}
+
+func (s *ServiceAccountServiceSuite) TestDelete() {
+	s.mockAccountRepo.EXPECT().Delete(s.ctx, "sa1").Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "sa1", gomock.Any()).Return(nil)
+	s.logger.EXPECT().Info("service account deleted successfully", gomock.Any()).Times(1)
+
+	err := s.serviceAccountService.Delete(s.ctx, "sa1")
+	s.NoError(err)
+}
+
+func (s *ServiceAccountServiceSuite) TestDeleteRevocationError() {
+	s.mockAccountRepo.EXPECT().Delete(s.ctx, "sa1").Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "sa1", gomock.Any()).Return(errors.New("redis down"))
+	s.logger.EXPECT().Error("failed to revoke service account's access tokens", gomock.Any()).Times(1)
+
+	err := s.serviceAccountService.Delete(s.ctx, "sa1")
+	s.ErrorContains(err, "redis down")
+}
+
+func (s *ServiceAccountServiceSuite) TestIssueToken() {
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(s.account("secret"), nil)
+	s.logger.EXPECT().Info("access token issued successfully", gomock.Any()).Times(1)
+
+	before := time.Now().UnixMilli()
+	response, err := s.serviceAccountService.IssueToken(s.ctx, "sa1", "secret", nil)
+	after := time.Now().UnixMilli()
+	s.NoError(err)
+	s.Equal("Bearer", response.TokenType)
+	s.Equal(int64(900), response.ExpiresIn)
+	s.Equal("container:view container:update", response.Scope)
+
+	// The token must pass the same checks as a user's token.
+	claims, err := pkgmiddlewares.NewJWTMiddleware(s.authEnv, nil, nil).Verify(context.Background(), response.AccessToken)
+	s.NoError(err)
+	s.Equal("sa1", claims["sub"])
+	s.Equal("sa1", claims["client_id"])
+	s.Equal("acme", claims[tenancy.Claim])
+	s.Equal([]interface{}{"container:view", "container:update"}, claims["scope"])
+	// iat keeps the milliseconds that tell it apart from a revocation made
+	// in the same second.
+	iat := int64(math.Round(claims["iat"].(float64) * 1e3))
+	s.GreaterOrEqual(iat, before)
+	s.LessOrEqual(iat, after)
+}
+
+func (s *ServiceAccountServiceSuite) TestIssueTokenRequestedScopes() {
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(s.account("secret"), nil)
+	s.logger.EXPECT().Info("access token issued successfully", gomock.Any()).Times(1)
+
+	response, err := s.serviceAccountService.IssueToken(s.ctx, "sa1", "secret", []string{"container:view"})
+	s.NoError(err)
+	s.Equal("container:view", response.Scope)
+}
+
+func (s *ServiceAccountServiceSuite) TestIssueTokenCappedAtExpiry() {
+	account := s.account("secret")
+	expiresAt := time.Now().Add(5 * time.Minute)
+	account.ExpiresAt = &expiresAt
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(account, nil)
+	s.logger.EXPECT().Info("access token issued successfully", gomock.Any()).Times(1)
+
+	response, err := s.serviceAccountService.IssueToken(s.ctx, "sa1", "secret", nil)
+	s.NoError(err)
+	s.LessOrEqual(response.ExpiresIn, int64(300))
+}
+
+func (s *ServiceAccountServiceSuite) TestIssueTokenInvalidClient() {
+	expired := s.account("secret")
+	past := time.Now().Add(-time.Minute)
+	expired.ExpiresAt = &past
+
+	tests := []struct {
+		name    string
+		account *entities.ServiceAccount
+		err     error
+		secret  string
+	}{
+		{"unknown client", nil, gorm.ErrRecordNotFound, "secret"},
+		{"wrong secret", s.account("secret"), nil, "wrong"},
+		{"expired account", expired, nil, "secret"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(tt.account, tt.err)
+			s.logger.EXPECT().Warn("client authentication failed", gomock.Any()).Times(1)
+
+			_, err := s.serviceAccountService.IssueToken(s.ctx, "sa1", tt.secret, nil)
+			s.assertCode(err, dto.CodeInvalidClient)
+		})
+	}
+}
+
+func (s *ServiceAccountServiceSuite) TestIssueTokenScopeNotGranted() {
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(s.account("secret"), nil)
+
+	_, err := s.serviceAccountService.IssueToken(s.ctx, "sa1", "secret", []string{"user:manage"})
+	s.assertCode(err, dto.CodeInvalidScope)
+}
+
+func (s *ServiceAccountServiceSuite) TestIssueTokenRepoError() {
+	s.mockAccountRepo.EXPECT().FindById(s.ctx, "sa1").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find service account", gomock.Any()).Times(1)
+
+	_, err := s.serviceAccountService.IssueToken(s.ctx, "sa1", "secret", nil)
+	s.ErrorContains(err, "db error")
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+)
+
+// ISessionService keeps the inventory of refresh sessions. The token issuer
+// registers every refresh token it hands out through POST /sessions/create,
+// which calls Create; revoking a session deletes it and refuses the access
+// tokens it issued.
+type ISessionService interface {
+	Create(ctx context.Context, userId, device, ip string, ttl time.Duration) (*entities.Session, error)
+	FindAll(ctx context.Context, userId string) ([]*entities.Session, error)
+	Revoke(ctx context.Context, userId, sessionId string) error
+	RevokeAll(ctx context.Context, userId string) error
+	RevokeEverything(ctx context.Context) error
+}
+
+type sessionService struct {
+	userRepo    repositories.IUserRepository
+	redisClient interfaces.IRedisClient
+	revocations revocation.IStore
+	logger      logger.ILogger
+}
+
+func NewSessionService(userRepo repositories.IUserRepository, redisClient interfaces.IRedisClient, revocations revocation.IStore, logger logger.ILogger) ISessionService {
+	return &sessionService{
+		userRepo:    userRepo,
+		redisClient: redisClient,
+		revocations: revocations,
+		logger:      logger,
+	}
+}
+
+func (s *sessionService) Create(ctx context.Context, userId, device, ip string, ttl time.Duration) (*entities.Session, error) {
+	if err := s.findUser(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	session := &entities.Session{
+		ID:        uuid.NewString(),
+		UserID:    userId,
+		Device:    device,
+		IP:        ip,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	key := sessionKeyPrefix + session.ID
+	if err := s.redisClient.HSet(ctx, key, map[string]interface{}{
+		"user_id":    session.UserID,
+		"device":     session.Device,
+		"ip":         session.IP,
+		"created_at": session.CreatedAt.Format(time.RFC3339),
+		"expires_at": session.ExpiresAt.Format(time.RFC3339),
+	}); err != nil {
+		s.logger.Error("failed to create session in redis", zap.Error(err))
+		return nil, err
+	}
+	if err := s.redisClient.Expire(ctx, key, ttl); err != nil {
+		s.logger.Error("failed to set session expiry in redis", zap.Error(err))
+		return nil, err
+	}
+	// The index must outlive every session it lists, so its expiry is only
+	// ever extended; ids of sessions that expired before it are pruned when
+	// the sessions are listed.
+	indexKey := userSessionsKeyPrefix + userId
+	if err := s.redisClient.SAdd(ctx, indexKey, session.ID); err != nil {
+		s.logger.Error("failed to index session in redis", zap.Error(err))
+		return nil, err
+	}
+	remaining, err := s.redisClient.TTL(ctx, indexKey)
+	if err != nil {
+		s.logger.Error("failed to get session index expiry in redis", zap.Error(err))
+		return nil, err
+	}
+	if remaining < ttl {
+		if err := s.redisClient.Expire(ctx, indexKey, ttl); err != nil {
+			s.logger.Error("failed to set session expiry in redis", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	s.logger.Info("new session created successfully", zap.String("id", userId))
+	return session, nil
+}
+
+// FindAll lists the user's sessions, newest first.
+func (s *sessionService) FindAll(ctx context.Context, userId string) ([]*entities.Session, error) {
+	if err := s.findUser(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	sessionIds, err := s.redisClient.SMembers(ctx, userSessionsKeyPrefix+userId)
+	if err != nil {
+		s.logger.Error("failed to find sessions in redis", zap.Error(err))
+		return nil, err
+	}
+
+	sessions := make([]*entities.Session, 0, len(sessionIds))
+	var expired []string
+	for _, sessionId := range sessionIds {
+		session, err := s.findSession(ctx, sessionId)
+		if err != nil {
+			return nil, err
+		}
+		if session == nil || session.UserID != userId {
+			expired = append(expired, sessionId)
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	if len(expired) > 0 {
+		if err := s.redisClient.SRem(ctx, userSessionsKeyPrefix+userId, expired...); err != nil {
+			s.logger.Warn("failed to prune expired sessions", zap.String("id", userId), zap.Error(err))
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].CreatedAt.After(sessions[j].CreatedAt) })
+	s.logger.Info("user's sessions retrieved successfully", zap.Int("count", len(sessions)))
+	return sessions, nil
+}
+
+func (s *sessionService) Revoke(ctx context.Context, userId, sessionId string) error {
+	if err := s.findUser(ctx, userId); err != nil {
+		return err
+	}
+
+	session, err := s.findSession(ctx, sessionId)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userId {
+		return apperrors.NotFound(dto.CodeSessionNotFound, "session not found", nil)
+	}
+
+	if err := s.revocations.RevokeSession(ctx, sessionId); err != nil {
+		s.logger.Error("failed to revoke session", zap.String("id", sessionId), zap.Error(err))
+		return err
+	}
+	if err := deleteSession(ctx, s.redisClient, userId, sessionId); err != nil {
+		s.logger.Error("failed to delete session in redis", zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("session revoked successfully", zap.String("id", sessionId))
+	return nil
+}
+
+func (s *sessionService) RevokeAll(ctx context.Context, userId string) error {
+	if err := s.findUser(ctx, userId); err != nil {
+		return err
+	}
+
+	if err := s.revocations.RevokeUser(ctx, userId, time.Now()); err != nil {
+		s.logger.Error("failed to revoke user's access tokens", zap.String("id", userId), zap.Error(err))
+		return err
+	}
+	if err := deleteSessions(ctx, s.redisClient, userId); err != nil {
+		s.logger.Error("failed to delete refresh token in redis", zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("user's sessions revoked successfully", zap.String("id", userId))
+	return nil
+}
+
+// RevokeEverything is the emergency switch: it refuses every access token
+// issued so far, in every organization, and deletes every refresh session,
+// so that everyone has to sign in again.
+func (s *sessionService) RevokeEverything(ctx context.Context) error {
+	if tenant, ok := tenancy.FromContext(ctx); ok && !tenant.Super {
+		s.logger.Warn("global session revocation rejected", zap.String("organization", tenant.OrganizationID))
+		return apperrors.Forbidden(dto.CodeTenantForbidden, "only super-admins revoke every session", nil)
+	}
+
+	if err := s.revocations.RevokeEveryone(ctx, time.Now()); err != nil {
+		s.logger.Error("failed to revoke every access token", zap.Error(err))
+		return err
+	}
+
+	deleted := 0
+	for _, prefix := range []string{sessionKeyPrefix, userSessionsKeyPrefix, refreshKeyPrefix} {
+		keys, err := s.redisClient.Scan(ctx, prefix+"*")
+		if err != nil {
+			s.logger.Error("failed to scan sessions in redis", zap.Error(err))
+			return err
+		}
+		if err := s.redisClient.Del(ctx, keys...); err != nil {
+			s.logger.Error("failed to delete sessions in redis", zap.Error(err))
+			return err
+		}
+		deleted += len(keys)
+	}
+
+	s.logger.Warn("every session revoked", zap.Int("keys", deleted))
+	return nil
+}
+
+// findUser keeps session management within the caller's organization, as
+// sessions themselves carry no tenant.
+func (s *sessionService) findUser(ctx context.Context, userId string) error {
+	if _, err := s.userRepo.FindById(ctx, userId); err != nil {
+		s.logger.Error("failed to find user by id", zap.String("id", userId), zap.Error(err))
+		return repositoryError(err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
+	}
+	return nil
+}
+
+// findSession returns nil when the session does not exist, which includes
+// sessions that have expired.
+func (s *sessionService) findSession(ctx context.Context, sessionId string) (*entities.Session, error) {
+	fields, err := s.redisClient.HGetAll(ctx, sessionKeyPrefix+sessionId)
+	if err != nil {
+		s.logger.Error("failed to find session in redis", zap.String("id", sessionId), zap.Error(err))
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339, fields["created_at"])
+	expiresAt, _ := time.Parse(time.RFC3339, fields["expires_at"])
+	return &entities.Session{
+		ID:        sessionId,
+		UserID:    fields["user_id"],
+		Device:    fields["device"],
+		IP:        fields["ip"],
+		CreatedAt: createdAt,
+		ExpiresAt: expiresAt,
+	}, nil
+}
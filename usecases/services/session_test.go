@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/tenancy"
+)
+
+type SessionServiceSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	sessionService ISessionService
+	mockUserRepo   *repositories.MockIUserRepository
+	mockRedis      *interfaces.MockIRedisClient
+	mockRevoke     *revocation.MockIStore
+	logger         *logger.MockILogger
+	ctx            context.Context
+}
+
+func (s *SessionServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockUserRepo = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockRedis = interfaces.NewMockIRedisClient(s.ctrl)
+	s.mockRevoke = revocation.NewMockIStore(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.sessionService = NewSessionService(s.mockUserRepo, s.mockRedis, s.mockRevoke, s.logger)
+	s.ctx = tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "acme"})
+}
+
+func (s *SessionServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestSessionServiceSuite(t *testing.T) {
+	suite.Run(t, new(SessionServiceSuite))
+}
+
+func (s *SessionServiceSuite) assertCode(err error, code string) {
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *SessionServiceSuite) expectUser(userId string) {
+	s.mockUserRepo.EXPECT().FindById(s.ctx, userId).Return(&entities.User{ID: userId}, nil)
+}
+
+func (s *SessionServiceSuite) sessionFields(userId, device string, createdAt time.Time) map[string]string {
+	return map[string]string{
+		"user_id":    userId,
+		"device":     device,
+		"ip":         "203.0.113.7",
+		"created_at": createdAt.Format(time.RFC3339),
+		"expires_at": createdAt.Add(24 * time.Hour).Format(time.RFC3339),
+	}
+}
+
+func (s *SessionServiceSuite) TestCreate() {
+	s.expectUser("user1")
+	var sessionId string
+	s.mockRedis.EXPECT().HSet(s.ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string, values map[string]interface{}) error {
+		sessionId = key[len("session:"):]
+		s.Equal("user1", values["user_id"])
+		s.Equal("Firefox on Linux", values["device"])
+		s.Equal("203.0.113.7", values["ip"])
+		return nil
+	})
+	s.mockRedis.EXPECT().Expire(s.ctx, gomock.Any(), 24*time.Hour).Return(nil)
+	s.mockRedis.EXPECT().SAdd(s.ctx, "sessions:user1", gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().TTL(s.ctx, "sessions:user1").Return(time.Duration(-2), nil)
+	s.mockRedis.EXPECT().Expire(s.ctx, "sessions:user1", 24*time.Hour).Return(nil)
+	s.logger.EXPECT().Info("new session created successfully", gomock.Any()).Times(1)
+
+	session, err := s.sessionService.Create(s.ctx, "user1", "Firefox on Linux", "203.0.113.7", 24*time.Hour)
+	s.NoError(err)
+	s.Equal(sessionId, session.ID)
+	s.Equal(24*time.Hour, session.ExpiresAt.Sub(session.CreatedAt))
+}
+
+func (s *SessionServiceSuite) TestCreateKeepsLongerIndexExpiry() {
+	s.expectUser("user1")
+	s.mockRedis.EXPECT().HSet(s.ctx, gomock.Any(), gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().Expire(s.ctx, gomock.Any(), time.Hour).Return(nil)
+	s.mockRedis.EXPECT().SAdd(s.ctx, "sessions:user1", gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().TTL(s.ctx, "sessions:user1").Return(30*24*time.Hour, nil)
+	s.logger.EXPECT().Info("new session created successfully", gomock.Any()).Times(1)
+
+	_, err := s.sessionService.Create(s.ctx, "user1", "Firefox on Linux", "203.0.113.7", time.Hour)
+	s.NoError(err)
+}
+
+func (s *SessionServiceSuite) TestCreateRedisError() {
+	s.expectUser("user1")
+	s.mockRedis.EXPECT().HSet(s.ctx, gomock.Any(), gomock.Any()).Return(errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to create session in redis", gomock.Any()).Times(1)
+
+	_, err := s.sessionService.Create(s.ctx, "user1", "Firefox on Linux", "203.0.113.7", time.Hour)
+	s.ErrorContains(err, "redis error")
+}
+
+func (s *SessionServiceSuite) TestFindAll() {
+	older := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Second)
+	newer := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+
+	s.expectUser("user1")
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user1").Return([]string{"s1", "s2", "gone"}, nil)
+	s.mockRedis.EXPECT().HGetAll(s.ctx, "session:s1").Return(s.sessionFields("user1", "laptop", older), nil)
+	s.mockRedis.EXPECT().HGetAll(s.ctx, "session:s2").Return(s.sessionFields("user1", "phone", newer), nil)
+	s.mockRedis.EXPECT().HGetAll(s.ctx, "session:gone").Return(map[string]string{}, nil)
+	s.mockRedis.EXPECT().SRem(s.ctx, "sessions:user1", "gone").Return(nil)
+	s.logger.EXPECT().Info("user's sessions retrieved successfully", gomock.Any()).Times(1)
+
+	sessions, err := s.sessionService.FindAll(s.ctx, "user1")
+	s.NoError(err)
+	s.Len(sessions, 2)
+	s.Equal("s2", sessions[0].ID)
+	s.Equal("phone", sessions[0].Device)
+	s.True(newer.Equal(sessions[0].CreatedAt))
+	s.Equal("s1", sessions[1].ID)
+	s.Equal("203.0.113.7", sessions[1].IP)
+}
+
+func (s *SessionServiceSuite) TestFindAllUserNotFound() {
+	s.mockUserRepo.EXPECT().FindById(s.ctx, "user1").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find user by id", gomock.Any()).Times(1)
+
+	_, err := s.sessionService.FindAll(s.ctx, "user1")
+	s.assertCode(err, dto.CodeUserNotFound)
+}
+
+func (s *SessionServiceSuite) TestFindAllRedisError() {
+	s.expectUser("user1")
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user1").Return(nil, errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to find sessions in redis", gomock.Any()).Times(1)
+
+	_, err := s.sessionService.FindAll(s.ctx, "user1")
+	s.ErrorContains(err, "redis error")
+}
+
+func (s *SessionServiceSuite) TestRevoke() {
+	s.expectUser("user1")
+	s.mockRedis.EXPECT().HGetAll(s.ctx, "session:s1").Return(s.sessionFields("user1", "laptop", time.Now()), nil)
+	s.mockRevoke.EXPECT().RevokeSession(s.ctx, "s1").Return(nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "session:s1").Return(nil)
+	s.mockRedis.EXPECT().SRem(s.ctx, "sessions:user1", "s1").Return(nil)
+	s.logger.EXPECT().Info("session revoked successfully", gomock.Any()).Times(1)
+
+	s.NoError(s.sessionService.Revoke(s.ctx, "user1", "s1"))
+}
+
+func (s *SessionServiceSuite) TestRevokeNotFound() {
+	s.expectUser("user1")
+	s.mockRedis.EXPECT().HGetAll(s.ctx, "session:s1").Return(map[string]string{}, nil)
+
+	s.assertCode(s.sessionService.Revoke(s.ctx, "user1", "s1"), dto.CodeSessionNotFound)
+}
+
+func (s *SessionServiceSuite) TestRevokeOtherUsersSession() {
+	s.expectUser("user1")
+	s.mockRedis.EXPECT().HGetAll(s.ctx, "session:s1").Return(s.sessionFields("user2", "laptop", time.Now()), nil)
+
+	s.assertCode(s.sessionService.Revoke(s.ctx, "user1", "s1"), dto.CodeSessionNotFound)
+}
+
+func (s *SessionServiceSuite) TestRevokeRevocationError() {
+	s.expectUser("user1")
+	s.mockRedis.EXPECT().HGetAll(s.ctx, "session:s1").Return(s.sessionFields("user1", "laptop", time.Now()), nil)
+	s.mockRevoke.EXPECT().RevokeSession(s.ctx, "s1").Return(errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to revoke session", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.sessionService.Revoke(s.ctx, "user1", "s1"), "redis error")
+}
+
+func (s *SessionServiceSuite) TestRevokeAll() {
+	s.expectUser("user1")
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "user1", gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user1").Return([]string{"s1", "s2"}, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:user1", "sessions:user1", "session:s1", "session:s2").Return(nil)
+	s.logger.EXPECT().Info("user's sessions revoked successfully", gomock.Any()).Times(1)
+
+	s.NoError(s.sessionService.RevokeAll(s.ctx, "user1"))
+}
+
+func (s *SessionServiceSuite) TestRevokeAllRedisError() {
+	s.expectUser("user1")
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "user1", gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:user1").Return(nil, errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to delete refresh token in redis", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.sessionService.RevokeAll(s.ctx, "user1"), "redis error")
+}
+
+func (s *SessionServiceSuite) TestRevokeEverything() {
+	ctx := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "platform", Super: true, CrossTenant: true})
+	s.mockRevoke.EXPECT().RevokeEveryone(ctx, gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().Scan(ctx, "session:*").Return([]string{"session:s1", "session:s2"}, nil)
+	s.mockRedis.EXPECT().Del(ctx, "session:s1", "session:s2").Return(nil)
+	s.mockRedis.EXPECT().Scan(ctx, "sessions:*").Return([]string{"sessions:user1"}, nil)
+	s.mockRedis.EXPECT().Del(ctx, "sessions:user1").Return(nil)
+	s.mockRedis.EXPECT().Scan(ctx, "refresh:*").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(ctx).Return(nil)
+	s.logger.EXPECT().Warn("every session revoked", gomock.Any()).Times(1)
+
+	s.NoError(s.sessionService.RevokeEverything(ctx))
+}
+
+func (s *SessionServiceSuite) TestRevokeEverythingRequiresSuperAdmin() {
+	s.logger.EXPECT().Warn("global session revocation rejected", gomock.Any()).Times(1)
+
+	s.assertCode(s.sessionService.RevokeEverything(s.ctx), dto.CodeTenantForbidden)
+}
+
+func (s *SessionServiceSuite) TestRevokeEverythingRevocationError() {
+	ctx := tenancy.WithTenant(context.Background(), tenancy.Tenant{OrganizationID: "platform", Super: true, CrossTenant: true})
+	s.mockRevoke.EXPECT().RevokeEveryone(ctx, gomock.Any()).Return(errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to revoke every access token", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.sessionService.RevokeEverything(ctx), "redis error")
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Refresh sessions are kept in Redis: each session is a hash under
+// session:<id> that expires with it, and sessions:<userId> indexes the ids
+// of a user's sessions. refresh:<userId> is the single refresh token kept
+// before sessions existed and is dropped alongside them.
+const (
+	sessionKeyPrefix      = "session:"
+	userSessionsKeyPrefix = "sessions:"
+	refreshKeyPrefix      = "refresh:"
+)
+
+// revokeRefreshTokens drops the cached effective scopes and deletes the
+// refresh sessions of every user whose scopes changed, carrying on past
+// failures so one unreachable key does not leave the rest valid.
+func revokeRefreshTokens(ctx context.Context, redisClient interfaces.IRedisClient, logger logger.ILogger, userIds []string) error {
+	var errs []error
+	if len(userIds) > 0 {
+		if err := invalidateEffectiveScopes(ctx, redisClient, userIds...); err != nil {
+			logger.Error("failed to invalidate effective scopes in redis", zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+	for _, userId := range userIds {
+		if err := deleteSessions(ctx, redisClient, userId); err != nil {
+			logger.Error("failed to delete refresh token in redis", zap.String("id", userId), zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deleteSessions deletes every refresh session of the user.
+func deleteSessions(ctx context.Context, redisClient interfaces.IRedisClient, userId string) error {
+	sessionIds, err := redisClient.SMembers(ctx, userSessionsKeyPrefix+userId)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{refreshKeyPrefix + userId, userSessionsKeyPrefix + userId}
+	for _, sessionId := range sessionIds {
+		keys = append(keys, sessionKeyPrefix+sessionId)
+	}
+	return redisClient.Del(ctx, keys...)
+}
+
+// deleteSession deletes one refresh session of the user.
+func deleteSession(ctx context.Context, redisClient interfaces.IRedisClient, userId, sessionId string) error {
+	if err := redisClient.Del(ctx, sessionKeyPrefix+sessionId); err != nil {
+		return err
+	}
+	return redisClient.SRem(ctx, userSessionsKeyPrefix+userId, sessionId)
+}
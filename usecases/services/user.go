@@ -3,52 +3,96 @@ package services
 import (
 	"context"
 	"net/mail"
+	"time"
 
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
 	"github.com/vnFuhung2903/vcs-user-management-service/entities"
 	"github.com/vnFuhung2903/vcs-user-management-service/interfaces"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
 	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/password"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/revocation"
 	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type IUserService interface {
-	Create(username, password, email string, scopes []*entities.UserScope) (*entities.User, error)
-	FindAll(ctx context.Context) ([]*entities.User, error)
-	UpdateScope(ctx context.Context, userId string, scope *entities.UserScope, isAdded bool) error
+	Create(ctx context.Context, username, password, email string, scopes []*entities.UserScope, expiries map[string]time.Time, roles []*entities.Role) (*entities.User, error)
+	FindById(ctx context.Context, userId string) (*entities.User, error)
+	FindAll(ctx context.Context, query dto.ListUsersRequest) ([]*entities.User, *dto.Paging, error)
+	UpdateScope(ctx context.Context, userId string, scope *entities.UserScope, isAdded bool, expiresAt *time.Time) error
+	UpdateRole(ctx context.Context, userId string, role *entities.Role, isAdded bool) error
+	ExplainScope(ctx context.Context, userId, scope string) ([]entities.ScopeGrant, error)
+	ChangePassword(ctx context.Context, userId, currentPassword, newPassword string) error
 	Delete(ctx context.Context, userId string) error
+	Logout(ctx context.Context, userId, tokenId, sessionId string, expiresAt time.Time) error
 }
 
 type userService struct {
-	userRepo    repositories.IUserRepository
-	redisClient interfaces.IRedisClient
-	logger      logger.ILogger
+	userRepo       repositories.IUserRepository
+	auditRepo      repositories.IAuditRepository
+	outboxRepo     repositories.IOutboxRepository
+	redisClient    interfaces.IRedisClient
+	revocations    revocation.IStore
+	hasher         password.IHasher
+	passwordPolicy password.IPolicy
+	logger         logger.ILogger
 }
 
-func NewUserService(userRepo repositories.IUserRepository, redisClient interfaces.IRedisClient, logger logger.ILogger) IUserService {
+func NewUserService(userRepo repositories.IUserRepository, auditRepo repositories.IAuditRepository, outboxRepo repositories.IOutboxRepository, redisClient interfaces.IRedisClient, revocations revocation.IStore, hasher password.IHasher, passwordPolicy password.IPolicy, logger logger.ILogger) IUserService {
 	return &userService{
-		userRepo:    userRepo,
-		redisClient: redisClient,
-		logger:      logger,
+		userRepo:       userRepo,
+		auditRepo:      auditRepo,
+		outboxRepo:     outboxRepo,
+		redisClient:    redisClient,
+		revocations:    revocations,
+		hasher:         hasher,
+		passwordPolicy: passwordPolicy,
+		logger:         logger,
 	}
 }
 
-func (s *userService) Create(username, password, email string, scopes []*entities.UserScope) (*entities.User, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+// Create registers a user. expiries maps a scope name to when its grant
+// expires; scopes missing from it are granted permanently.
+func (s *userService) Create(ctx context.Context, username, plaintext, email string, scopes []*entities.UserScope, expiries map[string]time.Time, roles []*entities.Role) (*entities.User, error) {
+	mail, err := mail.ParseAddress(email)
+	if err != nil {
+		s.logger.Error("failed to parse email", zap.Error(err))
+		return nil, apperrors.Validation(dto.CodeInvalidEmail, "invalid email address", err)
+	}
+
+	if err := s.checkExpiries(scopes, expiries); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPassword(password.Candidate{Password: plaintext, Username: username, Email: mail.Address}); err != nil {
+		return nil, err
+	}
+
+	hash, err := s.hasher.Hash(plaintext)
 	if err != nil {
 		s.logger.Error("failed to hash password", zap.Error(err))
 		return nil, err
 	}
 
-	mail, err := mail.ParseAddress(email)
+	tx, err := s.userRepo.BeginTransaction(ctx)
 	if err != nil {
-		s.logger.Error("failed to parse email", zap.Error(err))
+		s.logger.Error("failed to create transaction", zap.Error(err))
 		return nil, err
 	}
 
-	user, err := s.userRepo.Create(username, string(hash), mail.Address, scopes)
+	user, err := s.userRepo.WithTransaction(tx).Create(ctx, username, hash, mail.Address, scopes, expiries, roles)
 	if err != nil {
 		s.logger.Error("failed to create user", zap.Error(err))
+		tx.Rollback()
+		return nil, repositoryError(err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
+	}
+	if err := enqueueEvent(ctx, tx, s.outboxRepo, s.logger, events.UserCreated, user.OrganizationID, newUserData(user)); err != nil {
+		return nil, err
+	}
+	entry := userAuditEntry(user, entities.AuditUserCreate)
+	if err := commitAudited(ctx, tx, s.auditRepo, s.logger, entry, nil, newUserSnapshot(user, scopes, expiries, roles)); err != nil {
 		return nil, err
 	}
 
@@ -56,41 +100,92 @@ func (s *userService) Create(username, password, email string, scopes []*entitie
 	return user, nil
 }
 
-func (s *userService) FindAll(ctx context.Context) ([]*entities.User, error) {
-	users, err := s.userRepo.FindAll()
+func (s *userService) FindById(ctx context.Context, userId string) (*entities.User, error) {
+	user, err := s.userRepo.FindById(ctx, userId)
+	if err != nil {
+		s.logger.Error("failed to find user by id", zap.String("id", userId), zap.Error(err))
+		return nil, repositoryError(err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
+	}
+
+	s.logger.Info("user found successfully")
+	return user, nil
+}
+
+func (s *userService) FindAll(ctx context.Context, query dto.ListUsersRequest) ([]*entities.User, *dto.Paging, error) {
+	users, paging, err := s.userRepo.FindAll(ctx, query)
 	if err != nil {
 		s.logger.Error("failed to find all users", zap.Error(err))
-		return nil, err
+		return nil, nil, repositoryError(err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
 	}
 
 	s.logger.Info("all users retrieved successfully")
-	return users, nil
+	return users, paging, nil
 }
 
-func (s *userService) UpdateScope(ctx context.Context, userId string, scope *entities.UserScope, isAdded bool) error {
-	user, err := s.userRepo.FindById(userId)
+// UpdateScope grants or revokes a direct scope. A granted scope expires at
+// expiresAt, or never if it is nil; granting a held scope again replaces its
+// expiry. The expiries of the user's other scopes are kept.
+func (s *userService) UpdateScope(ctx context.Context, userId string, scope *entities.UserScope, isAdded bool, expiresAt *time.Time) error {
+	user, err := s.userRepo.FindById(ctx, userId)
 	if err != nil {
 		s.logger.Error("failed to find user by id", zap.Error(err))
-		return err
+		return repositoryError(err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
 	}
 
 	scopeList := make([]*entities.UserScope, 0, len(user.Scopes))
+	expiries := make(map[string]time.Time)
 	for _, s := range user.Scopes {
 		if s.ID == scope.ID {
 			continue
 		}
 		scopeList = append(scopeList, s)
+		if expiry := user.ScopeExpiry(s.ID); expiry != nil {
+			expiries[s.Name] = *expiry
+		}
 	}
 	if isAdded {
+		if expiresAt != nil {
+			if err := s.checkExpiries([]*entities.UserScope{scope}, map[string]time.Time{scope.Name: *expiresAt}); err != nil {
+				return err
+			}
+			expiries[scope.Name] = *expiresAt
+		}
 		scopeList = append(scopeList, scope)
 	}
 
-	if err := s.userRepo.UpdateScope(user, scopeList); err != nil {
+	before := newUserSnapshot(user, user.Scopes, scopeExpiries(user), user.Roles)
+	changed, isChanged := scopesChanged(user.ID, user.EffectiveScopes(), withGrants(user, scopeList, expiries, user.Roles).EffectiveScopes())
+	tx, err := s.userRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to create transaction", zap.Error(err))
+		return err
+	}
+
+	if err := s.userRepo.WithTransaction(tx).UpdateScope(ctx, user, scopeList, expiries); err != nil {
 		s.logger.Error("failed to update user's scopes", zap.Error(err))
+		tx.Rollback()
+		return repositoryError(err, dto.CodeScopeNotFound, dto.CodeScopeAlreadyExists)
+	}
+	if isChanged {
+		if err := enqueueEvent(ctx, tx, s.outboxRepo, s.logger, events.UserScopesChanged, user.OrganizationID, changed); err != nil {
+			return err
+		}
+	}
+	entry := userAuditEntry(user, entities.AuditUserScopeUpdate)
+	if err := commitAudited(ctx, tx, s.auditRepo, s.logger, entry, before, newUserSnapshot(user, scopeList, expiries, user.Roles)); err != nil {
 		return err
 	}
+	if err := invalidateEffectiveScopes(ctx, s.redisClient, user.ID); err != nil {
+		s.logger.Error("failed to invalidate effective scopes in redis", zap.Error(err))
+		return err
+	}
+	if isChanged && len(changed.Removed) > 0 {
+		if err := s.revokeAccessTokens(ctx, user.ID); err != nil {
+			return err
+		}
+	}
 
-	if err := s.redisClient.Del(ctx, "refresh:"+user.ID); err != nil {
+	if err := deleteSessions(ctx, s.redisClient, user.ID); err != nil {
 		s.logger.Error("failed to delete refresh token in redis", zap.Error(err))
 		return err
 	}
@@ -99,13 +194,185 @@ func (s *userService) UpdateScope(ctx context.Context, userId string, scope *ent
 	return nil
 }
 
+func (s *userService) UpdateRole(ctx context.Context, userId string, role *entities.Role, isAdded bool) error {
+	user, err := s.userRepo.FindById(ctx, userId)
+	if err != nil {
+		s.logger.Error("failed to find user by id", zap.Error(err))
+		return repositoryError(err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
+	}
+
+	roleList := make([]*entities.Role, 0, len(user.Roles))
+	for _, r := range user.Roles {
+		if r.ID == role.ID {
+			continue
+		}
+		roleList = append(roleList, r)
+	}
+	if isAdded {
+		roleList = append(roleList, role)
+	}
+
+	expiries := scopeExpiries(user)
+	before := newUserSnapshot(user, user.Scopes, expiries, user.Roles)
+	changed, isChanged := scopesChanged(user.ID, user.EffectiveScopes(), withGrants(user, user.Scopes, expiries, roleList).EffectiveScopes())
+	tx, err := s.userRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to create transaction", zap.Error(err))
+		return err
+	}
+
+	if err := s.userRepo.WithTransaction(tx).UpdateRole(ctx, user, roleList); err != nil {
+		s.logger.Error("failed to update user's roles", zap.Error(err))
+		tx.Rollback()
+		return repositoryError(err, dto.CodeRoleNotFound, dto.CodeRoleAlreadyExists)
+	}
+	if isChanged {
+		if err := enqueueEvent(ctx, tx, s.outboxRepo, s.logger, events.UserScopesChanged, user.OrganizationID, changed); err != nil {
+			return err
+		}
+	}
+	entry := userAuditEntry(user, entities.AuditUserRoleUpdate)
+	if err := commitAudited(ctx, tx, s.auditRepo, s.logger, entry, before, newUserSnapshot(user, user.Scopes, expiries, roleList)); err != nil {
+		return err
+	}
+	if err := invalidateEffectiveScopes(ctx, s.redisClient, user.ID); err != nil {
+		s.logger.Error("failed to invalidate effective scopes in redis", zap.Error(err))
+		return err
+	}
+	if isChanged && len(changed.Removed) > 0 {
+		if err := s.revokeAccessTokens(ctx, user.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := deleteSessions(ctx, s.redisClient, user.ID); err != nil {
+		s.logger.Error("failed to delete refresh token in redis", zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("user's roles updated successfully")
+	return nil
+}
+
+// ExplainScope lists the direct, role and group grants that give the user
+// scope. An empty list means the user does not hold it.
+func (s *userService) ExplainScope(ctx context.Context, userId, scope string) ([]entities.ScopeGrant, error) {
+	user, err := s.userRepo.FindById(ctx, userId)
+	if err != nil {
+		s.logger.Error("failed to find user by id", zap.String("id", userId), zap.Error(err))
+		return nil, repositoryError(err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
+	}
+
+	grants := user.ExplainScope(scope)
+	s.logger.Info("user's scope explained successfully", zap.String("scope", scope), zap.Int("grants", len(grants)))
+	return grants, nil
+}
+
+func (s *userService) ChangePassword(ctx context.Context, userId, currentPassword, newPassword string) error {
+	user, err := s.userRepo.FindById(ctx, userId)
+	if err != nil {
+		s.logger.Error("failed to find user by id", zap.Error(err))
+		return repositoryError(err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
+	}
+
+	if err := s.verifyPassword(ctx, user, currentPassword); err != nil {
+		return err
+	}
+
+	// The current hash counts towards the history, so only size-1 previous
+	// hashes are stored.
+	keep := s.passwordPolicy.HistorySize() - 1
+	history := []string{user.Hash}
+	if keep > 0 {
+		previous, err := s.userRepo.FindPasswordHistory(ctx, userId, keep)
+		if err != nil {
+			s.logger.Error("failed to find password history", zap.Error(err))
+			return err
+		}
+		history = append(history, previous...)
+	}
+
+	candidate := password.Candidate{Password: newPassword, Username: user.Username, Email: user.Email, History: history}
+	if err := s.checkPassword(candidate); err != nil {
+		return err
+	}
+
+	hash, err := s.hasher.Hash(newPassword)
+	if err != nil {
+		s.logger.Error("failed to hash password", zap.Error(err))
+		return err
+	}
+
+	tx, err := s.userRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to create transaction", zap.Error(err))
+		return err
+	}
+
+	txRepo := s.userRepo.WithTransaction(tx)
+	if err := txRepo.UpdateHash(ctx, userId, hash); err != nil {
+		s.logger.Error("failed to update password", zap.Error(err))
+		tx.Rollback()
+		return repositoryError(err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
+	}
+	if keep > 0 {
+		if err := txRepo.AddPasswordHistory(ctx, userId, user.Hash, keep); err != nil {
+			s.logger.Error("failed to record password history", zap.Error(err))
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		s.logger.Error("failed to commit transaction", zap.Error(err))
+		return err
+	}
+
+	if err := s.revokeAccessTokens(ctx, userId); err != nil {
+		return err
+	}
+	if err := deleteSessions(ctx, s.redisClient, userId); err != nil {
+		s.logger.Error("failed to delete refresh token in redis", zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("user's password changed successfully")
+	return nil
+}
+
 func (s *userService) Delete(ctx context.Context, userId string) error {
-	if err := s.userRepo.Delete(userId); err != nil {
+	user, err := s.userRepo.FindById(ctx, userId)
+	if err != nil {
+		s.logger.Error("failed to find user by id", zap.Error(err))
+		return repositoryError(err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
+	}
+
+	tx, err := s.userRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to create transaction", zap.Error(err))
+		return err
+	}
+
+	if err := s.userRepo.WithTransaction(tx).Delete(ctx, userId); err != nil {
 		s.logger.Error("failed to delete user", zap.Error(err))
+		tx.Rollback()
+		return repositoryError(err, dto.CodeUserNotFound, dto.CodeUserAlreadyExists)
+	}
+	if err := enqueueEvent(ctx, tx, s.outboxRepo, s.logger, events.UserDeleted, user.OrganizationID, newUserData(user)); err != nil {
+		return err
+	}
+	entry := userAuditEntry(user, entities.AuditUserDelete)
+	if err := commitAudited(ctx, tx, s.auditRepo, s.logger, entry, newUserSnapshot(user, user.Scopes, scopeExpiries(user), user.Roles), nil); err != nil {
+		return err
+	}
+	if err := invalidateEffectiveScopes(ctx, s.redisClient, userId); err != nil {
+		s.logger.Error("failed to invalidate effective scopes in redis", zap.Error(err))
+		return err
+	}
+	if err := s.revokeAccessTokens(ctx, userId); err != nil {
 		return err
 	}
 
-	if err := s.redisClient.Del(ctx, "refresh:"+userId); err != nil {
+	if err := deleteSessions(ctx, s.redisClient, userId); err != nil {
 		s.logger.Error("failed to delete refresh token in redis", zap.Error(err))
 		return err
 	}
@@ -113,3 +380,137 @@ func (s *userService) Delete(ctx context.Context, userId string) error {
 	s.logger.Info("user deleted successfully")
 	return nil
 }
+
+// Logout ends the caller's session. A token issued for a refresh session
+// ends that session and every access token it issued; any other token is
+// refused until it expires at expiresAt and the user's pre-session refresh
+// token is deleted. Tokens without a jti or a sid cannot be revoked one by
+// one and stay valid until they expire.
+func (s *userService) Logout(ctx context.Context, userId, tokenId, sessionId string, expiresAt time.Time) error {
+	if sessionId != "" {
+		if err := s.revocations.RevokeSession(ctx, sessionId); err != nil {
+			s.logger.Error("failed to revoke session", zap.String("id", userId), zap.Error(err))
+			return err
+		}
+		if err := deleteSession(ctx, s.redisClient, userId, sessionId); err != nil {
+			s.logger.Error("failed to delete session in redis", zap.Error(err))
+			return err
+		}
+		s.logger.Info("user logged out successfully")
+		return nil
+	}
+
+	if tokenId != "" {
+		if err := s.revocations.RevokeToken(ctx, tokenId, expiresAt); err != nil {
+			s.logger.Error("failed to revoke access token", zap.String("id", userId), zap.Error(err))
+			return err
+		}
+	}
+	if err := s.redisClient.Del(ctx, refreshKeyPrefix+userId); err != nil {
+		s.logger.Error("failed to delete refresh token in redis", zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("user logged out successfully")
+	return nil
+}
+
+// revokeAccessTokens refuses every access token issued to the user so far,
+// so removed scopes and deleted users stop working at once rather than when
+// their tokens expire.
+func (s *userService) revokeAccessTokens(ctx context.Context, userId string) error {
+	if err := s.revocations.RevokeUser(ctx, userId, time.Now()); err != nil {
+		s.logger.Error("failed to revoke user's access tokens", zap.String("id", userId), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// verifyPassword checks plaintext against the stored hash and, on a match,
+// upgrades a hash that is weaker than the configured hasher would produce.
+// A failed upgrade is logged and otherwise ignored; the next verification
+// retries it.
+func (s *userService) verifyPassword(ctx context.Context, user *entities.User, plaintext string) error {
+	match, needsRehash, err := s.hasher.Verify(user.Hash, plaintext)
+	if err != nil {
+		s.logger.Error("failed to verify password", zap.Error(err))
+		return err
+	}
+	if !match {
+		s.logger.Warn("current password mismatch", zap.String("id", user.ID))
+		return apperrors.Forbidden(dto.CodeInvalidCredentials, "current password is incorrect", nil)
+	}
+	if !needsRehash {
+		return nil
+	}
+
+	hash, err := s.hasher.Hash(plaintext)
+	if err != nil {
+		s.logger.Warn("failed to rehash password", zap.Error(err))
+		return nil
+	}
+	if err := s.userRepo.UpdateHash(ctx, user.ID, hash); err != nil {
+		s.logger.Warn("failed to upgrade password hash", zap.Error(err))
+		return nil
+	}
+
+	user.Hash = hash
+	s.logger.Info("password hash upgraded successfully")
+	return nil
+}
+
+// checkExpiries rejects an expiry for a scope that is not being granted and
+// one that has already passed, which the reaper would revoke at once.
+func (s *userService) checkExpiries(scopes []*entities.UserScope, expiries map[string]time.Time) error {
+	granted := make(map[string]struct{}, len(scopes))
+	for _, scope := range scopes {
+		granted[scope.Name] = struct{}{}
+	}
+
+	now := time.Now()
+	for name, expiresAt := range expiries {
+		if _, ok := granted[name]; !ok {
+			s.logger.Warn("grant expiry rejected", zap.String("scope", name))
+			return apperrors.Validation(dto.CodeInvalidGrantExpiry, "expiry given for a scope that is not granted", nil)
+		}
+		if !expiresAt.After(now) {
+			s.logger.Warn("grant expiry rejected", zap.String("scope", name), zap.Time("expires_at", expiresAt))
+			return apperrors.Validation(dto.CodeInvalidGrantExpiry, "grant expiry must be in the future", nil)
+		}
+	}
+	return nil
+}
+
+func (s *userService) checkPassword(candidate password.Candidate) error {
+	violations := s.passwordPolicy.Validate(candidate)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	s.logger.Warn("password rejected by policy", zap.Int("violations", len(violations)))
+	return apperrors.Validation(dto.CodeWeakPassword, "password does not satisfy the password policy", nil).WithDetails(violations)
+}
+
+// withGrants returns a copy of user holding the given direct scopes, with
+// their expiries, and roles, leaving its groups as they are.
+func withGrants(user *entities.User, scopes []*entities.UserScope, expiries map[string]time.Time, roles []*entities.Role) *entities.User {
+	updated := *user
+	updated.Scopes = scopes
+	updated.Roles = roles
+	updated.ScopeMappings = make([]*entities.UserScopeMapping, 0, len(expiries))
+	for _, scope := range scopes {
+		if expiresAt, ok := expiries[scope.Name]; ok {
+			updated.ScopeMappings = append(updated.ScopeMappings, &entities.UserScopeMapping{UserID: user.ID, UserScopeID: scope.ID, ExpiresAt: &expiresAt})
+		}
+	}
+	return &updated
+}
+
+func userAuditEntry(user *entities.User, action string) *entities.AuditEntry {
+	return &entities.AuditEntry{
+		OrganizationID: user.OrganizationID,
+		Action:         action,
+		TargetType:     entities.AuditTargetUser,
+		TargetID:       user.ID,
+	}
+}
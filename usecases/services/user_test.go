@@ -2,37 +2,79 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	Logger "gorm.io/gorm/logger"
 
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
 	"github.com/vnFuhung2903/vcs-user-management-service/entities"
 	"github.com/vnFuhung2903/vcs-user-management-service/mocks/interfaces"
 	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
 	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/revocation"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/audit"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/password"
 )
 
 type UserServiceSuite struct {
 	suite.Suite
-	ctrl        *gomock.Controller
-	userService IUserService
-	mockRepo    *repositories.MockIUserRepository
-	mockRedis   *interfaces.MockIRedisClient
-	logger      *logger.MockILogger
-	ctx         context.Context
+	ctrl         *gomock.Controller
+	userService  IUserService
+	mockRepo     *repositories.MockIUserRepository
+	mockTxRepo   *repositories.MockIUserRepository
+	mockAudit    *repositories.MockIAuditRepository
+	mockTxAudit  *repositories.MockIAuditRepository
+	mockOutbox   *repositories.MockIOutboxRepository
+	mockTxOutbox *repositories.MockIOutboxRepository
+	mockRedis    *interfaces.MockIRedisClient
+	mockRevoke   *revocation.MockIStore
+	logger       *logger.MockILogger
+	ctx          context.Context
 }
 
 func (s *UserServiceSuite) SetupTest() {
 	s.ctrl = gomock.NewController(s.T())
 	s.mockRepo = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockTxRepo = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockAudit = repositories.NewMockIAuditRepository(s.ctrl)
+	s.mockTxAudit = repositories.NewMockIAuditRepository(s.ctrl)
+	s.mockOutbox = repositories.NewMockIOutboxRepository(s.ctrl)
+	s.mockTxOutbox = repositories.NewMockIOutboxRepository(s.ctrl)
 	s.mockRedis = interfaces.NewMockIRedisClient(s.ctrl)
+	s.mockRevoke = revocation.NewMockIStore(s.ctrl)
 	s.logger = logger.NewMockILogger(s.ctrl)
-	s.userService = NewUserService(s.mockRepo, s.mockRedis, s.logger)
+	s.userService = s.newUserService("bcrypt")
 	s.ctx = context.Background()
 }
 
+func (s *UserServiceSuite) newUserService(algorithm string) IUserService {
+	hasher, err := password.LoadHasher(env.PasswordHashEnv{
+		Algorithm:         algorithm,
+		BcryptCost:        bcrypt.MinCost,
+		Argon2Memory:      1024,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+		Argon2SaltLength:  16,
+		Argon2KeyLength:   32,
+	})
+	s.Require().NoError(err)
+
+	policy := password.NewPolicy(3, password.Length(8, 72), password.NoPersonalInfo(), password.History(hasher))
+	return NewUserService(s.mockRepo, s.mockAudit, s.mockOutbox, s.mockRedis, s.mockRevoke, hasher, policy, s.logger)
+}
+
 func (s *UserServiceSuite) TearDownTest() {
 	s.ctrl.Finish()
 }
@@ -41,6 +83,40 @@ func TestUserServiceSuite(t *testing.T) {
 	suite.Run(t, new(UserServiceSuite))
 }
 
+// expectTransaction lets the service open a transaction and bind the user,
+// audit and outbox repositories to it.
+func (s *UserServiceSuite) expectTransaction() *gorm.DB {
+	tx := s.newTx()
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(s.mockTxRepo)
+	s.mockAudit.EXPECT().WithTransaction(tx).Return(s.mockTxAudit).AnyTimes()
+	s.mockOutbox.EXPECT().WithTransaction(tx).Return(s.mockTxOutbox).AnyTimes()
+	return tx
+}
+
+// expectEvent checks that the change enqueues one domain event of eventType
+// and decodes its data into data when it is not nil.
+func (s *UserServiceSuite) expectEvent(eventType string, data interface{}) {
+	s.mockTxOutbox.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *entities.OutboxEvent) error {
+		s.Equal(eventType, event.Type)
+		parsed, err := events.Parse(events.Encoded(event.Payload))
+		s.Require().NoError(err)
+		s.Equal(event.EventID, parsed.ID)
+		if data != nil {
+			s.Require().NoError(parsed.Decode(data))
+		}
+		return nil
+	})
+}
+
+func (s *UserServiceSuite) expectAudit(action string) {
+	s.mockTxAudit.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry *entities.AuditEntry) error {
+		s.Equal(action, entry.Action)
+		s.Equal(entities.AuditTargetUser, entry.TargetType)
+		return nil
+	})
+}
+
 func (s *UserServiceSuite) TestCreate() {
 	username := "testuser"
 	password := "password123"
@@ -54,12 +130,17 @@ func (s *UserServiceSuite) TestCreate() {
 		Scopes:   scopes,
 	}
 
-	s.mockRepo.EXPECT().Create(username, gomock.Any(), email, scopes).Return(expected, nil)
+	var data events.UserData
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Create(s.ctx, username, gomock.Any(), email, scopes, nil, nil).Return(expected, nil)
+	s.expectEvent(events.UserCreated, &data)
+	s.expectAudit(entities.AuditUserCreate)
 	s.logger.EXPECT().Info("new user registered successfully").Times(1)
 
-	result, err := s.userService.Create(username, password, email, scopes)
+	result, err := s.userService.Create(s.ctx, username, password, email, scopes, nil, nil)
 	s.NoError(err)
 	s.Equal(expected, result)
+	s.Equal(events.UserData{UserID: "test-id", Username: username, Email: email}, data)
 }
 
 func (s *UserServiceSuite) TestCreateInvalidEmail() {
@@ -70,8 +151,8 @@ func (s *UserServiceSuite) TestCreateInvalidEmail() {
 
 	s.logger.EXPECT().Error("failed to parse email", gomock.Any()).Times(1)
 
-	result, err := s.userService.Create(username, password, email, scopes)
-	s.Error(err)
+	result, err := s.userService.Create(s.ctx, username, password, email, scopes, nil, nil)
+	s.True(apperrors.IsKind(err, apperrors.KindValidation))
 	s.Nil(result)
 }
 
@@ -81,10 +162,11 @@ func (s *UserServiceSuite) TestCreateError() {
 	email := "test@example.com"
 	scopes := []*entities.UserScope{}
 
-	s.mockRepo.EXPECT().Create(username, gomock.Any(), email, scopes).Return(nil, errors.New("db error"))
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Create(s.ctx, username, gomock.Any(), email, scopes, nil, nil).Return(nil, errors.New("db error"))
 	s.logger.EXPECT().Error("failed to create user", gomock.Any()).Times(1)
 
-	result, err := s.userService.Create(username, password, email, scopes)
+	result, err := s.userService.Create(s.ctx, username, password, email, scopes, nil, nil)
 	s.ErrorContains(err, "db error")
 	s.Nil(result)
 }
@@ -105,13 +187,111 @@ func (s *UserServiceSuite) TestUpdateScopeAdd() {
 	}
 	expectedScope := existingUser.Scopes
 
-	s.mockRepo.EXPECT().FindById(userId).Return(existingUser, nil)
-	s.mockRepo.EXPECT().UpdateScope(existingUser, expectedScope).Return(nil)
-	s.mockRedis.EXPECT().Del(s.ctx, "refresh:"+userId).Return(nil)
+	s.mockRepo.EXPECT().FindById(s.ctx, userId).Return(existingUser, nil)
+	s.expectTransaction()
+	// The user already holds the scope, so no user.scopes_changed event is
+	// enqueued.
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, existingUser, expectedScope, map[string]time.Time{}).Return(nil)
+	s.expectAudit(entities.AuditUserScopeUpdate)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:"+userId).Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:"+userId).Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:"+userId, "sessions:"+userId).Return(nil)
+	s.logger.EXPECT().Info("user's scopes updated successfully").Times(1)
+
+	err := s.userService.UpdateScope(s.ctx, userId, newScope, true, nil)
+	s.NoError(err)
+}
+
+func (s *UserServiceSuite) TestCreateWithExpiry() {
+	scopes := []*entities.UserScope{{ID: 1, Name: "container:update"}}
+	expiries := map[string]time.Time{"container:update": time.Now().Add(time.Hour)}
+	expected := &entities.User{ID: "test-id", Username: "contractor", Scopes: scopes}
+
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Create(s.ctx, "contractor", gomock.Any(), "contractor@example.com", scopes, expiries, nil).Return(expected, nil)
+	s.expectEvent(events.UserCreated, nil)
+	s.expectAudit(entities.AuditUserCreate)
+	s.logger.EXPECT().Info("new user registered successfully").Times(1)
+
+	result, err := s.userService.Create(s.ctx, "contractor", "password123", "contractor@example.com", scopes, expiries, nil)
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *UserServiceSuite) TestCreateInvalidExpiry() {
+	scopes := []*entities.UserScope{{ID: 1, Name: "container:update"}}
+	s.logger.EXPECT().Warn("grant expiry rejected", gomock.Any(), gomock.Any()).Times(1)
+	s.logger.EXPECT().Warn("grant expiry rejected", gomock.Any()).Times(1)
+
+	for _, expiries := range []map[string]time.Time{
+		{"container:update": time.Now().Add(-time.Minute)},
+		{"container:view": time.Now().Add(time.Hour)},
+	} {
+		result, err := s.userService.Create(s.ctx, "contractor", "password123", "contractor@example.com", scopes, expiries, nil)
+		s.Nil(result)
+		appErr, ok := apperrors.As(err)
+		s.True(ok)
+		s.Equal(dto.CodeInvalidGrantExpiry, appErr.Code)
+	}
+}
+
+func (s *UserServiceSuite) TestUpdateScopeWithExpiry() {
+	userId := "test-id"
+	viewExpiry := time.Now().Add(time.Hour)
+	updateExpiry := time.Now().Add(2 * time.Hour)
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	update := &entities.UserScope{ID: 2, Name: "container:update"}
+	existingUser := &entities.User{
+		ID:            userId,
+		Scopes:        []*entities.UserScope{view},
+		ScopeMappings: []*entities.UserScopeMapping{{UserID: userId, UserScopeID: 1, ExpiresAt: &viewExpiry}},
+	}
+
+	s.mockRepo.EXPECT().FindById(s.ctx, userId).Return(existingUser, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, existingUser, []*entities.UserScope{view, update},
+		map[string]time.Time{"container:view": viewExpiry, "container:update": updateExpiry}).Return(nil)
+	var data events.UserScopesChangedData
+	s.expectEvent(events.UserScopesChanged, &data)
+	s.mockTxAudit.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry *entities.AuditEntry) error {
+		var before, after struct {
+			Scopes        []string             `json:"scopes"`
+			ScopeExpiries map[string]time.Time `json:"scope_expiries"`
+		}
+		s.NoError(json.Unmarshal([]byte(*entry.Before), &before))
+		s.NoError(json.Unmarshal([]byte(*entry.After), &after))
+		s.Equal([]string{"container:view"}, before.Scopes)
+		s.Equal([]string{"container:view", "container:update"}, after.Scopes)
+		s.True(updateExpiry.Equal(after.ScopeExpiries["container:update"]))
+		return nil
+	})
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:"+userId).Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:"+userId).Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:"+userId, "sessions:"+userId).Return(nil)
 	s.logger.EXPECT().Info("user's scopes updated successfully").Times(1)
 
-	err := s.userService.UpdateScope(s.ctx, userId, newScope, true)
+	err := s.userService.UpdateScope(s.ctx, userId, update, true, &updateExpiry)
 	s.NoError(err)
+	s.Equal(events.UserScopesChangedData{
+		UserID:  userId,
+		Scopes:  []string{"container:view", "container:update"},
+		Added:   []string{"container:update"},
+		Removed: []string{},
+	}, data)
+}
+
+func (s *UserServiceSuite) TestUpdateScopeExpiryInPast() {
+	userId := "test-id"
+	expiresAt := time.Now().Add(-time.Minute)
+	newScope := &entities.UserScope{ID: 1, Name: "container:update"}
+
+	s.mockRepo.EXPECT().FindById(s.ctx, userId).Return(&entities.User{ID: userId}, nil)
+	s.logger.EXPECT().Warn("grant expiry rejected", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.userService.UpdateScope(s.ctx, userId, newScope, true, &expiresAt)
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeInvalidGrantExpiry, appErr.Code)
 }
 
 func (s *UserServiceSuite) TestUpdateScopeUserNotFound() {
@@ -121,10 +301,10 @@ func (s *UserServiceSuite) TestUpdateScopeUserNotFound() {
 		ID:   1,
 	}
 
-	s.mockRepo.EXPECT().FindById(userId).Return(nil, errors.New("user not found"))
+	s.mockRepo.EXPECT().FindById(s.ctx, userId).Return(nil, errors.New("user not found"))
 	s.logger.EXPECT().Error("failed to find user by id", gomock.Any()).Times(1)
 
-	err := s.userService.UpdateScope(s.ctx, userId, newScope, false)
+	err := s.userService.UpdateScope(s.ctx, userId, newScope, false, nil)
 	s.ErrorContains(err, "user not found")
 }
 
@@ -141,11 +321,12 @@ func (s *UserServiceSuite) TestUpdateScopeRepoError() {
 	}
 	expectedScope := append(existingUser.Scopes, newScope)
 
-	s.mockRepo.EXPECT().FindById(userId).Return(existingUser, nil)
-	s.mockRepo.EXPECT().UpdateScope(existingUser, expectedScope).Return(errors.New("update failed"))
+	s.mockRepo.EXPECT().FindById(s.ctx, userId).Return(existingUser, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, existingUser, expectedScope, map[string]time.Time{}).Return(errors.New("update failed"))
 	s.logger.EXPECT().Error("failed to update user's scopes", gomock.Any()).Times(1)
 
-	err := s.userService.UpdateScope(s.ctx, userId, newScope, true)
+	err := s.userService.UpdateScope(s.ctx, userId, newScope, true, nil)
 	s.ErrorContains(err, "update failed")
 }
 
@@ -163,20 +344,162 @@ func (s *UserServiceSuite) TestUpdateScopeRedisError() {
 
 	expectedScope := append(existingUser.Scopes, newScope)
 
-	s.mockRepo.EXPECT().FindById(userId).Return(existingUser, nil)
-	s.mockRepo.EXPECT().UpdateScope(existingUser, expectedScope).Return(nil)
-	s.mockRedis.EXPECT().Del(s.ctx, "refresh:"+userId).Return(errors.New("redis error"))
+	s.mockRepo.EXPECT().FindById(s.ctx, userId).Return(existingUser, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, existingUser, expectedScope, map[string]time.Time{}).Return(nil)
+	s.expectEvent(events.UserScopesChanged, nil)
+	s.expectAudit(entities.AuditUserScopeUpdate)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:"+userId).Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:"+userId).Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:"+userId, "sessions:"+userId).Return(errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to delete refresh token in redis", gomock.Any()).Times(1)
+
+	err := s.userService.UpdateScope(s.ctx, userId, newScope, true, nil)
+	s.ErrorContains(err, "redis error")
+}
+
+func (s *UserServiceSuite) TestUpdateRoleAdd() {
+	userId := "test-id"
+	auditor := &entities.Role{ID: 1, Name: "auditor"}
+	operator := &entities.Role{ID: 2, Name: "operator", Scopes: []*entities.UserScope{{ID: 1, Name: "container:update"}}}
+	existingUser := &entities.User{
+		ID:    userId,
+		Roles: []*entities.Role{auditor},
+	}
+
+	var data events.UserScopesChangedData
+	s.mockRepo.EXPECT().FindById(s.ctx, userId).Return(existingUser, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateRole(s.ctx, existingUser, []*entities.Role{auditor, operator}).Return(nil)
+	s.expectEvent(events.UserScopesChanged, &data)
+	s.expectAudit(entities.AuditUserRoleUpdate)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:"+userId).Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:"+userId).Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:"+userId, "sessions:"+userId).Return(nil)
+	s.logger.EXPECT().Info("user's roles updated successfully").Times(1)
+
+	err := s.userService.UpdateRole(s.ctx, userId, operator, true)
+	s.NoError(err)
+	s.Equal([]string{"container:update"}, data.Added)
+	s.Empty(data.Removed)
+}
+
+func (s *UserServiceSuite) TestUpdateRoleRemove() {
+	userId := "test-id"
+	auditor := &entities.Role{ID: 1, Name: "auditor"}
+	operator := &entities.Role{ID: 2, Name: "operator"}
+	existingUser := &entities.User{
+		ID:    userId,
+		Roles: []*entities.Role{auditor, operator},
+	}
+
+	s.mockRepo.EXPECT().FindById(s.ctx, userId).Return(existingUser, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateRole(s.ctx, existingUser, []*entities.Role{auditor}).Return(nil)
+	s.expectAudit(entities.AuditUserRoleUpdate)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:"+userId).Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:"+userId).Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:"+userId, "sessions:"+userId).Return(nil)
+	s.logger.EXPECT().Info("user's roles updated successfully").Times(1)
+
+	err := s.userService.UpdateRole(s.ctx, userId, &entities.Role{ID: 2, Name: "operator"}, false)
+	s.NoError(err)
+}
+
+func (s *UserServiceSuite) TestUpdateRoleUserNotFound() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "nonexistent-id").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find user by id", gomock.Any()).Times(1)
+
+	err := s.userService.UpdateRole(s.ctx, "nonexistent-id", &entities.Role{ID: 1}, true)
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeUserNotFound, appErr.Code)
+}
+
+func (s *UserServiceSuite) TestUpdateRoleRepoError() {
+	existingUser := &entities.User{ID: "test-id"}
+	role := &entities.Role{ID: 1, Name: "operator"}
+
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(existingUser, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateRole(s.ctx, existingUser, []*entities.Role{role}).Return(errors.New("update failed"))
+	s.logger.EXPECT().Error("failed to update user's roles", gomock.Any()).Times(1)
+
+	err := s.userService.UpdateRole(s.ctx, "test-id", role, true)
+	s.ErrorContains(err, "update failed")
+}
+
+func (s *UserServiceSuite) TestUpdateRoleRedisError() {
+	existingUser := &entities.User{ID: "test-id"}
+	role := &entities.Role{ID: 1, Name: "operator"}
+
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(existingUser, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateRole(s.ctx, existingUser, []*entities.Role{role}).Return(nil)
+	s.expectAudit(entities.AuditUserRoleUpdate)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:test-id").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:test-id").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:test-id", "sessions:test-id").Return(errors.New("redis error"))
 	s.logger.EXPECT().Error("failed to delete refresh token in redis", gomock.Any()).Times(1)
 
-	err := s.userService.UpdateScope(s.ctx, userId, newScope, true)
+	err := s.userService.UpdateRole(s.ctx, "test-id", role, true)
 	s.ErrorContains(err, "redis error")
 }
 
+func (s *UserServiceSuite) TestExplainScope() {
+	engineering := &entities.Group{ID: 1, Name: "engineering", Scopes: []*entities.UserScope{{Name: "container:*"}}}
+	backend := &entities.Group{ID: 2, Name: "backend", Parent: engineering}
+	user := &entities.User{
+		ID:     "test-id",
+		Scopes: []*entities.UserScope{{Name: "container:view"}},
+		Roles:  []*entities.Role{{Name: "operator", Scopes: []*entities.UserScope{{Name: "container:view"}, {Name: "report:mail"}}}},
+		Groups: []*entities.Group{backend},
+	}
+
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(user, nil)
+	s.logger.EXPECT().Info("user's scope explained successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	grants, err := s.userService.ExplainScope(s.ctx, "test-id", "container:view")
+	s.NoError(err)
+	s.Equal([]entities.ScopeGrant{
+		{Scope: "container:view", Source: entities.GrantDirect, Path: []string{}},
+		{Scope: "container:view", Source: entities.GrantRole, Path: []string{"operator"}},
+		{Scope: "container:*", Source: entities.GrantGroup, Path: []string{"backend", "engineering"}},
+	}, grants)
+}
+
+func (s *UserServiceSuite) TestExplainScopeNotHeld() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(&entities.User{ID: "test-id"}, nil)
+	s.logger.EXPECT().Info("user's scope explained successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	grants, err := s.userService.ExplainScope(s.ctx, "test-id", "user:manage")
+	s.NoError(err)
+	s.Empty(grants)
+}
+
+func (s *UserServiceSuite) TestExplainScopeUserNotFound() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "missing").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find user by id", gomock.Any(), gomock.Any()).Times(1)
+
+	grants, err := s.userService.ExplainScope(s.ctx, "missing", "user:manage")
+	s.Nil(grants)
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeUserNotFound, appErr.Code)
+}
+
 func (s *UserServiceSuite) TestDelete() {
 	userId := "test-id"
 
-	s.mockRepo.EXPECT().Delete(userId).Return(nil)
-	s.mockRedis.EXPECT().Del(s.ctx, "refresh:"+userId).Return(nil)
+	s.mockRepo.EXPECT().FindById(s.ctx, userId).Return(&entities.User{ID: userId}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, userId).Return(nil)
+	s.expectEvent(events.UserDeleted, nil)
+	s.expectAudit(entities.AuditUserDelete)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:"+userId).Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, userId, gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:"+userId).Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:"+userId, "sessions:"+userId).Return(nil)
 	s.logger.EXPECT().Info("user deleted successfully").Times(1)
 
 	err := s.userService.Delete(s.ctx, userId)
@@ -186,7 +509,9 @@ func (s *UserServiceSuite) TestDelete() {
 func (s *UserServiceSuite) TestDeleteRepoError() {
 	userId := "test-id"
 
-	s.mockRepo.EXPECT().Delete(userId).Return(errors.New("delete failed"))
+	s.mockRepo.EXPECT().FindById(s.ctx, userId).Return(&entities.User{ID: userId}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, userId).Return(errors.New("delete failed"))
 	s.logger.EXPECT().Error("failed to delete user", gomock.Any()).Times(1)
 
 	err := s.userService.Delete(s.ctx, userId)
@@ -196,14 +521,153 @@ func (s *UserServiceSuite) TestDeleteRepoError() {
 func (s *UserServiceSuite) TestDeleteRedisError() {
 	userId := "test-id"
 
-	s.mockRepo.EXPECT().Delete(userId).Return(nil)
-	s.mockRedis.EXPECT().Del(s.ctx, "refresh:"+userId).Return(errors.New("redis error"))
+	s.mockRepo.EXPECT().FindById(s.ctx, userId).Return(&entities.User{ID: userId}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, userId).Return(nil)
+	s.expectEvent(events.UserDeleted, nil)
+	s.expectAudit(entities.AuditUserDelete)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:"+userId).Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, userId, gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:"+userId).Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:"+userId, "sessions:"+userId).Return(errors.New("redis error"))
 	s.logger.EXPECT().Error("failed to delete refresh token in redis", gomock.Any()).Times(1)
 
 	err := s.userService.Delete(s.ctx, userId)
 	s.ErrorContains(err, "redis error")
 }
 
+func (s *UserServiceSuite) TestDeleteNotFound() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "missing").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find user by id", gomock.Any()).Times(1)
+
+	err := s.userService.Delete(s.ctx, "missing")
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeUserNotFound, appErr.Code)
+}
+
+func (s *UserServiceSuite) TestDeleteRecordsOrigin() {
+	ctx := audit.WithOrigin(s.ctx, audit.Origin{ActorID: "admin", RequestID: "req-1", SourceIP: "10.0.0.1"})
+	user := &entities.User{
+		ID:             "test-id",
+		OrganizationID: "acme",
+		Username:       "testuser",
+		Email:          "test@example.com",
+		Hash:           "secret-hash",
+		Scopes:         []*entities.UserScope{{ID: 1, Name: "container:view"}},
+	}
+
+	tx := s.newTx()
+	s.mockRepo.EXPECT().FindById(ctx, "test-id").Return(user, nil)
+	s.mockRepo.EXPECT().BeginTransaction(ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(s.mockTxRepo)
+	s.mockAudit.EXPECT().WithTransaction(tx).Return(s.mockTxAudit)
+	s.mockOutbox.EXPECT().WithTransaction(tx).Return(s.mockTxOutbox)
+	s.mockTxRepo.EXPECT().Delete(ctx, "test-id").Return(nil)
+	s.mockTxOutbox.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *entities.OutboxEvent) error {
+		s.Equal("acme", event.OrganizationID)
+		s.Equal(events.UserDeleted, event.Type)
+		return nil
+	})
+	s.mockTxAudit.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry *entities.AuditEntry) error {
+		s.Equal("acme", entry.OrganizationID)
+		s.Equal("admin", entry.ActorID)
+		s.Equal("req-1", entry.RequestID)
+		s.Equal("10.0.0.1", entry.SourceIP)
+		s.Equal("test-id", entry.TargetID)
+		s.JSONEq(`{"id":"test-id","username":"testuser","email":"test@example.com","scopes":["container:view"],"roles":[]}`, *entry.Before)
+		s.Nil(entry.After)
+		return nil
+	})
+	s.mockRedis.EXPECT().Del(ctx, "authz:scopes:test-id").Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(ctx, "test-id", gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().SMembers(ctx, "sessions:test-id").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(ctx, "refresh:test-id", "sessions:test-id").Return(nil)
+	s.logger.EXPECT().Info("user deleted successfully").Times(1)
+
+	s.NoError(s.userService.Delete(ctx, "test-id"))
+}
+
+func (s *UserServiceSuite) TestCreateAuditError() {
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Create(s.ctx, "testuser", gomock.Any(), "test@example.com", nil, nil, nil).Return(&entities.User{ID: "test-id"}, nil)
+	s.expectEvent(events.UserCreated, nil)
+	s.mockTxAudit.EXPECT().Create(s.ctx, gomock.Any()).Return(errors.New("audit error"))
+	s.logger.EXPECT().Error("failed to record audit entry", gomock.Any()).Times(1)
+
+	result, err := s.userService.Create(s.ctx, "testuser", "password123", "test@example.com", nil, nil, nil)
+	s.Nil(result)
+	s.ErrorContains(err, "audit error")
+}
+
+func (s *UserServiceSuite) TestDeleteOutboxError() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(&entities.User{ID: "test-id"}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, "test-id").Return(nil)
+	s.mockTxOutbox.EXPECT().Create(s.ctx, gomock.Any()).Return(errors.New("outbox error"))
+	s.logger.EXPECT().Error("failed to enqueue domain event", gomock.Any(), gomock.Any()).Times(1)
+
+	s.ErrorContains(s.userService.Delete(s.ctx, "test-id"), "outbox error")
+}
+
+func (s *UserServiceSuite) TestUpdateScopeRemove() {
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	update := &entities.UserScope{ID: 2, Name: "container:update"}
+	existingUser := &entities.User{
+		ID:     "test-id",
+		Scopes: []*entities.UserScope{view, update},
+		Roles:  []*entities.Role{{ID: 1, Name: "viewer", Scopes: []*entities.UserScope{view}}},
+	}
+
+	var data events.UserScopesChangedData
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(existingUser, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, existingUser, []*entities.UserScope{view}, map[string]time.Time{}).Return(nil)
+	s.expectEvent(events.UserScopesChanged, &data)
+	s.expectAudit(entities.AuditUserScopeUpdate)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:test-id").Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "test-id", gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:test-id").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:test-id", "sessions:test-id").Return(nil)
+	s.logger.EXPECT().Info("user's scopes updated successfully").Times(1)
+
+	s.NoError(s.userService.UpdateScope(s.ctx, "test-id", update, false, nil))
+	s.Equal([]string{"container:view"}, data.Scopes)
+	s.Equal([]string{"container:update"}, data.Removed)
+	s.Empty(data.Added)
+}
+
+func (s *UserServiceSuite) TestUpdateScopeRemoveHeldThroughRole() {
+	view := &entities.UserScope{ID: 1, Name: "container:view"}
+	existingUser := &entities.User{
+		ID:     "test-id",
+		Scopes: []*entities.UserScope{view},
+		Roles:  []*entities.Role{{ID: 1, Name: "viewer", Scopes: []*entities.UserScope{view}}},
+	}
+
+	// The role still grants the scope, so no user.scopes_changed event is
+	// enqueued.
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(existingUser, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().UpdateScope(s.ctx, existingUser, []*entities.UserScope{}, map[string]time.Time{}).Return(nil)
+	s.expectAudit(entities.AuditUserScopeUpdate)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:test-id").Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:test-id").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:test-id", "sessions:test-id").Return(nil)
+	s.logger.EXPECT().Info("user's scopes updated successfully").Times(1)
+
+	s.NoError(s.userService.UpdateScope(s.ctx, "test-id", view, false, nil))
+}
+
+func (s *UserServiceSuite) TestUpdateScopeBeginTransactionError() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(&entities.User{ID: "test-id"}, nil)
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(nil, errors.New("transaction error"))
+	s.logger.EXPECT().Error("failed to create transaction", gomock.Any()).Times(1)
+
+	err := s.userService.UpdateScope(s.ctx, "test-id", &entities.UserScope{ID: 1, Name: "user:view"}, true, nil)
+	s.ErrorContains(err, "transaction error")
+}
+
 func (s *UserServiceSuite) TestFindAll() {
 	expectedUsers := []*entities.User{
 		{
@@ -226,19 +690,354 @@ func (s *UserServiceSuite) TestFindAll() {
 		},
 	}
 
-	s.mockRepo.EXPECT().FindAll().Return(expectedUsers, nil)
+	s.mockRepo.EXPECT().FindAll(s.ctx, dto.ListUsersRequest{HasScope: "read"}).Return(expectedUsers, &dto.Paging{Limit: 20}, nil)
 	s.logger.EXPECT().Info("all users retrieved successfully").Times(1)
 
-	result, err := s.userService.FindAll(s.ctx)
+	result, paging, err := s.userService.FindAll(s.ctx, dto.ListUsersRequest{HasScope: "read"})
 	s.NoError(err)
+	s.Equal(&dto.Paging{Limit: 20}, paging)
 	s.Equal(expectedUsers, result)
 }
 
 func (s *UserServiceSuite) TestFindAllError() {
-	s.mockRepo.EXPECT().FindAll().Return(nil, errors.New("database error"))
+	s.mockRepo.EXPECT().FindAll(s.ctx, dto.ListUsersRequest{}).Return(nil, nil, errors.New("database error"))
 	s.logger.EXPECT().Error("failed to find all users", gomock.Any()).Times(1)
 
-	result, err := s.userService.FindAll(s.ctx)
+	result, _, err := s.userService.FindAll(s.ctx, dto.ListUsersRequest{})
+	s.ErrorContains(err, "database error")
+	s.Nil(result)
+}
+
+func (s *UserServiceSuite) TestFindById() {
+	expected := &entities.User{
+		ID:       "user-1",
+		Username: "user1",
+		Email:    "user1@example.com",
+	}
+
+	s.mockRepo.EXPECT().FindById(s.ctx, "user-1").Return(expected, nil)
+	s.logger.EXPECT().Info("user found successfully").Times(1)
+
+	result, err := s.userService.FindById(s.ctx, "user-1")
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *UserServiceSuite) TestFindByIdError() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "user-1").Return(nil, errors.New("database error"))
+	s.logger.EXPECT().Error("failed to find user by id", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.userService.FindById(s.ctx, "user-1")
 	s.ErrorContains(err, "database error")
 	s.Nil(result)
 }
+
+func (s *UserServiceSuite) TestFindByIdNotFound() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "missing").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find user by id", gomock.Any(), gomock.Any()).Times(1)
+
+	result, err := s.userService.FindById(s.ctx, "missing")
+	s.Nil(result)
+
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(apperrors.KindNotFound, appErr.Kind)
+	s.Equal(dto.CodeUserNotFound, appErr.Code)
+}
+
+func (s *UserServiceSuite) TestCreateDuplicate() {
+	scopes := []*entities.UserScope{}
+
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Create(s.ctx, "testuser", gomock.Any(), "test@example.com", scopes, nil, nil).Return(nil, gorm.ErrDuplicatedKey)
+	s.logger.EXPECT().Error("failed to create user", gomock.Any()).Times(1)
+
+	result, err := s.userService.Create(s.ctx, "testuser", "password123", "test@example.com", scopes, nil, nil)
+	s.Nil(result)
+
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(apperrors.KindConflict, appErr.Kind)
+	s.Equal(dto.CodeUserAlreadyExists, appErr.Code)
+}
+
+func (s *UserServiceSuite) TestCreateWeakPassword() {
+	s.logger.EXPECT().Warn("password rejected by policy", gomock.Any()).Times(1)
+
+	result, err := s.userService.Create(s.ctx, "testuser", "testuser1", "test@example.com", []*entities.UserScope{}, nil, nil)
+	s.Nil(result)
+
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(apperrors.KindValidation, appErr.Kind)
+	s.Equal(dto.CodeWeakPassword, appErr.Code)
+	s.Equal([]password.Violation{{Rule: "personal_info", Message: "password must not contain the username or email"}}, appErr.Details)
+}
+
+func (s *UserServiceSuite) newTx() *gorm.DB {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: Logger.Default.LogMode(Logger.Silent),
+	})
+	s.Require().NoError(err)
+
+	tx := gormDB.Begin()
+	s.Require().NoError(tx.Error)
+	return tx
+}
+
+func (s *UserServiceSuite) existingUser(plaintext string) *entities.User {
+	hash, _ := bcrypt.GenerateFromPassword([]byte(plaintext), bcrypt.MinCost)
+	return &entities.User{ID: "test-id", Username: "testuser", Email: "test@example.com", Hash: string(hash)}
+}
+
+func (s *UserServiceSuite) TestChangePassword() {
+	existingUser := s.existingUser("old-password")
+	tx := s.newTx()
+	mockTxRepo := repositories.NewMockIUserRepository(s.ctrl)
+
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(existingUser, nil)
+	s.mockRepo.EXPECT().FindPasswordHistory(s.ctx, "test-id", 2).Return([]string{}, nil)
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(mockTxRepo)
+	mockTxRepo.EXPECT().UpdateHash(s.ctx, "test-id", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, newHash string) error {
+		s.NoError(bcrypt.CompareHashAndPassword([]byte(newHash), []byte("new-password")))
+		return nil
+	})
+	mockTxRepo.EXPECT().AddPasswordHistory(s.ctx, "test-id", existingUser.Hash, 2).Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "test-id", gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:test-id").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:test-id", "sessions:test-id").Return(nil)
+	s.logger.EXPECT().Info("user's password changed successfully").Times(1)
+
+	err := s.userService.ChangePassword(s.ctx, "test-id", "old-password", "new-password")
+	s.NoError(err)
+}
+
+func (s *UserServiceSuite) TestChangePasswordWrongCurrent() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(s.existingUser("old-password"), nil)
+	s.logger.EXPECT().Warn("current password mismatch", gomock.Any()).Times(1)
+
+	err := s.userService.ChangePassword(s.ctx, "test-id", "wrong-password", "new-password")
+
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(apperrors.KindForbidden, appErr.Kind)
+	s.Equal(dto.CodeInvalidCredentials, appErr.Code)
+}
+
+func (s *UserServiceSuite) TestChangePasswordUserNotFound() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "missing").Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find user by id", gomock.Any()).Times(1)
+
+	err := s.userService.ChangePassword(s.ctx, "missing", "old-password", "new-password")
+	s.True(apperrors.IsKind(err, apperrors.KindNotFound))
+}
+
+func (s *UserServiceSuite) TestChangePasswordReused() {
+	existingUser := s.existingUser("old-password")
+	previous, _ := bcrypt.GenerateFromPassword([]byte("older-password"), bcrypt.MinCost)
+
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(existingUser, nil)
+	s.mockRepo.EXPECT().FindPasswordHistory(s.ctx, "test-id", 2).Return([]string{string(previous)}, nil)
+	s.logger.EXPECT().Warn("password rejected by policy", gomock.Any()).Times(1)
+
+	err := s.userService.ChangePassword(s.ctx, "test-id", "old-password", "older-password")
+
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(dto.CodeWeakPassword, appErr.Code)
+	s.Equal([]password.Violation{{Rule: "reused", Message: "password was used recently"}}, appErr.Details)
+}
+
+func (s *UserServiceSuite) TestChangePasswordHistoryError() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(s.existingUser("old-password"), nil)
+	s.mockRepo.EXPECT().FindPasswordHistory(s.ctx, "test-id", 2).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find password history", gomock.Any()).Times(1)
+
+	err := s.userService.ChangePassword(s.ctx, "test-id", "old-password", "new-password")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *UserServiceSuite) TestChangePasswordRepoError() {
+	tx := s.newTx()
+	mockTxRepo := repositories.NewMockIUserRepository(s.ctrl)
+
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(s.existingUser("old-password"), nil)
+	s.mockRepo.EXPECT().FindPasswordHistory(s.ctx, "test-id", 2).Return(nil, nil)
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(mockTxRepo)
+	mockTxRepo.EXPECT().UpdateHash(s.ctx, "test-id", gomock.Any()).Return(errors.New("update failed"))
+	s.logger.EXPECT().Error("failed to update password", gomock.Any()).Times(1)
+
+	err := s.userService.ChangePassword(s.ctx, "test-id", "old-password", "new-password")
+	s.ErrorContains(err, "update failed")
+}
+
+func (s *UserServiceSuite) TestChangePasswordAddHistoryError() {
+	tx := s.newTx()
+	mockTxRepo := repositories.NewMockIUserRepository(s.ctrl)
+
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(s.existingUser("old-password"), nil)
+	s.mockRepo.EXPECT().FindPasswordHistory(s.ctx, "test-id", 2).Return(nil, nil)
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(mockTxRepo)
+	mockTxRepo.EXPECT().UpdateHash(s.ctx, "test-id", gomock.Any()).Return(nil)
+	mockTxRepo.EXPECT().AddPasswordHistory(s.ctx, "test-id", gomock.Any(), 2).Return(errors.New("insert failed"))
+	s.logger.EXPECT().Error("failed to record password history", gomock.Any()).Times(1)
+
+	err := s.userService.ChangePassword(s.ctx, "test-id", "old-password", "new-password")
+	s.ErrorContains(err, "insert failed")
+}
+
+func (s *UserServiceSuite) TestChangePasswordBeginTransactionError() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(s.existingUser("old-password"), nil)
+	s.mockRepo.EXPECT().FindPasswordHistory(s.ctx, "test-id", 2).Return(nil, nil)
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(nil, errors.New("transaction error"))
+	s.logger.EXPECT().Error("failed to create transaction", gomock.Any()).Times(1)
+
+	err := s.userService.ChangePassword(s.ctx, "test-id", "old-password", "new-password")
+	s.ErrorContains(err, "transaction error")
+}
+
+func (s *UserServiceSuite) TestChangePasswordRedisError() {
+	tx := s.newTx()
+	mockTxRepo := repositories.NewMockIUserRepository(s.ctrl)
+
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(s.existingUser("old-password"), nil)
+	s.mockRepo.EXPECT().FindPasswordHistory(s.ctx, "test-id", 2).Return(nil, nil)
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(mockTxRepo)
+	mockTxRepo.EXPECT().UpdateHash(s.ctx, "test-id", gomock.Any()).Return(nil)
+	mockTxRepo.EXPECT().AddPasswordHistory(s.ctx, "test-id", gomock.Any(), 2).Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "test-id", gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:test-id").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:test-id", "sessions:test-id").Return(errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to delete refresh token in redis", gomock.Any()).Times(1)
+
+	err := s.userService.ChangePassword(s.ctx, "test-id", "old-password", "new-password")
+	s.ErrorContains(err, "redis error")
+}
+
+func (s *UserServiceSuite) TestChangePasswordUpgradesLegacyHash() {
+	s.userService = s.newUserService("argon2id")
+	existingUser := s.existingUser("old-password")
+	legacyHash := existingUser.Hash
+	tx := s.newTx()
+	mockTxRepo := repositories.NewMockIUserRepository(s.ctrl)
+
+	var upgradedHash string
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(existingUser, nil)
+	s.mockRepo.EXPECT().UpdateHash(s.ctx, "test-id", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, hash string) error {
+		s.True(strings.HasPrefix(hash, "$argon2id$"))
+		upgradedHash = hash
+		return nil
+	})
+	s.logger.EXPECT().Info("password hash upgraded successfully").Times(1)
+	s.mockRepo.EXPECT().FindPasswordHistory(s.ctx, "test-id", 2).Return(nil, nil)
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(mockTxRepo)
+	mockTxRepo.EXPECT().UpdateHash(s.ctx, "test-id", gomock.Any()).Return(nil)
+	mockTxRepo.EXPECT().AddPasswordHistory(s.ctx, "test-id", gomock.Any(), 2).DoAndReturn(func(_ context.Context, _ string, hash string, _ int) error {
+		s.NotEqual(legacyHash, hash)
+		s.Equal(upgradedHash, hash)
+		return nil
+	})
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, "test-id", gomock.Any()).Return(nil)
+	s.mockRedis.EXPECT().SMembers(s.ctx, "sessions:test-id").Return(nil, nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:test-id", "sessions:test-id").Return(nil)
+	s.logger.EXPECT().Info("user's password changed successfully").Times(1)
+
+	err := s.userService.ChangePassword(s.ctx, "test-id", "old-password", "new-password")
+	s.NoError(err)
+}
+
+func (s *UserServiceSuite) TestChangePasswordUpgradeFailureIsIgnored() {
+	s.userService = s.newUserService("argon2id")
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(s.existingUser("old-password"), nil)
+	s.mockRepo.EXPECT().UpdateHash(s.ctx, "test-id", gomock.Any()).Return(errors.New("db error"))
+	s.logger.EXPECT().Warn("failed to upgrade password hash", gomock.Any()).Times(1)
+	s.mockRepo.EXPECT().FindPasswordHistory(s.ctx, "test-id", 2).Return(nil, nil)
+	s.logger.EXPECT().Warn("password rejected by policy", gomock.Any()).Times(1)
+
+	err := s.userService.ChangePassword(s.ctx, "test-id", "old-password", "short")
+	s.True(apperrors.IsKind(err, apperrors.KindValidation))
+}
+
+func (s *UserServiceSuite) TestChangePasswordUnknownHash() {
+	s.mockRepo.EXPECT().FindById(s.ctx, "test-id").Return(&entities.User{ID: "test-id", Hash: "plaintext"}, nil)
+	s.logger.EXPECT().Error("failed to verify password", gomock.Any()).Times(1)
+
+	err := s.userService.ChangePassword(s.ctx, "test-id", "old-password", "new-password")
+	s.ErrorIs(err, password.ErrUnknownHash)
+}
+
+func (s *UserServiceSuite) TestDeleteRevocationError() {
+	userId := "test-id"
+
+	s.mockRepo.EXPECT().FindById(s.ctx, userId).Return(&entities.User{ID: userId}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, userId).Return(nil)
+	s.expectEvent(events.UserDeleted, nil)
+	s.expectAudit(entities.AuditUserDelete)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:"+userId).Return(nil)
+	s.mockRevoke.EXPECT().RevokeUser(s.ctx, userId, gomock.Any()).Return(errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to revoke user's access tokens", gomock.Any()).Times(1)
+
+	err := s.userService.Delete(s.ctx, userId)
+	s.ErrorContains(err, "redis error")
+}
+
+func (s *UserServiceSuite) TestDeleteInvalidationError() {
+	userId := "test-id"
+
+	s.mockRepo.EXPECT().FindById(s.ctx, userId).Return(&entities.User{ID: userId}, nil)
+	s.expectTransaction()
+	s.mockTxRepo.EXPECT().Delete(s.ctx, userId).Return(nil)
+	s.expectEvent(events.UserDeleted, nil)
+	s.expectAudit(entities.AuditUserDelete)
+	s.mockRedis.EXPECT().Del(s.ctx, "authz:scopes:"+userId).Return(errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to invalidate effective scopes in redis", gomock.Any()).Times(1)
+
+	err := s.userService.Delete(s.ctx, userId)
+	s.ErrorContains(err, "redis error")
+}
+
+func (s *UserServiceSuite) TestLogout() {
+	s.mockRevoke.EXPECT().RevokeSession(s.ctx, "session1").Return(nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "session:session1").Return(nil)
+	s.mockRedis.EXPECT().SRem(s.ctx, "sessions:test-id", "session1").Return(nil)
+	s.logger.EXPECT().Info("user logged out successfully").Times(1)
+
+	s.NoError(s.userService.Logout(s.ctx, "test-id", "token1", "session1", time.Now().Add(time.Hour)))
+}
+
+func (s *UserServiceSuite) TestLogoutWithoutSession() {
+	expiresAt := time.Now().Add(time.Hour)
+	s.mockRevoke.EXPECT().RevokeToken(s.ctx, "token1", expiresAt).Return(nil)
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:test-id").Return(nil)
+	s.logger.EXPECT().Info("user logged out successfully").Times(1)
+
+	s.NoError(s.userService.Logout(s.ctx, "test-id", "token1", "", expiresAt))
+}
+
+func (s *UserServiceSuite) TestLogoutWithoutTokenId() {
+	s.mockRedis.EXPECT().Del(s.ctx, "refresh:test-id").Return(nil)
+	s.logger.EXPECT().Info("user logged out successfully").Times(1)
+
+	s.NoError(s.userService.Logout(s.ctx, "test-id", "", "", time.Time{}))
+}
+
+func (s *UserServiceSuite) TestLogoutRevocationError() {
+	expiresAt := time.Now().Add(time.Hour)
+	s.mockRevoke.EXPECT().RevokeToken(s.ctx, "token1", expiresAt).Return(errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to revoke access token", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.userService.Logout(s.ctx, "test-id", "token1", "", expiresAt), "redis error")
+}
+
+func (s *UserServiceSuite) TestLogoutSessionRevocationError() {
+	s.mockRevoke.EXPECT().RevokeSession(s.ctx, "session1").Return(errors.New("redis error"))
+	s.logger.EXPECT().Error("failed to revoke session", gomock.Any(), gomock.Any()).Times(1)
+
+	s.ErrorContains(s.userService.Logout(s.ctx, "test-id", "token1", "session1", time.Now().Add(time.Hour)), "redis error")
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/webhooks"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+)
+
+// IWebhookService manages webhook endpoints and queues deliveries for them.
+// Dispatch is an events.Handler: it turns one domain event into a pending
+// delivery for every active webhook of the event's organization that
+// subscribes to it, and IWebhookSender sends them.
+type IWebhookService interface {
+	Create(ctx context.Context, rawURL string, eventTypes []string, createdBy string) (*entities.Webhook, error)
+	FindById(ctx context.Context, webhookId uint) (*entities.Webhook, error)
+	FindAll(ctx context.Context, query dto.ListWebhooksRequest) ([]*entities.Webhook, *dto.Paging, error)
+	Update(ctx context.Context, req dto.UpdateWebhookRequest) (*entities.Webhook, error)
+	Delete(ctx context.Context, webhookId uint) error
+	FindDeliveries(ctx context.Context, webhookId uint, query dto.ListWebhookDeliveriesRequest) ([]*entities.WebhookDelivery, *dto.Paging, error)
+	Dispatch(ctx context.Context, event *events.Event) error
+}
+
+type webhookService struct {
+	webhookRepo repositories.IWebhookRepository
+	logger      logger.ILogger
+}
+
+func NewWebhookService(webhookRepo repositories.IWebhookRepository, logger logger.ILogger) IWebhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		logger:      logger,
+	}
+}
+
+func (s *webhookService) Create(ctx context.Context, rawURL string, eventTypes []string, createdBy string) (*entities.Webhook, error) {
+	if err := s.validate(ctx, rawURL, eventTypes); err != nil {
+		return nil, err
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		s.logger.Error("failed to generate webhook secret", zap.Error(err))
+		return nil, err
+	}
+
+	webhook := &entities.Webhook{
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: strings.Join(eventTypes, ","),
+		Active:     true,
+		CreatedBy:  createdBy,
+	}
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		s.logger.Error("failed to create webhook", zap.Error(err))
+		return nil, repositoryError(err, dto.CodeWebhookNotFound, dto.CodeInternalServerError)
+	}
+
+	s.logger.Info("new webhook created successfully", zap.Uint("id", webhook.ID))
+	return webhook, nil
+}
+
+func (s *webhookService) FindById(ctx context.Context, webhookId uint) (*entities.Webhook, error) {
+	webhook, err := s.webhookRepo.FindById(ctx, webhookId)
+	if err != nil {
+		s.logger.Error("failed to find webhook", zap.Uint("id", webhookId), zap.Error(err))
+		return nil, repositoryError(err, dto.CodeWebhookNotFound, dto.CodeInternalServerError)
+	}
+
+	s.logger.Info("webhook found successfully")
+	return webhook, nil
+}
+
+func (s *webhookService) FindAll(ctx context.Context, query dto.ListWebhooksRequest) ([]*entities.Webhook, *dto.Paging, error) {
+	webhooks, paging, err := s.webhookRepo.FindAll(ctx, query)
+	if err != nil {
+		s.logger.Error("failed to find all webhooks", zap.Error(err))
+		return nil, nil, repositoryError(err, dto.CodeWebhookNotFound, dto.CodeInternalServerError)
+	}
+
+	s.logger.Info("all webhooks retrieved successfully")
+	return webhooks, paging, nil
+}
+
+// Update changes the fields set in req. Re-activating a webhook clears its
+// failure count, so one more failure does not disable it again.
+func (s *webhookService) Update(ctx context.Context, req dto.UpdateWebhookRequest) (*entities.Webhook, error) {
+	webhook, err := s.FindById(ctx, req.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	rawURL := webhook.URL
+	if req.URL != nil {
+		rawURL = *req.URL
+	}
+	eventTypes := webhook.Events()
+	if req.EventTypes != nil {
+		eventTypes = req.EventTypes
+	}
+	if err := s.validate(ctx, rawURL, eventTypes); err != nil {
+		return nil, err
+	}
+
+	webhook.URL = rawURL
+	webhook.EventTypes = strings.Join(eventTypes, ",")
+	if req.Active != nil {
+		if *req.Active && !webhook.Active {
+			webhook.ConsecutiveFailures = 0
+			webhook.DisabledAt = nil
+		}
+		webhook.Active = *req.Active
+	}
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		s.logger.Error("failed to update webhook", zap.Uint("id", webhook.ID), zap.Error(err))
+		return nil, repositoryError(err, dto.CodeWebhookNotFound, dto.CodeInternalServerError)
+	}
+
+	s.logger.Info("webhook updated successfully", zap.Uint("id", webhook.ID))
+	return webhook, nil
+}
+
+func (s *webhookService) Delete(ctx context.Context, webhookId uint) error {
+	if err := s.webhookRepo.Delete(ctx, webhookId); err != nil {
+		s.logger.Error("failed to delete webhook", zap.Uint("id", webhookId), zap.Error(err))
+		return repositoryError(err, dto.CodeWebhookNotFound, dto.CodeInternalServerError)
+	}
+
+	s.logger.Info("webhook deleted successfully", zap.Uint("id", webhookId))
+	return nil
+}
+
+func (s *webhookService) FindDeliveries(ctx context.Context, webhookId uint, query dto.ListWebhookDeliveriesRequest) ([]*entities.WebhookDelivery, *dto.Paging, error) {
+	if _, err := s.FindById(ctx, webhookId); err != nil {
+		return nil, nil, err
+	}
+
+	deliveries, paging, err := s.webhookRepo.FindDeliveries(ctx, webhookId, query)
+	if err != nil {
+		s.logger.Error("failed to find webhook deliveries", zap.Uint("id", webhookId), zap.Error(err))
+		return nil, nil, repositoryError(err, dto.CodeWebhookNotFound, dto.CodeInternalServerError)
+	}
+
+	s.logger.Info("webhook deliveries retrieved successfully")
+	return deliveries, paging, nil
+}
+
+// Dispatch queues event for the subscribed webhooks. Returning an error
+// leaves the event unacknowledged so the consumer retries it; deliveries
+// already queued for it are skipped the second time.
+func (s *webhookService) Dispatch(ctx context.Context, event *events.Event) error {
+	subscribed, err := s.webhookRepo.FindActive(ctx, event.OrganizationID)
+	if err != nil {
+		s.logger.Error("failed to find active webhooks", zap.String("event_id", event.ID), zap.Error(err))
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		s.logger.Error("failed to encode webhook payload", zap.String("event_id", event.ID), zap.Error(err))
+		return err
+	}
+
+	now := time.Now()
+	deliveries := make([]*entities.WebhookDelivery, 0, len(subscribed))
+	for _, webhook := range subscribed {
+		if !webhook.Subscribes(event.Type) {
+			continue
+		}
+		deliveries = append(deliveries, &entities.WebhookDelivery{
+			OrganizationID: webhook.OrganizationID,
+			WebhookID:      webhook.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        string(payload),
+			Status:         entities.WebhookDeliveryPending,
+			NextAttemptAt:  &now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := s.webhookRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		s.logger.Error("failed to queue webhook deliveries", zap.String("event_id", event.ID), zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("webhook deliveries queued successfully", zap.String("event_id", event.ID), zap.Int("count", len(deliveries)))
+	return nil
+}
+
+// validate accepts absolute http and https URLs of public hosts and known
+// event types.
+func (s *webhookService) validate(ctx context.Context, rawURL string, eventTypes []string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		s.logger.Warn("webhook url rejected", zap.String("url", rawURL))
+		return apperrors.Validation(dto.CodeInvalidWebhookURL, "webhook url must be an absolute http or https url", err)
+	}
+	if err := webhooks.CheckHost(ctx, parsed.Hostname()); err != nil {
+		s.logger.Warn("webhook url rejected", zap.String("url", rawURL))
+		return apperrors.Validation(dto.CodeInvalidWebhookURL, "webhook url must point to a public address", err)
+	}
+
+	for _, eventType := range eventTypes {
+		if !events.Known(eventType) {
+			s.logger.Warn("webhook event type rejected", zap.String("event_type", eventType))
+			return apperrors.Validation(dto.CodeInvalidEventType, fmt.Sprintf("unknown event type %q", eventType), nil)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/webhooks"
+	"github.com/vnFuhung2903/vcs-user-management-service/usecases/repositories"
+	"go.uber.org/zap"
+)
+
+// IWebhookSender sends due webhook deliveries. Run sends on every tick of
+// interval until ctx is cancelled; Send does a single pass.
+type IWebhookSender interface {
+	Run(ctx context.Context, interval time.Duration)
+	Send(ctx context.Context) error
+}
+
+type webhookSender struct {
+	webhookRepo repositories.IWebhookRepository
+	client      *http.Client
+	env         env.WebhookEnv
+	logger      logger.ILogger
+}
+
+func NewWebhookSender(webhookRepo repositories.IWebhookRepository, env env.WebhookEnv, logger logger.ILogger) IWebhookSender {
+	return &webhookSender{
+		webhookRepo: webhookRepo,
+		client:      webhooks.NewClient(env.Timeout),
+		env:         env,
+		logger:      logger,
+	}
+}
+
+func (s *webhookSender) Run(ctx context.Context, interval time.Duration) {
+	runEvery(ctx, interval, func(ctx context.Context) {
+		s.Send(ctx)
+	})
+}
+
+// Send attempts every due delivery once. Failures are recorded on the
+// delivery and retried later, so only a failure to load or record
+// deliveries is returned.
+//
+// Due deliveries are claimed before they are sent, so that replicas do not
+// send the same delivery. The claim lasts for as long as the batch can take
+// to send one after the other, with a spare timeout; a delivery this sender
+// gives up on is sent by another once the claim runs out.
+func (s *webhookSender) Send(ctx context.Context) error {
+	now := time.Now()
+	leaseUntil := now.Add(time.Duration(s.env.BatchSize+1) * s.env.Timeout)
+	due, err := s.webhookRepo.ClaimDueDeliveries(ctx, now, leaseUntil, s.env.BatchSize)
+	if err != nil {
+		s.logger.Error("failed to claim due webhook deliveries", zap.Error(err))
+		return err
+	}
+
+	for _, delivery := range due {
+		attempt := s.attempt(ctx, delivery)
+		s.schedule(delivery, attempt)
+
+		disabled, err := s.webhookRepo.RecordAttempt(ctx, delivery, attempt, s.env.DisableAfter)
+		if err != nil {
+			s.logger.Error("failed to record webhook attempt", zap.Uint("delivery_id", delivery.ID), zap.Error(err))
+			return err
+		}
+		if disabled {
+			s.logger.Warn("webhook disabled after repeated failures", zap.Uint("webhook_id", delivery.WebhookID))
+		}
+	}
+
+	if len(due) > 0 {
+		s.logger.Info("webhook deliveries sent successfully", zap.Int("count", len(due)))
+	}
+	return nil
+}
+
+// attempt POSTs the delivery's payload, signed with the webhook's secret,
+// and reports how the receiver answered.
+func (s *webhookSender) attempt(ctx context.Context, delivery *entities.WebhookDelivery) *entities.WebhookAttempt {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return &entities.WebhookAttempt{Error: err.Error()}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhooks.DeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(webhooks.EventHeader, delivery.EventType)
+	req.Header.Set(webhooks.TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(webhooks.SignatureHeader, webhooks.Sign(delivery.Webhook.Secret, timestamp, body))
+
+	start := time.Now()
+	res, err := s.client.Do(req)
+	latency := time.Since(start).Milliseconds()
+	if err != nil {
+		s.logger.Warn("failed to send webhook delivery", zap.Uint("delivery_id", delivery.ID), zap.Error(err))
+		return &entities.WebhookAttempt{LatencyMs: latency, Error: err.Error()}
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	attempt := &entities.WebhookAttempt{StatusCode: res.StatusCode, LatencyMs: latency}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		attempt.Error = res.Status
+	}
+	return attempt
+}
+
+// schedule moves the delivery on after attempt: it succeeds on a 2xx, fails
+// for good once the attempts run out, and is otherwise retried after an
+// exponential backoff.
+func (s *webhookSender) schedule(delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt) {
+	now := time.Now()
+	delivery.AttemptCount++
+	if attempt.StatusCode != 0 {
+		delivery.LastStatusCode = &attempt.StatusCode
+	}
+
+	switch {
+	case attempt.Error == "":
+		delivery.Status = entities.WebhookDeliverySucceeded
+		delivery.NextAttemptAt = nil
+		delivery.CompletedAt = &now
+	case delivery.AttemptCount >= s.env.MaxAttempts:
+		delivery.Status = entities.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.CompletedAt = &now
+	default:
+		next := now.Add(s.backoff(delivery.AttemptCount))
+		delivery.NextAttemptAt = &next
+	}
+}
+
+// backoff is the wait after the given number of failed attempts.
+func (s *webhookSender) backoff(attempts int) time.Duration {
+	wait := s.env.BackoffBase
+	for i := 1; i < attempts && wait < s.env.BackoffMax; i++ {
+		wait *= 2
+	}
+	if wait > s.env.BackoffMax {
+		wait = s.env.BackoffMax
+	}
+	return wait
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/env"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/webhooks"
+)
+
+type WebhookSenderSuite struct {
+	suite.Suite
+	ctrl          *gomock.Controller
+	webhookSender IWebhookSender
+	mockRepo      *repositories.MockIWebhookRepository
+	logger        *logger.MockILogger
+	ctx           context.Context
+	receiver      *httptest.Server
+	status        int
+	received      []*http.Request
+	bodies        [][]byte
+}
+
+func (s *WebhookSenderSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIWebhookRepository(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.webhookSender = NewWebhookSender(s.mockRepo, env.WebhookEnv{
+		Timeout:      time.Second,
+		MaxAttempts:  3,
+		BackoffBase:  time.Minute,
+		BackoffMax:   90 * time.Second,
+		DisableAfter: 5,
+		BatchSize:    10,
+	}, s.logger)
+	s.ctx = context.Background()
+
+	s.status = http.StatusNoContent
+	s.received = nil
+	s.bodies = nil
+	s.receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.received = append(s.received, r)
+		s.bodies = append(s.bodies, body)
+		w.WriteHeader(s.status)
+	}))
+	// The receiver listens on loopback, which the sender's own client refuses
+	// to dial, so deliveries go through the receiver's client instead, with
+	// the sender's redirect policy.
+	client := s.receiver.Client()
+	client.CheckRedirect = s.webhookSender.(*webhookSender).client.CheckRedirect
+	s.webhookSender.(*webhookSender).client = client
+}
+
+func (s *WebhookSenderSuite) TearDownTest() {
+	s.receiver.Close()
+	s.ctrl.Finish()
+}
+
+func TestWebhookSenderSuite(t *testing.T) {
+	suite.Run(t, new(WebhookSenderSuite))
+}
+
+func (s *WebhookSenderSuite) delivery(attemptCount int) *entities.WebhookDelivery {
+	return &entities.WebhookDelivery{
+		ID:           7,
+		WebhookID:    1,
+		EventID:      "event1",
+		EventType:    events.UserCreated,
+		Payload:      `{"id":"event1","type":"user.created"}`,
+		Status:       entities.WebhookDeliveryPending,
+		AttemptCount: attemptCount,
+		Webhook:      &entities.Webhook{ID: 1, URL: s.receiver.URL, Secret: "whsec_test", Active: true},
+	}
+}
+
+func (s *WebhookSenderSuite) TestSend() {
+	delivery := s.delivery(0)
+	s.mockRepo.EXPECT().ClaimDueDeliveries(s.ctx, gomock.Any(), gomock.Any(), 10).DoAndReturn(func(_ context.Context, now, leaseUntil time.Time, _ int) ([]*entities.WebhookDelivery, error) {
+		// The claim outlasts ten deliveries timing out one after the other.
+		s.Equal(11*time.Second, leaseUntil.Sub(now))
+		return []*entities.WebhookDelivery{delivery}, nil
+	})
+	s.mockRepo.EXPECT().RecordAttempt(s.ctx, delivery, gomock.Any(), 5).DoAndReturn(func(_ context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt, _ int) (bool, error) {
+		s.Equal(http.StatusNoContent, attempt.StatusCode)
+		s.Empty(attempt.Error)
+		s.Equal(entities.WebhookDeliverySucceeded, delivery.Status)
+		s.Equal(1, delivery.AttemptCount)
+		s.Equal(http.StatusNoContent, *delivery.LastStatusCode)
+		s.Nil(delivery.NextAttemptAt)
+		s.NotNil(delivery.CompletedAt)
+		return false, nil
+	})
+	s.logger.EXPECT().Info("webhook deliveries sent successfully", gomock.Any()).Times(1)
+
+	s.NoError(s.webhookSender.Send(s.ctx))
+
+	s.Require().Len(s.received, 1)
+	req := s.received[0]
+	s.Equal(http.MethodPost, req.Method)
+	s.Equal("application/json", req.Header.Get("Content-Type"))
+	s.Equal("7", req.Header.Get(webhooks.DeliveryHeader))
+	s.Equal(events.UserCreated, req.Header.Get(webhooks.EventHeader))
+	s.Equal(delivery.Payload, string(s.bodies[0]))
+	s.NoError(webhooks.Verify("whsec_test", req.Header.Get(webhooks.TimestampHeader), req.Header.Get(webhooks.SignatureHeader), s.bodies[0], time.Now(), time.Minute))
+}
+
+func (s *WebhookSenderSuite) TestSendRetriesWithBackoff() {
+	s.status = http.StatusInternalServerError
+	delivery := s.delivery(1)
+	s.mockRepo.EXPECT().ClaimDueDeliveries(s.ctx, gomock.Any(), gomock.Any(), 10).Return([]*entities.WebhookDelivery{delivery}, nil)
+	s.mockRepo.EXPECT().RecordAttempt(s.ctx, delivery, gomock.Any(), 5).DoAndReturn(func(_ context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt, _ int) (bool, error) {
+		s.Equal(http.StatusInternalServerError, attempt.StatusCode)
+		s.NotEmpty(attempt.Error)
+		s.Equal(entities.WebhookDeliveryPending, delivery.Status)
+		s.Equal(2, delivery.AttemptCount)
+		s.Nil(delivery.CompletedAt)
+		// The second failure doubles the one minute base, capped at 90s.
+		s.WithinDuration(time.Now().Add(90*time.Second), *delivery.NextAttemptAt, 5*time.Second)
+		return false, nil
+	})
+	s.logger.EXPECT().Info("webhook deliveries sent successfully", gomock.Any()).Times(1)
+
+	s.NoError(s.webhookSender.Send(s.ctx))
+}
+
+func (s *WebhookSenderSuite) TestSendGivesUpAndDisables() {
+	s.status = http.StatusBadGateway
+	delivery := s.delivery(2)
+	s.mockRepo.EXPECT().ClaimDueDeliveries(s.ctx, gomock.Any(), gomock.Any(), 10).Return([]*entities.WebhookDelivery{delivery}, nil)
+	s.mockRepo.EXPECT().RecordAttempt(s.ctx, delivery, gomock.Any(), 5).DoAndReturn(func(_ context.Context, delivery *entities.WebhookDelivery, _ *entities.WebhookAttempt, _ int) (bool, error) {
+		s.Equal(entities.WebhookDeliveryFailed, delivery.Status)
+		s.Equal(3, delivery.AttemptCount)
+		s.Nil(delivery.NextAttemptAt)
+		s.NotNil(delivery.CompletedAt)
+		return true, nil
+	})
+	s.logger.EXPECT().Warn("webhook disabled after repeated failures", gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("webhook deliveries sent successfully", gomock.Any()).Times(1)
+
+	s.NoError(s.webhookSender.Send(s.ctx))
+}
+
+func (s *WebhookSenderSuite) TestSendUnreachable() {
+	delivery := s.delivery(0)
+	s.receiver.Close()
+	s.mockRepo.EXPECT().ClaimDueDeliveries(s.ctx, gomock.Any(), gomock.Any(), 10).Return([]*entities.WebhookDelivery{delivery}, nil)
+	s.logger.EXPECT().Warn("failed to send webhook delivery", gomock.Any()).Times(1)
+	s.mockRepo.EXPECT().RecordAttempt(s.ctx, delivery, gomock.Any(), 5).DoAndReturn(func(_ context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt, _ int) (bool, error) {
+		s.Zero(attempt.StatusCode)
+		s.NotEmpty(attempt.Error)
+		s.Nil(delivery.LastStatusCode)
+		s.WithinDuration(time.Now().Add(time.Minute), *delivery.NextAttemptAt, 5*time.Second)
+		return false, nil
+	})
+	s.logger.EXPECT().Info("webhook deliveries sent successfully", gomock.Any()).Times(1)
+
+	s.NoError(s.webhookSender.Send(s.ctx))
+}
+
+func (s *WebhookSenderSuite) TestSendDoesNotFollowRedirects() {
+	s.status = http.StatusFound
+	delivery := s.delivery(0)
+	s.mockRepo.EXPECT().ClaimDueDeliveries(s.ctx, gomock.Any(), gomock.Any(), 10).Return([]*entities.WebhookDelivery{delivery}, nil)
+	s.mockRepo.EXPECT().RecordAttempt(s.ctx, delivery, gomock.Any(), 5).DoAndReturn(func(_ context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt, _ int) (bool, error) {
+		s.Equal(http.StatusFound, attempt.StatusCode)
+		s.NotEmpty(attempt.Error)
+		s.Equal(entities.WebhookDeliveryPending, delivery.Status)
+		return false, nil
+	})
+	s.logger.EXPECT().Info("webhook deliveries sent successfully", gomock.Any()).Times(1)
+
+	s.NoError(s.webhookSender.Send(s.ctx))
+	s.Len(s.received, 1)
+}
+
+func (s *WebhookSenderSuite) TestSendRefusesPrivateAddress() {
+	sender := NewWebhookSender(s.mockRepo, env.WebhookEnv{Timeout: time.Second, MaxAttempts: 3, BackoffBase: time.Minute, BackoffMax: time.Minute, DisableAfter: 5, BatchSize: 10}, s.logger)
+	delivery := s.delivery(0)
+	s.mockRepo.EXPECT().ClaimDueDeliveries(s.ctx, gomock.Any(), gomock.Any(), 10).Return([]*entities.WebhookDelivery{delivery}, nil)
+	s.mockRepo.EXPECT().RecordAttempt(s.ctx, delivery, gomock.Any(), 5).DoAndReturn(func(_ context.Context, _ *entities.WebhookDelivery, attempt *entities.WebhookAttempt, _ int) (bool, error) {
+		s.Contains(attempt.Error, webhooks.ErrPrivateAddress.Error())
+		return false, nil
+	})
+	s.logger.EXPECT().Warn("failed to send webhook delivery", gomock.Any(), gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("webhook deliveries sent successfully", gomock.Any()).Times(1)
+
+	s.NoError(sender.Send(s.ctx))
+	s.Empty(s.received)
+}
+
+func (s *WebhookSenderSuite) TestSendRepoError() {
+	s.mockRepo.EXPECT().ClaimDueDeliveries(s.ctx, gomock.Any(), gomock.Any(), 10).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to claim due webhook deliveries", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.webhookSender.Send(s.ctx), "db error")
+}
+
+func (s *WebhookSenderSuite) TestSendRecordError() {
+	delivery := s.delivery(0)
+	s.mockRepo.EXPECT().ClaimDueDeliveries(s.ctx, gomock.Any(), gomock.Any(), 10).Return([]*entities.WebhookDelivery{delivery}, nil)
+	s.mockRepo.EXPECT().RecordAttempt(s.ctx, delivery, gomock.Any(), 5).Return(false, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to record webhook attempt", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.webhookSender.Send(s.ctx), "db error")
+}
+
+func (s *WebhookSenderSuite) TestRunStopsWithContext() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.mockRepo.EXPECT().ClaimDueDeliveries(ctx, gomock.Any(), gomock.Any(), 10).DoAndReturn(func(context.Context, time.Time, time.Time, int) ([]*entities.WebhookDelivery, error) {
+		cancel()
+		return nil, nil
+	}).MinTimes(1)
+
+	done := make(chan struct{})
+	go func() {
+		s.webhookSender.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("sender did not stop after the context was cancelled")
+	}
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-user-management-service/dto"
+	"github.com/vnFuhung2903/vcs-user-management-service/entities"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/logger"
+	"github.com/vnFuhung2903/vcs-user-management-service/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/apperrors"
+	"github.com/vnFuhung2903/vcs-user-management-service/pkg/events"
+)
+
+type WebhookServiceSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	webhookService IWebhookService
+	mockRepo       *repositories.MockIWebhookRepository
+	logger         *logger.MockILogger
+	ctx            context.Context
+}
+
+func (s *WebhookServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIWebhookRepository(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.webhookService = NewWebhookService(s.mockRepo, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *WebhookServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestWebhookServiceSuite(t *testing.T) {
+	suite.Run(t, new(WebhookServiceSuite))
+}
+
+func (s *WebhookServiceSuite) assertCode(err error, code string) {
+	appErr, ok := apperrors.As(err)
+	s.True(ok)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *WebhookServiceSuite) TestCreate() {
+	s.mockRepo.EXPECT().Create(s.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, webhook *entities.Webhook) error {
+		webhook.ID = 1
+		return nil
+	})
+	s.logger.EXPECT().Info("new webhook created successfully", gomock.Any()).Times(1)
+
+	webhook, err := s.webhookService.Create(s.ctx, "https://example.com/hook", []string{events.UserCreated, events.ScopeDeleted}, "admin")
+	s.NoError(err)
+	s.Equal("user.created,scope.deleted", webhook.EventTypes)
+	s.True(webhook.Active)
+	s.Equal("admin", webhook.CreatedBy)
+	s.Contains(webhook.Secret, "whsec_")
+}
+
+func (s *WebhookServiceSuite) TestCreateInvalidURL() {
+	s.logger.EXPECT().Warn("webhook url rejected", gomock.Any()).Times(1)
+
+	_, err := s.webhookService.Create(s.ctx, "ftp://example.com/hook", []string{events.UserCreated}, "admin")
+	s.assertCode(err, dto.CodeInvalidWebhookURL)
+}
+
+func (s *WebhookServiceSuite) TestCreatePrivateURL() {
+	for _, rawURL := range []string{"http://127.0.0.1:8080/hook", "http://169.254.169.254/latest/meta-data", "http://[::1]/hook", "http://localhost/hook"} {
+		s.logger.EXPECT().Warn("webhook url rejected", gomock.Any()).Times(1)
+
+		_, err := s.webhookService.Create(s.ctx, rawURL, []string{events.UserCreated}, "admin")
+		s.assertCode(err, dto.CodeInvalidWebhookURL)
+	}
+}
+
+func (s *WebhookServiceSuite) TestCreateUnknownEventType() {
+	s.logger.EXPECT().Warn("webhook event type rejected", gomock.Any()).Times(1)
+
+	_, err := s.webhookService.Create(s.ctx, "https://example.com/hook", []string{"user.renamed"}, "admin")
+	s.assertCode(err, dto.CodeInvalidEventType)
+}
+
+func (s *WebhookServiceSuite) TestFindByIdNotFound() {
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to find webhook", gomock.Any()).Times(1)
+
+	_, err := s.webhookService.FindById(s.ctx, 1)
+	s.assertCode(err, dto.CodeWebhookNotFound)
+}
+
+func (s *WebhookServiceSuite) TestUpdateReactivate() {
+	webhook := &entities.Webhook{ID: 1, URL: "https://example.com/hook", EventTypes: events.UserCreated, Active: false, ConsecutiveFailures: 20}
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(webhook, nil)
+	s.logger.EXPECT().Info("webhook found successfully").Times(1)
+	s.mockRepo.EXPECT().Update(s.ctx, webhook).Return(nil)
+	s.logger.EXPECT().Info("webhook updated successfully", gomock.Any()).Times(1)
+
+	active := true
+	updated, err := s.webhookService.Update(s.ctx, dto.UpdateWebhookRequest{WebhookID: 1, EventTypes: []string{events.UserDeleted}, Active: &active})
+	s.NoError(err)
+	s.True(updated.Active)
+	s.Zero(updated.ConsecutiveFailures)
+	s.Nil(updated.DisabledAt)
+	s.Equal("user.deleted", updated.EventTypes)
+	s.Equal("https://example.com/hook", updated.URL)
+}
+
+func (s *WebhookServiceSuite) TestUpdateInvalidURL() {
+	webhook := &entities.Webhook{ID: 1, URL: "https://example.com/hook", EventTypes: events.UserCreated, Active: true}
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(webhook, nil)
+	s.logger.EXPECT().Info("webhook found successfully").Times(1)
+	s.logger.EXPECT().Warn("webhook url rejected", gomock.Any()).Times(1)
+
+	rawURL := "/relative"
+	_, err := s.webhookService.Update(s.ctx, dto.UpdateWebhookRequest{WebhookID: 1, URL: &rawURL})
+	s.assertCode(err, dto.CodeInvalidWebhookURL)
+}
+
+func (s *WebhookServiceSuite) TestDeleteNotFound() {
+	s.mockRepo.EXPECT().Delete(s.ctx, uint(1)).Return(gorm.ErrRecordNotFound)
+	s.logger.EXPECT().Error("failed to delete webhook", gomock.Any()).Times(1)
+
+	err := s.webhookService.Delete(s.ctx, 1)
+	s.assertCode(err, dto.CodeWebhookNotFound)
+}
+
+func (s *WebhookServiceSuite) TestFindDeliveries() {
+	query := dto.ListWebhookDeliveriesRequest{Status: entities.WebhookDeliveryFailed}
+	deliveries := []*entities.WebhookDelivery{{ID: 1, WebhookID: 1}}
+	s.mockRepo.EXPECT().FindById(s.ctx, uint(1)).Return(&entities.Webhook{ID: 1}, nil)
+	s.logger.EXPECT().Info("webhook found successfully").Times(1)
+	s.mockRepo.EXPECT().FindDeliveries(s.ctx, uint(1), query).Return(deliveries, &dto.Paging{}, nil)
+	s.logger.EXPECT().Info("webhook deliveries retrieved successfully").Times(1)
+
+	found, _, err := s.webhookService.FindDeliveries(s.ctx, 1, query)
+	s.NoError(err)
+	s.Equal(deliveries, found)
+}
+
+func (s *WebhookServiceSuite) TestDispatch() {
+	event, err := events.New(events.UserCreated, "acme", events.UserData{UserID: "user1", Username: "alice"})
+	s.Require().NoError(err)
+	s.mockRepo.EXPECT().FindActive(s.ctx, "acme").Return([]*entities.Webhook{
+		{ID: 1, OrganizationID: "acme", EventTypes: "user.created,user.deleted"},
+		{ID: 2, OrganizationID: "acme", EventTypes: "scope.created"},
+		{ID: 3, OrganizationID: "acme", EventTypes: "user.created"},
+	}, nil)
+	s.mockRepo.EXPECT().CreateDeliveries(s.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, deliveries []*entities.WebhookDelivery) error {
+		s.Len(deliveries, 2)
+		s.Equal(uint(1), deliveries[0].WebhookID)
+		s.Equal(uint(3), deliveries[1].WebhookID)
+		for _, delivery := range deliveries {
+			s.Equal(event.ID, delivery.EventID)
+			s.Equal(entities.WebhookDeliveryPending, delivery.Status)
+			s.NotNil(delivery.NextAttemptAt)
+
+			var payload events.Event
+			s.NoError(json.Unmarshal([]byte(delivery.Payload), &payload))
+			s.Equal(event.ID, payload.ID)
+		}
+		return nil
+	})
+	s.logger.EXPECT().Info("webhook deliveries queued successfully", gomock.Any()).Times(1)
+
+	s.NoError(s.webhookService.Dispatch(s.ctx, event))
+}
+
+func (s *WebhookServiceSuite) TestDispatchNoSubscribers() {
+	event, err := events.New(events.ScopeDeleted, "acme", events.ScopeData{ScopeID: 1, Name: "user:view"})
+	s.Require().NoError(err)
+	s.mockRepo.EXPECT().FindActive(s.ctx, "acme").Return([]*entities.Webhook{
+		{ID: 1, OrganizationID: "acme", EventTypes: "user.created"},
+	}, nil)
+
+	s.NoError(s.webhookService.Dispatch(s.ctx, event))
+}
+
+func (s *WebhookServiceSuite) TestDispatchRepoError() {
+	event, err := events.New(events.UserCreated, "acme", events.UserData{UserID: "user1"})
+	s.Require().NoError(err)
+	s.mockRepo.EXPECT().FindActive(s.ctx, "acme").Return([]*entities.Webhook{
+		{ID: 1, OrganizationID: "acme", EventTypes: "user.created"},
+	}, nil)
+	s.mockRepo.EXPECT().CreateDeliveries(s.ctx, gomock.Any()).Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to queue webhook deliveries", gomock.Any()).Times(1)
+
+	s.ErrorContains(s.webhookService.Dispatch(s.ctx, event), "db error")
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+// runEvery calls job on every tick of interval until ctx is cancelled. Jobs
+// log their own failures.
+func runEvery(ctx context.Context, interval time.Duration, job func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			job(ctx)
+		}
+	}
+}